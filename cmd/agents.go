@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/antopolskiy/kanban-md/internal/board"
+	"github.com/antopolskiy/kanban-md/internal/output"
+	"github.com/antopolskiy/kanban-md/internal/task"
+)
+
+var agentsCmd = &cobra.Command{
+	Use:   "agents",
+	Short: "List agents working on the board",
+	Long: `Lists the agents that have worked on the board under a claim name, with
+when each was last seen, its last action and the tasks it has claimed.
+
+Agents are recorded in the agents/ directory of the board whenever they
+create, move, edit, pick, hand off or heartbeat a task with --claim. An agent
+is live if it was last seen within claim_timeout and stale otherwise; stale
+agents' claims can be released with "agents reap".`,
+	Args: cobra.NoArgs,
+	RunE: runAgents,
+}
+
+var agentsReapCmd = &cobra.Command{
+	Use:   "reap",
+	Short: "Release claims held by stale agents",
+	Long: `Releases every claim held by an agent that has not been seen within
+claim_timeout. Each release is logged as a "reap" entry and can be reverted
+with undo. Use --dry-run to see which claims would be released.`,
+	Args: cobra.NoArgs,
+	RunE: runAgentsReap,
+}
+
+func init() {
+	agentsCmd.Flags().Bool("stale", false, "only show stale agents")
+	agentsReapCmd.Flags().Bool("dry-run", false, "show the claims that would be released without releasing them")
+	agentsCmd.AddCommand(agentsReapCmd)
+	rootCmd.AddCommand(agentsCmd)
+}
+
+func runAgents(cmd *cobra.Command, _ []string) error {
+	staleOnly, _ := cmd.Flags().GetBool("stale")
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	tasks, _, err := task.ReadAllLenient(cfg.TasksPath())
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	agents, err := board.ListAgents(cfg, tasks, now)
+	if err != nil {
+		return err
+	}
+	if staleOnly {
+		all := agents
+		agents = []board.Agent{}
+		for _, a := range all {
+			if !a.Live {
+				agents = append(agents, a)
+			}
+		}
+	}
+
+	switch outputFormat() {
+	case output.FormatJSON:
+		return output.JSON(os.Stdout, agents)
+	case output.FormatCompact:
+		output.AgentsCompact(os.Stdout, agents, now)
+	default:
+		output.AgentsTable(os.Stdout, agents, now)
+	}
+	return nil
+}
+
+func runAgentsReap(cmd *cobra.Command, _ []string) error {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	reaped, err := board.ReapAgents(cfg, dryRun, now)
+	if err != nil {
+		return err
+	}
+
+	if outputFormat() == output.FormatJSON {
+		return output.JSON(os.Stdout, reaped)
+	}
+	output.ReapTable(os.Stdout, reaped, dryRun, now)
+	return nil
+}
@@ -39,6 +39,7 @@ func init() {
 	rootCmd.AddCommand(boardCmd)
 	boardCmd.Flags().BoolVarP(&flagWatch, "watch", "w", false, "live-update the board on file changes")
 	boardCmd.Flags().String("group-by", "", "group board by field ("+strings.Join(board.ValidGroupByFields(), ", ")+")")
+	boardCmd.Flags().StringP("query", "q", "", "only count tasks matching this query")
 }
 
 func runBoard(cmd *cobra.Command, _ []string) error {
@@ -52,9 +53,10 @@ func runBoard(cmd *cobra.Command, _ []string) error {
 		return clierr.Newf(clierr.InvalidGroupBy, "invalid --group-by field %q; valid: %s",
 			groupBy, strings.Join(board.ValidGroupByFields(), ", "))
 	}
+	query, _ := cmd.Flags().GetString("query")
 
 	// Render once.
-	if err := renderBoard(cfg, groupBy); err != nil {
+	if err := renderBoard(cfg, groupBy, query); err != nil {
 		return err
 	}
 
@@ -62,10 +64,18 @@ func runBoard(cmd *cobra.Command, _ []string) error {
 		return nil
 	}
 
-	return watchBoard(cfg, groupBy)
+	return watchBoard(cfg, groupBy, query)
 }
 
-func renderBoard(cfg *config.Config, groupBy string) error {
+func renderBoard(cfg *config.Config, groupBy, query string) error {
+	var q *board.Query
+	if query != "" {
+		var err error
+		if q, err = board.ParseQuery(query, cfg); err != nil {
+			return err
+		}
+	}
+
 	tasks, warnings, err := task.ReadAllLenient(cfg.TasksPath())
 	if err != nil {
 		return err
@@ -75,10 +85,10 @@ func renderBoard(cfg *config.Config, groupBy string) error {
 		tasks = []*task.Task{}
 	}
 
-	// Exclude archived tasks from board display.
+	// Exclude archived tasks, and tasks not matching --query, from board display.
 	var activeTasks []*task.Task
 	for _, t := range tasks {
-		if !cfg.IsArchivedStatus(t.Status) {
+		if !cfg.IsArchivedStatus(t.Status) && (q == nil || q.Match(t)) {
 			activeTasks = append(activeTasks, t)
 		}
 	}
@@ -113,7 +123,7 @@ func renderGroupedBoard(cfg *config.Config, tasks []*task.Task, groupBy string)
 	return nil
 }
 
-func watchBoard(cfg *config.Config, groupBy string) error {
+func watchBoard(cfg *config.Config, groupBy, query string) error {
 	// Watch both the tasks directory and the config file's directory.
 	watchPaths := []string{cfg.TasksPath(), cfg.Dir()}
 
@@ -128,7 +138,7 @@ func watchBoard(cfg *config.Config, groupBy string) error {
 			fmt.Fprintf(os.Stderr, "Warning: reloading config: %v\n", loadErr)
 			freshCfg = cfg
 		}
-		if renderErr := renderBoard(freshCfg, groupBy); renderErr != nil {
+		if renderErr := renderBoard(freshCfg, groupBy, query); renderErr != nil {
 			fmt.Fprintf(os.Stderr, "Warning: rendering board: %v\n", renderErr)
 		}
 	})
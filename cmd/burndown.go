@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/antopolskiy/kanban-md/internal/board"
+	"github.com/antopolskiy/kanban-md/internal/clierr"
+	"github.com/antopolskiy/kanban-md/internal/date"
+	"github.com/antopolskiy/kanban-md/internal/output"
+	"github.com/antopolskiy/kanban-md/internal/task"
+)
+
+var burndownCmd = &cobra.Command{
+	Use:   "burndown",
+	Short: "Show a burndown of the tasks with a tag or under a parent",
+	Long: `Tracks a release or milestone: the tasks with --tag, the subtasks of --parent,
+or the tasks matching both. For every day it counts the tasks in scope, the
+completed ones (in a terminal status) and the remaining ones, and totals their
+estimates (4h, 90m, 2d, 1w; days are 8 working hours and weeks 5 days).
+
+The daily scope is reconstructed by replaying the status, tag, parent and
+estimate changes in the activity log, so tasks added to the scope mid-stream
+(created, tagged or moved under the parent) show up as scope changes: ▲ below
+the chart, +#N in the list. Tasks that left the scope show as ▼ and -#N.
+
+The chart shows completed work filled and remaining work shaded up to the
+scope; --estimate charts estimated hours instead of task counts. Use --json for
+the full daily series for external plotting.`,
+	Args: cobra.NoArgs,
+	RunE: runBurndown,
+}
+
+func init() {
+	burndownCmd.Flags().String("tag", "", "tasks with this tag")
+	burndownCmd.Flags().Int("parent", 0, "subtasks of this parent task")
+	burndownCmd.Flags().String("since", "", "first day (YYYY-MM-DD, default: when the first task in scope was created)")
+	burndownCmd.Flags().String("until", "", "last day (YYYY-MM-DD, default: today)")
+	burndownCmd.Flags().Bool("estimate", false, "chart estimated hours instead of task counts")
+	rootCmd.AddCommand(burndownCmd)
+}
+
+func runBurndown(cmd *cobra.Command, _ []string) error {
+	var scope board.BurndownScope
+	scope.Tag, _ = cmd.Flags().GetString("tag")
+	if cmd.Flags().Changed("parent") {
+		parent, _ := cmd.Flags().GetInt("parent")
+		scope.Parent = &parent
+	}
+	if scope.Tag == "" && scope.Parent == nil {
+		return clierr.New(clierr.InvalidInput, "burndown needs a scope: --tag or --parent")
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	tasks, warnings, err := task.ReadAllLenient(cfg.TasksPath())
+	if err != nil {
+		return err
+	}
+	printWarnings(warnings)
+
+	until, err := dateFlag(cmd, "until", date.Today())
+	if err != nil {
+		return err
+	}
+	since, err := dateFlag(cmd, "since", burndownStart(tasks, scope, until))
+	if err != nil {
+		return err
+	}
+	if since.After(until.Time) {
+		return clierr.Newf(clierr.InvalidInput, "--since %s is after --until %s", since, until).
+			WithDetails(map[string]any{"since": since.String(), "until": until.String()})
+	}
+
+	entries, err := board.ReadLog(cfg.Dir(), board.LogFilterOptions{})
+	if err != nil {
+		return err
+	}
+	b := board.ComputeBurndown(cfg, tasks, entries, scope, since, until)
+
+	switch outputFormat() {
+	case output.FormatJSON:
+		return output.JSON(os.Stdout, b)
+	case output.FormatCompact:
+		output.BurndownCompact(os.Stdout, b)
+	default:
+		weighted, _ := cmd.Flags().GetBool("estimate")
+		output.BurndownChart(os.Stdout, b, weighted)
+	}
+	return nil
+}
+
+// burndownStart returns the day the oldest task now in scope was created, or
+// until when the scope is empty.
+func burndownStart(tasks []*task.Task, scope board.BurndownScope, until date.Date) date.Date {
+	start := until
+	for _, t := range board.Filter(tasks, board.FilterOptions{Tag: scope.Tag, ParentID: scope.Parent}) {
+		created := t.Created.Local()
+		if d := date.New(created.Year(), created.Month(), created.Day()); d.Before(start.Time) {
+			start = d
+		}
+	}
+	return start
+}
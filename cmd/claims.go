@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/antopolskiy/kanban-md/internal/board"
+	"github.com/antopolskiy/kanban-md/internal/output"
+	"github.com/antopolskiy/kanban-md/internal/task"
+)
+
+var claimsCmd = &cobra.Command{
+	Use:   "claims",
+	Short: "List claimed tasks and when their claims expire",
+	Long: `Lists the claims held on tasks with the claimant and the time left before
+each claim expires (claim_timeout after it was made or last renewed).
+
+An expired claim no longer stops other agents, but it stays on the task until
+it is cleared. Use "claims expire" to clear expired claims.`,
+	Args: cobra.NoArgs,
+	RunE: runClaims,
+}
+
+var claimsExpireCmd = &cobra.Command{
+	Use:   "expire",
+	Short: "Clear expired claims",
+	Long: `Clears every expired claim. Each task gets a note recording whose claim
+expired and is logged as a "claim-expired" entry, which undo can revert.
+
+Tasks are moved to claim_expired_status if it is configured, or to the status
+given with --move (--move "" leaves them in place). Use --dry-run to see which
+claims would be cleared.`,
+	Args: cobra.NoArgs,
+	RunE: runClaimsExpire,
+}
+
+func init() {
+	claimsCmd.Flags().Bool("expired", false, "only show expired claims")
+	claimsExpireCmd.Flags().String("move", "", "move tasks to this status (default: claim_expired_status)")
+	claimsExpireCmd.Flags().Bool("dry-run", false, "show the claims that would be cleared without clearing them")
+	claimsCmd.AddCommand(claimsExpireCmd)
+	rootCmd.AddCommand(claimsCmd)
+}
+
+func runClaims(cmd *cobra.Command, _ []string) error {
+	expiredOnly, _ := cmd.Flags().GetBool("expired")
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	tasks, _, err := task.ReadAllLenient(cfg.TasksPath())
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	claims := board.ListClaims(cfg, tasks, now)
+	if expiredOnly {
+		all := claims
+		claims = []board.Claim{}
+		for _, c := range all {
+			if c.Expired {
+				claims = append(claims, c)
+			}
+		}
+	}
+
+	switch outputFormat() {
+	case output.FormatJSON:
+		return output.JSON(os.Stdout, claims)
+	case output.FormatCompact:
+		output.ClaimsCompact(os.Stdout, claims, now)
+	default:
+		output.ClaimsTable(os.Stdout, claims, now)
+	}
+	return nil
+}
+
+func runClaimsExpire(cmd *cobra.Command, _ []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	params := board.ExpireClaimsParams{MoveTo: cfg.ClaimExpiredStatus}
+	if cmd.Flags().Changed("move") {
+		params.MoveTo, _ = cmd.Flags().GetString("move")
+	}
+	params.DryRun, _ = cmd.Flags().GetBool("dry-run")
+
+	expired, err := board.ExpireClaims(cfg, params, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if outputFormat() == output.FormatJSON {
+		return output.JSON(os.Stdout, expired)
+	}
+	output.ExpiredClaimsTable(os.Stdout, expired, params.MoveTo, params.DryRun)
+	return nil
+}
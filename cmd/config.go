@@ -8,7 +8,9 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v3"
 
+	"github.com/antopolskiy/kanban-md/internal/board"
 	"github.com/antopolskiy/kanban-md/internal/clierr"
 	"github.com/antopolskiy/kanban-md/internal/config"
 	"github.com/antopolskiy/kanban-md/internal/output"
@@ -145,9 +147,85 @@ func addExtendedConfigAccessors(accessors map[string]configAccessor) {
 		},
 		writable: true,
 	}
+	accessors["claim_expired_status"] = configAccessor{
+		get: func(c *config.Config) any { return c.ClaimExpiredStatus },
+		set: func(c *config.Config, v string) error {
+			if v != "" && config.IndexOf(c.BoardStatuses(), v) < 0 {
+				return clierr.Newf(clierr.InvalidInput,
+					"invalid claim_expired_status %q; allowed: %s", v, strings.Join(c.BoardStatuses(), ", "))
+			}
+			c.ClaimExpiredStatus = v
+			return nil
+		},
+		writable: true,
+	}
+	accessors["lock_timeout"] = configAccessor{
+		get: func(c *config.Config) any { return c.LockTimeout },
+		set: func(c *config.Config, v string) error {
+			if _, err := time.ParseDuration(v); err != nil {
+				return clierr.Newf(clierr.InvalidInput,
+					"invalid lock_timeout %q: %v", v, err)
+			}
+			c.LockTimeout = v
+			return nil // validation handles non-negative check
+		},
+		writable: true,
+	}
 	accessors["classes"] = configAccessor{
 		get: func(c *config.Config) any { return c.Classes },
 	}
+	accessors["views"] = configAccessor{
+		get: func(c *config.Config) any {
+			if c.Views == nil {
+				return []config.ViewConfig{}
+			}
+			return c.Views
+		},
+	}
+	accessors["fields"] = configAccessor{
+		get: func(c *config.Config) any {
+			if c.Fields == nil {
+				return []config.FieldConfig{}
+			}
+			return c.Fields
+		},
+	}
+	accessors["transitions"] = configAccessor{
+		get: func(c *config.Config) any {
+			if c.Transitions == nil {
+				return []config.TransitionConfig{}
+			}
+			return c.Transitions
+		},
+	}
+	accessors["hooks"] = configAccessor{
+		get: func(c *config.Config) any {
+			if c.Hooks == nil {
+				return []config.HookConfig{}
+			}
+			return c.Hooks
+		},
+	}
+	accessors["parent_done"] = configAccessor{
+		get: func(c *config.Config) any { return c.ParentDone },
+		set: func(c *config.Config, v string) error {
+			if v != "" && config.IndexOf(config.ParentDoneModes(), v) < 0 {
+				return clierr.Newf(clierr.InvalidInput,
+					"invalid parent_done %q; allowed: %s", v, strings.Join(config.ParentDoneModes(), ", "))
+			}
+			c.ParentDone = v
+			return nil
+		},
+		writable: true,
+	}
+	accessors["recurring"] = configAccessor{
+		get: func(c *config.Config) any {
+			if c.Recurring == nil {
+				return []config.RecurringConfig{}
+			}
+			return c.Recurring
+		},
+	}
 	accessors["tui.title_lines"] = configAccessor{
 		get: func(c *config.Config) any { return c.TUI.TitleLines },
 		set: func(c *config.Config, v string) error {
@@ -206,7 +284,15 @@ func allConfigKeys() []string {
 		"defaults.class",
 		"wip_limits",
 		"claim_timeout",
+		"claim_expired_status",
+		"lock_timeout",
 		"classes",
+		"views",
+		"fields",
+		"transitions",
+		"hooks",
+		"parent_done",
+		"recurring",
 		"tui.title_lines",
 		"tui.hide_empty_columns",
 		"tui.narrow_threshold",
@@ -246,10 +332,9 @@ func runConfigGet(_ *cobra.Command, args []string) error {
 	}
 
 	key := args[0]
-	accessors := configAccessors()
-	acc, ok := accessors[key]
-	if !ok {
-		return clierr.Newf(clierr.InvalidInput, "unknown config key %q", key)
+	acc, err := lookupConfigAccessor(cfg, key, false)
+	if err != nil {
+		return err
 	}
 
 	val := acc.get(cfg)
@@ -268,11 +353,23 @@ func runConfigSet(_ *cobra.Command, args []string) error {
 		return err
 	}
 
+	// Hold the board lock and re-read config.yml so a concurrent create's
+	// next_id bump is not overwritten by this save.
+	unlock, err := board.Lock(cfg)
+	if err != nil {
+		return err
+	}
+	defer unlock() //nolint:errcheck // best-effort unlock
+
+	cfg, err = config.Load(cfg.Dir())
+	if err != nil {
+		return err
+	}
+
 	key, value := args[0], args[1]
-	accessors := configAccessors()
-	acc, ok := accessors[key]
-	if !ok {
-		return clierr.Newf(clierr.InvalidInput, "unknown config key %q", key)
+	acc, err := lookupConfigAccessor(cfg, key, true)
+	if err != nil {
+		return err
 	}
 	if !acc.writable {
 		return clierr.Newf(clierr.InvalidInput, "config key %q is read-only", key)
@@ -298,10 +395,108 @@ func runConfigSet(_ *cobra.Command, args []string) error {
 	return nil
 }
 
+// lookupConfigAccessor resolves a config key, including the per-view
+// views.NAME keys. Reading a view that does not exist is an error; setting
+// one creates it.
+func lookupConfigAccessor(cfg *config.Config, key string, setting bool) (configAccessor, error) {
+	if name, ok := strings.CutPrefix(key, viewKeyPrefix); ok {
+		if !setting {
+			if _, err := board.FindView(cfg, name); err != nil {
+				return configAccessor{}, err
+			}
+		}
+		return viewAccessor(name), nil
+	}
+	acc, ok := configAccessors()[key]
+	if !ok {
+		return configAccessor{}, clierr.Newf(clierr.InvalidInput, "unknown config key %q", key)
+	}
+	return acc, nil
+}
+
+const viewKeyPrefix = "views."
+
+// viewAccessor gets and sets a single saved view. The value is a YAML (or
+// JSON) mapping of view fields; an empty value removes the view.
+func viewAccessor(name string) configAccessor {
+	return configAccessor{
+		get: func(c *config.Config) any {
+			if v := c.ViewByName(name); v != nil {
+				return *v
+			}
+			return nil
+		},
+		set: func(c *config.Config, value string) error {
+			if strings.TrimSpace(value) == "" {
+				if !c.RemoveView(name) {
+					_, err := board.FindView(c, name)
+					return err
+				}
+				return nil
+			}
+			v, err := parseViewValue(name, value)
+			if err != nil {
+				return err
+			}
+			if err := board.ValidateView(c, &v); err != nil {
+				return err
+			}
+			c.SetView(v)
+			// Report unknown statuses/priorities/classes as input errors
+			// rather than letting the later config validation fail.
+			if err := c.Validate(); err != nil {
+				return clierr.New(clierr.InvalidInput, err.Error())
+			}
+			return nil
+		},
+		writable: true,
+	}
+}
+
+func parseViewValue(name, value string) (config.ViewConfig, error) {
+	var v config.ViewConfig
+	dec := yaml.NewDecoder(strings.NewReader(value))
+	dec.KnownFields(true)
+	if err := dec.Decode(&v); err != nil {
+		return v, clierr.Newf(clierr.InvalidInput, "invalid view %q: %v", name, err)
+	}
+	if v.Name != "" && v.Name != name {
+		return v, clierr.Newf(clierr.InvalidInput,
+			"view name %q does not match key %s%s", v.Name, viewKeyPrefix, name)
+	}
+	v.Name = name
+	return v, nil
+}
+
 func formatConfigValue(val any) string {
 	switch v := val.(type) {
+	case nil:
+		return "--"
 	case []string:
 		return strings.Join(v, ", ")
+	case []config.ViewConfig:
+		if len(v) == 0 {
+			return "--"
+		}
+		names := make([]string, len(v))
+		for i := range v {
+			names[i] = v[i].Name
+		}
+		return strings.Join(names, ", ")
+	case []config.FieldConfig:
+		return formatFieldList(v)
+	case []config.TransitionConfig:
+		return formatTransitionList(v)
+	case []config.HookConfig:
+		return formatHookList(v)
+	case []config.RecurringConfig:
+		return formatRecurringList(v)
+	case config.ViewConfig:
+		out, err := yaml.Marshal(v)
+		if err != nil {
+			return fmt.Sprintf("%v", v)
+		}
+		return strings.TrimRight(string(out), "\n")
 	case map[string]int:
 		if len(v) == 0 {
 			return "--"
@@ -315,3 +510,62 @@ func formatConfigValue(val any) string {
 		return fmt.Sprintf("%v", v)
 	}
 }
+
+// formatFieldList renders custom fields as "name:type, ...".
+func formatFieldList(fields []config.FieldConfig) string {
+	if len(fields) == 0 {
+		return "--"
+	}
+	parts := make([]string, len(fields))
+	for i := range fields {
+		parts[i] = fields[i].Name + ":" + fields[i].Type
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatTransitionList renders transitions as "from->to,to (class); ...".
+func formatTransitionList(rules []config.TransitionConfig) string {
+	if len(rules) == 0 {
+		return "--"
+	}
+	parts := make([]string, len(rules))
+	for i, r := range rules {
+		parts[i] = r.From + "->" + strings.Join(r.To, ",")
+		if r.Class != "" {
+			parts[i] += " (" + r.Class + ")"
+		}
+	}
+	return strings.Join(parts, "; ")
+}
+
+// formatHookList renders hooks as "when event[statuses]: command; ...".
+func formatHookList(hooks []config.HookConfig) string {
+	if len(hooks) == 0 {
+		return "--"
+	}
+	parts := make([]string, len(hooks))
+	for i, h := range hooks {
+		parts[i] = h.Phase() + " " + h.Event
+		if len(h.Statuses) > 0 {
+			parts[i] += "[" + strings.Join(h.Statuses, ",") + "]"
+		}
+		parts[i] += ": " + h.Command
+	}
+	return strings.Join(parts, "; ")
+}
+
+// formatRecurringList renders recurring rules as "name: every 1w; name: cron ...".
+func formatRecurringList(rules []config.RecurringConfig) string {
+	if len(rules) == 0 {
+		return "--"
+	}
+	parts := make([]string, len(rules))
+	for i, r := range rules {
+		if r.Cron != "" {
+			parts[i] = r.Name + ": cron " + r.Cron
+		} else {
+			parts[i] = r.Name + ": every " + r.Every
+		}
+	}
+	return strings.Join(parts, "; ")
+}
@@ -202,11 +202,19 @@ func TestConfigAccessors_GetReadOnlyValues(t *testing.T) {
 		{"version", true},
 		{"wip_limits", true},
 		{"classes", true},
+		{"views", true},
+		{"fields", true},
+		{"transitions", true},
+		{"hooks", true},
+		{"parent_done", true},
+		{"recurring", true},
 		{"tui.title_lines", true},
 		{"tui.hide_empty_columns", true},
 		{"tui.narrow_threshold", true},
 		{"tui.age_thresholds", true},
 		{"claim_timeout", true},
+		{"claim_expired_status", true},
+		{"lock_timeout", true},
 	}
 
 	for _, tt := range tests {
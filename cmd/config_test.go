@@ -35,7 +35,15 @@ func TestAllConfigKeys_ExpectedCoverage(t *testing.T) {
 		"defaults.class",
 		"wip_limits",
 		"claim_timeout",
+		"claim_expired_status",
+		"lock_timeout",
 		"classes",
+		"views",
+		"fields",
+		"transitions",
+		"hooks",
+		"parent_done",
+		"recurring",
 		"tui.title_lines",
 		"tui.hide_empty_columns",
 		"tui.narrow_threshold",
@@ -163,6 +171,22 @@ func TestConfigAccessors_SetClaimTimeout_Invalid(t *testing.T) {
 	}
 }
 
+func TestConfigAccessors_SetLockTimeout(t *testing.T) {
+	accessors := configAccessors()
+	cfg := config.NewDefault("Test")
+
+	if err := accessors["lock_timeout"].set(cfg, "30s"); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.LockTimeout != "30s" {
+		t.Errorf("lock_timeout = %q, want %q", cfg.LockTimeout, "30s")
+	}
+
+	if err := accessors["lock_timeout"].set(cfg, "soon"); err == nil {
+		t.Fatal("expected error for invalid lock_timeout")
+	}
+}
+
 func TestConfigAccessors_SetTUITitleLines(t *testing.T) {
 	accessors := configAccessors()
 	cfg := config.NewDefault("Test")
@@ -259,7 +283,7 @@ func TestConfigAccessors_WritableKeys(t *testing.T) {
 	accessors := configAccessors()
 	writableKeys := []string{
 		"board.name", "board.description", "defaults.status", "defaults.priority",
-		"defaults.class", "claim_timeout", "tui.title_lines", "tui.hide_empty_columns",
+		"defaults.class", "claim_timeout", "lock_timeout", "tui.title_lines", "tui.hide_empty_columns",
 		"tui.narrow_threshold",
 	}
 
@@ -2,9 +2,7 @@ package cmd
 
 import (
 	"errors"
-	"fmt"
 	"os"
-	"path/filepath"
 	"strings"
 	"time"
 
@@ -13,8 +11,8 @@ import (
 
 	"github.com/antopolskiy/kanban-md/internal/board"
 	"github.com/antopolskiy/kanban-md/internal/clierr"
+	"github.com/antopolskiy/kanban-md/internal/config"
 	"github.com/antopolskiy/kanban-md/internal/date"
-	"github.com/antopolskiy/kanban-md/internal/filelock"
 	"github.com/antopolskiy/kanban-md/internal/output"
 	"github.com/antopolskiy/kanban-md/internal/task"
 )
@@ -26,7 +24,11 @@ var createCmd = &cobra.Command{
 	Long: `Creates a new task file with the given title and optional fields.
 
 Title can be provided as a positional argument or via --title flag.
-Body/description can be provided via --body or --description flag.`,
+Body/description can be provided via --body or --description flag.
+
+--template NAME starts from a template in the templates/ directory of the
+board (see "template list"): its fields fill in anything not given as a flag,
+its tags are added to --tags, and its body is used unless --body is given.`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runCreate,
 }
@@ -53,22 +55,12 @@ func init() {
 	createCmd.Flags().String("body", "", "task body/description (markdown)")
 	createCmd.Flags().String("class", "", "class of service (expedite, fixed-date, standard, intangible)")
 	createCmd.Flags().String("claim", "", "claim task for an agent (use 'agent-name' to generate)")
+	createCmd.Flags().StringArray("set", nil, "set a custom field NAME=VALUE (repeatable)")
+	createCmd.Flags().String("template", "", "start from a task template (see 'template list')")
 	rootCmd.AddCommand(createCmd)
 }
 
 func runCreate(cmd *cobra.Command, args []string) error {
-	// Acquire an exclusive lock to prevent concurrent creates from
-	// reading the same next_id and generating duplicate task IDs.
-	dir, err := resolveDir()
-	if err != nil {
-		return err
-	}
-	unlock, err := filelock.Lock(filepath.Join(dir, ".lock"))
-	if err != nil {
-		return fmt.Errorf("acquiring lock: %w", err)
-	}
-	defer unlock() //nolint:errcheck // best-effort unlock on exit
-
 	cfg, err := loadConfig()
 	if err != nil {
 		return err
@@ -83,7 +75,16 @@ func runCreate(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	sets, _ := cmd.Flags().GetStringArray("set")
+	if params.Custom, err = board.ParseFieldAssignments(cfg, sets); err != nil {
+		return err
+	}
+	if params, err = applyCreateTemplate(cmd, cfg, params); err != nil {
+		return err
+	}
 
+	// board.Create holds the board lock and re-reads next_id from disk, so
+	// concurrent creates never generate duplicate task IDs.
 	result, err := board.Create(cfg, params, time.Now())
 	if err != nil {
 		return err
@@ -92,6 +93,19 @@ func runCreate(cmd *cobra.Command, args []string) error {
 	return outputCreateResult(result.Task, result.Path)
 }
 
+// applyCreateTemplate merges the --template template, if any, into params.
+func applyCreateTemplate(cmd *cobra.Command, cfg *config.Config, params board.CreateParams) (board.CreateParams, error) {
+	name, _ := cmd.Flags().GetString("template")
+	if name == "" {
+		return params, nil
+	}
+	tmpl, err := board.FindTemplate(cfg, name)
+	if err != nil {
+		return params, err
+	}
+	return board.ApplyTemplate(cfg, tmpl, params, time.Now())
+}
+
 func outputCreateResult(t *task.Task, path string) error {
 	if outputFormat() == output.FormatJSON {
 		return output.JSON(os.Stdout, t)
@@ -29,6 +29,7 @@ Multiple IDs can be provided as a comma-separated list (requires --yes).`,
 
 func init() {
 	deleteCmd.Flags().BoolP("yes", "y", false, "skip confirmation prompt")
+	deleteCmd.Flags().String("if-rev", "", "fail with CONFLICT unless the task is at this revision")
 	rootCmd.AddCommand(deleteCmd)
 }
 
@@ -44,6 +45,10 @@ func runDelete(cmd *cobra.Command, args []string) error {
 	}
 
 	yes, _ := cmd.Flags().GetBool("yes")
+	ifRev, err := ifRevFlag(cmd, ids)
+	if err != nil {
+		return err
+	}
 
 	// Batch mode requires --yes.
 	if len(ids) > 1 && !yes {
@@ -53,7 +58,7 @@ func runDelete(cmd *cobra.Command, args []string) error {
 
 	// Single ID: preserve exact current behavior.
 	if len(ids) == 1 {
-		return deleteSingleTask(cfg, ids[0], yes)
+		return deleteSingleTask(cfg, ids[0], yes, ifRev)
 	}
 
 	// Batch mode (yes is guaranteed true here).
@@ -63,7 +68,7 @@ func runDelete(cmd *cobra.Command, args []string) error {
 }
 
 // deleteSingleTask handles a single task delete with confirmation and output.
-func deleteSingleTask(cfg *config.Config, id int, yes bool) error {
+func deleteSingleTask(cfg *config.Config, id int, yes bool, ifRev string) error {
 	// Pre-read the task for confirmation prompt (before the actual delete).
 	path, err := task.FindByID(cfg.TasksPath(), id)
 	if err != nil {
@@ -90,7 +95,7 @@ func deleteSingleTask(cfg *config.Config, id int, yes bool) error {
 		}
 	}
 
-	result, err := board.Delete(cfg, id, "", time.Now())
+	result, err := board.Delete(cfg, id, "", ifRev, time.Now())
 	if err != nil {
 		return err
 	}
@@ -112,7 +117,7 @@ func deleteSingleTask(cfg *config.Config, id int, yes bool) error {
 
 // executeDelete performs the core delete via the shared board.Delete.
 func executeDelete(cfg *config.Config, id int) error {
-	result, err := board.Delete(cfg, id, "", time.Now())
+	result, err := board.Delete(cfg, id, "", "", time.Now())
 	if err != nil {
 		return err
 	}
@@ -125,7 +130,7 @@ func executeDelete(cfg *config.Config, id int) error {
 // softDeleteAndLog archives the task and logs the delete action.
 // Kept for test compatibility; production paths use board.Delete directly.
 func softDeleteAndLog(cfg *config.Config, _ string, t *task.Task) error {
-	result, err := board.Delete(cfg, t.ID, "", time.Now())
+	result, err := board.Delete(cfg, t.ID, "", "", time.Now())
 	if err != nil {
 		return err
 	}
@@ -156,7 +156,7 @@ func TestDeleteSingleTask_WithYesTableOutput(t *testing.T) {
 	setFlags(t, false, true, false)
 	r, w := captureStdout(t)
 
-	err = deleteSingleTask(cfg, 1, true)
+	err = deleteSingleTask(cfg, 1, true, "")
 	got := drainPipe(t, r, w)
 
 	if err != nil {
@@ -187,7 +187,7 @@ func TestDeleteSingleTask_WithYesJSONOutput(t *testing.T) {
 	setFlags(t, true, false, false)
 	r, w := captureStdout(t)
 
-	err = deleteSingleTask(cfg, 1, true)
+	err = deleteSingleTask(cfg, 1, true, "")
 	got := drainPipe(t, r, w)
 
 	if err != nil {
@@ -208,7 +208,7 @@ func TestDeleteSingleTask_TaskNotFound(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	err = deleteSingleTask(cfg, 999, true)
+	err = deleteSingleTask(cfg, 999, true, "")
 	if err == nil {
 		t.Fatal("expected error for non-existent task")
 	}
@@ -233,7 +233,7 @@ func TestDeleteSingleTask_ClaimedTaskBlocked(t *testing.T) {
 		Updated:   now,
 	})
 
-	err = deleteSingleTask(cfg, 1, true)
+	err = deleteSingleTask(cfg, 1, true, "")
 	if err == nil {
 		t.Fatal("expected error for claimed task")
 	}
@@ -264,7 +264,7 @@ func TestDeleteSingleTask_NonTTYRequiresYes(t *testing.T) {
 	})
 
 	// Without --yes and in non-TTY (test environment), should fail.
-	err = deleteSingleTask(cfg, 1, false)
+	err = deleteSingleTask(cfg, 1, false, "")
 	if err == nil {
 		t.Fatal("expected error for non-TTY without --yes")
 	}
@@ -625,7 +625,7 @@ func TestSoftDeleteAndLog_DependentWarning(t *testing.T) {
 	r, w := captureStdout(t)
 	rErr, wErr := captureStderr(t)
 
-	err = deleteSingleTask(cfg, 1, true)
+	err = deleteSingleTask(cfg, 1, true, "")
 	_ = drainPipe(t, r, w)
 	stderr := drainPipe(t, rErr, wErr)
 
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"fmt"
 	"os"
 	"time"
 
@@ -49,6 +50,9 @@ func init() {
 	editCmd.Flags().String("claim", "", "claim task for an agent")
 	editCmd.Flags().Bool("release", false, "release claim on task")
 	editCmd.Flags().String("class", "", "set class of service")
+	editCmd.Flags().String("if-rev", "", "fail with CONFLICT unless the task is at this revision")
+	editCmd.Flags().StringArray("set", nil, "set a custom field NAME=VALUE (repeatable)")
+	editCmd.Flags().StringSlice("unset", nil, "remove custom fields (comma-separated names)")
 	rootCmd.AddCommand(editCmd)
 }
 
@@ -58,6 +62,10 @@ func runEdit(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if _, err = ifRevFlag(cmd, ids); err != nil {
+		return err
+	}
+
 	cfg, err := loadConfig()
 	if err != nil {
 		return err
@@ -96,14 +104,19 @@ func editSingleTask(cfg *config.Config, id int, cmd *cobra.Command) error {
 func executeEdit(cfg *config.Config, id int, cmd *cobra.Command) (*task.Task, string, error) {
 	claimant, _ := cmd.Flags().GetString("claim")
 	release, _ := cmd.Flags().GetBool("release")
+	ifRev, _ := cmd.Flags().GetString("if-rev")
 
-	result, err := board.Edit(cfg, id, claimant, release,
+	result, err := board.Edit(cfg, id, claimant, ifRev, release,
 		func(t *task.Task) (bool, error) {
 			return applyEditChanges(cmd, t, cfg, claimant, release)
 		}, time.Now())
 	if err != nil {
 		return nil, "", err
 	}
+	for _, w := range result.Warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", w)
+	}
+	printParentsDone(cfg, result.ParentsDone)
 
 	return result.Task, result.NewPath, nil
 }
@@ -150,6 +163,11 @@ func applyEditFlags(cmd *cobra.Command, t *task.Task, cfg *config.Config) (bool,
 	if err != nil {
 		return false, err
 	}
+	if c, fieldErr := applyCustomFieldFlags(cmd, t, cfg); fieldErr != nil {
+		return false, fieldErr
+	} else if c {
+		changed = true
+	}
 
 	// Apply grouped flag helpers, each returning (bool, error).
 	for _, fn := range []func(*cobra.Command, *task.Task) (bool, error){
@@ -226,6 +244,37 @@ func applySimpleEditFlags(cmd *cobra.Command, t *task.Task, cfg *config.Config)
 	return changed, nil
 }
 
+// applyCustomFieldFlags handles --set and --unset for custom fields.
+func applyCustomFieldFlags(cmd *cobra.Command, t *task.Task, cfg *config.Config) (bool, error) {
+	sets, _ := cmd.Flags().GetStringArray("set")
+	unsets, _ := cmd.Flags().GetStringSlice("unset")
+
+	values, err := board.ParseFieldAssignments(cfg, sets)
+	if err != nil {
+		return false, err
+	}
+	for _, name := range unsets {
+		if _, ok := values[name]; ok {
+			return false, clierr.Newf(clierr.StatusConflict, "cannot use --set and --unset for the same field %q", name)
+		}
+	}
+	if err := board.ValidateFieldUnset(cfg, unsets); err != nil {
+		return false, err
+	}
+
+	changed := false
+	for name, v := range values {
+		t.SetCustom(name, v)
+		changed = true
+	}
+	for _, name := range unsets {
+		if t.UnsetCustom(name) {
+			changed = true
+		}
+	}
+	return changed, nil
+}
+
 func applyTimestampFlags(cmd *cobra.Command, t *task.Task) (bool, error) {
 	changed := false
 
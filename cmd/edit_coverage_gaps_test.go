@@ -211,7 +211,7 @@ func TestDeleteSingleTask_TaskReadError(t *testing.T) {
 		t.Fatal(writeErr)
 	}
 
-	err = deleteSingleTask(cfg, 1, true)
+	err = deleteSingleTask(cfg, 1, true, "")
 	if err == nil {
 		t.Fatal("expected error from malformed task file")
 	}
@@ -227,7 +227,7 @@ func TestDeleteSingleTask_NonTTYWithoutYes(t *testing.T) {
 
 	// In tests, stdin is a pipe (not a TTY), so this should return
 	// ConfirmationReq without --yes.
-	err = deleteSingleTask(cfg, 1, false)
+	err = deleteSingleTask(cfg, 1, false, "")
 	if err == nil {
 		t.Fatal("expected confirmation error in non-TTY mode")
 	}
@@ -251,7 +251,7 @@ func TestDeleteSingleTask_JSONOutput(t *testing.T) {
 	setFlags(t, true, false, false)
 	r, w := captureStdout(t)
 
-	err = deleteSingleTask(cfg, 1, true)
+	err = deleteSingleTask(cfg, 1, true, "")
 	got := drainPipe(t, r, w)
 
 	if err != nil {
@@ -386,7 +386,7 @@ func TestDeleteSingleTask_SoftDeleteWriteError(t *testing.T) {
 	}
 	t.Cleanup(func() { _ = os.RemoveAll(path) })
 
-	err = deleteSingleTask(cfg, 1, true)
+	err = deleteSingleTask(cfg, 1, true, "")
 	if err == nil {
 		t.Fatal("expected write error from softDeleteAndLog")
 	}
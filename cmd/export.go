@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/antopolskiy/kanban-md/internal/board"
+	"github.com/antopolskiy/kanban-md/internal/clierr"
+	"github.com/antopolskiy/kanban-md/internal/config"
+	"github.com/antopolskiy/kanban-md/internal/output"
+	"github.com/antopolskiy/kanban-md/internal/task"
+)
+
+// Export formats and the file each is written to with --out.
+var exportFiles = map[string]string{
+	"html": "index.html",
+	"md":   "board.md",
+	"csv":  "board.csv",
+	"json": "board.json",
+}
+
+const exportDirMode = 0o750
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the board as HTML, Markdown, CSV or JSON",
+	Long: `Renders the board for people without kanban-md: the board columns with
+their cards (priority, class, tags, due date, assignee, claims and blocks) and
+WIP usage per column.
+
+  html  a self-contained static page (no scripts or external assets)
+  md    a single Markdown report
+  csv   one row per task (re-importable with import --from csv)
+  json  the columns, their tasks and the board summary
+
+Without --out, the export is written to standard output. With --out DIR, each
+format is written to a file in DIR (index.html, board.md, board.csv,
+board.json), so several formats can be exported at once, e.g. as CI artifacts:
+
+  kanban-md export --format html,md,csv --out public/
+
+Archived tasks are left out. The filter flags select which cards are shown;
+the summary and WIP usage always cover the whole board.`,
+	Args: cobra.NoArgs,
+	RunE: runExport,
+}
+
+func init() {
+	exportCmd.Flags().StringSlice("format", []string{"html"}, "export formats: html, md, csv, json (comma-separated)")
+	exportCmd.Flags().StringP("out", "o", "", "directory to write the exports to (default: standard output)")
+	exportCmd.Flags().StringSlice("status", nil, "only tasks in these statuses (comma-separated)")
+	exportCmd.Flags().StringSlice("priority", nil, "only tasks with these priorities (comma-separated)")
+	exportCmd.Flags().String("assignee", "", "only tasks assigned to this person")
+	exportCmd.Flags().String("tag", "", "only tasks with this tag")
+	exportCmd.Flags().String("class", "", "only tasks of this class of service")
+	exportCmd.Flags().StringP("search", "s", "", "only tasks matching this text (title, body or tags)")
+	exportCmd.Flags().StringP("query", "q", "", `only tasks matching this query, e.g. "tag:api"`)
+	rootCmd.AddCommand(exportCmd)
+}
+
+func runExport(cmd *cobra.Command, _ []string) error {
+	formats, _ := cmd.Flags().GetStringSlice("format")
+	outDir, _ := cmd.Flags().GetString("out")
+	if outDir == "" && outputFormat() == output.FormatJSON && !cmd.Flags().Changed("format") {
+		formats = []string{"json"}
+	}
+	for _, f := range formats {
+		if _, ok := exportFiles[f]; !ok {
+			return clierr.Newf(clierr.InvalidInput, "invalid export format %q; allowed: html, md, csv, json", f)
+		}
+	}
+	if len(formats) > 1 && outDir == "" {
+		return clierr.New(clierr.InvalidInput, "exporting several formats requires --out")
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	filter, err := exportFilter(cmd, cfg)
+	if err != nil {
+		return err
+	}
+	tasks, warnings, err := task.ReadAllLenient(cfg.TasksPath())
+	if err != nil {
+		return err
+	}
+	printWarnings(warnings)
+	e := board.BuildExport(cfg, tasks, filter, time.Now())
+
+	if outDir == "" {
+		return renderExport(os.Stdout, formats[0], e)
+	}
+	if err := os.MkdirAll(outDir, exportDirMode); err != nil {
+		return fmt.Errorf("creating %s: %w", outDir, err)
+	}
+	written := make([]string, 0, len(formats))
+	for _, f := range formats {
+		path := filepath.Join(outDir, exportFiles[f])
+		if err := writeExport(path, f, e); err != nil {
+			return err
+		}
+		written = append(written, path)
+	}
+	if outputFormat() == output.FormatJSON {
+		return output.JSON(os.Stdout, map[string]any{"files": written})
+	}
+	for _, path := range written {
+		fmt.Fprintf(os.Stdout, "Wrote %s\n", path)
+	}
+	return nil
+}
+
+// exportFilter builds the task filter from the export flags.
+func exportFilter(cmd *cobra.Command, cfg *config.Config) (board.FilterOptions, error) {
+	statuses, _ := cmd.Flags().GetStringSlice("status")
+	priorities, _ := cmd.Flags().GetStringSlice("priority")
+	assignee, _ := cmd.Flags().GetString("assignee")
+	tag, _ := cmd.Flags().GetString("tag")
+	class, _ := cmd.Flags().GetString("class")
+	search, _ := cmd.Flags().GetString("search")
+	query, _ := cmd.Flags().GetString("query")
+
+	filter := board.FilterOptions{
+		Statuses:        statuses,
+		ExcludeStatuses: []string{config.ArchivedStatus},
+		Priorities:      priorities,
+		Assignee:        assignee,
+		Tag:             tag,
+		Class:           class,
+		Search:          search,
+	}
+	for _, s := range statuses {
+		if err := task.ValidateStatus(s, cfg.StatusNames()); err != nil {
+			return filter, err
+		}
+	}
+	if query != "" {
+		var err error
+		if filter.Query, err = board.ParseQuery(query, cfg); err != nil {
+			return filter, err
+		}
+	}
+	return filter, nil
+}
+
+// renderExport writes e to w in the given format.
+func renderExport(w io.Writer, format string, e *board.Export) error {
+	switch format {
+	case "md":
+		output.ExportMarkdown(w, e)
+		return nil
+	case "csv":
+		return output.ExportCSV(w, e)
+	case "json":
+		return output.JSON(w, e)
+	default:
+		return output.ExportHTML(w, e)
+	}
+}
+
+// writeExport renders e in the given format to the file at path.
+func writeExport(path, format string, e *board.Export) error {
+	var buf bytes.Buffer
+	if err := renderExport(&buf, format, e); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil { //nolint:gosec // published export, meant to be readable
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return nil
+}
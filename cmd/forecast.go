@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"math/rand/v2"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/antopolskiy/kanban-md/internal/board"
+	"github.com/antopolskiy/kanban-md/internal/clierr"
+	"github.com/antopolskiy/kanban-md/internal/config"
+	"github.com/antopolskiy/kanban-md/internal/date"
+	"github.com/antopolskiy/kanban-md/internal/output"
+	"github.com/antopolskiy/kanban-md/internal/task"
+)
+
+const (
+	defaultForecastRuns    = 10000
+	defaultForecastHistory = 30
+)
+
+var forecastCmd = &cobra.Command{
+	Use:   "forecast",
+	Short: "Forecast delivery with Monte Carlo simulation on past throughput",
+	Long: `Answers "when will these tasks be done?" and "how many tasks will be done by
+a date?" by simulating many possible futures: each simulated day's throughput
+is drawn at random from the number of tasks completed on a day of the recent
+history (--history days, ending today). The results are reported at 50%, 85%
+and 95% confidence.
+
+Without --by, forecasts when N tasks will be done: --tasks N, or else the
+unfinished tasks matching the filter flags (all unfinished tasks by default).
+The date at 85% confidence means 85% of the simulations finished by then.
+
+With --by DATE, forecasts how many tasks will be done by the end of that day.
+The count at 85% confidence means 85% of the simulations finished at least that
+many.
+
+Simulations start tomorrow. Use --seed for reproducible results.`,
+	Args: cobra.NoArgs,
+	RunE: runForecast,
+}
+
+func init() {
+	forecastCmd.Flags().Int("tasks", 0, "number of tasks to forecast (default: unfinished tasks matching the filters)")
+	forecastCmd.Flags().String("by", "", "forecast how many tasks will be done by this date (YYYY-MM-DD)")
+	forecastCmd.Flags().Int("history", defaultForecastHistory, "days of throughput history to sample from")
+	forecastCmd.Flags().Int("runs", defaultForecastRuns, "number of simulations")
+	forecastCmd.Flags().Uint64("seed", 0, "random seed for reproducible results (0 = random)")
+	forecastCmd.Flags().String("tag", "", "count unfinished tasks with this tag")
+	forecastCmd.Flags().Int("parent", 0, "count unfinished subtasks of this parent task")
+	forecastCmd.Flags().StringP("query", "q", "", `count unfinished tasks matching this query, e.g. "tag:api"`)
+	rootCmd.AddCommand(forecastCmd)
+}
+
+func runForecast(cmd *cobra.Command, _ []string) error {
+	history, _ := cmd.Flags().GetInt("history")
+	runs, _ := cmd.Flags().GetInt("runs")
+	seed, _ := cmd.Flags().GetUint64("seed")
+	if history < 1 || runs < 1 {
+		return clierr.New(clierr.InvalidInput, "--history and --runs must be at least 1")
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	tasks, warnings, err := task.ReadAllLenient(cfg.TasksPath())
+	if err != nil {
+		return err
+	}
+	printWarnings(warnings)
+
+	now := time.Now()
+	today := date.New(now.Year(), now.Month(), now.Day())
+	p := board.ForecastParams{Today: today, Runs: runs}
+	if err := forecastTarget(cmd, cfg, tasks, &p); err != nil {
+		return err
+	}
+
+	since := date.New(today.Year(), today.Month(), today.Day()-history+1)
+	throughput := board.DailyThroughput(cfg, tasks, since, today)
+	if seed == 0 {
+		seed = uint64(now.UnixNano()) //nolint:gosec // any clock value makes a seed
+	}
+	f, err := board.RunForecast(p, throughput, rand.New(rand.NewPCG(seed, seed))) //nolint:gosec // simulation, not security
+	if err != nil {
+		return err
+	}
+
+	switch outputFormat() {
+	case output.FormatJSON:
+		return output.JSON(os.Stdout, f)
+	case output.FormatCompact:
+		output.ForecastCompact(os.Stdout, f)
+	default:
+		output.ForecastTable(os.Stdout, f)
+	}
+	return nil
+}
+
+// forecastTarget sets what p forecasts from the flags: a date (--by) or a
+// number of tasks (--tasks, or the unfinished tasks matching the filters).
+func forecastTarget(cmd *cobra.Command, cfg *config.Config, tasks []*task.Task, p *board.ForecastParams) error {
+	n, _ := cmd.Flags().GetInt("tasks")
+	by, _ := cmd.Flags().GetString("by")
+	if by != "" {
+		if cmd.Flags().Changed("tasks") {
+			return clierr.New(clierr.InvalidInput, "--tasks and --by cannot be combined")
+		}
+		d, err := date.Parse(by)
+		if err != nil {
+			return task.ValidateDate("by", by, err)
+		}
+		if !d.After(p.Today.Time) {
+			return clierr.Newf(clierr.InvalidInput, "--by %s must be after today", d)
+		}
+		p.By = &d
+		return nil
+	}
+	if cmd.Flags().Changed("tasks") {
+		if n < 1 {
+			return clierr.New(clierr.InvalidInput, "--tasks must be at least 1")
+		}
+		p.Tasks = n
+		return nil
+	}
+
+	filter, err := forecastFilter(cmd, cfg)
+	if err != nil {
+		return err
+	}
+	for _, t := range board.Filter(tasks, filter) {
+		if !cfg.IsTerminalStatus(t.Status) {
+			p.Tasks++
+		}
+	}
+	if p.Tasks == 0 {
+		return clierr.New(clierr.InvalidInput, "no unfinished tasks to forecast; use --tasks N")
+	}
+	return nil
+}
+
+// forecastFilter builds the filter selecting the tasks to forecast.
+func forecastFilter(cmd *cobra.Command, cfg *config.Config) (board.FilterOptions, error) {
+	tag, _ := cmd.Flags().GetString("tag")
+	query, _ := cmd.Flags().GetString("query")
+	filter := board.FilterOptions{Tag: tag}
+	if cmd.Flags().Changed("parent") {
+		parent, _ := cmd.Flags().GetInt("parent")
+		filter.ParentID = &parent
+	}
+	if query != "" {
+		var err error
+		if filter.Query, err = board.ParseQuery(query, cfg); err != nil {
+			return filter, err
+		}
+	}
+	return filter, nil
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/antopolskiy/kanban-md/internal/board"
+	"github.com/antopolskiy/kanban-md/internal/clierr"
+	"github.com/antopolskiy/kanban-md/internal/output"
+	"github.com/antopolskiy/kanban-md/internal/task"
+)
+
+// Graph output formats and highlights.
+const (
+	graphFormatDOT     = "dot"
+	graphFormatMermaid = "mermaid"
+	graphFormatJSON    = "json"
+
+	highlightCritical = "critical"
+	highlightBlocked  = "blocked"
+	highlightCycles   = "cycles"
+	highlightAll      = "all"
+)
+
+var graphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "Export the task dependency graph",
+	Long: `Exports the dependency and parent graph of the board as Graphviz DOT
+(default), a Mermaid flowchart, or JSON adjacency lists. Dependency edges point
+from a prerequisite to the task that depends on it; parent edges are dashed.
+
+The graph is analyzed for the critical path (the longest chain of unfinished
+dependent tasks), blocked chains (tasks waiting on a blocked task) and
+dependency cycles. --highlight draws these in DOT and Mermaid output; JSON
+output always includes them.
+
+Archived tasks are left out. Use --root to show only the tasks related to one
+task, and --query to filter tasks.`,
+	Args: cobra.NoArgs,
+	RunE: runGraph,
+}
+
+func init() {
+	graphCmd.Flags().String("format", graphFormatDOT, "output format (dot, mermaid, json)")
+	graphCmd.Flags().StringSlice("highlight", nil, "highlight critical, blocked, cycles, or all (comma-separated)")
+	graphCmd.Flags().Int("root", 0, "only show this task, its dependencies, dependents and subtasks")
+	graphCmd.Flags().StringP("query", "q", "", `only include tasks matching this query, e.g. "tag:backend"`)
+	rootCmd.AddCommand(graphCmd)
+}
+
+func runGraph(cmd *cobra.Command, _ []string) error {
+	format, _ := cmd.Flags().GetString("format")
+	highlights, _ := cmd.Flags().GetStringSlice("highlight")
+	root, _ := cmd.Flags().GetInt("root")
+	query, _ := cmd.Flags().GetString("query")
+
+	if outputFormat() == output.FormatJSON {
+		format = graphFormatJSON
+	}
+	if format != graphFormatDOT && format != graphFormatMermaid && format != graphFormatJSON {
+		return clierr.Newf(clierr.InvalidInput, "invalid graph format %q; allowed: dot, mermaid, json", format)
+	}
+	hl, err := parseHighlights(highlights)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	var q *board.Query
+	if query != "" {
+		if q, err = board.ParseQuery(query, cfg); err != nil {
+			return err
+		}
+	}
+
+	tasks, warnings, err := task.ReadAllLenient(cfg.TasksPath())
+	if err != nil {
+		return err
+	}
+	printWarnings(warnings)
+
+	var included []*task.Task
+	for _, t := range tasks {
+		if !cfg.IsArchivedStatus(t.Status) && (q == nil || q.Match(t)) {
+			included = append(included, t)
+		}
+	}
+
+	g, err := board.BuildGraph(cfg, included, root)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case graphFormatJSON:
+		return output.JSON(os.Stdout, g)
+	case graphFormatMermaid:
+		output.GraphMermaid(os.Stdout, g, hl)
+	default:
+		output.GraphDOT(os.Stdout, g, hl)
+	}
+	return nil
+}
+
+// parseHighlights converts --highlight values into a GraphHighlight.
+func parseHighlights(values []string) (output.GraphHighlight, error) {
+	var hl output.GraphHighlight
+	for _, v := range values {
+		switch strings.TrimSpace(v) {
+		case highlightCritical:
+			hl.Critical = true
+		case highlightBlocked:
+			hl.Blocked = true
+		case highlightCycles:
+			hl.Cycles = true
+		case highlightAll:
+			hl = output.GraphHighlight{Critical: true, Blocked: true, Cycles: true}
+		default:
+			return hl, clierr.Newf(clierr.InvalidInput,
+				"invalid highlight %q; allowed: critical, blocked, cycles, all", v)
+		}
+	}
+	return hl, nil
+}
@@ -31,6 +31,7 @@ func init() {
 	handoffCmd.Flags().BoolP("timestamp", "t", false, "prefix a timestamp line to the note")
 	handoffCmd.Flags().String("block", "", "mark task as blocked with reason")
 	handoffCmd.Flags().Bool("release", false, "release claim after handoff")
+	handoffCmd.Flags().String("if-rev", "", "fail with CONFLICT unless the task is at this revision")
 	rootCmd.AddCommand(handoffCmd)
 }
 
@@ -40,6 +41,10 @@ func runHandoff(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if _, err = ifRevFlag(cmd, ids); err != nil {
+		return err
+	}
+
 	cfg, err := loadConfig()
 	if err != nil {
 		return err
@@ -83,6 +88,7 @@ func executeHandoff(cfg *config.Config, id int, cmd *cobra.Command) (*task.Task,
 	blockReason, _ := cmd.Flags().GetString("block")
 	note, _ := cmd.Flags().GetString("note")
 	addTimestamp, _ := cmd.Flags().GetBool("timestamp")
+	ifRev, _ := cmd.Flags().GetString("if-rev")
 
 	if claimant == "" {
 		return nil, clierr.New(clierr.InvalidInput, "claim name is required (use --claim NAME)")
@@ -99,6 +105,7 @@ func executeHandoff(cfg *config.Config, id int, cmd *cobra.Command) (*task.Task,
 		BlockReason:  blockReason,
 		Note:         note,
 		AddTimestamp: addTimestamp,
+		IfRev:        ifRev,
 	}
 
 	return board.Handoff(cfg, params, time.Now())
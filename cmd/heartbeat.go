@@ -0,0 +1,213 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/antopolskiy/kanban-md/internal/board"
+	"github.com/antopolskiy/kanban-md/internal/clierr"
+	"github.com/antopolskiy/kanban-md/internal/config"
+	"github.com/antopolskiy/kanban-md/internal/output"
+	"github.com/antopolskiy/kanban-md/internal/task"
+)
+
+var heartbeatCmd = &cobra.Command{
+	Use:   "heartbeat ID [-- COMMAND [ARGS...]]",
+	Short: "Renew your claim on a task",
+	Long: `Renews your claim on a task so it does not expire while you keep working.
+Only the agent holding the claim can renew it: if the claim was released or
+taken over by another agent, heartbeat fails with CLAIM_LOST.
+
+With --keepalive, the claim is renewed at half the claim timeout until
+interrupted, or, if a command follows "--", until that command exits (see
+"run"). Renewals are logged as "heartbeat" entries, separately from edits.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runHeartbeat,
+}
+
+var runCmd = &cobra.Command{
+	Use:   "run ID -- COMMAND [ARGS...]",
+	Short: "Run a command while keeping your claim on a task alive",
+	Long: `Runs a command and renews your claim on the task at half the claim timeout
+until it exits, e.g. "kanban-md run 5 --claim me -- go test ./...". The command
+inherits stdin, stdout and stderr, and run exits with its exit code. If the
+claim is lost while the command runs, a warning is printed and renewals stop.
+
+Equivalent to "heartbeat ID --claim NAME --keepalive -- COMMAND".`,
+	Args: cobra.MinimumNArgs(2), //nolint:mnd // task ID plus the command
+	RunE: runRun,
+}
+
+func init() {
+	heartbeatCmd.Flags().String("claim", "", "agent holding the claim (required)")
+	heartbeatCmd.Flags().Bool("keepalive", false, "keep renewing until interrupted or the command after -- exits")
+	rootCmd.AddCommand(heartbeatCmd)
+
+	runCmd.Flags().String("claim", "", "agent holding the claim (required)")
+	rootCmd.AddCommand(runCmd)
+}
+
+func runHeartbeat(cmd *cobra.Command, args []string) error {
+	claimant, _ := cmd.Flags().GetString("claim")
+	keepalive, _ := cmd.Flags().GetBool("keepalive")
+	if claimant == "" {
+		return clierr.New(clierr.InvalidInput, "claim name is required (use --claim NAME)")
+	}
+
+	var command []string
+	if dash := cmd.ArgsLenAtDash(); dash >= 0 {
+		if !keepalive {
+			return clierr.New(clierr.InvalidInput, "a command after -- requires --keepalive (or use 'run')")
+		}
+		command = args[dash:]
+		args = args[:dash]
+	}
+	if len(args) != 1 {
+		return clierr.New(clierr.InvalidInput, "heartbeat takes one task ID argument")
+	}
+
+	ids, err := parseIDs(args[0])
+	if err != nil {
+		return err
+	}
+	if keepalive && len(ids) > 1 {
+		return clierr.New(clierr.InvalidInput, "--keepalive requires a single task ID")
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	if keepalive {
+		return keepClaimAlive(cfg, ids[0], claimant, command)
+	}
+	if len(ids) == 1 {
+		t, err := board.Heartbeat(cfg, ids[0], claimant, time.Now())
+		if err != nil {
+			return err
+		}
+		return outputHeartbeat(cfg, t)
+	}
+	return runBatch(ids, func(id int) error {
+		_, err := board.Heartbeat(cfg, id, claimant, time.Now())
+		return err
+	})
+}
+
+func runRun(cmd *cobra.Command, args []string) error {
+	claimant, _ := cmd.Flags().GetString("claim")
+	if claimant == "" {
+		return clierr.New(clierr.InvalidInput, "claim name is required (use --claim NAME)")
+	}
+	if dash := cmd.ArgsLenAtDash(); dash != 1 {
+		return clierr.New(clierr.InvalidInput, "usage: run ID --claim NAME -- COMMAND [ARGS...]")
+	}
+
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return task.ValidateTaskID(args[0])
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	return keepClaimAlive(cfg, id, claimant, args[1:])
+}
+
+func outputHeartbeat(cfg *config.Config, t *task.Task) error {
+	if outputFormat() == output.FormatJSON {
+		return output.JSON(os.Stdout, t)
+	}
+	if timeout := cfg.ClaimTimeoutDuration(); timeout > 0 {
+		output.Messagef(os.Stdout, "Renewed claim on task #%d for %s (expires in %s)", t.ID, t.ClaimedBy, timeout)
+	} else {
+		output.Messagef(os.Stdout, "Renewed claim on task #%d for %s (claims do not expire)", t.ID, t.ClaimedBy)
+	}
+	return nil
+}
+
+// keepClaimAlive renews claimant's claim on task id at half the claim
+// timeout. With a command it runs the command and returns when it exits,
+// propagating a non-zero exit code; without one it runs until interrupted.
+// A claim that is lost along the way is reported once and no longer renewed.
+func keepClaimAlive(cfg *config.Config, id int, claimant string, command []string) error {
+	if _, err := board.Heartbeat(cfg, id, claimant, time.Now()); err != nil {
+		return err
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	child, exited, err := startCommand(command)
+	if err != nil {
+		return err
+	}
+
+	var renew <-chan time.Time
+	if interval := cfg.ClaimTimeoutDuration() / 2; interval > 0 { //nolint:mnd // renew at half the timeout
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		renew = ticker.C
+	}
+
+	for {
+		select {
+		case <-renew:
+			if _, err := board.Heartbeat(cfg, id, claimant, time.Now()); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: renewing claim on task #%d: %v\n", id, err)
+				var cliErr *clierr.Error
+				if errors.As(err, &cliErr) && cliErr.Code == clierr.ClaimLost {
+					renew = nil
+				}
+			}
+		case sig := <-signals:
+			if child == nil {
+				return nil
+			}
+			_ = child.Process.Signal(sig)
+		case err := <-exited:
+			return commandExit(err)
+		}
+	}
+}
+
+// startCommand starts command with inherited stdio and returns a channel
+// receiving its exit error. Without a command it returns a nil process and a
+// channel that never fires.
+func startCommand(command []string) (*exec.Cmd, <-chan error, error) {
+	exited := make(chan error, 1)
+	if len(command) == 0 {
+		return nil, exited, nil
+	}
+	child := exec.Command(command[0], command[1:]...) //nolint:gosec,noctx // runs the user's own command
+	child.Stdin, child.Stdout, child.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := child.Start(); err != nil {
+		return nil, nil, clierr.Newf(clierr.InvalidInput, "running %s: %v", command[0], err)
+	}
+	go func() { exited <- child.Wait() }()
+	return child, exited, nil
+}
+
+// commandExit converts a command's exit error into run's own exit status.
+func commandExit(err error) error {
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return err
+	}
+	code := exitErr.ExitCode()
+	if code < 0 { // killed by a signal
+		code = 1
+	}
+	return &clierr.SilentError{Code: code}
+}
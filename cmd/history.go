@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/antopolskiy/kanban-md/internal/board"
+	"github.com/antopolskiy/kanban-md/internal/date"
+	"github.com/antopolskiy/kanban-md/internal/output"
+	"github.com/antopolskiy/kanban-md/internal/task"
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history ID",
+	Short: "Show a task's change history",
+	Long: `Displays a timeline of every recorded mutation of a task: who made it and
+which fields changed, with their values before and after.
+
+History comes from the activity log, so it covers only changes made since
+field-level logging was introduced and still within the log's retention.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runHistory,
+}
+
+func init() {
+	historyCmd.Flags().String("since", "", "show entries after this date (YYYY-MM-DD)")
+	historyCmd.Flags().Int("limit", 0, "maximum number of entries to show (most recent)")
+	rootCmd.AddCommand(historyCmd)
+}
+
+func runHistory(cmd *cobra.Command, args []string) error {
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return task.ValidateTaskID(args[0])
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	opts := board.LogFilterOptions{TaskID: id}
+	if v, _ := cmd.Flags().GetString("since"); v != "" {
+		d, parseErr := date.Parse(v)
+		if parseErr != nil {
+			return task.ValidateDate("since", v, parseErr)
+		}
+		opts.Since = d.Time
+	}
+	if v, _ := cmd.Flags().GetInt("limit"); v > 0 {
+		opts.Limit = v
+	}
+
+	entries, err := board.ReadLog(cfg.Dir(), opts)
+	if err != nil {
+		return err
+	}
+	// A task with no log entries may simply not exist.
+	if len(entries) == 0 {
+		if _, err := task.FindByID(cfg.TasksPath(), id); err != nil {
+			return err
+		}
+	}
+
+	format := outputFormat()
+	if format == output.FormatJSON {
+		if entries == nil {
+			entries = []board.LogEntry{}
+		}
+		return output.JSON(os.Stdout, entries)
+	}
+	if format == output.FormatCompact {
+		output.HistoryCompact(os.Stdout, id, entries)
+		return nil
+	}
+
+	output.HistoryTable(os.Stdout, id, entries)
+	return nil
+}
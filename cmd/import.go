@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/antopolskiy/kanban-md/internal/board"
+	"github.com/antopolskiy/kanban-md/internal/clierr"
+	"github.com/antopolskiy/kanban-md/internal/importer"
+	"github.com/antopolskiy/kanban-md/internal/output"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import FILE",
+	Short: "Import tasks from GitHub, GitLab, Trello or CSV",
+	Long: `Imports issues from another tracker as tasks. FILE is an export in the
+format given by --from ("-" reads standard input):
+
+  github  JSON from gh issue list --json number,title,body,state,labels,assignees,milestone,url
+  gitlab  JSON (or one JSON object per line) from the GitLab issues API or glab
+  trello  Trello board JSON export (cards; the list name is the state)
+  csv     CSV with a header row; columns default to the field names
+          (id, title, body, status, priority, tags, assignee, due, milestone_due,
+          estimate)
+
+Labels become tags, the first assignee the assignee, the issue due date (or
+its milestone's) the due date, and the state the status: open goes to the
+default status, closed to the last board status, and any state or list named
+like a board status to that status.
+
+--mapping reads a YAML file that renames CSV columns and maps states and
+priorities (or labels) onto the board:
+
+  columns: {id: Key, title: Summary, tags: Labels}
+  statuses: {To Do: todo, Doing: in-progress}
+  priorities: {P1: high, P2: medium}
+  tag_separator: ";"
+
+Each task keeps its source ID in external_id (e.g. github:owner/repo#12), and
+issues already on the board are skipped, so importing an updated export again
+only adds the new issues. CSV rows without an id cannot be matched that way,
+so they are refused unless --allow-duplicates is given, in which case every
+import creates them again.
+
+All new tasks are checked (statuses, WIP limits, dependencies) before any is
+created, so an invalid item fails the import without touching the board. Use
+--dry-run to see what would be created.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runImport,
+}
+
+func init() {
+	importCmd.Flags().String("from", "", "export format: "+strings.Join(importer.Formats(), ", "))
+	importCmd.Flags().String("mapping", "", "YAML file mapping columns, states and priorities")
+	importCmd.Flags().Bool("dry-run", false, "show what would be imported without creating tasks")
+	importCmd.Flags().Bool("allow-duplicates", false, "import CSV rows without an id (created again on every import)")
+	_ = importCmd.MarkFlagRequired("from")
+	rootCmd.AddCommand(importCmd)
+}
+
+func runImport(cmd *cobra.Command, args []string) error {
+	from, _ := cmd.Flags().GetString("from")
+	mappingPath, _ := cmd.Flags().GetString("mapping")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	m := &importer.Mapping{}
+	if mappingPath != "" {
+		if m, err = importer.ReadMapping(mappingPath); err != nil {
+			return err
+		}
+		if err = m.Validate(cfg); err != nil {
+			return err
+		}
+	}
+	data, err := readImportFile(args[0])
+	if err != nil {
+		return err
+	}
+	issues, err := importer.Parse(from, data, m)
+	if err != nil {
+		return err
+	}
+	items, warnings := importer.Items(cfg, issues, m)
+	for _, w := range warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", w)
+	}
+	if allow, _ := cmd.Flags().GetBool("allow-duplicates"); !allow {
+		if err := checkImportIDs(items); err != nil {
+			return err
+		}
+	}
+
+	results, err := board.Import(cfg, items, dryRun, time.Now())
+	if err != nil {
+		if len(results) > 0 {
+			output.ImportTable(os.Stderr, results)
+		}
+		return err
+	}
+	if outputFormat() == output.FormatJSON {
+		return output.JSON(os.Stdout, results)
+	}
+	output.ImportTable(os.Stdout, results)
+	return nil
+}
+
+// checkImportIDs refuses items without an external ID: nothing marks them as
+// imported, so importing the same file again would duplicate them.
+func checkImportIDs(items []board.ImportItem) error {
+	var titles []string
+	for _, item := range items {
+		if item.ExternalID == "" {
+			titles = append(titles, item.Params.Title)
+		}
+	}
+	if len(titles) == 0 {
+		return nil
+	}
+	return clierr.Newf(clierr.InvalidInput,
+		"%d rows have no id, so importing again would duplicate them; add an id column or use --allow-duplicates",
+		len(titles)).WithDetails(map[string]any{"titles": titles})
+}
+
+// readImportFile reads the export to import; "-" is standard input.
+func readImportFile(path string) ([]byte, error) {
+	if path == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	data, err := os.ReadFile(path) //nolint:gosec // import path given by the user
+	if err != nil {
+		return nil, clierr.Newf(clierr.InvalidInput, "reading %s: %v", path, err)
+	}
+	return data, nil
+}
@@ -27,7 +27,7 @@ func init() {
 	listCmd.Flags().StringSlice("priority", nil, "filter by priority (comma-separated)")
 	listCmd.Flags().String("assignee", "", "filter by assignee")
 	listCmd.Flags().String("tag", "", "filter by tag")
-	listCmd.Flags().String("sort", "id", "sort field (id, title, status, priority, created, updated, due)")
+	listCmd.Flags().String("sort", "id", "sort field (id, title, status, priority, created, updated, due, or a custom field)")
 	listCmd.Flags().BoolP("reverse", "r", false, "reverse sort order")
 	listCmd.Flags().IntP("limit", "n", 0, "limit number of results")
 	listCmd.Flags().Bool("blocked", false, "show only blocked tasks")
@@ -37,9 +37,12 @@ func init() {
 	listCmd.Flags().Bool("unclaimed", false, "show only unclaimed or expired-claim tasks")
 	listCmd.Flags().String("claimed-by", "", "filter by claimant")
 	listCmd.Flags().String("class", "", "filter by class of service")
+	listCmd.Flags().StringArray("field", nil, "filter by custom field NAME=VALUE (repeatable)")
 	listCmd.Flags().StringP("search", "s", "", "search tasks by title, body, or tags (case-insensitive)")
+	listCmd.Flags().StringP("query", "q", "", `filter by query, e.g. "status:todo AND (tag:api OR priority>=high)"`)
 	listCmd.Flags().Bool("archived", false, "show only archived tasks")
 	listCmd.Flags().String("group-by", "", "group results by field ("+strings.Join(board.ValidGroupByFields(), ", ")+")")
+	listCmd.Flags().Bool("tree", false, "show subtasks indented under their parent")
 	rootCmd.AddCommand(listCmd)
 }
 
@@ -64,12 +67,13 @@ func runList(cmd *cobra.Command, _ []string) error {
 	claimedBy, _ := cmd.Flags().GetString("claimed-by")
 	class, _ := cmd.Flags().GetString("class")
 	search, _ := cmd.Flags().GetString("search")
-	groupBy, _ := cmd.Flags().GetString("group-by")
 	archived, _ := cmd.Flags().GetBool("archived")
+	queryStr, _ := cmd.Flags().GetString("query")
+	fieldFilters, _ := cmd.Flags().GetStringArray("field")
 
-	if groupBy != "" && !slices.Contains(board.ValidGroupByFields(), groupBy) {
-		return clierr.Newf(clierr.InvalidGroupBy, "invalid --group-by field %q; valid: %s",
-			groupBy, strings.Join(board.ValidGroupByFields(), ", "))
+	groupBy, tree, err := listLayout(cmd)
+	if err != nil {
+		return err
 	}
 
 	filter := board.FilterOptions{
@@ -78,27 +82,29 @@ func runList(cmd *cobra.Command, _ []string) error {
 		Assignee:     assignee,
 		Tag:          tag,
 		Search:       search,
+		Unclaimed:    unclaimed,
+		ClaimedBy:    claimedBy,
 		ClaimTimeout: cfg.ClaimTimeoutDuration(),
+		Class:        class,
+	}
+	if filter.Fields, err = board.ParseFieldFilters(fieldFilters); err != nil {
+		return err
+	}
+
+	if queryStr != "" {
+		if filter.Query, err = board.ParseQuery(queryStr, cfg); err != nil {
+			return err
+		}
 	}
 
 	// --archived flag: show only archived tasks.
-	// Default (no --status, no --archived): exclude archived.
+	// Default (no --status, no --archived, no status in --query): exclude archived.
 	if archived {
 		filter.Statuses = []string{config.ArchivedStatus}
-	} else if !cmd.Flags().Changed("status") {
+	} else if !cmd.Flags().Changed("status") && (filter.Query == nil || !filter.Query.UsesField("status")) {
 		filter.ExcludeStatuses = []string{config.ArchivedStatus}
 	}
 
-	if unclaimed {
-		filter.Unclaimed = true
-	}
-	if claimedBy != "" {
-		filter.ClaimedBy = claimedBy
-	}
-	if class != "" {
-		filter.Class = class
-	}
-
 	if blocked {
 		v := true
 		filter.Blocked = &v
@@ -124,12 +130,33 @@ func runList(cmd *cobra.Command, _ []string) error {
 		return err
 	}
 	printWarnings(warnings)
+	return outputList(cfg, tasks, groupBy, tree)
+}
 
-	if groupBy != "" {
+// outputList prints the listed tasks as a plain list, a tree or groups.
+func outputList(cfg *config.Config, tasks []*task.Task, groupBy string, tree bool) error {
+	switch {
+	case groupBy != "":
 		return outputGroupedList(tasks, groupBy, cfg)
+	case tree:
+		return outputTaskTree(tasks)
+	default:
+		return outputTaskList(tasks)
 	}
+}
 
-	return outputTaskList(tasks)
+// listLayout reads and checks the --group-by and --tree flags.
+func listLayout(cmd *cobra.Command) (groupBy string, tree bool, err error) {
+	groupBy, _ = cmd.Flags().GetString("group-by")
+	tree, _ = cmd.Flags().GetBool("tree")
+	if groupBy != "" && !slices.Contains(board.ValidGroupByFields(), groupBy) {
+		return "", false, clierr.Newf(clierr.InvalidGroupBy, "invalid --group-by field %q; valid: %s",
+			groupBy, strings.Join(board.ValidGroupByFields(), ", "))
+	}
+	if groupBy != "" && tree {
+		return "", false, clierr.New(clierr.StatusConflict, "cannot use --group-by and --tree together")
+	}
+	return groupBy, tree, nil
 }
 
 func outputGroupedList(tasks []*task.Task, groupBy string, cfg *config.Config) error {
@@ -157,3 +184,23 @@ func outputTaskList(tasks []*task.Task) error {
 	output.TaskTable(os.Stdout, tasks)
 	return nil
 }
+
+// outputTaskTree prints tasks with subtasks nested under their parent. In
+// JSON, each task carries its subtasks in a "subtasks" array.
+func outputTaskTree(tasks []*task.Task) error {
+	roots := board.TaskTree(tasks)
+	format := outputFormat()
+	if format == output.FormatJSON {
+		if roots == nil {
+			roots = []*board.TaskNode{}
+		}
+		return output.JSON(os.Stdout, roots)
+	}
+	if format == output.FormatCompact {
+		output.TaskTreeCompact(os.Stdout, roots)
+		return nil
+	}
+
+	output.TaskTreeTable(os.Stdout, roots)
+	return nil
+}
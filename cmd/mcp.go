@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"github.com/antopolskiy/kanban-md/internal/mcp"
+)
+
+var mcpCmd = &cobra.Command{
+	Use:   "mcp",
+	Short: "Serve board tools over the Model Context Protocol (stdio)",
+	Long: `Runs a Model Context Protocol server on stdin/stdout, exposing board
+operations as tools for MCP clients: list_tasks, pick_task, move_task,
+handoff_task, append_note, board_summary and context.
+
+The command is meant to be launched by an MCP client, not run interactively.
+Like the CLI, every tool call works directly against the files on disk under
+the board lock.`,
+	Args: cobra.NoArgs,
+	RunE: runMCP,
+}
+
+func init() {
+	rootCmd.AddCommand(mcpCmd)
+}
+
+func runMCP(_ *cobra.Command, _ []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	return mcp.New(cfg.Dir(), version).Serve(ctx, os.Stdin, os.Stdout)
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/antopolskiy/kanban-md/internal/board"
+	"github.com/antopolskiy/kanban-md/internal/clierr"
+	"github.com/antopolskiy/kanban-md/internal/config"
 	"github.com/antopolskiy/kanban-md/internal/date"
 	"github.com/antopolskiy/kanban-md/internal/output"
 	"github.com/antopolskiy/kanban-md/internal/task"
@@ -15,29 +17,175 @@ import (
 var metricsCmd = &cobra.Command{
 	Use:   "metrics",
 	Short: "Show flow metrics",
-	Long:  `Displays flow metrics: throughput, average lead/cycle time, flow efficiency, and aging work items.`,
-	RunE:  runMetrics,
+	Long: `Displays flow metrics: throughput, average lead/cycle time, flow efficiency, aging work items,
+p50/p85/p95 lead and cycle times (also per class of service and per tag), and
+the average and percentile time tasks spent in each status (from the activity
+log).
+
+Use "metrics cfd" for the daily flow of the board over time, "metrics
+cycle-time" for the cycle time distribution, and "forecast" for delivery
+forecasts.`,
+	RunE: runMetrics,
 }
 
 func init() {
 	metricsCmd.Flags().String("since", "", "only include tasks completed after this date (YYYY-MM-DD)")
+	metricsCfdCmd.Flags().String("since", "", "first day of the series (YYYY-MM-DD, default: 30 days before --until)")
+	metricsCfdCmd.Flags().String("until", "", "last day of the series (YYYY-MM-DD, default: today)")
+	metricsCycleTimeCmd.Flags().String("since", "", "only include tasks completed after this date (YYYY-MM-DD)")
+	metricsCmd.AddCommand(metricsCfdCmd, metricsCycleTimeCmd)
 	rootCmd.AddCommand(metricsCmd)
 }
 
-func runMetrics(cmd *cobra.Command, _ []string) error {
+// cfdDefaultDays is how many days metrics cfd shows without --since.
+const cfdDefaultDays = 30
+
+var metricsCfdCmd = &cobra.Command{
+	Use:   "cfd",
+	Short: "Show a cumulative flow diagram with daily flow series",
+	Long: `Reconstructs the number of tasks in each status at the end of every day by
+replaying the status changes in the activity log, and renders them as a
+cumulative flow diagram. Each day also shows the work in progress (tasks
+started but not finished), arrivals (tasks started) and departures (tasks
+finished); the averages over the period are printed below.
+
+A widening band means work is piling up in that status. Use --json for the
+full daily series for external plotting.
+
+Tasks created before the activity log was kept are counted in the status they
+had when the log begins.`,
+	Args: cobra.NoArgs,
+	RunE: runMetricsCfd,
+}
+
+var metricsCycleTimeCmd = &cobra.Command{
+	Use:   "cycle-time",
+	Short: "Show the cycle time histogram and scatter plot",
+	Long: `Shows the cycle time (started to completed) of every completed task as a
+histogram (tasks per day of cycle time) and a scatter plot of cycle time
+against completion date, with the p50, p85 and p95 lines: 85% of tasks
+finished within the p85 cycle time. Dots far above the p95 line are the long
+tail worth a retrospective.
+
+Use --json for the points (with lead time, class and tags) and the histogram.`,
+	Args: cobra.NoArgs,
+	RunE: runMetricsCycleTime,
+}
+
+func runMetricsCfd(cmd *cobra.Command, _ []string) error {
 	cfg, err := loadConfig()
 	if err != nil {
 		return err
 	}
+	until, err := dateFlag(cmd, "until", date.Today())
+	if err != nil {
+		return err
+	}
+	since, err := dateFlag(cmd, "since", date.New(until.Year(), until.Month(), until.Day()-cfdDefaultDays))
+	if err != nil {
+		return err
+	}
+	if since.After(until.Time) {
+		return clierr.Newf(clierr.InvalidInput, "--since %s is after --until %s", since, until).
+			WithDetails(map[string]any{"since": since.String(), "until": until.String()})
+	}
 
-	allTasks, warnings, err := task.ReadAllLenient(cfg.TasksPath())
+	tasks, warnings, err := task.ReadAllLenient(cfg.TasksPath())
 	if err != nil {
 		return err
 	}
 	printWarnings(warnings)
-	if allTasks == nil {
-		allTasks = []*task.Task{}
+	entries, err := board.ReadLog(cfg.Dir(), board.LogFilterOptions{})
+	if err != nil {
+		return err
 	}
+	f := board.ComputeFlow(cfg, tasks, board.Transitions(entries), since, until)
+
+	switch outputFormat() {
+	case output.FormatJSON:
+		return output.JSON(os.Stdout, f)
+	case output.FormatCompact:
+		output.FlowCompact(os.Stdout, f)
+	default:
+		output.FlowTable(os.Stdout, f)
+	}
+	return nil
+}
+
+// dateFlag returns the date in flag name, or def when it is not set.
+func dateFlag(cmd *cobra.Command, name string, def date.Date) (date.Date, error) {
+	s, _ := cmd.Flags().GetString(name)
+	if s == "" {
+		return def, nil
+	}
+	d, err := date.Parse(s)
+	if err != nil {
+		return date.Date{}, task.ValidateDate(name, s, err)
+	}
+	return d, nil
+}
+
+func runMetrics(cmd *cobra.Command, _ []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	tasks, err := metricsTasks(cmd, cfg)
+	if err != nil {
+		return err
+	}
+
+	entries, err := board.ReadLog(cfg.Dir(), board.LogFilterOptions{})
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	m := board.ComputeMetrics(cfg, tasks, now)
+	m.StatusTimes = board.ComputeStatusTimes(cfg, tasks, board.Transitions(entries), now)
+
+	format := outputFormat()
+	if format == output.FormatJSON {
+		return output.JSON(os.Stdout, m)
+	}
+	if format == output.FormatCompact {
+		output.MetricsCompact(os.Stdout, m)
+		return nil
+	}
+
+	output.MetricsTable(os.Stdout, m)
+	return nil
+}
+
+func runMetricsCycleTime(cmd *cobra.Command, _ []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	tasks, err := metricsTasks(cmd, cfg)
+	if err != nil {
+		return err
+	}
+	ct := board.ComputeCycleTimes(tasks)
+
+	switch outputFormat() {
+	case output.FormatJSON:
+		return output.JSON(os.Stdout, ct)
+	case output.FormatCompact:
+		output.CycleTimeCompact(os.Stdout, ct)
+	default:
+		output.CycleTimeChart(os.Stdout, ct)
+	}
+	return nil
+}
+
+// metricsTasks reads the tasks metrics are computed from: all but archived
+// ones, and with --since, only those not completed before that date.
+func metricsTasks(cmd *cobra.Command, cfg *config.Config) ([]*task.Task, error) {
+	allTasks, warnings, err := task.ReadAllLenient(cfg.TasksPath())
+	if err != nil {
+		return nil, err
+	}
+	printWarnings(warnings)
 
 	// Exclude archived tasks from metrics.
 	tasks := make([]*task.Task, 0, len(allTasks))
@@ -51,7 +199,7 @@ func runMetrics(cmd *cobra.Command, _ []string) error {
 	if sinceStr != "" {
 		d, parseErr := date.Parse(sinceStr)
 		if parseErr != nil {
-			return task.ValidateDate("since", sinceStr, parseErr)
+			return nil, task.ValidateDate("since", sinceStr, parseErr)
 		}
 		sinceTime := d.Time
 		filtered := make([]*task.Task, 0, len(tasks))
@@ -62,19 +210,5 @@ func runMetrics(cmd *cobra.Command, _ []string) error {
 		}
 		tasks = filtered
 	}
-
-	now := time.Now()
-	m := board.ComputeMetrics(cfg, tasks, now)
-
-	format := outputFormat()
-	if format == output.FormatJSON {
-		return output.JSON(os.Stdout, m)
-	}
-	if format == output.FormatCompact {
-		output.MetricsCompact(os.Stdout, m)
-		return nil
-	}
-
-	output.MetricsTable(os.Stdout, m)
-	return nil
+	return tasks, nil
 }
@@ -28,6 +28,7 @@ func init() {
 	moveCmd.Flags().Bool("next", false, "move to next status")
 	moveCmd.Flags().Bool("prev", false, "move to previous status")
 	moveCmd.Flags().String("claim", "", "claim task for an agent during move")
+	moveCmd.Flags().String("if-rev", "", "fail with CONFLICT unless the task is at this revision")
 	rootCmd.AddCommand(moveCmd)
 }
 
@@ -37,6 +38,10 @@ func runMove(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if _, err = ifRevFlag(cmd, ids); err != nil {
+		return err
+	}
+
 	cfg, err := loadConfig()
 	if err != nil {
 		return err
@@ -85,6 +90,7 @@ func moveSingleTask(cfg *config.Config, id int, cmd *cobra.Command, args []strin
 // (idempotent), oldStatus is empty and the task is returned unchanged.
 func executeMove(cfg *config.Config, id int, cmd *cobra.Command, args []string) (*task.Task, string, error) {
 	claimant, _ := cmd.Flags().GetString("claim")
+	ifRev, _ := cmd.Flags().GetString("if-rev")
 
 	// Resolve the target status from CLI flags/args. This requires reading
 	// the task for --next/--prev, so we do a pre-read for those cases.
@@ -98,6 +104,7 @@ func executeMove(cfg *config.Config, id int, cmd *cobra.Command, args []string)
 		NewStatus: newStatus,
 		Claimant:  claimant,
 		SetClaim:  cmd.Flags().Changed("claim") && claimant != "",
+		IfRev:     ifRev,
 	}, time.Now())
 	if err != nil {
 		return nil, "", err
@@ -106,6 +113,7 @@ func executeMove(cfg *config.Config, id int, cmd *cobra.Command, args []string)
 	for _, w := range result.Warnings {
 		fmt.Fprintf(os.Stderr, "Warning: %s\n", w)
 	}
+	printParentsDone(cfg, result.ParentsDone)
 
 	return result.Task, result.OldStatus, nil
 }
@@ -140,24 +148,36 @@ func resolveTargetStatus(cmd *cobra.Command, args []string, t *task.Task, cfg *c
 		status := args[1] //nolint:gosec // args length checked by case guard
 		return status, nil
 	case next:
-		names := cfg.StatusNames()
-		idx := cfg.StatusIndex(t.Status)
-		if idx < 0 || idx >= len(names)-1 {
-			return "", task.ValidateBoundaryError(t.ID, t.Status, "last")
-		}
-		return names[idx+1], nil
+		return adjacentStatus(cfg, t, 1, "last")
 	case prev:
-		names := cfg.StatusNames()
-		idx := cfg.StatusIndex(t.Status)
-		if idx <= 0 {
-			return "", task.ValidateBoundaryError(t.ID, t.Status, "first")
-		}
-		return names[idx-1], nil
+		return adjacentStatus(cfg, t, -1, "first")
 	default:
 		return "", clierr.New(clierr.InvalidInput, "provide a target status or use --next/--prev")
 	}
 }
 
+// adjacentStatus resolves --next (step 1) or --prev (step -1), following the
+// configured transitions when the board has any.
+func adjacentStatus(cfg *config.Config, t *task.Task, step int, boundary string) (string, error) {
+	if s := cfg.AdjacentStatus(cfg.StatusNames(), t.Status, t.Class, step); s != "" {
+		return s, nil
+	}
+	if cfg.HasTransitions() && cfg.StatusIndex(t.Status) >= 0 {
+		direction := "next"
+		if step < 0 {
+			direction = "previous"
+		}
+		return "", clierr.Newf(clierr.TransitionNotAllowed,
+			"task #%d has no allowed %s status from %s", t.ID, direction, t.Status).
+			WithDetails(map[string]any{
+				"id":      t.ID,
+				"from":    t.Status,
+				"allowed": cfg.AllowedTransitions(t.Status, t.Class),
+			})
+	}
+	return "", task.ValidateBoundaryError(t.ID, t.Status, boundary)
+}
+
 func outputMoveResult(t *task.Task, changed bool) error {
 	format := outputFormat()
 	if format == output.FormatJSON {
@@ -168,3 +188,10 @@ func outputMoveResult(t *task.Task, changed bool) error {
 	}
 	return nil
 }
+
+// printParentsDone reports the parents that parent_done: auto moved to done.
+func printParentsDone(cfg *config.Config, ids []int) {
+	for _, id := range ids {
+		fmt.Fprintf(os.Stderr, "Moved parent task #%d to %s: all subtasks done\n", id, cfg.DoneStatus())
+	}
+}
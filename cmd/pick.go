@@ -26,6 +26,7 @@ func init() {
 	pickCmd.Flags().String("status", "", "status column to pick from (default: all non-terminal)")
 	pickCmd.Flags().String("move", "", "also move the picked task to this status")
 	pickCmd.Flags().StringSlice("tags", nil, "filter by tags (comma-separated, OR logic)")
+	pickCmd.Flags().StringP("query", "q", "", `only pick tasks matching this query, e.g. "tag:backend AND priority>=high"`)
 	pickCmd.Flags().Bool("no-body", false, "suppress full task details after pick")
 	_ = pickCmd.MarkFlagRequired("claim")
 	rootCmd.AddCommand(pickCmd)
@@ -41,13 +42,14 @@ func runPick(cmd *cobra.Command, _ []string) error {
 	statusFilter, _ := cmd.Flags().GetString("status")
 	moveTarget, _ := cmd.Flags().GetString("move")
 	tags, _ := cmd.Flags().GetStringSlice("tags")
+	query, _ := cmd.Flags().GetString("query")
 	noBody, _ := cmd.Flags().GetBool("no-body")
 
 	if err = validatePickFlags(cfg, statusFilter, moveTarget); err != nil {
 		return err
 	}
 
-	picked, oldStatus, err := executePick(cfg, claimant, statusFilter, moveTarget, tags)
+	picked, oldStatus, err := executePick(cfg, claimant, statusFilter, moveTarget, tags, query)
 	if err != nil {
 		return err
 	}
@@ -69,12 +71,13 @@ func validatePickFlags(cfg *config.Config, statusFilter, moveTarget string) erro
 	return nil
 }
 
-func executePick(cfg *config.Config, claimant, statusFilter, moveTarget string, tags []string) (*task.Task, string, error) {
+func executePick(cfg *config.Config, claimant, statusFilter, moveTarget string, tags []string, query string) (*task.Task, string, error) {
 	params := board.PickAndClaimParams{
 		Claimant:     claimant,
 		StatusFilter: statusFilter,
 		MoveTarget:   moveTarget,
 		Tags:         tags,
+		Query:        query,
 	}
 
 	picked, oldStatus, warnings, err := board.PickAndClaim(cfg, params, time.Now())
@@ -99,5 +102,5 @@ func outputPickResult(picked *task.Task, oldStatus, claimant string, noBody bool
 	if _, err := fmt.Fprintln(os.Stdout); err != nil {
 		return err
 	}
-	return outputTaskDetail(picked)
+	return outputTaskDetail(picked, nil, nil)
 }
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/antopolskiy/kanban-md/internal/board"
+	"github.com/antopolskiy/kanban-md/internal/clierr"
+	"github.com/antopolskiy/kanban-md/internal/output"
+)
+
+var recurCmd = &cobra.Command{
+	Use:   "recur",
+	Short: "Create due recurring tasks",
+	Long: `Creates a task for every rule in the recurring section of config.yml that
+is due. A rule is scheduled with every (an interval such as 1d or 2w, counted
+from start or from the last task it created) or cron (minute hour
+day-of-month month day-of-week, in local time).
+
+Each rule creates at most one task per run, for its latest occurrence; missed
+occurrences are not backfilled. The last occurrence of each rule is recorded
+in recurring.json in the board directory, and each task carries it as its
+external_id, so recur is safe to run as often as you like — from cron, a CI
+schedule or a shell prompt hook. A rule whose task cannot be created (e.g.
+over a WIP limit) is reported and tried again next time, without holding up
+the other rules; the exit code is then 1. Use --dry-run to see which tasks
+would be created.`,
+	Args: cobra.NoArgs,
+	RunE: runRecur,
+}
+
+func init() {
+	recurCmd.Flags().Bool("dry-run", false, "show the tasks that would be created without creating them")
+	rootCmd.AddCommand(recurCmd)
+}
+
+func runRecur(cmd *cobra.Command, _ []string) error {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	created, err := board.RunRecurring(cfg, dryRun, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if outputFormat() == output.FormatJSON {
+		if err := output.JSON(os.Stdout, created); err != nil {
+			return err
+		}
+	} else {
+		output.RecurredTable(os.Stdout, created, dryRun)
+	}
+	for _, rt := range created {
+		if rt.Error != "" {
+			return &clierr.SilentError{Code: 1}
+		}
+	}
+	return nil
+}
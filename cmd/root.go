@@ -61,6 +61,7 @@ func init() {
 // Execute runs the root command.
 func Execute() {
 	_, err := rootCmd.ExecuteC()
+	board.WaitForHooks()
 	if err == nil {
 		return
 	}
@@ -123,6 +124,30 @@ func loadConfig() (*config.Config, error) {
 		return nil, err
 	}
 
+	warnings, needsRepair, err := task.CheckConsistency(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if !needsRepair {
+		printWarnings(warnings)
+		return cfg, nil
+	}
+
+	// The repair may rewrite config.yml and rename task files, so it runs
+	// under the board lock on a freshly loaded config. Otherwise it could
+	// observe a concurrent create between its task write and its config save
+	// and "repair" next_id back to a stale value.
+	unlock, err := board.Lock(cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock() //nolint:errcheck // best-effort unlock
+
+	cfg, err = config.Load(dir)
+	if err != nil {
+		return nil, err
+	}
+
 	report, err := task.EnsureConsistency(cfg)
 	if err != nil {
 		return nil, err
@@ -156,6 +181,16 @@ func parseIDs(arg string) ([]int, error) {
 	return board.ParseIDs(arg)
 }
 
+// ifRevFlag returns the --if-rev value. A revision identifies one version of
+// one task, so it cannot be combined with a comma-separated ID list.
+func ifRevFlag(cmd *cobra.Command, ids []int) (string, error) {
+	ifRev, _ := cmd.Flags().GetString("if-rev")
+	if ifRev != "" && len(ids) > 1 {
+		return "", clierr.New(clierr.InvalidInput, "--if-rev requires a single task ID")
+	}
+	return ifRev, nil
+}
+
 // runBatch executes fn for each ID and collects results. Returns a SilentError
 // with exit code 1 if any operation failed (after outputting results).
 func runBatch(ids []int, fn func(int) error) error {
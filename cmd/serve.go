@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/antopolskiy/kanban-md/internal/clierr"
+	"github.com/antopolskiy/kanban-md/internal/output"
+	"github.com/antopolskiy/kanban-md/internal/server"
+)
+
+const (
+	defaultServeAddr  = "127.0.0.1:8377"
+	readHeaderTimeout = 10 * time.Second
+	shutdownTimeout   = 5 * time.Second
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve the board as a local HTTP/JSON API",
+	Long: `Starts an HTTP server exposing board operations as a JSON API, plus a
+Server-Sent Events stream of changes at /api/events.
+
+The server works directly against the files on disk and uses the same board
+lock as the CLI, so CLI, TUI and server can be used side by side.
+It listens on localhost by default; use --socket to listen on a Unix socket.
+There is no authentication: do not expose it beyond the local machine.`,
+	Args: cobra.NoArgs,
+	RunE: runServe,
+}
+
+func init() {
+	serveCmd.Flags().String("addr", defaultServeAddr, "TCP address to listen on")
+	serveCmd.Flags().String("socket", "", "listen on this Unix socket path instead of TCP")
+	rootCmd.AddCommand(serveCmd)
+}
+
+func runServe(cmd *cobra.Command, _ []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	addr, _ := cmd.Flags().GetString("addr")
+	socket, _ := cmd.Flags().GetString("socket")
+	if socket != "" && cmd.Flags().Changed("addr") {
+		return clierr.New(clierr.InvalidInput, "cannot use --addr and --socket together")
+	}
+
+	ln, url, err := serveListener(addr, socket)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	srv := &http.Server{
+		Handler:           server.New(cfg.Dir()).Handler(),
+		ReadHeaderTimeout: readHeaderTimeout,
+		// Request contexts derive from ctx so open event streams end on shutdown.
+		BaseContext: func(net.Listener) context.Context { return ctx },
+	}
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- srv.Serve(ln) }()
+	output.Messagef(os.Stderr, "Serving board %q on %s (Ctrl+C to stop)", cfg.Board.Name, url)
+
+	select {
+	case err = <-errCh:
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		err = srv.Shutdown(shutdownCtx)
+	}
+	if socket != "" {
+		_ = os.Remove(socket)
+	}
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+// serveListener opens a Unix socket listener if socket is set, otherwise a
+// TCP listener on addr. It returns the listener and a URL-ish description.
+func serveListener(addr, socket string) (net.Listener, string, error) {
+	if socket != "" {
+		// Remove a stale socket left by a previous run.
+		if info, err := os.Stat(socket); err == nil && info.Mode()&os.ModeSocket != 0 {
+			_ = os.Remove(socket)
+		}
+		ln, err := net.Listen("unix", socket)
+		if err != nil {
+			return nil, "", fmt.Errorf("listening on %s: %w", socket, err)
+		}
+		return ln, "unix:" + socket, nil
+	}
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, "", fmt.Errorf("listening on %s: %w", addr, err)
+	}
+	return ln, "http://" + ln.Addr().String(), nil
+}
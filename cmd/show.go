@@ -3,9 +3,11 @@ package cmd
 import (
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/antopolskiy/kanban-md/internal/board"
 	"github.com/antopolskiy/kanban-md/internal/output"
 	"github.com/antopolskiy/kanban-md/internal/task"
 )
@@ -13,9 +15,12 @@ import (
 var showCmd = &cobra.Command{
 	Use:   "show ID",
 	Short: "Show task details",
-	Long:  `Displays full details of a single task including its markdown body.`,
-	Args:  cobra.ExactArgs(1),
-	RunE:  runShow,
+	Long: `Displays full details of a single task including its markdown body.
+The status timeline lists each status the task has been in, when it entered
+it and how long it stayed, from the activity log. For a parent task, its
+subtasks are listed with their statuses and the share done.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runShow,
 }
 
 func init() {
@@ -43,19 +48,50 @@ func runShow(_ *cobra.Command, args []string) error {
 		return err
 	}
 
-	return outputTaskDetail(t)
+	all, _, err := task.ReadAllLenient(cfg.TasksPath())
+	if err != nil {
+		return err
+	}
+	entries, err := board.ReadLog(cfg.Dir(), board.LogFilterOptions{TaskID: t.ID})
+	if err != nil {
+		return err
+	}
+	timeline := board.Timeline(t, board.Transitions(entries), time.Now())
+	return outputTaskDetail(t, timeline, board.ParentRollup(cfg, all, t.ID))
+}
+
+// taskDetail wraps a task with its status timeline and subtask roll-up for
+// JSON output.
+type taskDetail struct {
+	*task.Task
+	Timeline []board.StatusPeriod `json:"timeline,omitempty"`
+	Subtasks *board.Rollup        `json:"subtasks,omitempty"`
 }
 
-func outputTaskDetail(t *task.Task) error {
+// outputTaskDetail prints a task, followed by its status timeline and its
+// subtasks when they are not nil.
+func outputTaskDetail(t *task.Task, timeline []board.StatusPeriod, subtasks *board.Rollup) error {
 	format := outputFormat()
 	if format == output.FormatJSON {
-		return output.JSON(os.Stdout, t)
+		return output.JSON(os.Stdout, taskDetail{Task: t, Timeline: timeline, Subtasks: subtasks})
 	}
 	if format == output.FormatCompact {
 		output.TaskDetailCompact(os.Stdout, t)
+		if timeline != nil {
+			output.TimelineCompact(os.Stdout, timeline)
+		}
+		if subtasks != nil {
+			output.SubtasksCompact(os.Stdout, subtasks)
+		}
 		return nil
 	}
 
 	output.TaskDetail(os.Stdout, t)
+	if timeline != nil {
+		output.TimelineDetail(os.Stdout, timeline)
+	}
+	if subtasks != nil {
+		output.SubtasksDetail(os.Stdout, subtasks)
+	}
 	return nil
 }
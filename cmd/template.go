@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/antopolskiy/kanban-md/internal/board"
+	"github.com/antopolskiy/kanban-md/internal/output"
+	"github.com/antopolskiy/kanban-md/internal/task"
+)
+
+var templateCmd = &cobra.Command{
+	Use:   "template",
+	Short: "List and show task templates",
+	Long: `Task templates are markdown files in the templates/ directory of the
+board. The frontmatter holds task defaults (title, status, priority, class,
+assignee, tags, estimate, due_in and custom fields) and the body is the
+skeleton of the task body. Use one with "create --template NAME".
+
+In the title and body, {title} is replaced with the title given to create,
+and {date}, {year}, {month}, {week} and {day} with the creation date.`,
+}
+
+var templateListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List task templates",
+	Args:  cobra.NoArgs,
+	RunE:  runTemplateList,
+}
+
+var templateShowCmd = &cobra.Command{
+	Use:   "show NAME",
+	Short: "Show a task template",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runTemplateShow,
+}
+
+func init() {
+	templateCmd.AddCommand(templateListCmd, templateShowCmd)
+	rootCmd.AddCommand(templateCmd)
+}
+
+func runTemplateList(_ *cobra.Command, _ []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	templates, err := board.ListTemplates(cfg)
+	if err != nil {
+		return err
+	}
+
+	switch outputFormat() {
+	case output.FormatJSON:
+		if templates == nil {
+			templates = []*task.Template{}
+		}
+		return output.JSON(os.Stdout, templates)
+	case output.FormatCompact:
+		output.TemplateCompact(os.Stdout, templates)
+	default:
+		output.TemplateTable(os.Stdout, templates)
+	}
+	return nil
+}
+
+func runTemplateShow(_ *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	tmpl, err := board.FindTemplate(cfg, args[0])
+	if err != nil {
+		return err
+	}
+
+	if outputFormat() == output.FormatJSON {
+		return output.JSON(os.Stdout, tmpl)
+	}
+	output.TemplateDetail(os.Stdout, tmpl)
+	return nil
+}
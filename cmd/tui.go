@@ -12,6 +12,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
 
+	"github.com/antopolskiy/kanban-md/internal/board"
 	"github.com/antopolskiy/kanban-md/internal/clierr"
 	"github.com/antopolskiy/kanban-md/internal/config"
 	"github.com/antopolskiy/kanban-md/internal/tui"
@@ -95,6 +96,8 @@ func runTUI(cmd *cobra.Command, _ []string) error {
 		programOptions = append(programOptions, tea.WithMouseCellMotion())
 	}
 	p := tea.NewProgram(model, programOptions...)
+	// Warnings on stderr would garble the full-screen UI.
+	board.SetPostHookReporter(func(err error) { p.Send(tui.HookFailedMsg{Err: err}) })
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
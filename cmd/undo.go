@@ -0,0 +1,245 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/antopolskiy/kanban-md/internal/board"
+	"github.com/antopolskiy/kanban-md/internal/clierr"
+	"github.com/antopolskiy/kanban-md/internal/config"
+	"github.com/antopolskiy/kanban-md/internal/date"
+	"github.com/antopolskiy/kanban-md/internal/output"
+	"github.com/antopolskiy/kanban-md/internal/task"
+)
+
+var undoCmd = &cobra.Command{
+	Use:   "undo",
+	Short: "Undo recent board mutations",
+	Long: `Reverts recent mutations recorded in the activity log, newest first, by
+restoring every changed field to its previous value.
+
+By default the most recent mutation not yet undone is reverted; use --last N
+for more, or --entry ID for a specific log entry (see "history" or "log --json").
+An undo fails with CONFLICT if a field it would restore has changed since;
+undo the later change first. Restored tasks are validated like an edit (claims,
+WIP limits, dependencies), and each undo is itself logged.`,
+	Args: cobra.NoArgs,
+	RunE: runUndo,
+}
+
+var revertCmd = &cobra.Command{
+	Use:   "revert",
+	Short: "Revert all mutations since a point in time",
+	Long: `Reverts every mutation recorded in the activity log since --since, newest
+first, optionally only those made by --actor. Use it to roll back what an agent
+did, e.g. "kanban-md revert --since 8h --actor night-agent".
+
+Tasks still claimed by --actor are reverted without --claim: undoing an
+agent's work does not require taking over its claims. Tasks claimed by anyone
+else still need --claim with the holder's name.
+
+--since accepts a date (YYYY-MM-DD), an RFC 3339 timestamp, or a duration
+ago (e.g. 90m, 8h). Each reverted entry is undone as with "undo".`,
+	Args: cobra.NoArgs,
+	RunE: runRevert,
+}
+
+func init() {
+	undoCmd.Flags().Int("last", 0, "undo the N most recent mutations (default 1)")
+	undoCmd.Flags().String("entry", "", "undo a specific log entry by ID")
+	undoCmd.Flags().String("claim", "", "claim name for tasks claimed by an agent")
+	undoCmd.Flags().Bool("dry-run", false, "list the entries that would be undone without changing anything")
+	rootCmd.AddCommand(undoCmd)
+
+	revertCmd.Flags().String("since", "", "revert mutations at or after this time (required)")
+	revertCmd.Flags().String("actor", "", "only revert mutations made by this agent")
+	revertCmd.Flags().String("claim", "", "claim name for tasks claimed by an agent other than --actor")
+	revertCmd.Flags().Bool("dry-run", false, "list the entries that would be reverted without changing anything")
+	rootCmd.AddCommand(revertCmd)
+}
+
+// undoResult is the outcome of reverting one log entry.
+type undoResult struct {
+	Entry  string `json:"entry"`
+	Action string `json:"action"`
+	TaskID int    `json:"task_id"`
+	OK     bool   `json:"ok"`
+	Error  string `json:"error,omitempty"`
+	Code   string `json:"code,omitempty"`
+}
+
+func runUndo(cmd *cobra.Command, _ []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	last, _ := cmd.Flags().GetInt("last")
+	entryID, _ := cmd.Flags().GetString("entry")
+	if entryID != "" && last > 0 {
+		return clierr.New(clierr.InvalidInput, "cannot use --last and --entry together")
+	}
+	if last < 0 {
+		return clierr.New(clierr.InvalidInput, "--last must be positive")
+	}
+
+	var entries []board.LogEntry
+	if entryID != "" {
+		entry, undone, findErr := board.FindLogEntry(cfg.Dir(), entryID)
+		if findErr != nil {
+			return findErr
+		}
+		if undone {
+			return clierr.Newf(clierr.InvalidInput, "log entry %s has already been undone", entryID).
+				WithDetails(map[string]any{"entry": entryID})
+		}
+		entries = []board.LogEntry{entry}
+	} else {
+		all, readErr := board.ReadLog(cfg.Dir(), board.LogFilterOptions{})
+		if readErr != nil {
+			return readErr
+		}
+		entries = board.PendingUndo(all)
+		if last == 0 {
+			last = 1
+		}
+		if len(entries) > last {
+			entries = entries[:last]
+		}
+	}
+
+	return undoEntries(cmd, cfg, entries, "")
+}
+
+func runRevert(cmd *cobra.Command, _ []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	sinceStr, _ := cmd.Flags().GetString("since")
+	if sinceStr == "" {
+		return clierr.New(clierr.InvalidInput, "--since is required")
+	}
+	since, err := parseSinceTime(sinceStr, time.Now())
+	if err != nil {
+		return err
+	}
+	actor, _ := cmd.Flags().GetString("actor")
+
+	all, err := board.ReadLog(cfg.Dir(), board.LogFilterOptions{Since: since})
+	if err != nil {
+		return err
+	}
+	var entries []board.LogEntry
+	for _, e := range board.PendingUndo(all) {
+		if actor == "" || e.Actor == actor {
+			entries = append(entries, e)
+		}
+	}
+
+	return undoEntries(cmd, cfg, entries, actor)
+}
+
+// parseSinceTime parses a --since value: a duration ago, an RFC 3339
+// timestamp, or a date.
+func parseSinceTime(v string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(v); err == nil && d > 0 {
+		return now.Add(-d), nil
+	}
+	if ts, err := time.Parse(time.RFC3339, v); err == nil {
+		return ts, nil
+	}
+	d, err := date.Parse(v)
+	if err != nil {
+		return time.Time{}, task.ValidateDate("since", v, err)
+	}
+	return d.Time, nil
+}
+
+// undoEntries reverts entries in order (newest first) and reports the outcome
+// of each. A failed undo does not stop the others. Without --claim, tasks
+// claimed by actor (the agent being reverted, if any) are undone as actor.
+func undoEntries(cmd *cobra.Command, cfg *config.Config, entries []board.LogEntry, actor string) error {
+	if len(entries) == 0 {
+		if outputFormat() == output.FormatJSON {
+			return output.JSON(os.Stdout, []undoResult{})
+		}
+		output.Messagef(os.Stderr, "Nothing to undo.")
+		return nil
+	}
+
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	claimant, _ := cmd.Flags().GetString("claim")
+
+	results := make([]undoResult, 0, len(entries))
+	anyFailed := false
+	for _, e := range entries {
+		r := undoResult{Entry: e.ID, Action: e.Action, TaskID: e.TaskID, OK: true}
+		if !dryRun {
+			if _, err := board.Undo(cfg, e, undoClaimant(cfg, e, claimant, actor), time.Now()); err != nil {
+				anyFailed = true
+				r.OK = false
+				r.Error = err.Error()
+				var cliErr *clierr.Error
+				if errors.As(err, &cliErr) {
+					r.Error, r.Code = cliErr.Message, cliErr.Code
+				}
+			}
+		}
+		results = append(results, r)
+	}
+
+	if outputFormat() == output.FormatJSON {
+		if err := output.JSON(os.Stdout, results); err != nil {
+			return err
+		}
+	} else {
+		printUndoResults(results, dryRun)
+	}
+
+	if anyFailed {
+		return &clierr.SilentError{Code: 1}
+	}
+	return nil
+}
+
+// undoClaimant returns the claimant to undo e with: claimant if given,
+// otherwise actor when the task is still claimed by actor.
+func undoClaimant(cfg *config.Config, e board.LogEntry, claimant, actor string) string {
+	if claimant != "" || actor == "" {
+		return claimant
+	}
+	path, err := task.FindByID(cfg.TasksPath(), e.TaskID)
+	if err != nil {
+		return claimant
+	}
+	t, err := task.Read(path)
+	if err != nil || t.ClaimedBy != actor {
+		return claimant
+	}
+	return actor
+}
+
+func printUndoResults(results []undoResult, dryRun bool) {
+	verb := "Undid"
+	if dryRun {
+		verb = "Would undo"
+	}
+	succeeded := 0
+	for _, r := range results {
+		if !r.OK {
+			fmt.Fprintf(os.Stderr, "Error: entry %s (%s #%d): %s\n", r.Entry, r.Action, r.TaskID, r.Error)
+			continue
+		}
+		succeeded++
+		output.Messagef(os.Stdout, "%s %s of task #%d (entry %s)", verb, r.Action, r.TaskID, r.Entry)
+	}
+	if !dryRun && len(results) > 1 {
+		output.Messagef(os.Stdout, "Completed %d/%d undos", succeeded, len(results))
+	}
+}
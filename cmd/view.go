@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/antopolskiy/kanban-md/internal/board"
+	"github.com/antopolskiy/kanban-md/internal/config"
+	"github.com/antopolskiy/kanban-md/internal/output"
+)
+
+var viewCmd = &cobra.Command{
+	Use:   "view [NAME]",
+	Short: "Show tasks through a saved view",
+	Long: `Lists tasks using a named view saved in config.yml (filters, sort, and
+group-by). Without a name, lists the saved views.
+
+Manage views with "config set views.NAME '<yaml>'" and remove one with
+"config set views.NAME ''".`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runView,
+}
+
+func init() {
+	rootCmd.AddCommand(viewCmd)
+}
+
+func runView(_ *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 0 {
+		return outputViewList(cfg.Views)
+	}
+
+	v, err := board.FindView(cfg, args[0])
+	if err != nil {
+		return err
+	}
+	opts, err := board.ViewListOptions(cfg, v)
+	if err != nil {
+		return err
+	}
+
+	tasks, warnings, err := board.List(cfg, opts)
+	if err != nil {
+		return err
+	}
+	printWarnings(warnings)
+
+	if v.GroupBy != "" {
+		return outputGroupedList(tasks, v.GroupBy, cfg)
+	}
+	return outputTaskList(tasks)
+}
+
+func outputViewList(views []config.ViewConfig) error {
+	format := outputFormat()
+	if format == output.FormatJSON {
+		if views == nil {
+			views = []config.ViewConfig{}
+		}
+		return output.JSON(os.Stdout, views)
+	}
+	if format == output.FormatCompact {
+		output.ViewCompact(os.Stdout, views)
+		return nil
+	}
+
+	output.ViewTable(os.Stdout, views)
+	return nil
+}
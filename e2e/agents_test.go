@@ -0,0 +1,69 @@
+package e2e_test
+
+import (
+	"testing"
+	"time"
+)
+
+type agentJSON struct {
+	Name       string         `json:"name"`
+	Live       bool           `json:"live"`
+	LastAction string         `json:"last_action"`
+	Actions    map[string]int `json:"actions"`
+	Claims     []int          `json:"claims"`
+}
+
+type reapedJSON struct {
+	Agent  string `json:"agent"`
+	TaskID int    `json:"task_id"`
+}
+
+func TestAgentsListsPresence(t *testing.T) {
+	kanbanDir := initBoard(t)
+	mustCreateTask(t, kanbanDir, "Alpha")
+	mustCreateTask(t, kanbanDir, "Beta")
+	runKanban(t, kanbanDir, "pick", "--claim", claimAgent1)
+	runKanban(t, kanbanDir, "edit", "2", "--priority", "high", "--claim", claimTestAgent)
+
+	var agents []agentJSON
+	runKanbanJSON(t, kanbanDir, &agents, "agents")
+	if len(agents) != 2 {
+		t.Fatalf("agents = %+v, want two", agents)
+	}
+	byName := map[string]agentJSON{}
+	for _, a := range agents {
+		byName[a.Name] = a
+	}
+	if a := byName[claimAgent1]; !a.Live || a.LastAction != "pick" || len(a.Claims) != 1 {
+		t.Errorf("%s = %+v, want live with one claim from pick", claimAgent1, a)
+	}
+	if a := byName[claimTestAgent]; a.Actions["edit"] != 1 || len(a.Claims) != 1 {
+		t.Errorf("%s = %+v, want one edit and one claim", claimTestAgent, a)
+	}
+}
+
+func TestAgentsReapReleasesStaleClaims(t *testing.T) {
+	kanbanDir := initBoard(t)
+	setConfigClaimTimeout(t, kanbanDir, "1s")
+	mustCreateTask(t, kanbanDir, "Alpha", "--claim", claimAgent1)
+
+	time.Sleep(1100 * time.Millisecond)
+
+	var stale []agentJSON
+	runKanbanJSON(t, kanbanDir, &stale, "agents", "--stale")
+	if len(stale) != 1 || stale[0].Live {
+		t.Fatalf("stale agents = %+v, want %s", stale, claimAgent1)
+	}
+
+	var reaped []reapedJSON
+	runKanbanJSON(t, kanbanDir, &reaped, "agents", "reap")
+	if len(reaped) != 1 || reaped[0].Agent != claimAgent1 || reaped[0].TaskID != 1 {
+		t.Fatalf("reaped = %+v, want task #1 from %s", reaped, claimAgent1)
+	}
+
+	var tk taskJSON
+	runKanbanJSON(t, kanbanDir, &tk, "show", "1")
+	if tk.ClaimedBy != "" {
+		t.Errorf("claimed_by = %q, want released", tk.ClaimedBy)
+	}
+}
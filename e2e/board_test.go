@@ -93,6 +93,26 @@ func TestBoardSummaryWithTasks(t *testing.T) {
 	}
 }
 
+func TestBoardQuery(t *testing.T) {
+	kanbanDir := initBoard(t)
+	mustCreateTask(t, kanbanDir, "API", "--tags", "backend")
+	mustCreateTask(t, kanbanDir, "UI", "--tags", "frontend")
+	mustCreateTask(t, kanbanDir, "DB", "--tags", "backend", "--status", statusTodo)
+
+	var summary struct {
+		TotalTasks int `json:"total_tasks"`
+	}
+	runKanbanJSON(t, kanbanDir, &summary, "board", "--query", "tag:backend")
+	if summary.TotalTasks != 2 {
+		t.Errorf("TotalTasks = %d, want 2", summary.TotalTasks)
+	}
+
+	errResp := runKanbanJSONError(t, kanbanDir, "board", "--query", "tag:")
+	if errResp.Code != "INVALID_QUERY" {
+		t.Errorf("code = %q, want INVALID_QUERY", errResp.Code)
+	}
+}
+
 func TestBoardSummaryTableOutput(t *testing.T) {
 	kanbanDir := initBoard(t)
 	mustCreateTask(t, kanbanDir, "Table test")
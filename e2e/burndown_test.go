@@ -0,0 +1,105 @@
+package e2e_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// ---------------------------------------------------------------------------
+// Burndown tests
+// ---------------------------------------------------------------------------
+
+type burndownJSON struct {
+	Scope       string `json:"scope"`
+	Unestimated int    `json:"unestimated"`
+	Days        []struct {
+		Scope             int     `json:"scope"`
+		Completed         int     `json:"completed"`
+		Remaining         int     `json:"remaining"`
+		ScopeEstimate     float64 `json:"scope_estimate"`
+		RemainingEstimate float64 `json:"remaining_estimate"`
+		Added             []int   `json:"added"`
+		Removed           []int   `json:"removed"`
+	} `json:"days"`
+}
+
+func TestBurndownTag(t *testing.T) {
+	kanbanDir := initBoard(t)
+	mustCreateTask(t, kanbanDir, "Task A", "--tags", "v2", "--estimate", "1d")
+	mustCreateTask(t, kanbanDir, "Task B", "--tags", "v2", "--estimate", "4h")
+	mustCreateTask(t, kanbanDir, "Task C")
+	mustCreateTask(t, kanbanDir, "Task D", "--tags", "v2")
+	runKanban(t, kanbanDir, "--json", "edit", "3", "--add-tag", "v2")
+	runKanban(t, kanbanDir, "--json", "edit", "4", "--remove-tag", "v2")
+	runKanban(t, kanbanDir, "--json", "move", "1", "done")
+
+	var b burndownJSON
+	runKanbanJSON(t, kanbanDir, &b, "burndown", "--tag", "v2")
+
+	if b.Scope != "tag v2" || len(b.Days) != 1 {
+		t.Fatalf("scope = %q with %d days, want tag v2 over today", b.Scope, len(b.Days))
+	}
+	d := b.Days[0]
+	if d.Scope != 3 || d.Completed != 1 || d.Remaining != 2 {
+		t.Errorf("scope/completed/remaining = %d/%d/%d, want 3/1/2", d.Scope, d.Completed, d.Remaining)
+	}
+	if d.ScopeEstimate != 12 || d.RemainingEstimate != 4 || b.Unestimated != 1 {
+		t.Errorf("scope/remaining estimate = %v/%v, unestimated %d; want 12/4, 1",
+			d.ScopeEstimate, d.RemainingEstimate, b.Unestimated)
+	}
+	if !slices.Equal(d.Added, []int{1, 2, 3}) || len(d.Removed) != 0 {
+		t.Errorf("added/removed = %v/%v, want [1 2 3]/[] (4 was added and removed the same day)", d.Added, d.Removed)
+	}
+}
+
+func TestBurndownParentSince(t *testing.T) {
+	kanbanDir := initBoard(t)
+	mustCreateTask(t, kanbanDir, "Epic")
+	mustCreateTask(t, kanbanDir, "Sub A", "--parent", "1")
+	mustCreateTask(t, kanbanDir, "Sub B", "--parent", "1")
+	runKanban(t, kanbanDir, "--json", "move", "2", "done")
+
+	var b burndownJSON
+	runKanbanJSON(t, kanbanDir, &b, "burndown", "--parent", "1", "--since", "2020-01-01", "--until", "2099-01-01")
+
+	if b.Days[0].Scope != 0 {
+		t.Errorf("first day scope = %d, want 0 (before the tasks existed)", b.Days[0].Scope)
+	}
+	last := b.Days[len(b.Days)-1]
+	if last.Scope != 2 || last.Completed != 1 {
+		t.Errorf("last day scope/completed = %d/%d, want 2/1", last.Scope, last.Completed)
+	}
+}
+
+func TestBurndownTableOutput(t *testing.T) {
+	kanbanDir := initBoard(t)
+	mustCreateTask(t, kanbanDir, "Task A", "--tags", "v2")
+
+	r := runKanban(t, kanbanDir, "--table", "burndown", "--tag", "v2")
+	if r.exitCode != 0 {
+		t.Fatalf("burndown failed (exit %d): %s", r.exitCode, r.stderr)
+	}
+	for _, want := range []string{"Burndown: tag v2", "Remaining:", "Scope changes", "+#1"} {
+		if !strings.Contains(r.stdout, want) {
+			t.Errorf("table output missing %q:\n%s", want, r.stdout)
+		}
+	}
+}
+
+func TestBurndownErrors(t *testing.T) {
+	kanbanDir := initBoard(t)
+
+	errResp := runKanbanJSONError(t, kanbanDir, "burndown")
+	if errResp.Code != codeInvalidInput {
+		t.Errorf("no scope: code = %q, want %q", errResp.Code, codeInvalidInput)
+	}
+	errResp = runKanbanJSONError(t, kanbanDir, "burndown", "--tag", "v2", "--since", "2026-02-01", "--until", "2026-01-01")
+	if errResp.Code != codeInvalidInput {
+		t.Errorf("since after until: code = %q, want %q", errResp.Code, codeInvalidInput)
+	}
+	errResp = runKanbanJSONError(t, kanbanDir, "burndown", "--tag", "v2", "--since", "soon")
+	if errResp.Code != codeInvalidDate {
+		t.Errorf("bad date: code = %q, want %q", errResp.Code, codeInvalidDate)
+	}
+}
@@ -0,0 +1,46 @@
+package e2e_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type claimInfoJSON struct {
+	ID        int    `json:"id"`
+	ClaimedBy string `json:"claimed_by"`
+	Expired   bool   `json:"expired"`
+}
+
+func TestClaimsExpireMovesAbandonedTasks(t *testing.T) {
+	kanbanDir := initBoard(t)
+	setConfigClaimTimeout(t, kanbanDir, "1s")
+	runKanban(t, kanbanDir, "config", "set", "claim_expired_status", statusTodo)
+	mustCreateTask(t, kanbanDir, "Alpha", "--status", statusInProgress, "--claim", claimAgent1)
+
+	var claims []claimInfoJSON
+	runKanbanJSON(t, kanbanDir, &claims, "claims")
+	if len(claims) != 1 || claims[0].Expired {
+		t.Fatalf("claims = %+v, want one active claim", claims)
+	}
+
+	time.Sleep(1100 * time.Millisecond)
+
+	var expired []claimInfoJSON
+	runKanbanJSON(t, kanbanDir, &expired, "claims", "expire")
+	if len(expired) != 1 || expired[0].ID != 1 || expired[0].ClaimedBy != claimAgent1 {
+		t.Fatalf("expired = %+v, want task #1 from %s", expired, claimAgent1)
+	}
+
+	var tk taskJSON
+	runKanbanJSON(t, kanbanDir, &tk, "show", "1")
+	if tk.ClaimedBy != "" || tk.Status != statusTodo || !strings.Contains(tk.Body, "Claim by "+claimAgent1+" expired") {
+		t.Errorf("task = %+v, want released, back in todo, with a note", tk)
+	}
+
+	var entries []logEntry
+	runKanbanJSON(t, kanbanDir, &entries, "log", "--action", "claim-expired")
+	if len(entries) != 1 {
+		t.Errorf("claim-expired log entries = %d, want 1", len(entries))
+	}
+}
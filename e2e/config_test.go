@@ -19,9 +19,9 @@ func TestConfigShowAll(t *testing.T) {
 	expectedKeys := []string{
 		"version", "board.name", "board.description", "tasks_dir",
 		"statuses", "priorities", "defaults.status", "defaults.priority", "defaults.class",
-		"wip_limits", "claim_timeout", "classes",
-		"tui.title_lines", "tui.hide_empty_columns", "tui.narrow_threshold",
-		"tui.age_thresholds", "next_id",
+		"wip_limits", "claim_timeout", "claim_expired_status", "lock_timeout", "classes", "views",
+		"fields", "transitions", "hooks", "parent_done", "recurring", "tui.title_lines",
+		"tui.hide_empty_columns", "tui.narrow_threshold", "tui.age_thresholds", "next_id",
 	}
 	for _, key := range expectedKeys {
 		if _, ok := cfg[key]; !ok {
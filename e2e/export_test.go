@@ -0,0 +1,47 @@
+package e2e_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExport(t *testing.T) {
+	kanbanDir := initBoard(t)
+	mustCreateTask(t, kanbanDir, "Ship <release>", "--status", statusTodo, "--priority", "high", "--tags", "launch")
+	mustCreateTask(t, kanbanDir, "Write notes", "--tags", "docs")
+
+	r := runKanban(t, kanbanDir, "export", "--format", "md", "--tag", "launch")
+	if !strings.Contains(r.stdout, "## todo (1)") || strings.Contains(r.stdout, "Write notes") {
+		t.Errorf("md export = %q, want only the launch task", r.stdout)
+	}
+
+	out := filepath.Join(t.TempDir(), "site")
+	var written struct {
+		Files []string `json:"files"`
+	}
+	runKanbanJSON(t, kanbanDir, &written, "export", "--format", "html,csv,json", "--out", out)
+	if len(written.Files) != 3 {
+		t.Fatalf("files = %v, want three", written.Files)
+	}
+	page, err := os.ReadFile(filepath.Join(out, "index.html")) //nolint:gosec // e2e test file
+	if err != nil {
+		t.Fatalf("reading index.html: %v", err)
+	}
+	if !strings.Contains(string(page), "Ship &lt;release&gt;") || !strings.Contains(string(page), "Write notes") {
+		t.Error("index.html should show both tasks, escaped")
+	}
+	csvData, err := os.ReadFile(filepath.Join(out, "board.csv")) //nolint:gosec // e2e test file
+	if err != nil {
+		t.Fatalf("reading board.csv: %v", err)
+	}
+	if lines := strings.Split(strings.TrimSpace(string(csvData)), "\n"); len(lines) != 3 {
+		t.Errorf("board.csv has %d lines, want header and two rows", len(lines))
+	}
+
+	errResp := runKanbanJSONError(t, kanbanDir, "export", "--format", "html,md")
+	if errResp.Code != "INVALID_INPUT" {
+		t.Errorf("several formats without --out: code = %q, want INVALID_INPUT", errResp.Code)
+	}
+}
@@ -0,0 +1,166 @@
+package e2e_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const codeInvalidField = "INVALID_FIELD"
+
+type customTaskJSON struct {
+	ID     int            `json:"id"`
+	Title  string         `json:"title"`
+	Custom map[string]any `json:"custom"`
+}
+
+// addFieldSchema appends a custom field schema to config.yml.
+func addFieldSchema(t *testing.T, kanbanDir string) {
+	t.Helper()
+	cfgPath := filepath.Join(kanbanDir, "config.yml")
+	data, err := os.ReadFile(cfgPath) //nolint:gosec // e2e test file
+	if err != nil {
+		t.Fatalf("reading config: %v", err)
+	}
+	schema := `fields:
+    - name: severity
+      type: enum
+      values: [s1, s2, s3]
+      default: s3
+    - name: points
+      type: int
+    - name: areas
+      type: list
+`
+	if err := os.WriteFile(cfgPath, append(data, schema...), 0o600); err != nil {
+		t.Fatalf("writing config: %v", err)
+	}
+}
+
+func TestCreateWithCustomFields(t *testing.T) {
+	kanbanDir := initBoard(t)
+	addFieldSchema(t, kanbanDir)
+
+	var tk customTaskJSON
+	runKanbanJSON(t, kanbanDir, &tk, "create", "Alpha",
+		"--set", "points=5", "--set", "areas=api,ui", "--set", "jira=ABC-1")
+	if tk.Custom["points"] != float64(5) || tk.Custom["severity"] != "s3" || tk.Custom["jira"] != "ABC-1" {
+		t.Errorf("custom = %v, want points 5, default severity s3, jira ABC-1", tk.Custom)
+	}
+
+	data, err := os.ReadFile(filepath.Join(kanbanDir, "tasks", "001-alpha.md")) //nolint:gosec // e2e test file
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "points: 5\n") || !strings.Contains(string(data), "jira: ABC-1\n") {
+		t.Errorf("task file missing custom fields:\n%s", data)
+	}
+
+	errResp := runKanbanJSONError(t, kanbanDir, "create", "Beta", "--set", "points=lots")
+	if errResp.Code != codeInvalidField {
+		t.Errorf("code = %q, want %q", errResp.Code, codeInvalidField)
+	}
+	errResp = runKanbanJSONError(t, kanbanDir, "create", "Beta", "--set", "status=done")
+	if errResp.Code != codeInvalidField {
+		t.Errorf("code = %q, want %q", errResp.Code, codeInvalidField)
+	}
+}
+
+func TestEditSetAndUnsetCustomFields(t *testing.T) {
+	kanbanDir := initBoard(t)
+	addFieldSchema(t, kanbanDir)
+	mustCreateTask(t, kanbanDir, "Alpha", "--set", "jira=ABC-1")
+
+	var tk customTaskJSON
+	runKanbanJSON(t, kanbanDir, &tk, "edit", "1", "--set", "severity=s1", "--unset", "jira")
+	if tk.Custom["severity"] != "s1" {
+		t.Errorf("severity = %v, want s1", tk.Custom["severity"])
+	}
+	if _, ok := tk.Custom["jira"]; ok {
+		t.Errorf("jira still set: %v", tk.Custom)
+	}
+
+	errResp := runKanbanJSONError(t, kanbanDir, "edit", "1", "--set", "severity=s9")
+	if errResp.Code != codeInvalidField {
+		t.Errorf("code = %q, want %q", errResp.Code, codeInvalidField)
+	}
+
+	r := runKanban(t, kanbanDir, "show", "1")
+	if !strings.Contains(r.stdout, "severity:") || !strings.Contains(r.stdout, "s1") {
+		t.Errorf("show output missing custom field:\n%s", r.stdout)
+	}
+}
+
+func TestRequiredCustomField(t *testing.T) {
+	kanbanDir := initBoard(t)
+	cfgPath := filepath.Join(kanbanDir, "config.yml")
+	data, err := os.ReadFile(cfgPath) //nolint:gosec // e2e test file
+	if err != nil {
+		t.Fatal(err)
+	}
+	data = append(data, "fields:\n    - name: team\n      type: string\n      required: true\n"...)
+	if err := os.WriteFile(cfgPath, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	errResp := runKanbanJSONError(t, kanbanDir, "create", "Alpha")
+	if errResp.Code != codeInvalidField {
+		t.Errorf("code = %q, want %q", errResp.Code, codeInvalidField)
+	}
+	mustCreateTask(t, kanbanDir, "Alpha", "--set", "team=core")
+
+	errResp = runKanbanJSONError(t, kanbanDir, "edit", "1", "--unset", "team")
+	if errResp.Code != codeInvalidField {
+		t.Errorf("code = %q, want %q", errResp.Code, codeInvalidField)
+	}
+}
+
+func TestListFilterAndSortByCustomField(t *testing.T) {
+	kanbanDir := initBoard(t)
+	addFieldSchema(t, kanbanDir)
+	mustCreateTask(t, kanbanDir, "Alpha", "--set", "points=8", "--set", "areas=api")
+	mustCreateTask(t, kanbanDir, "Beta", "--set", "points=2", "--set", "severity=s1")
+	mustCreateTask(t, kanbanDir, "Gamma", "--set", "areas=api,ui")
+
+	var tasks []customTaskJSON
+	runKanbanJSON(t, kanbanDir, &tasks, "list", "--field", "areas=api", "--sort", "points")
+	if len(tasks) != 2 || tasks[0].Title != "Alpha" || tasks[1].Title != "Gamma" {
+		t.Errorf("list --field areas=api = %+v, want Alpha, Gamma", tasks)
+	}
+
+	runKanbanJSON(t, kanbanDir, &tasks, "list", "--sort", "points")
+	if len(tasks) != 3 || tasks[0].Title != "Beta" || tasks[2].Title != "Gamma" {
+		t.Errorf("list --sort points = %+v, want Beta, Alpha, Gamma", tasks)
+	}
+
+	runKanbanJSON(t, kanbanDir, &tasks, "list", "-q", "points>=5 OR severity:s1")
+	if len(tasks) != 2 {
+		t.Errorf("query = %+v, want Alpha and Beta", tasks)
+	}
+}
+
+func TestUnknownFrontmatterPreservedOnEdit(t *testing.T) {
+	kanbanDir := initBoard(t)
+	mustCreateTask(t, kanbanDir, "Alpha")
+
+	path := filepath.Join(kanbanDir, "tasks", "001-alpha.md")
+	data, err := os.ReadFile(path) //nolint:gosec // e2e test file
+	if err != nil {
+		t.Fatal(err)
+	}
+	edited := strings.Replace(string(data), "title: Alpha\n", "title: Alpha\nexternal_ref: JIRA-9\n", 1)
+	if err := os.WriteFile(path, []byte(edited), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	runKanban(t, kanbanDir, "edit", "1", "--priority", priorityHigh)
+
+	data, err = os.ReadFile(path) //nolint:gosec // e2e test file
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "external_ref: JIRA-9") {
+		t.Errorf("unknown frontmatter lost after edit:\n%s", data)
+	}
+}
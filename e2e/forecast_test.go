@@ -0,0 +1,103 @@
+package e2e_test
+
+import (
+	"strings"
+	"testing"
+)
+
+// ---------------------------------------------------------------------------
+// Forecast tests
+// ---------------------------------------------------------------------------
+
+type forecastJSON struct {
+	Mode          string  `json:"mode"`
+	Tasks         int     `json:"tasks"`
+	AvgThroughput float64 `json:"avg_throughput"`
+	Outcomes      []struct {
+		Confidence int    `json:"confidence"`
+		Date       string `json:"date"`
+		Days       *int   `json:"days"`
+		Tasks      *int   `json:"tasks"`
+	} `json:"outcomes"`
+}
+
+// setupForecastBoard creates a board with two tasks finished today and three
+// unfinished ones, one tagged api.
+func setupForecastBoard(t *testing.T) string {
+	t.Helper()
+	kanbanDir := initBoard(t)
+	for _, title := range []string{"Done A", "Done B", "Open C", "Open D"} {
+		mustCreateTask(t, kanbanDir, title)
+	}
+	mustCreateTask(t, kanbanDir, "Open E", "--tags", "api")
+	runKanban(t, kanbanDir, "--json", "move", "1", "done")
+	runKanban(t, kanbanDir, "--json", "move", "2", "done")
+	return kanbanDir
+}
+
+func TestForecastWhen(t *testing.T) {
+	kanbanDir := setupForecastBoard(t)
+
+	var f forecastJSON
+	runKanbanJSON(t, kanbanDir, &f, "forecast", "--seed", "42", "--runs", "500")
+
+	if f.Mode != "when" || f.Tasks != 3 {
+		t.Errorf("mode/tasks = %s/%d, want when/3 (the unfinished tasks)", f.Mode, f.Tasks)
+	}
+	if len(f.Outcomes) != 3 || f.Outcomes[0].Confidence != 50 || f.Outcomes[2].Confidence != 95 {
+		t.Fatalf("outcomes = %+v, want 50/85/95%%", f.Outcomes)
+	}
+	for _, o := range f.Outcomes {
+		if o.Date == "" || o.Days == nil || *o.Days < 1 {
+			t.Errorf("outcome = %+v, want a date at least a day ahead", o)
+		}
+	}
+
+	var again forecastJSON
+	runKanbanJSON(t, kanbanDir, &again, "forecast", "--seed", "42", "--runs", "500")
+	if again.Outcomes[1].Date != f.Outcomes[1].Date {
+		t.Errorf("same seed gave %s and %s", f.Outcomes[1].Date, again.Outcomes[1].Date)
+	}
+
+	runKanbanJSON(t, kanbanDir, &f, "forecast", "--tag", "api")
+	if f.Tasks != 1 {
+		t.Errorf("tasks with --tag api = %d, want 1", f.Tasks)
+	}
+}
+
+func TestForecastHowMany(t *testing.T) {
+	kanbanDir := setupForecastBoard(t)
+
+	var f forecastJSON
+	runKanbanJSON(t, kanbanDir, &f, "forecast", "--by", "2099-01-01", "--runs", "100")
+
+	if f.Mode != "how_many" || len(f.Outcomes) != 3 {
+		t.Fatalf("forecast = %+v", f)
+	}
+	if f.Outcomes[0].Tasks == nil || *f.Outcomes[0].Tasks < 1 {
+		t.Errorf("outcome = %+v, want a task count", f.Outcomes[0])
+	}
+
+	r := runKanban(t, kanbanDir, "--table", "forecast", "--by", "2099-01-01", "--runs", "100")
+	if !strings.Contains(r.stdout, "how many tasks will be done by 2099-01-01?") || !strings.Contains(r.stdout, "tasks or more") {
+		t.Errorf("table output:\n%s", r.stdout)
+	}
+}
+
+func TestForecastErrors(t *testing.T) {
+	kanbanDir := initBoard(t)
+	mustCreateTask(t, kanbanDir, "Open")
+
+	errResp := runKanbanJSONError(t, kanbanDir, "forecast")
+	if errResp.Code != "NO_THROUGHPUT" {
+		t.Errorf("code = %q, want NO_THROUGHPUT", errResp.Code)
+	}
+	errResp = runKanbanJSONError(t, kanbanDir, "forecast", "--tasks", "3", "--by", "2099-01-01")
+	if errResp.Code != codeInvalidInput {
+		t.Errorf("code = %q, want %q", errResp.Code, codeInvalidInput)
+	}
+	errResp = runKanbanJSONError(t, kanbanDir, "forecast", "--by", "2020-01-01")
+	if errResp.Code != codeInvalidInput {
+		t.Errorf("code = %q, want %q", errResp.Code, codeInvalidInput)
+	}
+}
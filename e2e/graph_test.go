@@ -0,0 +1,61 @@
+package e2e_test
+
+import (
+	"strings"
+	"testing"
+)
+
+const codeDependencyCycle = "DEPENDENCY_CYCLE"
+
+type graphJSON struct {
+	Nodes []struct {
+		ID int `json:"id"`
+	} `json:"nodes"`
+	Edges []struct {
+		From int    `json:"from"`
+		To   int    `json:"to"`
+		Kind string `json:"kind"`
+	} `json:"edges"`
+	CriticalPath []int   `json:"critical_path"`
+	Cycles       [][]int `json:"cycles"`
+}
+
+func TestGraphFormats(t *testing.T) {
+	kanbanDir := initBoard(t)
+	mustCreateTask(t, kanbanDir, "Design")
+	mustCreateTask(t, kanbanDir, "Build", "--depends-on", "1")
+	mustCreateTask(t, kanbanDir, "Ship", "--depends-on", "2")
+	mustCreateTask(t, kanbanDir, "Other")
+
+	var g graphJSON
+	runKanbanJSON(t, kanbanDir, &g, "graph", "--root", "2")
+	if len(g.Nodes) != 3 || len(g.Edges) != 2 {
+		t.Errorf("graph = %+v, want tasks 1-3 and their two dependency edges", g)
+	}
+	if len(g.CriticalPath) != 3 || g.CriticalPath[0] != 1 || g.CriticalPath[2] != 3 {
+		t.Errorf("critical_path = %v, want [1 2 3]", g.CriticalPath)
+	}
+
+	r := runKanban(t, kanbanDir, "graph", "--highlight", "critical")
+	if r.exitCode != 0 || !strings.Contains(r.stdout, "digraph kanban") || !strings.Contains(r.stdout, "t1 -> t2 [color=") {
+		t.Errorf("dot output = %q (stderr %q)", r.stdout, r.stderr)
+	}
+	r = runKanban(t, kanbanDir, "graph", "--format", "mermaid")
+	if r.exitCode != 0 || !strings.HasPrefix(r.stdout, "flowchart LR") || !strings.Contains(r.stdout, "t2 --> t3") {
+		t.Errorf("mermaid output = %q (stderr %q)", r.stdout, r.stderr)
+	}
+}
+
+func TestDependencyCycleRejected(t *testing.T) {
+	kanbanDir := initBoard(t)
+	mustCreateTask(t, kanbanDir, "A")
+	mustCreateTask(t, kanbanDir, "B", "--depends-on", "1")
+
+	errResp := runKanbanJSONError(t, kanbanDir, "edit", "1", "--add-dep", "2")
+	if errResp.Code != codeDependencyCycle {
+		t.Fatalf("code = %q, want %q", errResp.Code, codeDependencyCycle)
+	}
+	if !strings.Contains(errResp.Error, "#1 -> #2 -> #1") {
+		t.Errorf("error = %q, want the cycle path", errResp.Error)
+	}
+}
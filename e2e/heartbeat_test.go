@@ -0,0 +1,50 @@
+package e2e_test
+
+import (
+	"runtime"
+	"testing"
+)
+
+const codeClaimLost = "CLAIM_LOST"
+
+func TestHeartbeatRenewsOwnClaimOnly(t *testing.T) {
+	kanbanDir := initBoard(t)
+	mustCreateTask(t, kanbanDir, "Alpha", "--claim", claimAgent1)
+
+	var tk taskJSON
+	runKanbanJSON(t, kanbanDir, &tk, "heartbeat", "1", "--claim", claimAgent1)
+	if tk.ClaimedBy != claimAgent1 {
+		t.Errorf("claimed_by = %q, want %q", tk.ClaimedBy, claimAgent1)
+	}
+
+	errResp := runKanbanJSONError(t, kanbanDir, "heartbeat", "1", "--claim", claimTestAgent)
+	if errResp.Code != codeClaimLost {
+		t.Errorf("code = %q, want %q", errResp.Code, codeClaimLost)
+	}
+
+	var entries []logEntry
+	runKanbanJSON(t, kanbanDir, &entries, "log", "--action", "heartbeat")
+	if len(entries) != 1 {
+		t.Errorf("heartbeat log entries = %d, want 1", len(entries))
+	}
+}
+
+func TestRunKeepsClaimAlive(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	kanbanDir := initBoard(t)
+	setConfigClaimTimeout(t, kanbanDir, "1s")
+	mustCreateTask(t, kanbanDir, "Alpha", "--claim", claimAgent1)
+
+	r := runKanban(t, kanbanDir, "run", "1", "--claim", claimAgent1, "--", "sh", "-c", "sleep 1.3; exit 3")
+	if r.exitCode != 3 {
+		t.Fatalf("exit code = %d, want the command's 3 (stderr: %s)", r.exitCode, r.stderr)
+	}
+
+	var entries []logEntry
+	runKanbanJSON(t, kanbanDir, &entries, "log", "--action", "heartbeat")
+	if len(entries) < 2 {
+		t.Errorf("heartbeat log entries = %d, want the initial renewal plus at least one more", len(entries))
+	}
+}
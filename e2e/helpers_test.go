@@ -30,6 +30,7 @@ const (
 	claimAgent1          = "agent-1"
 	codeTaskClaimed      = "TASK_CLAIMED"
 	codeStatusConflict   = "STATUS_CONFLICT"
+	codeConflict         = "CONFLICT"
 	statusReview         = "review"
 	statusTodo           = "todo"
 	assigneeAlice        = "alice"
@@ -97,6 +98,7 @@ type taskJSON struct {
 	ClaimedBy   string   `json:"claimed_by,omitempty"`
 	Blocked     bool     `json:"blocked,omitempty"`
 	BlockReason string   `json:"block_reason,omitempty"`
+	Rev         string   `json:"rev,omitempty"`
 }
 
 // runKanban executes the binary with --dir prepended for test isolation.
@@ -0,0 +1,87 @@
+package e2e_test
+
+import (
+	"strings"
+	"testing"
+)
+
+type historyEntry struct {
+	Action  string `json:"action"`
+	Actor   string `json:"actor"`
+	Changes []struct {
+		Field  string `json:"field"`
+		Before any    `json:"before"`
+		After  any    `json:"after"`
+	} `json:"changes"`
+	Transition *struct {
+		From string `json:"from"`
+		To   string `json:"to"`
+	} `json:"transition"`
+}
+
+func TestHistoryRecordsFieldChanges(t *testing.T) {
+	kanbanDir := initBoard(t)
+	mustCreateTask(t, kanbanDir, "Audited")
+	runKanban(t, kanbanDir, "edit", "1", "--priority", "high", "--claim", "night-agent")
+	runKanban(t, kanbanDir, "move", "1", "todo", "--claim", "night-agent")
+
+	var entries []historyEntry
+	runKanbanJSON(t, kanbanDir, &entries, "history", "1")
+
+	var edit, move *historyEntry
+	for i := range entries {
+		switch entries[i].Action {
+		case "edit":
+			edit = &entries[i]
+		case "move":
+			move = &entries[i]
+		}
+	}
+	if edit == nil || move == nil {
+		t.Fatalf("missing edit or move entry: %+v", entries)
+	}
+	if edit.Actor != "night-agent" {
+		t.Errorf("edit actor = %q, want night-agent", edit.Actor)
+	}
+	found := false
+	for _, c := range edit.Changes {
+		if c.Field == "priority" && c.Before == "medium" && c.After == "high" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("edit changes missing priority medium -> high: %+v", edit.Changes)
+	}
+	if move.Transition == nil || move.Transition.From != "backlog" || move.Transition.To != "todo" {
+		t.Errorf("move transition = %+v", move.Transition)
+	}
+
+	r := runKanban(t, kanbanDir, "--table", "history", "1")
+	if !strings.Contains(r.stdout, "priority") || !strings.Contains(r.stdout, "medium -> high") {
+		t.Errorf("table history missing priority change:\n%s", r.stdout)
+	}
+}
+
+func TestHistoryLargeBody(t *testing.T) {
+	kanbanDir := initBoard(t)
+	body := strings.Repeat("A long line of the task body.\n", 1500) // about 45KB
+	runKanban(t, kanbanDir, "create", "Big", "--body", body)
+	runKanban(t, kanbanDir, "edit", "1", "--body", body+"One more line.")
+
+	r := runKanban(t, kanbanDir, "--table", "history", "1")
+	if r.exitCode != 0 || !strings.Contains(r.stdout, "(1500 lines) -> (1501 lines)") {
+		t.Fatalf("history after a large body edit (exit %d): %s%s", r.exitCode, r.stdout, r.stderr)
+	}
+	if r = runKanban(t, kanbanDir, "undo"); r.exitCode != 0 {
+		t.Fatalf("undo of a large body edit failed (exit %d): %s", r.exitCode, r.stderr)
+	}
+}
+
+func TestHistoryTaskNotFound(t *testing.T) {
+	kanbanDir := initBoard(t)
+
+	errResp := runKanbanJSONError(t, kanbanDir, "history", "42")
+	if errResp.Code != "TASK_NOT_FOUND" {
+		t.Errorf("code = %q, want TASK_NOT_FOUND", errResp.Code)
+	}
+}
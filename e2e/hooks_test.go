@@ -0,0 +1,122 @@
+package e2e_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+const codeHookRejected = "HOOK_REJECTED"
+
+// addHooks appends hooks to config.yml: a pre-hook that rejects moves into
+// review unless the task body mentions tests, and a post-hook that records
+// blocked tasks in blocked.log next to the kanban directory.
+func addHooks(t *testing.T, kanbanDir string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("hook commands in these tests use sh syntax")
+	}
+	cfgPath := filepath.Join(kanbanDir, "config.yml")
+	data, err := os.ReadFile(cfgPath) //nolint:gosec // e2e test file
+	if err != nil {
+		t.Fatalf("reading config: %v", err)
+	}
+	hooks := `hooks:
+    - event: move
+      when: pre
+      statuses: [review]
+      command: grep -q tests || { echo "body must mention tests"; exit 1; }
+    - event: block
+      command: echo "#$KANBAN_TASK_ID $KANBAN_TASK_TITLE" >> blocked.log
+`
+	if err := os.WriteFile(cfgPath, append(data, hooks...), 0o600); err != nil {
+		t.Fatalf("writing config: %v", err)
+	}
+}
+
+func TestPreHookRejectsMove(t *testing.T) {
+	kanbanDir := initBoard(t)
+	addHooks(t, kanbanDir)
+	mustCreateTask(t, kanbanDir, "Alpha")
+
+	errResp := runKanbanJSONError(t, kanbanDir, "move", "1", statusReview, "--claim", claimAgent1)
+	if errResp.Code != codeHookRejected {
+		t.Fatalf("code = %q, want %q", errResp.Code, codeHookRejected)
+	}
+	if !strings.Contains(errResp.Error, "body must mention tests") || errResp.Details["exit_code"] != float64(1) {
+		t.Errorf("error = %+v, want hook output and exit code", errResp)
+	}
+
+	runKanban(t, kanbanDir, "edit", "1", "-a", "All tests pass", "--claim", claimAgent1)
+	if r := runKanban(t, kanbanDir, "move", "1", statusReview, "--claim", claimAgent1); r.exitCode != 0 {
+		t.Fatalf("move after satisfying hook failed: %s", r.stderr)
+	}
+}
+
+func TestPostHookRunsOnBlock(t *testing.T) {
+	kanbanDir := initBoard(t)
+	addHooks(t, kanbanDir)
+	mustCreateTask(t, kanbanDir, "Alpha")
+
+	runKanban(t, kanbanDir, "edit", "1", "--block", "waiting on API")
+
+	data, err := os.ReadFile(filepath.Join(filepath.Dir(kanbanDir), "blocked.log")) //nolint:gosec // e2e test file
+	if err != nil {
+		t.Fatalf("post-hook did not run: %v", err)
+	}
+	if strings.TrimSpace(string(data)) != "#1 Alpha" {
+		t.Errorf("blocked.log = %q, want %q", data, "#1 Alpha")
+	}
+}
+
+func TestPreHookCanReadButNotModifyBoard(t *testing.T) {
+	kanbanDir := initBoard(t)
+	if runtime.GOOS == "windows" {
+		t.Skip("hook commands in these tests use sh syntax")
+	}
+	mustCreateTask(t, kanbanDir, "Alpha")
+	cfgPath := filepath.Join(kanbanDir, "config.yml")
+	data, err := os.ReadFile(cfgPath) //nolint:gosec // e2e test file
+	if err != nil {
+		t.Fatalf("reading config: %v", err)
+	}
+	// The hook reads the board while the move holds the board lock.
+	hooks := `hooks:
+    - event: move
+      when: pre
+      command: '"` + binPath + `" --dir "$KANBAN_BOARD_DIR" --compact show "$KANBAN_TASK_ID" > shown.txt'
+`
+	if err := os.WriteFile(cfgPath, append(data, hooks...), 0o600); err != nil {
+		t.Fatalf("writing config: %v", err)
+	}
+
+	if r := runKanban(t, kanbanDir, "move", "1", statusTodo); r.exitCode != 0 {
+		t.Fatalf("move with a pre-hook running kanban-md failed: %s", r.stderr)
+	}
+	shown, err := os.ReadFile(filepath.Join(filepath.Dir(kanbanDir), "shown.txt")) //nolint:gosec // e2e test file
+	if err != nil || !strings.Contains(string(shown), "Alpha") {
+		t.Errorf("hook output = %q (%v), want the task shown", shown, err)
+	}
+
+	// Mutations from a pre-hook fail at once instead of writing behind the
+	// back of the command holding the lock.
+	hooks = `hooks:
+    - event: edit
+      when: pre
+      command: '"` + binPath + `" --dir "$KANBAN_BOARD_DIR" move "$KANBAN_TASK_ID" done'
+`
+	if err := os.WriteFile(cfgPath, append(data, hooks...), 0o600); err != nil {
+		t.Fatalf("writing config: %v", err)
+	}
+	errResp := runKanbanJSONError(t, kanbanDir, "edit", "1", "--priority", "high")
+	if errResp.Code != "HOOK_REJECTED" || !strings.Contains(errResp.Error, "pre-hooks") {
+		t.Errorf("edit with a mutating pre-hook: code %q, error %q; want HOOK_REJECTED naming the pre-hook", errResp.Code, errResp.Error)
+	}
+	var tk taskJSON
+	runKanbanJSON(t, kanbanDir, &tk, "show", "1")
+	if tk.Status != statusTodo || tk.Priority == "high" {
+		t.Errorf("task = %s/%s, want neither the hook's move nor the edit written", tk.Status, tk.Priority)
+	}
+}
@@ -0,0 +1,118 @@
+package e2e_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestImportGitHub(t *testing.T) {
+	kanbanDir := initBoard(t)
+	export := filepath.Join(t.TempDir(), "issues.json")
+	issues := `[
+  {"number": 7, "title": "Crash on save", "body": "Trace", "state": "OPEN",
+   "labels": [{"name": "bug"}], "assignees": [{"login": "alice"}],
+   "milestone": {"title": "v2", "dueOn": "2026-05-01T00:00:00Z"},
+   "url": "https://github.com/acme/app/issues/7"},
+  {"number": 8, "title": "Shipped", "state": "CLOSED", "url": "https://github.com/acme/app/issues/8"}
+]`
+	if err := os.WriteFile(export, []byte(issues), 0o600); err != nil {
+		t.Fatalf("writing export: %v", err)
+	}
+
+	r := runKanban(t, kanbanDir, "--table", "import", "--from", "github", "--dry-run", export)
+	if !strings.Contains(r.stdout, `Would create "Crash on save"`) || !strings.Contains(r.stdout, "2 to create") {
+		t.Errorf("dry run = %q, want both issues to create", r.stdout)
+	}
+
+	var results []struct {
+		ExternalID string `json:"external_id"`
+		ID         int    `json:"id"`
+		Action     string `json:"action"`
+	}
+	runKanbanJSON(t, kanbanDir, &results, "import", "--from", "github", export)
+	if len(results) != 2 || results[0].Action != "created" || results[0].ExternalID != "github:acme/app#7" {
+		t.Fatalf("import = %+v, want two created tasks", results)
+	}
+	var tk taskJSON
+	runKanbanJSON(t, kanbanDir, &tk, "show", "1")
+	if tk.Assignee != "alice" || len(tk.Tags) != 1 || tk.Due != "2026-05-01" {
+		t.Errorf("task = %+v, want alice, tag bug, due 2026-05-01", tk)
+	}
+	runKanbanJSON(t, kanbanDir, &tk, "show", "2")
+	if tk.Status != "done" {
+		t.Errorf("closed issue status = %q, want %q", tk.Status, "done")
+	}
+
+	r = runKanban(t, kanbanDir, "--table", "import", "--from", "github", export)
+	if !strings.Contains(r.stdout, "0 created, 2 already imported.") {
+		t.Errorf("re-import = %q, want nothing new", r.stdout)
+	}
+}
+
+func TestImportCSVWithMapping(t *testing.T) {
+	kanbanDir := initBoard(t)
+	dir := t.TempDir()
+	csvPath := filepath.Join(dir, "tasks.csv")
+	mappingPath := filepath.Join(dir, "mapping.yml")
+	if err := os.WriteFile(csvPath, []byte("Key,Summary,State\nK-1,Write docs,Doing\n"), 0o600); err != nil {
+		t.Fatalf("writing csv: %v", err)
+	}
+	mapping := "columns: {id: Key, title: Summary, status: State}\nstatuses: {Doing: in-progress}\n"
+	if err := os.WriteFile(mappingPath, []byte(mapping), 0o600); err != nil {
+		t.Fatalf("writing mapping: %v", err)
+	}
+
+	var tasks []taskJSON
+	runKanbanJSON(t, kanbanDir, &[]any{}, "import", "--from", "csv", "--mapping", mappingPath, csvPath)
+	runKanbanJSON(t, kanbanDir, &tasks, "list", "-q", `external_id:"csv:K-1"`)
+	if len(tasks) != 1 || tasks[0].Title != "Write docs" || tasks[0].Status != statusInProgress {
+		t.Errorf("tasks = %+v, want the in-progress docs task", tasks)
+	}
+
+	errResp := runKanbanJSONError(t, kanbanDir, "import", "--from", "jira", csvPath)
+	if errResp.Code != "INVALID_INPUT" {
+		t.Errorf("code = %q, want INVALID_INPUT", errResp.Code)
+	}
+}
+
+func TestImportCSVWithoutIDs(t *testing.T) {
+	kanbanDir := initBoard(t)
+	csvPath := filepath.Join(t.TempDir(), "tasks.csv")
+	if err := os.WriteFile(csvPath, []byte("title\nWrite docs\n"), 0o600); err != nil {
+		t.Fatalf("writing csv: %v", err)
+	}
+
+	errResp := runKanbanJSONError(t, kanbanDir, "import", "--from", "csv", csvPath)
+	if errResp.Code != codeInvalidInput || !strings.Contains(errResp.Error, "--allow-duplicates") {
+		t.Errorf("error = %+v, want INVALID_INPUT suggesting --allow-duplicates", errResp)
+	}
+	runKanbanJSON(t, kanbanDir, &[]any{}, "import", "--from", "csv", "--allow-duplicates", csvPath)
+	var tasks []taskJSON
+	runKanbanJSON(t, kanbanDir, &tasks, "list")
+	if len(tasks) != 1 {
+		t.Errorf("tasks = %d, want 1", len(tasks))
+	}
+}
+
+func TestImportDryRunChecksWIPLimits(t *testing.T) {
+	kanbanDir := initBoardWithWIP(t, 2)
+	csvPath := filepath.Join(t.TempDir(), "tasks.csv")
+	rows := "id,title,status\n1,A,in-progress\n2,B,in-progress\n3,C,in-progress\n"
+	if err := os.WriteFile(csvPath, []byte(rows), 0o600); err != nil {
+		t.Fatalf("writing csv: %v", err)
+	}
+
+	for _, args := range [][]string{{"--dry-run"}, {}} {
+		errResp := runKanbanJSONError(t, kanbanDir, append([]string{"import", "--from", "csv", csvPath}, args...)...)
+		if errResp.Code != codeWIPLimitExceeded {
+			t.Errorf("import %v: code = %q, want %q", args, errResp.Code, codeWIPLimitExceeded)
+		}
+	}
+	var tasks []taskJSON
+	runKanbanJSON(t, kanbanDir, &tasks, "list")
+	if len(tasks) != 0 {
+		t.Errorf("failed import left %d tasks, want none", len(tasks))
+	}
+}
@@ -52,6 +52,10 @@ func TestListFilters(t *testing.T) {
 		{"status+assignee", []string{"--status", statusTodo, "--assignee", assigneeAlice}, []int{1, 3}},
 		{"assignee+tag", []string{"--assignee", assigneeAlice, "--tag", "api"}, []int{1}},
 		{"no match", []string{"--assignee", "nobody"}, []int{}},
+		{"query or", []string{"--query", "tag:api OR tag:docs"}, []int{1, 4}},
+		{"query grouped", []string{"--query", "status:todo,in-progress AND (tag:frontend OR priority>=critical)"}, []int{2, 3}},
+		{"query not", []string{"-q", "NOT assignee:alice AND NOT has:assignee"}, []int{4}},
+		{"query with flags", []string{"--assignee", assigneeAlice, "--query", "priority<critical"}, []int{1}},
 	}
 
 	for _, tt := range tests {
@@ -431,3 +435,39 @@ func TestListCompactOutput(t *testing.T) {
 		t.Error("compact list output should contain task title")
 	}
 }
+
+func TestListQueryArchivedDefault(t *testing.T) {
+	kanbanDir := initBoard(t)
+	mustCreateTask(t, kanbanDir, "Live")
+	mustCreateTask(t, kanbanDir, "Old")
+	runKanban(t, kanbanDir, "archive", "2")
+
+	var tasks []taskJSON
+	runKanbanJSON(t, kanbanDir, &tasks, "list", "--query", "title:o")
+	if len(tasks) != 0 {
+		t.Errorf("query without status returned archived tasks: %+v", tasks)
+	}
+
+	// A query that mentions status decides for itself.
+	runKanbanJSON(t, kanbanDir, &tasks, "list", "--query", "status:archived")
+	if len(tasks) != 1 || tasks[0].ID != 2 {
+		t.Errorf("status:archived = %+v, want task 2", tasks)
+	}
+}
+
+func TestListInvalidQuery(t *testing.T) {
+	kanbanDir := initBoard(t)
+
+	errResp := runKanbanJSONError(t, kanbanDir, "list", "--query", "tag:a AND (priority>=high")
+	if errResp.Code != "INVALID_QUERY" {
+		t.Errorf("code = %q, want INVALID_QUERY", errResp.Code)
+	}
+	if errResp.Details["position"] == nil {
+		t.Errorf("details = %v, want position", errResp.Details)
+	}
+
+	errResp = runKanbanJSONError(t, kanbanDir, "list", "--query", "status:doing")
+	if errResp.Code != codeInvalidStatus {
+		t.Errorf("code = %q, want INVALID_STATUS", errResp.Code)
+	}
+}
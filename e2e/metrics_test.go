@@ -130,3 +130,199 @@ func TestMetricsWithBadSince(t *testing.T) {
 		t.Errorf("error code = %q, want %q", errResp.Code, codeInvalidDate)
 	}
 }
+
+func TestMetricsCfd(t *testing.T) {
+	kanbanDir := initBoard(t)
+	mustCreateTask(t, kanbanDir, "Task A")
+	mustCreateTask(t, kanbanDir, "Task B")
+	runKanban(t, kanbanDir, "--json", "move", "1", "in-progress", "--claim", claimTestAgent)
+	runKanban(t, kanbanDir, "--json", "move", "1", "done", "--claim", claimTestAgent)
+	runKanban(t, kanbanDir, "--json", "move", "2", "in-progress", "--claim", claimTestAgent)
+
+	var f struct {
+		Statuses []string `json:"statuses"`
+		Days     []struct {
+			Counts    map[string]int `json:"counts"`
+			Total     int            `json:"total"`
+			WIP       int            `json:"wip"`
+			Started   int            `json:"started"`
+			Completed int            `json:"completed"`
+		} `json:"days"`
+	}
+	runKanbanJSON(t, kanbanDir, &f, "metrics", "cfd", "--since", "2020-01-01", "--until", "2099-01-01")
+
+	if len(f.Days) == 0 {
+		t.Fatal("no days in the series")
+	}
+	if f.Days[0].Total != 0 {
+		t.Errorf("first day total = %d, want 0 (before the tasks existed)", f.Days[0].Total)
+	}
+	last := f.Days[len(f.Days)-1]
+	if last.Counts["done"] != 1 || last.Counts[statusInProgress] != 1 || last.WIP != 1 {
+		t.Errorf("last day = %+v, want 1 done and 1 in progress", last)
+	}
+	var started, completed int
+	for _, d := range f.Days {
+		started += d.Started
+		completed += d.Completed
+	}
+	if started != 2 || completed != 1 {
+		t.Errorf("started/completed = %d/%d, want 2/1", started, completed)
+	}
+}
+
+func TestMetricsCfdPickAndHandoffCountOnce(t *testing.T) {
+	kanbanDir := initBoard(t)
+	mustCreateTask(t, kanbanDir, "Task A")
+	runKanban(t, kanbanDir, "--json", "pick", "--claim", claimTestAgent, "--move", statusInProgress)
+	runKanban(t, kanbanDir, "--json", "handoff", "1", "--claim", claimTestAgent, "--note", "done", "--release")
+
+	var f struct {
+		Days []struct {
+			Started int `json:"started"`
+		} `json:"days"`
+	}
+	runKanbanJSON(t, kanbanDir, &f, "metrics", "cfd")
+
+	started := 0
+	for _, d := range f.Days {
+		started += d.Started
+	}
+	if started != 1 {
+		t.Errorf("started = %d, want 1 (pick moved the task once)", started)
+	}
+
+	var tl struct {
+		Timeline []struct {
+			Status string `json:"status"`
+		} `json:"timeline"`
+	}
+	runKanbanJSON(t, kanbanDir, &tl, "show", "1")
+	var statuses []string
+	for _, p := range tl.Timeline {
+		statuses = append(statuses, p.Status)
+	}
+	if strings.Join(statuses, ",") != "backlog,in-progress,review" {
+		t.Errorf("timeline = %v, want backlog, in-progress, review (one period each)", statuses)
+	}
+}
+
+func TestMetricsCfdTableOutput(t *testing.T) {
+	kanbanDir := initBoard(t)
+	mustCreateTask(t, kanbanDir, "Task A")
+
+	r := runKanban(t, kanbanDir, "--table", "metrics", "cfd")
+	if r.exitCode != 0 {
+		t.Fatalf("metrics cfd failed (exit %d): %s", r.exitCode, r.stderr)
+	}
+	for _, want := range []string{"Cumulative flow", "backlog", "Arrivals", "Avg WIP"} {
+		if !strings.Contains(r.stdout, want) {
+			t.Errorf("table output missing %q:\n%s", want, r.stdout)
+		}
+	}
+}
+
+func TestMetricsCfdSinceAfterUntil(t *testing.T) {
+	kanbanDir := initBoard(t)
+
+	errResp := runKanbanJSONError(t, kanbanDir, "metrics", "cfd", "--since", "2026-02-01", "--until", "2026-01-01")
+	if errResp.Code != codeInvalidInput {
+		t.Errorf("code = %q, want %q", errResp.Code, codeInvalidInput)
+	}
+	errResp = runKanbanJSONError(t, kanbanDir, "metrics", "cfd", "--until", "soon")
+	if errResp.Code != codeInvalidDate {
+		t.Errorf("code = %q, want %q", errResp.Code, codeInvalidDate)
+	}
+}
+
+func TestMetricsStatusTimes(t *testing.T) {
+	kanbanDir := initBoard(t)
+	mustCreateTask(t, kanbanDir, "Task A")
+	runKanban(t, kanbanDir, "--json", "move", "1", statusInProgress, "--claim", claimTestAgent)
+	runKanban(t, kanbanDir, "--json", "move", "1", "review", "--claim", claimTestAgent)
+
+	var m struct {
+		StatusTimes []struct {
+			Status string `json:"status"`
+			Count  int    `json:"count"`
+		} `json:"status_times"`
+	}
+	runKanbanJSON(t, kanbanDir, &m, "metrics")
+
+	got := make(map[string]int)
+	for _, st := range m.StatusTimes {
+		got[st.Status] = st.Count
+	}
+	if got[statusBacklog] != 1 || got[statusInProgress] != 1 {
+		t.Errorf("status_times = %+v, want one finished period in backlog and in-progress", m.StatusTimes)
+	}
+	if _, ok := got["review"]; ok {
+		t.Errorf("status_times = %+v, review has not been left yet", m.StatusTimes)
+	}
+
+	r := runKanban(t, kanbanDir, "--table", "metrics")
+	if !strings.Contains(r.stdout, "TIME IN STATUS") {
+		t.Errorf("table output missing time in status:\n%s", r.stdout)
+	}
+}
+
+func TestMetricsPercentilesAndBreakdowns(t *testing.T) {
+	kanbanDir := initBoard(t)
+	mustCreateTask(t, kanbanDir, "Task A", "--tags", "api")
+	runKanban(t, kanbanDir, "--json", "move", "1", "done")
+
+	var m struct {
+		CycleTimeP85Hours *float64 `json:"cycle_time_p85_hours"`
+		LeadTimeP95Hours  *float64 `json:"lead_time_p95_hours"`
+		ByClass           []struct {
+			Name      string `json:"name"`
+			Completed int    `json:"completed"`
+		} `json:"by_class"`
+		ByTag []struct {
+			Name      string `json:"name"`
+			Completed int    `json:"completed"`
+		} `json:"by_tag"`
+	}
+	runKanbanJSON(t, kanbanDir, &m, "metrics")
+
+	if m.CycleTimeP85Hours == nil || m.LeadTimeP95Hours == nil {
+		t.Error("missing lead/cycle time percentiles")
+	}
+	if len(m.ByClass) != 1 || m.ByClass[0].Name != "standard" || m.ByClass[0].Completed != 1 {
+		t.Errorf("by_class = %+v, want standard with 1 task", m.ByClass)
+	}
+	if len(m.ByTag) != 1 || m.ByTag[0].Name != "api" {
+		t.Errorf("by_tag = %+v, want api", m.ByTag)
+	}
+}
+
+func TestMetricsCycleTime(t *testing.T) {
+	kanbanDir := initBoard(t)
+	mustCreateTask(t, kanbanDir, "Task A")
+	mustCreateTask(t, kanbanDir, "Task B")
+	runKanban(t, kanbanDir, "--json", "move", "1", "done")
+
+	var ct struct {
+		Points []struct {
+			ID int `json:"id"`
+		} `json:"points"`
+		Histogram []struct {
+			Count int `json:"count"`
+		} `json:"histogram"`
+	}
+	runKanbanJSON(t, kanbanDir, &ct, "metrics", "cycle-time")
+
+	if len(ct.Points) != 1 || ct.Points[0].ID != 1 {
+		t.Errorf("points = %+v, want task 1", ct.Points)
+	}
+	if len(ct.Histogram) != 1 || ct.Histogram[0].Count != 1 {
+		t.Errorf("histogram = %+v, want one bin with task 1", ct.Histogram)
+	}
+
+	r := runKanban(t, kanbanDir, "--table", "metrics", "cycle-time")
+	for _, want := range []string{"Histogram", "Scatter", "p85"} {
+		if !strings.Contains(r.stdout, want) {
+			t.Errorf("table output missing %q:\n%s", want, r.stdout)
+		}
+	}
+}
@@ -1,8 +1,14 @@
 package e2e_test
 
 import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/antopolskiy/kanban-md/internal/filelock"
 )
 
 // ---------------------------------------------------------------------------
@@ -72,6 +78,28 @@ func TestPickWithTagFilter(t *testing.T) {
 	}
 }
 
+func TestPickWithQuery(t *testing.T) {
+	kanbanDir := initBoard(t)
+	mustCreateTask(t, kanbanDir, "Urgent frontend", "--priority", "critical", "--tags", "frontend")
+	mustCreateTask(t, kanbanDir, "Minor backend", "--priority", "low", "--tags", "backend")
+	mustCreateTask(t, kanbanDir, "Important backend", "--priority", "high", "--tags", "backend")
+
+	var picked taskJSON
+	r := runKanbanJSON(t, kanbanDir, &picked, "pick", "--claim", claimAgent1,
+		"--query", "tag:backend AND priority>=medium")
+	if r.exitCode != 0 {
+		t.Fatalf("pick --query failed (exit %d): %s", r.exitCode, r.stderr)
+	}
+	if picked.ID != 3 {
+		t.Errorf("picked #%d, want #3", picked.ID)
+	}
+
+	errResp := runKanbanJSONError(t, kanbanDir, "pick", "--claim", claimAgent1, "--query", "tag:backend AND priority>=medium")
+	if errResp.Code != "NOTHING_TO_PICK" {
+		t.Errorf("second pick code = %q, want NOTHING_TO_PICK", errResp.Code)
+	}
+}
+
 func TestPickNothingAvailable(t *testing.T) {
 	kanbanDir := initBoard(t)
 
@@ -178,3 +206,103 @@ func TestPickInvalidMoveTarget(t *testing.T) {
 // ---------------------------------------------------------------------------
 // Class-aware WIP limit tests
 // ---------------------------------------------------------------------------
+
+// ---------------------------------------------------------------------------
+// Concurrent pick — board lock prevents double claims
+// ---------------------------------------------------------------------------
+
+func TestConcurrentPickNoDoubleClaims(t *testing.T) {
+	kanbanDir := initBoard(t)
+
+	const (
+		tasks   = 5
+		pickers = 20
+	)
+	for i := range tasks {
+		mustCreateTask(t, kanbanDir, fmt.Sprintf("Pickable %d", i))
+	}
+
+	type pickResult struct {
+		agent string
+		task  taskJSON
+		code  string
+		err   error
+	}
+	results := make(chan pickResult, pickers)
+
+	for i := range pickers {
+		go func(agent string) {
+			args := []string{"--dir", kanbanDir, "--json", "pick", "--claim", agent, "--no-body"}
+			out, err := exec.Command(binPath, args...).Output() //nolint:gosec,noctx // e2e test
+			res := pickResult{agent: agent}
+			if err != nil {
+				var errResp errorJSON
+				if jsonErr := json.Unmarshal(out, &errResp); jsonErr != nil {
+					res.err = fmt.Errorf("pick by %s failed: %w", agent, err)
+				}
+				res.code = errResp.Code
+				results <- res
+				return
+			}
+			if jsonErr := json.Unmarshal(out, &res.task); jsonErr != nil {
+				res.err = fmt.Errorf("parse pick by %s: %w", agent, jsonErr)
+			}
+			results <- res
+		}(fmt.Sprintf("agent-%02d", i))
+	}
+
+	claimedBy := make(map[int]string, tasks)
+	for range pickers {
+		r := <-results
+		switch {
+		case r.err != nil:
+			t.Fatal(r.err)
+		case r.code == "NOTHING_TO_PICK":
+			continue
+		case r.code != "":
+			t.Fatalf("pick by %s: unexpected error code %s", r.agent, r.code)
+		}
+		if prev, ok := claimedBy[r.task.ID]; ok {
+			t.Errorf("task #%d claimed by both %s and %s", r.task.ID, prev, r.agent)
+		}
+		claimedBy[r.task.ID] = r.agent
+	}
+
+	if len(claimedBy) != tasks {
+		t.Errorf("claimed %d tasks, want %d", len(claimedBy), tasks)
+	}
+}
+
+func TestMutationLockTimeout(t *testing.T) {
+	kanbanDir := initBoard(t)
+	mustCreateTask(t, kanbanDir, "Locked out")
+
+	r := runKanban(t, kanbanDir, "config", "set", "lock_timeout", "100ms")
+	if r.exitCode != 0 {
+		t.Fatalf("config set lock_timeout failed (exit %d): %s", r.exitCode, r.stderr)
+	}
+
+	// Simulate another process holding the board lock.
+	unlock, err := filelock.Lock(filepath.Join(kanbanDir, ".lock"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer unlock() //nolint:errcheck // test cleanup
+
+	// Reads of a consistent board do not need the lock.
+	for _, args := range [][]string{{"list"}, {"show", "1"}} {
+		if r := runKanban(t, kanbanDir, args...); r.exitCode != 0 {
+			t.Errorf("%s while locked failed (exit %d): %s", args[0], r.exitCode, r.stderr)
+		}
+	}
+
+	errResp := runKanbanJSONError(t, kanbanDir, "move", "1", "todo")
+	if errResp.Code != "LOCK_TIMEOUT" {
+		t.Errorf("code = %q, want LOCK_TIMEOUT", errResp.Code)
+	}
+
+	errResp = runKanbanJSONError(t, kanbanDir, "pick", "--claim", claimAgent1)
+	if errResp.Code != "LOCK_TIMEOUT" {
+		t.Errorf("pick code = %q, want LOCK_TIMEOUT", errResp.Code)
+	}
+}
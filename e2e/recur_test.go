@@ -0,0 +1,52 @@
+package e2e_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecur(t *testing.T) {
+	kanbanDir := initBoard(t)
+	cfgPath := filepath.Join(kanbanDir, "config.yml")
+	data, err := os.ReadFile(cfgPath) //nolint:gosec // e2e test file
+	if err != nil {
+		t.Fatalf("reading config: %v", err)
+	}
+	recurring := `recurring:
+    - name: audit
+      title: Dependency audit {date}
+      every: 1w
+      priority: high
+      tags: [chore]
+`
+	if err := os.WriteFile(cfgPath, append(data, recurring...), 0o600); err != nil {
+		t.Fatalf("writing config: %v", err)
+	}
+
+	r := runKanban(t, kanbanDir, "--table", "recur", "--dry-run")
+	if !strings.Contains(r.stdout, `Would create "Dependency audit `) {
+		t.Errorf("dry run = %q, want the audit task", r.stdout)
+	}
+
+	var created []struct {
+		Rule  string `json:"rule"`
+		ID    int    `json:"id"`
+		Title string `json:"title"`
+	}
+	runKanbanJSON(t, kanbanDir, &created, "recur")
+	if len(created) != 1 || created[0].Rule != "audit" || created[0].ID != 1 {
+		t.Fatalf("recur = %+v, want task #1 from audit", created)
+	}
+	var tk taskJSON
+	runKanbanJSON(t, kanbanDir, &tk, "show", "1")
+	if tk.Title != created[0].Title || tk.Priority != "high" {
+		t.Errorf("task = %+v, want %q with high priority", tk, created[0].Title)
+	}
+
+	r = runKanban(t, kanbanDir, "--table", "recur")
+	if !strings.Contains(r.stdout, "No recurring tasks due.") {
+		t.Errorf("second run = %q, want nothing due", r.stdout)
+	}
+}
@@ -0,0 +1,126 @@
+package e2e_test
+
+import (
+	"testing"
+)
+
+// ---------------------------------------------------------------------------
+// Revision (--if-rev) tests
+// ---------------------------------------------------------------------------
+
+func showRev(t *testing.T, kanbanDir, id string) string {
+	t.Helper()
+	var task taskJSON
+	runKanbanJSON(t, kanbanDir, &task, "show", id)
+	if task.Rev == "" {
+		t.Fatalf("show %s: rev is empty", id)
+	}
+	return task.Rev
+}
+
+func TestShowExposesRev(t *testing.T) {
+	kanbanDir := initBoard(t)
+	created := mustCreateTask(t, kanbanDir, "Rev task")
+
+	rev := showRev(t, kanbanDir, "1")
+	if created.Rev != rev {
+		t.Errorf("create rev = %q, show rev = %q; want equal", created.Rev, rev)
+	}
+
+	// Unchanged file keeps the same revision.
+	if again := showRev(t, kanbanDir, "1"); again != rev {
+		t.Errorf("rev changed without a write: %q -> %q", rev, again)
+	}
+
+	runKanban(t, kanbanDir, "edit", "1", "--priority", priorityHigh)
+	if after := showRev(t, kanbanDir, "1"); after == rev {
+		t.Error("rev should change after edit")
+	}
+}
+
+func TestEditIfRevMatches(t *testing.T) {
+	kanbanDir := initBoard(t)
+	mustCreateTask(t, kanbanDir, "CAS edit")
+	rev := showRev(t, kanbanDir, "1")
+
+	var task taskJSON
+	r := runKanbanJSON(t, kanbanDir, &task, "edit", "1", "--priority", priorityHigh, "--if-rev", rev)
+	if r.exitCode != 0 {
+		t.Fatalf("edit --if-rev failed: %s", r.stderr)
+	}
+	if task.Priority != priorityHigh {
+		t.Errorf("Priority = %q, want %q", task.Priority, priorityHigh)
+	}
+	if task.Rev == "" || task.Rev == rev {
+		t.Errorf("edit should return the new rev, got %q (old %q)", task.Rev, rev)
+	}
+	if shown := showRev(t, kanbanDir, "1"); shown != task.Rev {
+		t.Errorf("returned rev %q does not match show rev %q", task.Rev, shown)
+	}
+}
+
+func TestIfRevStaleReturnsConflict(t *testing.T) {
+	kanbanDir := initBoard(t)
+	mustCreateTask(t, kanbanDir, "Contended")
+	stale := showRev(t, kanbanDir, "1")
+
+	// A teammate changes the task in between.
+	runKanban(t, kanbanDir, "edit", "1", "--assignee", assigneeAlice)
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"edit", []string{"edit", "1", "--priority", priorityHigh, "--if-rev", stale}},
+		{"move", []string{"move", "1", statusTodo, "--if-rev", stale}},
+		{"handoff", []string{"handoff", "1", "--claim", claimAgent1, "--if-rev", stale}},
+		{"delete", []string{"delete", "1", "--yes", "--if-rev", stale}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errResp := runKanbanJSONError(t, kanbanDir, tt.args...)
+			if errResp.Code != codeConflict {
+				t.Errorf("code = %q, want %q", errResp.Code, codeConflict)
+			}
+			if errResp.Details["expected"] != stale {
+				t.Errorf("details.expected = %v, want %q", errResp.Details["expected"], stale)
+			}
+			if cur, _ := errResp.Details["current"].(string); cur == "" || cur == stale {
+				t.Errorf("details.current = %v, want the new rev", errResp.Details["current"])
+			}
+		})
+	}
+
+	// Nothing was applied.
+	var task taskJSON
+	runKanbanJSON(t, kanbanDir, &task, "show", "1")
+	if task.Status != statusBacklog || task.Priority == priorityHigh {
+		t.Errorf("task was modified despite conflict: status=%q priority=%q", task.Status, task.Priority)
+	}
+}
+
+func TestMoveIfRevMatches(t *testing.T) {
+	kanbanDir := initBoard(t)
+	mustCreateTask(t, kanbanDir, "CAS move")
+	rev := showRev(t, kanbanDir, "1")
+
+	var task taskJSON
+	r := runKanbanJSON(t, kanbanDir, &task, "move", "1", statusTodo, "--if-rev", rev)
+	if r.exitCode != 0 {
+		t.Fatalf("move --if-rev failed: %s", r.stderr)
+	}
+	if task.Status != statusTodo {
+		t.Errorf("Status = %q, want %q", task.Status, statusTodo)
+	}
+}
+
+func TestIfRevRejectsBatch(t *testing.T) {
+	kanbanDir := initBoard(t)
+	mustCreateTask(t, kanbanDir, "A")
+	mustCreateTask(t, kanbanDir, "B")
+
+	errResp := runKanbanJSONError(t, kanbanDir, "move", "1,2", statusTodo, "--if-rev", "abc")
+	if errResp.Code != codeInvalidInput {
+		t.Errorf("code = %q, want %q", errResp.Code, codeInvalidInput)
+	}
+}
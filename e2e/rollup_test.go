@@ -0,0 +1,130 @@
+package e2e_test
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestShowSubtasks(t *testing.T) {
+	kanbanDir := initBoard(t)
+	mustCreateTask(t, kanbanDir, "Epic")
+	mustCreateTask(t, kanbanDir, "Part one", "--parent", "1", "--status", "done")
+	mustCreateTask(t, kanbanDir, "Part two", "--parent", "1")
+	mustCreateTask(t, kanbanDir, "Part three", "--parent", "1")
+
+	var shown struct {
+		taskJSON
+		Subtasks struct {
+			Children []struct {
+				ID     int    `json:"id"`
+				Status string `json:"status"`
+			} `json:"children"`
+			Done    int `json:"done"`
+			Total   int `json:"total"`
+			Percent int `json:"percent"`
+		} `json:"subtasks"`
+	}
+	runKanbanJSON(t, kanbanDir, &shown, "show", "1")
+	if shown.Subtasks.Done != 1 || shown.Subtasks.Total != 3 || shown.Subtasks.Percent != 33 {
+		t.Errorf("subtasks = %+v, want 1/3 done (33%%)", shown.Subtasks)
+	}
+	if len(shown.Subtasks.Children) != 3 || shown.Subtasks.Children[0].Status != "done" {
+		t.Errorf("children = %+v, want #2 (done), #3, #4", shown.Subtasks.Children)
+	}
+
+	r := runKanban(t, kanbanDir, "--table", "show", "1")
+	if !strings.Contains(r.stdout, "Subtasks: 1/3 done (33%)") || !strings.Contains(r.stdout, "Part two") {
+		t.Errorf("show output = %q, want the subtask roll-up", r.stdout)
+	}
+
+	var leaf map[string]any
+	runKanbanJSON(t, kanbanDir, &leaf, "show", "2")
+	if _, ok := leaf["subtasks"]; ok {
+		t.Errorf("show of a task without subtasks = %v, want no subtasks key", leaf)
+	}
+}
+
+func TestListTree(t *testing.T) {
+	kanbanDir := initBoard(t)
+	mustCreateTask(t, kanbanDir, "Epic")
+	mustCreateTask(t, kanbanDir, "Standalone")
+	mustCreateTask(t, kanbanDir, "Child", "--parent", "1")
+	mustCreateTask(t, kanbanDir, "Grandchild", "--parent", "3")
+
+	r := runKanban(t, kanbanDir, "--compact", "list", "--tree")
+	want := []string{"#1 ", "  #3 ", "    #4 ", "#2 "}
+	lines := strings.Split(strings.TrimSpace(r.stdout), "\n")
+	if len(lines) != len(want) {
+		t.Fatalf("list --tree = %q, want %d lines", r.stdout, len(want))
+	}
+	for i, prefix := range want {
+		if !strings.HasPrefix(lines[i], prefix) {
+			t.Errorf("line %d = %q, want prefix %q", i, lines[i], prefix)
+		}
+	}
+
+	var roots []struct {
+		ID       int `json:"id"`
+		Subtasks []struct {
+			ID       int `json:"id"`
+			Subtasks []struct {
+				ID int `json:"id"`
+			} `json:"subtasks"`
+		} `json:"subtasks"`
+	}
+	runKanbanJSON(t, kanbanDir, &roots, "list", "--tree")
+	if len(roots) != 2 || len(roots[0].Subtasks) != 1 || len(roots[0].Subtasks[0].Subtasks) != 1 {
+		t.Errorf("list --tree JSON = %+v, want #1 > #3 > #4 and #2", roots)
+	}
+
+	errResp := runKanbanJSONError(t, kanbanDir, "list", "--tree", "--group-by", "parent")
+	if errResp.Code != codeStatusConflict {
+		t.Errorf("code = %q, want STATUS_CONFLICT", errResp.Code)
+	}
+}
+
+func TestBoardGroupByParent(t *testing.T) {
+	kanbanDir := initBoard(t)
+	mustCreateTask(t, kanbanDir, "Epic")
+	mustCreateTask(t, kanbanDir, "Child", "--parent", "1")
+
+	r := runKanban(t, kanbanDir, "--table", "board", "--group-by", "parent")
+	if r.exitCode != 0 || !strings.Contains(r.stdout, "#1 Epic (1 tasks)") || !strings.Contains(r.stdout, "(no parent)") {
+		t.Errorf("board --group-by parent = %q (stderr %q)", r.stdout, r.stderr)
+	}
+}
+
+func TestParentDoneRules(t *testing.T) {
+	kanbanDir := initBoard(t)
+	mustCreateTask(t, kanbanDir, "Epic")
+	mustCreateTask(t, kanbanDir, "Part one", "--parent", "1")
+	mustCreateTask(t, kanbanDir, "Part two", "--parent", "1")
+
+	runKanban(t, kanbanDir, "config", "set", "parent_done", "require")
+	errResp := runKanbanJSONError(t, kanbanDir, "move", "1", "done")
+	if errResp.Code != codeTransitionNotAllowed || !strings.Contains(errResp.Error, "#2, #3") {
+		t.Errorf("move parent = %+v, want TRANSITION_NOT_ALLOWED listing #2, #3", errResp)
+	}
+
+	runKanban(t, kanbanDir, "config", "set", "parent_done", "auto")
+	runKanban(t, kanbanDir, "move", "2", "done")
+	var epic taskJSON
+	runKanbanJSON(t, kanbanDir, &epic, "show", "1")
+	if epic.Status == "done" {
+		t.Fatal("epic moved to done with a subtask still open")
+	}
+
+	r := runKanban(t, kanbanDir, "--table", "move", "3", "done")
+	if !strings.Contains(r.stderr, "Moved parent task #1 to done") {
+		t.Errorf("stderr = %q, want the parent move reported", r.stderr)
+	}
+	runKanbanJSON(t, kanbanDir, &epic, "show", "1")
+	if epic.Status != "done" {
+		t.Errorf("epic status = %q, want done once every subtask is done", epic.Status)
+	}
+
+	errResp = runKanbanJSONError(t, kanbanDir, "config", "set", "parent_done", "always")
+	if errResp.Code != codeInvalidInput {
+		t.Errorf("code = %q, want INVALID_INPUT", errResp.Code)
+	}
+}
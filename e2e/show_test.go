@@ -90,3 +90,36 @@ func TestShowCompactOutput(t *testing.T) {
 		t.Error("compact show output should contain task title")
 	}
 }
+
+func TestShowStatusTimeline(t *testing.T) {
+	kanbanDir := initBoard(t)
+	mustCreateTask(t, kanbanDir, "Timeline")
+	runKanban(t, kanbanDir, "--json", "move", "1", statusInProgress, "--claim", claimTestAgent)
+	runKanban(t, kanbanDir, "--json", "move", "1", "review", "--claim", claimTestAgent)
+
+	var detail struct {
+		Timeline []struct {
+			Status string  `json:"status"`
+			Exited *string `json:"exited"`
+		} `json:"timeline"`
+	}
+	runKanbanJSON(t, kanbanDir, &detail, "show", "1")
+
+	want := []string{statusBacklog, statusInProgress, "review"}
+	if len(detail.Timeline) != len(want) {
+		t.Fatalf("timeline = %+v, want statuses %v", detail.Timeline, want)
+	}
+	for i, s := range want {
+		if detail.Timeline[i].Status != s {
+			t.Errorf("timeline[%d].status = %q, want %q", i, detail.Timeline[i].Status, s)
+		}
+	}
+	if detail.Timeline[2].Exited != nil {
+		t.Error("current status should have no exit time")
+	}
+
+	r := runKanban(t, kanbanDir, "--table", "show", "1")
+	if !strings.Contains(r.stdout, "Status timeline") || !strings.Contains(r.stdout, "(current)") {
+		t.Errorf("table output missing status timeline:\n%s", r.stdout)
+	}
+}
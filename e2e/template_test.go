@@ -0,0 +1,56 @@
+package e2e_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTemplate(t *testing.T) {
+	kanbanDir := initBoard(t)
+	dir := filepath.Join(kanbanDir, "templates")
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		t.Fatalf("creating templates dir: %v", err)
+	}
+	bug := `---
+description: Report a defect
+title: "Bug: {title}"
+priority: high
+tags: [bug]
+---
+## Steps to reproduce
+`
+	if err := os.WriteFile(filepath.Join(dir, "bug.md"), []byte(bug), 0o600); err != nil {
+		t.Fatalf("writing template: %v", err)
+	}
+
+	r := runKanban(t, kanbanDir, "--table", "template", "list")
+	if !strings.Contains(r.stdout, "bug") || !strings.Contains(r.stdout, "Report a defect") {
+		t.Errorf("template list = %q, want the bug template", r.stdout)
+	}
+
+	var shown struct {
+		Name     string `json:"name"`
+		Priority string `json:"priority"`
+		Body     string `json:"body"`
+	}
+	runKanbanJSON(t, kanbanDir, &shown, "template", "show", "bug")
+	if shown.Name != "bug" || shown.Priority != "high" || !strings.Contains(shown.Body, "Steps to reproduce") {
+		t.Errorf("template show = %+v, want the bug template", shown)
+	}
+
+	var tk taskJSON
+	runKanbanJSON(t, kanbanDir, &tk, "create", "--template", "bug", "Login fails", "--tags", "auth")
+	if tk.Title != "Bug: Login fails" || tk.Priority != "high" || strings.Join(tk.Tags, ",") != "bug,auth" {
+		t.Errorf("created = %+v, want the bug template applied", tk)
+	}
+	if !strings.Contains(tk.Body, "Steps to reproduce") {
+		t.Errorf("body = %q, want the template body", tk.Body)
+	}
+
+	errResp := runKanbanJSONError(t, kanbanDir, "create", "--template", "feature", "Nope")
+	if errResp.Code != "TEMPLATE_NOT_FOUND" {
+		t.Errorf("code = %q, want TEMPLATE_NOT_FOUND", errResp.Code)
+	}
+}
@@ -0,0 +1,89 @@
+package e2e_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const codeTransitionNotAllowed = "TRANSITION_NOT_ALLOWED"
+
+// addTransitions appends a workflow where backlog skips todo, and review
+// needs a handoff note before done.
+func addTransitions(t *testing.T, kanbanDir string) {
+	t.Helper()
+	cfgPath := filepath.Join(kanbanDir, "config.yml")
+	data, err := os.ReadFile(cfgPath) //nolint:gosec // e2e test file
+	if err != nil {
+		t.Fatalf("reading config: %v", err)
+	}
+	rules := `transitions:
+    - from: backlog
+      to: [in-progress]
+    - from: in-progress
+      to: [review, backlog]
+    - from: review
+      to: [done, in-progress]
+      guards: ["body_contains:handoff"]
+`
+	if err := os.WriteFile(cfgPath, append(data, rules...), 0o600); err != nil {
+		t.Fatalf("writing config: %v", err)
+	}
+}
+
+func TestMoveRejectsDisallowedTransition(t *testing.T) {
+	kanbanDir := initBoard(t)
+	addTransitions(t, kanbanDir)
+	mustCreateTask(t, kanbanDir, "Alpha")
+
+	errResp := runKanbanJSONError(t, kanbanDir, "move", "1", "done")
+	if errResp.Code != codeTransitionNotAllowed {
+		t.Fatalf("code = %q, want %q", errResp.Code, codeTransitionNotAllowed)
+	}
+	allowed, _ := errResp.Details["allowed"].([]any)
+	if len(allowed) != 2 || allowed[0] != statusInProgress {
+		t.Errorf("allowed = %v, want [in-progress archived]", errResp.Details["allowed"])
+	}
+
+	errResp = runKanbanJSONError(t, kanbanDir, "edit", "1", "--status", statusTodo)
+	if errResp.Code != codeTransitionNotAllowed {
+		t.Errorf("edit --status code = %q, want %q", errResp.Code, codeTransitionNotAllowed)
+	}
+}
+
+func TestMoveNextFollowsTransitions(t *testing.T) {
+	kanbanDir := initBoard(t)
+	addTransitions(t, kanbanDir)
+	mustCreateTask(t, kanbanDir, "Alpha")
+
+	var moved struct {
+		Status string `json:"status"`
+	}
+	runKanbanJSON(t, kanbanDir, &moved, "move", "1", "--next", "--claim", claimAgent1)
+	if moved.Status != statusInProgress {
+		t.Errorf("move --next from backlog = %q, want %q (todo is skipped)", moved.Status, statusInProgress)
+	}
+
+	runKanbanJSON(t, kanbanDir, &moved, "move", "1", "--prev", "--claim", claimAgent1)
+	if moved.Status != statusBacklog {
+		t.Errorf("move --prev from in-progress = %q, want %q", moved.Status, statusBacklog)
+	}
+}
+
+func TestHandoffNoteSatisfiesGuard(t *testing.T) {
+	kanbanDir := initBoard(t)
+	addTransitions(t, kanbanDir)
+	mustCreateTask(t, kanbanDir, "Alpha")
+	runKanban(t, kanbanDir, "move", "1", statusInProgress, "--claim", claimAgent1)
+	runKanban(t, kanbanDir, "move", "1", statusReview, "--claim", claimAgent1)
+
+	errResp := runKanbanJSONError(t, kanbanDir, "move", "1", "done", "--claim", claimAgent1)
+	if errResp.Code != codeTransitionNotAllowed || errResp.Details["guard"] != "body_contains:handoff" {
+		t.Fatalf("error = %+v, want guard failure", errResp)
+	}
+
+	runKanban(t, kanbanDir, "handoff", "1", "--claim", claimAgent1, "--note", "Handoff: tests pass")
+	if r := runKanban(t, kanbanDir, "move", "1", "done", "--claim", claimAgent1); r.exitCode != 0 {
+		t.Fatalf("move to done after handoff note failed: %s", r.stderr)
+	}
+}
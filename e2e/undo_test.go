@@ -0,0 +1,155 @@
+package e2e_test
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+type undoResultJSON struct {
+	Entry  string `json:"entry"`
+	Action string `json:"action"`
+	TaskID int    `json:"task_id"`
+	OK     bool   `json:"ok"`
+	Code   string `json:"code"`
+}
+
+func TestUndoLastRevertsMostRecentMutation(t *testing.T) {
+	kanbanDir := initBoard(t)
+	mustCreateTask(t, kanbanDir, "Undo target")
+	runKanban(t, kanbanDir, "move", "1", statusTodo)
+
+	var results []undoResultJSON
+	runKanbanJSON(t, kanbanDir, &results, "undo")
+	if len(results) != 1 || !results[0].OK || results[0].Action != "move" {
+		t.Fatalf("undo results = %+v", results)
+	}
+
+	var tk taskJSON
+	runKanbanJSON(t, kanbanDir, &tk, "show", "1")
+	if tk.Status != statusBacklog {
+		t.Errorf("status = %q, want %q", tk.Status, statusBacklog)
+	}
+
+	// Undoing again walks further back: the create is undone, removing the task.
+	runKanbanJSON(t, kanbanDir, &results, "undo")
+	if len(results) != 1 || !results[0].OK || results[0].Action != "create" {
+		t.Fatalf("second undo results = %+v", results)
+	}
+	errResp := runKanbanJSONError(t, kanbanDir, "show", "1")
+	if errResp.Code != "TASK_NOT_FOUND" {
+		t.Errorf("show after undoing create: code = %q, want TASK_NOT_FOUND", errResp.Code)
+	}
+}
+
+func TestUndoEntryConflict(t *testing.T) {
+	kanbanDir := initBoard(t)
+	mustCreateTask(t, kanbanDir, "Edited twice")
+	runKanban(t, kanbanDir, "edit", "1", "--priority", priorityHigh)
+
+	editID := ""
+	var raw []struct {
+		ID     string `json:"id"`
+		Action string `json:"action"`
+	}
+	runKanbanJSON(t, kanbanDir, &raw, "log", "--action", "edit")
+	if len(raw) == 1 {
+		editID = raw[0].ID
+	}
+	if editID == "" {
+		t.Fatalf("edit entry ID not found in log: %+v", raw)
+	}
+
+	runKanban(t, kanbanDir, "edit", "1", "--priority", "low")
+
+	var results []undoResultJSON
+	r := runKanban(t, kanbanDir, "--json", "undo", "--entry", editID)
+	if r.exitCode == 0 {
+		t.Fatal("expected undo of an overwritten edit to fail")
+	}
+	if err := json.Unmarshal([]byte(r.stdout), &results); err != nil {
+		t.Fatalf("parsing JSON output: %v\nstdout: %s", err, r.stdout)
+	}
+	if len(results) != 1 || results[0].OK || results[0].Code != codeConflict {
+		t.Errorf("results = %+v, want one CONFLICT", results)
+	}
+}
+
+func TestRevertByActor(t *testing.T) {
+	kanbanDir := initBoard(t)
+	mustCreateTask(t, kanbanDir, "Human task")
+	mustCreateTask(t, kanbanDir, "Rogue target")
+	runKanban(t, kanbanDir, "edit", "1", "--assignee", assigneeAlice)
+	runKanban(t, kanbanDir, "move", "2", statusTodo, "--claim", "rogue")
+	runKanban(t, kanbanDir, "edit", "2", "--priority", "critical", "--claim", "rogue")
+
+	var dry []undoResultJSON
+	runKanbanJSON(t, kanbanDir, &dry, "revert", "--since", "1h", "--actor", "rogue", "--dry-run")
+	if len(dry) != 2 {
+		t.Fatalf("dry run = %+v, want 2 entries", dry)
+	}
+	var tk taskJSON
+	runKanbanJSON(t, kanbanDir, &tk, "show", "2")
+	if tk.Status != statusTodo {
+		t.Fatalf("dry run changed the task: status %q", tk.Status)
+	}
+
+	var results []undoResultJSON
+	runKanbanJSON(t, kanbanDir, &results, "revert", "--since", "1h", "--actor", "rogue", "--claim", "rogue")
+	for _, r := range results {
+		if !r.OK || r.TaskID != 2 {
+			t.Errorf("result = %+v", r)
+		}
+	}
+
+	var reverted taskJSON
+	runKanbanJSON(t, kanbanDir, &reverted, "show", "2")
+	if reverted.Status != statusBacklog || reverted.Priority != "medium" || reverted.ClaimedBy != "" {
+		t.Errorf("task 2 after revert = %+v", reverted)
+	}
+	var untouched taskJSON
+	runKanbanJSON(t, kanbanDir, &untouched, "show", "1")
+	if untouched.Assignee != assigneeAlice {
+		t.Errorf("other actor's change was reverted: assignee %q", untouched.Assignee)
+	}
+}
+
+func TestRevertByActorOwnClaims(t *testing.T) {
+	kanbanDir := initBoard(t)
+	mustCreateTask(t, kanbanDir, "Rogue target")
+	mustCreateTask(t, kanbanDir, "Taken over")
+	runKanban(t, kanbanDir, "move", "1", statusTodo, "--claim", "rogue")
+	runKanban(t, kanbanDir, "edit", "2", "--priority", "critical", "--claim", "rogue")
+	runKanban(t, kanbanDir, "edit", "2", "--release")
+	runKanban(t, kanbanDir, "edit", "2", "--title", "Taken over", "--claim", "other")
+
+	// The claim rogue still holds on #1 needs no --claim; #2 is now claimed
+	// by another agent, whose claim still applies.
+	var results []undoResultJSON
+	r := runKanban(t, kanbanDir, "--json", "revert", "--since", "1h", "--actor", "rogue")
+	if err := json.Unmarshal([]byte(r.stdout), &results); err != nil {
+		t.Fatalf("parsing JSON output: %v\nstdout: %s", err, r.stdout)
+	}
+	for _, res := range results {
+		if res.TaskID == 1 && !res.OK {
+			t.Errorf("revert of rogue's own claimed task failed: %+v", res)
+		}
+		if res.TaskID == 2 && res.OK {
+			t.Errorf("revert of a task claimed by another agent succeeded: %+v", res)
+		}
+	}
+
+	var reverted taskJSON
+	runKanbanJSON(t, kanbanDir, &reverted, "show", "1")
+	if reverted.Status != statusBacklog || reverted.ClaimedBy != "" {
+		t.Errorf("task 1 after revert = %+v", reverted)
+	}
+}
+
+func TestRevertRequiresSince(t *testing.T) {
+	kanbanDir := initBoard(t)
+
+	errResp := runKanbanJSONError(t, kanbanDir, "revert")
+	if errResp.Code != codeInvalidInput {
+		t.Errorf("code = %q, want INVALID_INPUT", errResp.Code)
+	}
+}
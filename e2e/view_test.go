@@ -0,0 +1,113 @@
+package e2e_test
+
+import (
+	"strings"
+	"testing"
+)
+
+type viewJSON struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Query       string   `json:"query"`
+	Statuses    []string `json:"statuses"`
+	Sort        string   `json:"sort"`
+	Reverse     bool     `json:"reverse"`
+	GroupBy     string   `json:"group_by"`
+}
+
+func TestViewRunsSavedFilterAndSort(t *testing.T) {
+	kanbanDir := initBoard(t)
+	mustCreateTask(t, kanbanDir, "Alpha", "--tags", "api", "--priority", priorityHigh)
+	mustCreateTask(t, kanbanDir, "Beta", "--tags", "api")
+	mustCreateTask(t, kanbanDir, "Gamma", "--tags", "ui")
+
+	runKanban(t, kanbanDir, "config", "set", "views.api",
+		`{description: API work, query: "tag:api", sort: title, reverse: true}`)
+
+	var tasks []taskJSON
+	runKanbanJSON(t, kanbanDir, &tasks, "view", "api")
+	if len(tasks) != 2 || tasks[0].Title != "Beta" || tasks[1].Title != "Alpha" {
+		t.Fatalf("view api = %+v, want Beta, Alpha", tasks)
+	}
+
+	var views []viewJSON
+	runKanbanJSON(t, kanbanDir, &views, "view")
+	if len(views) != 1 || views[0].Name != "api" || views[0].Description != "API work" {
+		t.Errorf("views = %+v", views)
+	}
+
+	var got viewJSON
+	runKanbanJSON(t, kanbanDir, &got, "config", "get", "views.api")
+	if got.Query != "tag:api" || got.Sort != "title" || !got.Reverse {
+		t.Errorf("config get views.api = %+v", got)
+	}
+}
+
+func TestViewGroupBy(t *testing.T) {
+	kanbanDir := initBoard(t)
+	mustCreateTask(t, kanbanDir, "Alpha", "--assignee", assigneeAlice)
+	mustCreateTask(t, kanbanDir, "Beta")
+
+	runKanban(t, kanbanDir, "config", "set", "views.people", "group_by: assignee")
+
+	var grouped struct {
+		Groups []struct {
+			Key   string `json:"key"`
+			Total int    `json:"total"`
+		} `json:"groups"`
+	}
+	runKanbanJSON(t, kanbanDir, &grouped, "view", "people")
+	if len(grouped.Groups) != 2 {
+		t.Fatalf("groups = %+v, want 2", grouped.Groups)
+	}
+}
+
+func TestViewRemove(t *testing.T) {
+	kanbanDir := initBoard(t)
+	runKanban(t, kanbanDir, "config", "set", "views.todo", "statuses: [todo]")
+	runKanban(t, kanbanDir, "config", "set", "views.todo", "")
+
+	errResp := runKanbanJSONError(t, kanbanDir, "view", "todo")
+	if errResp.Code != "VIEW_NOT_FOUND" {
+		t.Errorf("code = %q, want VIEW_NOT_FOUND", errResp.Code)
+	}
+	errResp = runKanbanJSONError(t, kanbanDir, "config", "get", "views.todo")
+	if errResp.Code != "VIEW_NOT_FOUND" {
+		t.Errorf("config get code = %q, want VIEW_NOT_FOUND", errResp.Code)
+	}
+}
+
+func TestViewSetValidation(t *testing.T) {
+	kanbanDir := initBoard(t)
+
+	tests := []struct {
+		name  string
+		value string
+		code  string
+		msg   string
+	}{
+		{"unknown status", "statuses: [doing]", codeInvalidInput, "unknown status"},
+		{"unknown field", "colour: red", codeInvalidInput, "colour"},
+		{"bad sort", "sort: size", codeInvalidInput, "invalid sort field"},
+		{"bad group_by", "group_by: due", "INVALID_GROUP_BY", "group_by"},
+		{"bad query", `query: "(tag:a"`, "INVALID_QUERY", "invalid query"},
+		{"mismatched name", "name: other", codeInvalidInput, "does not match"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errResp := runKanbanJSONError(t, kanbanDir, "config", "set", "views.v", tt.value)
+			if errResp.Code != tt.code {
+				t.Errorf("code = %q, want %s", errResp.Code, tt.code)
+			}
+			if !strings.Contains(errResp.Error, tt.msg) {
+				t.Errorf("error = %q, want %q", errResp.Error, tt.msg)
+			}
+		})
+	}
+
+	var views []viewJSON
+	runKanbanJSON(t, kanbanDir, &views, "view")
+	if len(views) != 0 {
+		t.Errorf("views = %+v, want none saved after failed sets", views)
+	}
+}
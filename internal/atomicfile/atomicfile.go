@@ -0,0 +1,73 @@
+// Package atomicfile writes files so that concurrent readers only ever see
+// the complete old contents or the complete new contents, never a partially
+// written file.
+package atomicfile
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Write atomically replaces the file at path with data. The data is written
+// to a temporary file in the same directory, fsynced, given mode perm, and
+// then renamed over path.
+//
+// If path already exists but cannot be opened for writing (e.g. it is
+// read-only), Write fails the same way os.WriteFile would, so callers that
+// relied on read-only files being protected keep that behavior.
+func Write(path string, data []byte, perm os.FileMode) error {
+	if err := checkWritable(path); err != nil {
+		return err
+	}
+
+	dir, base := filepath.Split(path)
+	if dir == "" {
+		dir = "."
+	}
+	// The leading dot and trailing .tmp keep the temp file out of directory
+	// scans that match on the real file's extension (e.g. "*.md").
+	tmp, err := os.CreateTemp(dir, "."+base+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	committed := false
+	defer func() {
+		if !committed {
+			_ = tmp.Close()
+			_ = os.Remove(tmpPath)
+		}
+	}()
+
+	if _, err := tmp.Write(data); err != nil {
+		return fmt.Errorf("writing temp file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		return fmt.Errorf("syncing temp file: %w", err)
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		return fmt.Errorf("setting permissions: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("closing temp file: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return err
+	}
+	committed = true
+	return nil
+}
+
+// checkWritable returns an error if path exists and cannot be opened for
+// writing. A missing file is fine: Write will create it.
+func checkWritable(path string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY, 0) //nolint:gosec // path from trusted caller
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,154 @@
+package atomicfile_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/antopolskiy/kanban-md/internal/atomicfile"
+)
+
+func TestWriteCreatesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+
+	if err := atomicfile.Write(path, []byte("hello\n"), 0o600); err != nil {
+		t.Fatalf("Write() error: %v", err)
+	}
+
+	got, err := os.ReadFile(path) //nolint:gosec // test temp path
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "hello\n" {
+		t.Errorf("contents = %q, want %q", got, "hello\n")
+	}
+}
+
+func TestWriteReplacesAndLeavesNoTempFiles(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "001-task.md")
+
+	for _, content := range []string{"first", "second, longer content", "3"} {
+		if err := atomicfile.Write(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("Write(%q) error: %v", content, err)
+		}
+	}
+
+	got, err := os.ReadFile(path) //nolint:gosec // test temp path
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "3" {
+		t.Errorf("contents = %q, want %q", got, "3")
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		names := make([]string, 0, len(entries))
+		for _, e := range entries {
+			names = append(names, e.Name())
+		}
+		t.Errorf("directory entries = %v, want only 001-task.md", names)
+	}
+}
+
+func TestWriteAppliesPermissions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file permission checks differ on Windows")
+	}
+	path := filepath.Join(t.TempDir(), "claimed.md")
+
+	if err := atomicfile.Write(path, []byte("x"), 0o444); err != nil {
+		t.Fatalf("Write() error: %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o444 {
+		t.Errorf("perm = %o, want 444", perm)
+	}
+}
+
+func TestWriteRespectsReadOnlyTarget(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file permission checks differ on Windows")
+	}
+	path := filepath.Join(t.TempDir(), "config.yml")
+	if err := os.WriteFile(path, []byte("original"), 0o400); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := atomicfile.Write(path, []byte("replaced"), 0o600); err == nil {
+		t.Fatal("expected error writing over a read-only file")
+	}
+
+	got, err := os.ReadFile(path) //nolint:gosec // test temp path
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "original" {
+		t.Errorf("contents = %q, want original contents preserved", got)
+	}
+}
+
+func TestWriteMissingDirectory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing", "file.md")
+	if err := atomicfile.Write(path, []byte("x"), 0o600); err == nil {
+		t.Fatal("expected error for missing directory")
+	}
+}
+
+func TestWriteConcurrentReadersNeverSeePartialContent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "task.md")
+	small := []byte("small\n")
+	large := []byte(strings.Repeat("large content line\n", 4096))
+	if err := atomicfile.Write(path, small, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	const rounds = 200
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			got, err := os.ReadFile(path) //nolint:gosec // test temp path
+			if err != nil {
+				t.Errorf("ReadFile() error: %v", err)
+				return
+			}
+			if !bytes.Equal(got, small) && !bytes.Equal(got, large) {
+				t.Errorf("reader saw partial file (%d bytes)", len(got))
+				return
+			}
+		}
+	}()
+
+	for i := range rounds {
+		data := small
+		if i%2 == 0 {
+			data = large
+		}
+		if err := atomicfile.Write(path, data, 0o600); err != nil {
+			t.Fatalf("Write() error: %v", err)
+		}
+	}
+	close(done)
+	wg.Wait()
+}
@@ -0,0 +1,235 @@
+package board
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/antopolskiy/kanban-md/internal/atomicfile"
+	"github.com/antopolskiy/kanban-md/internal/config"
+	"github.com/antopolskiy/kanban-md/internal/task"
+)
+
+const (
+	agentsDirName = "agents"
+	agentsDirMode = 0o750
+	agentFileMode = 0o600
+	agentFileExt  = ".json"
+	actionReap    = "reap"
+)
+
+// AgentPresence is an agent's entry in the registry, stored as
+// agents/<name>.json in the kanban directory. It is updated whenever the agent
+// mutates the board under its claim name.
+type AgentPresence struct {
+	Name       string         `json:"name"`
+	FirstSeen  time.Time      `json:"first_seen"`
+	LastSeen   time.Time      `json:"last_seen"`
+	LastAction string         `json:"last_action,omitempty"`
+	LastTask   int            `json:"last_task,omitempty"`
+	Actions    map[string]int `json:"actions,omitempty"` // mutation counts by action
+}
+
+// Agent describes an agent for `agents`: its registry entry (if any), the
+// claims it holds, and whether it has been seen within the claim timeout.
+type Agent struct {
+	AgentPresence
+	Live   bool  `json:"live"`
+	Claims []int `json:"claims"`
+}
+
+// ReapedClaim is a claim released by ReapAgents.
+type ReapedClaim struct {
+	Agent    string    `json:"agent"`
+	TaskID   int       `json:"task_id"`
+	LastSeen time.Time `json:"last_seen"`
+}
+
+// agentPath returns the registry file for an agent. Claim names are free-form,
+// so the name is escaped to stay a single file name.
+func agentPath(kanbanDir, name string) string {
+	return filepath.Join(kanbanDir, agentsDirName, url.PathEscape(name)+agentFileExt)
+}
+
+// recordPresence notes that agent name performed action on a task. It is
+// best-effort, like the activity log: a registry that cannot be written never
+// fails the mutation. Callers hold the board lock.
+func recordPresence(kanbanDir, name, action string, taskID int, now time.Time) {
+	if name == "" {
+		return
+	}
+	path := agentPath(kanbanDir, name)
+	p := AgentPresence{Name: name, FirstSeen: now}
+	if data, err := os.ReadFile(path); err == nil { //nolint:gosec // registry path from trusted kanban dir
+		_ = json.Unmarshal(data, &p)
+	}
+	p.LastSeen = now
+	p.LastAction = action
+	p.LastTask = taskID
+	if p.Actions == nil {
+		p.Actions = map[string]int{}
+	}
+	p.Actions[action]++
+
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), agentsDirMode); err != nil {
+		return
+	}
+	_ = atomicfile.Write(path, append(data, '\n'), agentFileMode)
+}
+
+// ReadAgentRegistry returns every agent in the registry, in no particular
+// order. A missing registry yields no agents; unreadable entries are skipped.
+func ReadAgentRegistry(kanbanDir string) ([]AgentPresence, error) {
+	entries, err := os.ReadDir(filepath.Join(kanbanDir, agentsDirName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading agent registry: %w", err)
+	}
+	var agents []AgentPresence
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), agentFileExt) {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(kanbanDir, agentsDirName, e.Name())) //nolint:gosec // registry path from trusted kanban dir
+		if err != nil {
+			continue
+		}
+		var p AgentPresence
+		if json.Unmarshal(data, &p) != nil || p.Name == "" {
+			continue
+		}
+		agents = append(agents, p)
+	}
+	return agents, nil
+}
+
+// ListAgents combines the registry with the claims on the board: every
+// registered agent and every agent holding a claim is listed, most recently
+// seen first. An agent's last-seen time is its last registered mutation or
+// its latest claim, whichever is newer, and it is live if that is within the
+// claim timeout (always, when claims never expire).
+func ListAgents(cfg *config.Config, tasks []*task.Task, now time.Time) ([]Agent, error) {
+	registry, err := ReadAgentRegistry(cfg.Dir())
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]*Agent, len(registry))
+	for _, p := range registry {
+		byName[p.Name] = &Agent{AgentPresence: p}
+	}
+	for _, t := range tasks {
+		if t.ClaimedBy == "" {
+			continue
+		}
+		a, ok := byName[t.ClaimedBy]
+		if !ok {
+			a = &Agent{AgentPresence: AgentPresence{Name: t.ClaimedBy}}
+			byName[t.ClaimedBy] = a
+		}
+		a.Claims = append(a.Claims, t.ID)
+		if t.ClaimedAt != nil && t.ClaimedAt.After(a.LastSeen) {
+			a.LastSeen = *t.ClaimedAt
+		}
+	}
+
+	timeout := cfg.ClaimTimeoutDuration()
+	agents := make([]Agent, 0, len(byName))
+	for _, a := range byName {
+		a.Live = timeout <= 0 || now.Sub(a.LastSeen) <= timeout
+		if a.Claims == nil {
+			a.Claims = []int{}
+		}
+		slices.Sort(a.Claims)
+		agents = append(agents, *a)
+	}
+	slices.SortFunc(agents, func(a, b Agent) int {
+		if c := b.LastSeen.Compare(a.LastSeen); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return agents, nil
+}
+
+// ReapAgents releases every claim held by an agent that is not live (see
+// ListAgents). Each release is logged as a "reap" entry, which undo can
+// revert, and runs release hooks. With dryRun, nothing is written and the
+// claims that would be released are returned.
+func ReapAgents(cfg *config.Config, dryRun bool, now time.Time) ([]ReapedClaim, error) {
+	unlock, err := Lock(cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock() //nolint:errcheck // best-effort unlock
+
+	tasks, _, err := task.ReadAllLenient(cfg.TasksPath())
+	if err != nil {
+		return nil, err
+	}
+	agents, err := ListAgents(cfg, tasks, now)
+	if err != nil {
+		return nil, err
+	}
+
+	stale := make(map[string]time.Time)
+	for _, a := range agents {
+		if !a.Live {
+			stale[a.Name] = a.LastSeen
+		}
+	}
+
+	reaped := []ReapedClaim{}
+	for _, t := range tasks {
+		lastSeen, ok := stale[t.ClaimedBy]
+		if !ok {
+			continue
+		}
+		claim := ReapedClaim{Agent: t.ClaimedBy, TaskID: t.ID, LastSeen: lastSeen}
+		if !dryRun {
+			if err := releaseClaim(cfg, t, actionReap, nil, now); err != nil {
+				return reaped, err
+			}
+		}
+		reaped = append(reaped, claim)
+	}
+	return reaped, nil
+}
+
+// releaseClaim clears t's claim, applies any further changes, and writes and
+// logs the task as action with the former claimant as detail. Release (and
+// any implied move) hooks run around the write.
+func releaseClaim(cfg *config.Config, t *task.Task, action string, apply func(*task.Task), now time.Time) error {
+	before := TakeSnapshot(t)
+	agent := t.ClaimedBy
+	t.ClaimedBy = ""
+	t.ClaimedAt = nil
+	if apply != nil {
+		apply(t)
+	}
+	t.Updated = now
+
+	hooks := newTaskHooks(cfg, config.HookRelease, before, t, "")
+	if err := hooks.runPre(t); err != nil {
+		return err
+	}
+	if err := task.Write(t.File, t); err != nil {
+		return fmt.Errorf("writing task: %w", err)
+	}
+	LogChange(cfg.Dir(), action, "", before, t, agent)
+	hooks.runPost(t)
+	return nil
+}
@@ -0,0 +1,101 @@
+package board_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/antopolskiy/kanban-md/internal/board"
+	"github.com/antopolskiy/kanban-md/internal/task"
+)
+
+func TestAgents_PresenceFromMutations(t *testing.T) {
+	cfg := setupWorkflowBoard(t, &task.Task{ID: 1, Title: "a", Status: "todo"})
+	cfg.Transitions = nil
+
+	start := time.Now()
+	if _, err := board.Move(cfg, board.MoveParams{ID: 1, NewStatus: "in-progress", Claimant: "bot/1", SetClaim: true}, start); err != nil {
+		t.Fatal(err)
+	}
+	later := start.Add(time.Minute)
+	if _, err := board.Heartbeat(cfg, 1, "bot/1", later); err != nil {
+		t.Fatal(err)
+	}
+
+	agents, err := board.ListAgents(cfg, readTasks(t, cfg.TasksPath()), later)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(agents) != 1 {
+		t.Fatalf("agents = %+v, want one", agents)
+	}
+	a := agents[0]
+	if a.Name != "bot/1" || !a.Live || !a.FirstSeen.Equal(start) || !a.LastSeen.Equal(later) {
+		t.Errorf("agent = %+v, want live bot/1 first seen at move, last seen at heartbeat", a)
+	}
+	if a.LastAction != "heartbeat" || a.LastTask != 1 || a.Actions["move"] != 1 || a.Actions["heartbeat"] != 1 {
+		t.Errorf("activity = %q #%d %v", a.LastAction, a.LastTask, a.Actions)
+	}
+	if len(a.Claims) != 1 || a.Claims[0] != 1 {
+		t.Errorf("claims = %v, want [1]", a.Claims)
+	}
+}
+
+func TestReapAgents_ReleasesStaleClaims(t *testing.T) {
+	now := time.Now()
+	old := now.Add(-2 * time.Hour) // older than the 1h default timeout
+	cfg := setupWorkflowBoard(t,
+		&task.Task{ID: 1, Title: "stale", Status: "in-progress", ClaimedBy: "gone", ClaimedAt: &old},
+		&task.Task{ID: 2, Title: "live", Status: "in-progress", ClaimedBy: transitionAgent, ClaimedAt: &now},
+	)
+
+	reaped, err := board.ReapAgents(cfg, true, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reaped) != 1 || reaped[0].TaskID != 1 || reaped[0].Agent != "gone" {
+		t.Fatalf("dry run = %+v, want task #1 held by gone", reaped)
+	}
+	if tk := readTask(t, cfg.TasksPath(), 1); tk.ClaimedBy != "gone" {
+		t.Fatalf("dry run released the claim")
+	}
+
+	if _, err := board.ReapAgents(cfg, false, now); err != nil {
+		t.Fatal(err)
+	}
+	if tk := readTask(t, cfg.TasksPath(), 1); tk.ClaimedBy != "" || tk.ClaimedAt != nil {
+		t.Errorf("task #1 claim = %q, want released", tk.ClaimedBy)
+	}
+	if tk := readTask(t, cfg.TasksPath(), 2); tk.ClaimedBy != transitionAgent {
+		t.Errorf("task #2 claim = %q, live claim must be kept", tk.ClaimedBy)
+	}
+
+	entries, err := board.ReadLog(cfg.Dir(), board.LogFilterOptions{Action: "reap"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Detail != "gone" || !board.Undoable(entries[0]) {
+		t.Errorf("log = %+v, want one undoable reap entry", entries)
+	}
+}
+
+func readTasks(t *testing.T, dir string) []*task.Task {
+	t.Helper()
+	tasks, _, err := task.ReadAllLenient(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tasks
+}
+
+func readTask(t *testing.T, dir string, id int) *task.Task {
+	t.Helper()
+	path, err := task.FindByID(dir, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tk, err := task.Read(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tk
+}
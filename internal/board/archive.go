@@ -16,6 +16,12 @@ type ArchiveResult struct {
 
 // Archive soft-deletes a task by moving it to the archived status.
 func Archive(cfg *config.Config, id int, claimant string, now time.Time) (*ArchiveResult, error) {
+	unlock, err := Lock(cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock() //nolint:errcheck // best-effort unlock
+
 	path, err := task.FindByID(cfg.TasksPath(), id)
 	if err != nil {
 		return nil, err
@@ -38,16 +44,24 @@ func Archive(cfg *config.Config, id int, claimant string, now time.Time) (*Archi
 		return &ArchiveResult{Task: t, OldStatus: ""}, nil
 	}
 
+	before := TakeSnapshot(t)
 	oldStatus := t.Status
 	t.Status = targetStatus
 	task.UpdateTimestamps(t, oldStatus, targetStatus, cfg)
 	t.Updated = now
 
+	hooks := newTaskHooks(cfg, config.HookArchive, before, t, Actor(claimant, t))
+	if err := hooks.runPre(t); err != nil {
+		return nil, err
+	}
+
 	if err := task.Write(path, t); err != nil {
 		return nil, fmt.Errorf("writing task: %w", err)
 	}
 
-	LogMutation(cfg.Dir(), "move", t.ID, oldStatus+" -> "+targetStatus)
+	LogChange(cfg.Dir(), "move", Actor(claimant, t), before, t, oldStatus+" -> "+targetStatus)
+	recordPresence(cfg.Dir(), claimant, "archive", t.ID, now)
+	hooks.runPost(t)
 
 	return &ArchiveResult{Task: t, OldStatus: oldStatus}, nil
 }
@@ -0,0 +1,265 @@
+package board
+
+import (
+	"encoding/json"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/antopolskiy/kanban-md/internal/config"
+	"github.com/antopolskiy/kanban-md/internal/date"
+	"github.com/antopolskiy/kanban-md/internal/task"
+)
+
+// Working time units of task estimates, in hours.
+const (
+	estimateDayHours  = 8
+	estimateWeekHours = 40
+)
+
+// ParseEstimate returns the hours of work an estimate such as "4h", "90m",
+// "1h30m", "2d" or "1w" stands for. Days are working days of 8 hours and
+// weeks five of them; a bare number is taken as hours. ok is false (and
+// hours 0) for an empty, negative, infinite or unrecognized estimate.
+func ParseEstimate(s string) (hours float64, ok bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return 0, false
+	}
+	if n, err := strconv.ParseFloat(s, 64); err == nil {
+		return validEstimate(n)
+	}
+	for _, u := range []struct {
+		suffix string
+		hours  float64
+	}{{"d", estimateDayHours}, {"w", estimateWeekHours}} {
+		if num, found := strings.CutSuffix(s, u.suffix); found {
+			n, err := strconv.ParseFloat(strings.TrimSpace(num), 64)
+			if err != nil {
+				return 0, false
+			}
+			return validEstimate(n * u.hours)
+		}
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, false
+	}
+	return validEstimate(d.Hours())
+}
+
+// validEstimate returns hours, or 0 and false unless it is a finite,
+// non-negative number.
+func validEstimate(hours float64) (float64, bool) {
+	if math.IsNaN(hours) || math.IsInf(hours, 0) || hours < 0 {
+		return 0, false
+	}
+	return hours, true
+}
+
+// BurndownScope selects the tasks of a burndown: the tasks with Tag, the
+// subtasks of Parent, or the tasks matching both when both are set.
+type BurndownScope struct {
+	Tag    string
+	Parent *int
+}
+
+// String describes the scope, e.g. "tag v2.0" or "parent #12".
+func (s BurndownScope) String() string {
+	var parts []string
+	if s.Tag != "" {
+		parts = append(parts, "tag "+s.Tag)
+	}
+	if s.Parent != nil {
+		parts = append(parts, "parent #"+strconv.Itoa(*s.Parent))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// BurndownDay holds the scope of a burndown at the end of one day, and the
+// tasks that entered or left it during the day.
+type BurndownDay struct {
+	Date              date.Date `json:"date"`
+	Scope             int       `json:"scope"`     // tasks in scope
+	Completed         int       `json:"completed"` // tasks in scope in a terminal status
+	Remaining         int       `json:"remaining"`
+	ScopeEstimate     float64   `json:"scope_estimate"` // hours, from the tasks' estimates
+	CompletedEstimate float64   `json:"completed_estimate"`
+	RemainingEstimate float64   `json:"remaining_estimate"`
+	Added             []int     `json:"added,omitempty"`   // IDs of tasks that entered the scope
+	Removed           []int     `json:"removed,omitempty"` // IDs of tasks that left the scope
+}
+
+// Burndown is a daily series of the completed and remaining work of a scope
+// of tasks: the data of a burndown or burnup chart.
+type Burndown struct {
+	Scope       string        `json:"scope"`
+	Since       date.Date     `json:"since"`
+	Until       date.Date     `json:"until"`
+	Unestimated int           `json:"unestimated"` // tasks in scope at the end without a usable estimate
+	Days        []BurndownDay `json:"days"`
+}
+
+// scopeState is what decides whether a task is in a burndown's scope, and
+// how much it weighs, at one point in time.
+type scopeState struct {
+	status   string // "" before the task was created
+	created  time.Time
+	tags     []string
+	parent   *int
+	estimate string
+}
+
+// scopeFields are the fields whose history a burndown replays, besides the
+// status.
+var scopeFields = map[string]bool{"tags": true, "parent": true, "estimate": true}
+
+// fieldRevert restores a field of a task to its value before a logged change.
+type fieldRevert struct {
+	taskID int
+	at     time.Time
+	field  string
+	before json.RawMessage
+}
+
+// revert sets the field changed by r back to its value before the change.
+func (s *scopeState) revert(r fieldRevert) {
+	switch r.field {
+	case "tags":
+		s.tags = nil
+		_ = json.Unmarshal(r.before, &s.tags)
+	case "parent":
+		s.parent = nil
+		_ = json.Unmarshal(r.before, &s.parent)
+	case "estimate":
+		s.estimate = ""
+		_ = json.Unmarshal(r.before, &s.estimate)
+	}
+}
+
+// matches reports whether a task in state st is in the scope.
+func (s BurndownScope) matches(st *scopeState) bool {
+	if st.status == "" {
+		return false
+	}
+	if s.Tag != "" && !slices.Contains(st.tags, s.Tag) {
+		return false
+	}
+	return s.Parent == nil || (st.parent != nil && *st.parent == *s.Parent)
+}
+
+// ComputeBurndown reconstructs the scope of a burndown at the end of every
+// day from since to until (inclusive, local days) by replaying the status,
+// tag, parent and estimate changes in the activity log backwards from the
+// tasks' current state. A task in a terminal status counts as completed.
+// Each day records the tasks that entered or left the scope since the day
+// before. Tasks that predate the log count from their created time with the
+// fields they had when the log begins; deleted tasks are not counted.
+func ComputeBurndown(cfg *config.Config, tasks []*task.Task, entries []LogEntry, scope BurndownScope, since, until date.Date) Burndown {
+	b := Burndown{Scope: scope.String(), Since: since, Until: until}
+	states := make(map[int]*scopeState, len(tasks))
+	for _, t := range tasks {
+		states[t.ID] = &scopeState{
+			status: t.Status, created: t.Created, tags: t.Tags, parent: t.Parent, estimate: t.Estimate,
+		}
+	}
+	transitions := Transitions(entries)
+	reverts := fieldReverts(entries)
+
+	// Replay one extra day before since, so the first day's scope changes
+	// are known too.
+	days := dayRange(date.New(since.Year(), since.Month(), since.Day()-1), until)
+	inScope := make([]map[int]bool, len(days))
+	b.Days = make([]BurndownDay, len(days))
+	nextTr, nextRev := len(transitions)-1, len(reverts)-1
+	for i := len(days) - 1; i >= 0; i-- {
+		end := dayStart(days[i]).AddDate(0, 0, 1)
+		for ; nextTr >= 0 && !transitions[nextTr].At.Before(end); nextTr-- {
+			if st, ok := states[transitions[nextTr].TaskID]; ok {
+				st.status = transitions[nextTr].From
+			}
+		}
+		for ; nextRev >= 0 && !reverts[nextRev].at.Before(end); nextRev-- {
+			if st, ok := states[reverts[nextRev].taskID]; ok {
+				st.revert(reverts[nextRev])
+			}
+		}
+		b.Days[i], inScope[i] = burndownDay(cfg, days[i], scope, states, end)
+		if i == len(days)-1 {
+			b.Unestimated = unestimated(states, inScope[i])
+		}
+	}
+
+	for i := 1; i < len(days); i++ {
+		b.Days[i].Added = scopeDiff(inScope[i], inScope[i-1])
+		b.Days[i].Removed = scopeDiff(inScope[i-1], inScope[i])
+	}
+	b.Days = b.Days[1:]
+	return b
+}
+
+// fieldReverts collects the changes to scope fields from log entries, oldest
+// first.
+func fieldReverts(entries []LogEntry) []fieldRevert {
+	var out []fieldRevert
+	for _, e := range entries {
+		for _, c := range e.Changes {
+			if scopeFields[c.Field] {
+				out = append(out, fieldRevert{taskID: e.TaskID, at: e.Timestamp, field: c.Field, before: c.Before})
+			}
+		}
+	}
+	slices.SortStableFunc(out, func(a, b fieldRevert) int { return a.at.Compare(b.at) })
+	return out
+}
+
+// burndownDay totals the tasks in scope at end, the end of day d, and
+// returns the IDs of those tasks.
+func burndownDay(cfg *config.Config, d date.Date, scope BurndownScope, states map[int]*scopeState, end time.Time) (BurndownDay, map[int]bool) {
+	day := BurndownDay{Date: d}
+	in := make(map[int]bool)
+	for id, st := range states {
+		if !st.created.Before(end) || !scope.matches(st) {
+			continue
+		}
+		in[id] = true
+		hours, ok := ParseEstimate(st.estimate)
+		if !ok {
+			hours = 0
+		}
+		day.Scope++
+		day.ScopeEstimate += hours
+		if cfg.IsTerminalStatus(st.status) {
+			day.Completed++
+			day.CompletedEstimate += hours
+		}
+	}
+	day.Remaining = day.Scope - day.Completed
+	day.RemainingEstimate = day.ScopeEstimate - day.CompletedEstimate
+	return day, in
+}
+
+// unestimated counts the tasks in the set without a usable estimate.
+func unestimated(states map[int]*scopeState, in map[int]bool) int {
+	n := 0
+	for id := range in {
+		if _, ok := ParseEstimate(states[id].estimate); !ok {
+			n++
+		}
+	}
+	return n
+}
+
+// scopeDiff returns the IDs in a but not in b, in ascending order.
+func scopeDiff(a, b map[int]bool) []int {
+	var ids []int
+	for id := range a {
+		if !b[id] {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+	return ids
+}
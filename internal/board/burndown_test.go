@@ -0,0 +1,128 @@
+package board_test
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/antopolskiy/kanban-md/internal/board"
+	"github.com/antopolskiy/kanban-md/internal/config"
+	"github.com/antopolskiy/kanban-md/internal/date"
+	"github.com/antopolskiy/kanban-md/internal/task"
+)
+
+func TestParseEstimate(t *testing.T) {
+	tests := []struct {
+		in    string
+		hours float64
+		ok    bool
+	}{
+		{"4h", 4, true},
+		{"90m", 1.5, true},
+		{"1h30m", 1.5, true},
+		{"2d", 16, true},
+		{"1.5 D", 12, true},
+		{"1w", 40, true},
+		{"3", 3, true},
+		{"", 0, false},
+		{"soon", 0, false},
+		{"-2h", 0, false},
+		{"-2d", 0, false},
+		{"-3", 0, false},
+		{"nan", 0, false},
+		{"inf", 0, false},
+		{"+Inf d", 0, false},
+		{"1e400", 0, false},
+	}
+	for _, tt := range tests {
+		hours, ok := board.ParseEstimate(tt.in)
+		if ok != tt.ok || (ok && hours != tt.hours) {
+			t.Errorf("ParseEstimate(%q) = %v, %v; want %v, %v", tt.in, hours, ok, tt.hours, tt.ok)
+		}
+	}
+}
+
+func TestComputeBurndown_ReplaysScope(t *testing.T) {
+	cfg := config.NewDefault("Test")
+	day := func(d int, hour int) time.Time { return time.Date(2026, 5, d, hour, 0, 0, 0, time.Local) }
+	raw := func(v any) json.RawMessage {
+		b, _ := json.Marshal(v)
+		return b
+	}
+	tasks := []*task.Task{
+		{ID: 1, Status: "done", Created: day(1, 9), Tags: []string{"v2"}, Estimate: "1d"},
+		{ID: 2, Status: "todo", Created: day(1, 9), Tags: []string{"v2"}, Estimate: "4h"},
+		{ID: 3, Status: "todo", Created: day(1, 9), Tags: []string{"v2"}},    // tagged on day 2
+		{ID: 4, Status: "todo", Created: day(1, 9), Tags: []string{"other"}}, // untagged on day 3
+	}
+	entries := []board.LogEntry{
+		{Timestamp: day(2, 10), Action: "edit", TaskID: 3, Changes: []board.FieldChange{
+			{Field: "tags", After: raw([]string{"v2"})},
+		}},
+		{Timestamp: day(3, 10), Action: "move", TaskID: 1, Transition: &board.StatusTransition{From: "todo", To: "done"}},
+		{Timestamp: day(3, 11), Action: "edit", TaskID: 4, Changes: []board.FieldChange{
+			{Field: "tags", Before: raw([]string{"v2"}), After: raw([]string{"other"})},
+		}},
+		{Timestamp: day(3, 12), Action: "edit", TaskID: 2, Changes: []board.FieldChange{
+			{Field: "estimate", Before: raw("2h"), After: raw("4h")},
+		}},
+	}
+
+	b := board.ComputeBurndown(cfg, tasks, entries, board.BurndownScope{Tag: "v2"},
+		date.New(2026, 5, 1), date.New(2026, 5, 3))
+
+	if b.Scope != "tag v2" || b.Unestimated != 1 {
+		t.Errorf("scope = %q, unestimated = %d; want %q, 1", b.Scope, b.Unestimated, "tag v2")
+	}
+	if len(b.Days) != 3 {
+		t.Fatalf("days = %d, want 3", len(b.Days))
+	}
+	tests := []struct {
+		scope, completed, remaining int
+		scopeEst, remainingEst      float64
+		added, removed              []int
+	}{
+		{3, 0, 3, 10, 10, []int{1, 2, 4}, nil},
+		{4, 0, 4, 10, 10, []int{3}, nil},
+		{3, 1, 2, 12, 4, nil, []int{4}},
+	}
+	for i, tt := range tests {
+		d := b.Days[i]
+		if d.Scope != tt.scope || d.Completed != tt.completed || d.Remaining != tt.remaining {
+			t.Errorf("%s scope/completed/remaining = %d/%d/%d, want %d/%d/%d", d.Date,
+				d.Scope, d.Completed, d.Remaining, tt.scope, tt.completed, tt.remaining)
+		}
+		if d.ScopeEstimate != tt.scopeEst || d.RemainingEstimate != tt.remainingEst {
+			t.Errorf("%s scope/remaining estimate = %v/%v, want %v/%v", d.Date,
+				d.ScopeEstimate, d.RemainingEstimate, tt.scopeEst, tt.remainingEst)
+		}
+		if !slices.Equal(d.Added, tt.added) || !slices.Equal(d.Removed, tt.removed) {
+			t.Errorf("%s added/removed = %v/%v, want %v/%v", d.Date, d.Added, d.Removed, tt.added, tt.removed)
+		}
+	}
+}
+
+func TestComputeBurndown_Parent(t *testing.T) {
+	cfg := config.NewDefault("Test")
+	parent := 1
+	created := time.Date(2026, 5, 1, 9, 0, 0, 0, time.Local)
+	tasks := []*task.Task{
+		{ID: 1, Status: "in-progress", Created: created},
+		{ID: 2, Status: "done", Created: created, Parent: &parent},
+		{ID: 3, Status: "todo", Created: created.AddDate(0, 0, 1), Parent: &parent},
+	}
+	b := board.ComputeBurndown(cfg, tasks, nil, board.BurndownScope{Parent: &parent},
+		date.New(2026, 5, 1), date.New(2026, 5, 2))
+
+	if b.Scope != "parent #1" {
+		t.Errorf("scope = %q, want %q", b.Scope, "parent #1")
+	}
+	first, last := b.Days[0], b.Days[1]
+	if first.Scope != 1 || first.Completed != 1 || last.Scope != 2 || last.Remaining != 1 {
+		t.Errorf("days = %+v, %+v; want scope 1 (1 completed) then 2 (1 remaining)", first, last)
+	}
+	if !slices.Equal(last.Added, []int{3}) {
+		t.Errorf("added on day 2 = %v, want [3]", last.Added)
+	}
+}
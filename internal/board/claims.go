@@ -0,0 +1,138 @@
+package board
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/antopolskiy/kanban-md/internal/clierr"
+	"github.com/antopolskiy/kanban-md/internal/config"
+	"github.com/antopolskiy/kanban-md/internal/task"
+)
+
+const actionClaimExpired = "claim-expired"
+
+// Claim describes a claimed task for `claims`. ExpiresAt is nil when the
+// claim never expires (claim_timeout is 0 or the claim time is unknown).
+type Claim struct {
+	ID        int        `json:"id"`
+	Title     string     `json:"title"`
+	Status    string     `json:"status"`
+	ClaimedBy string     `json:"claimed_by"`
+	ClaimedAt *time.Time `json:"claimed_at,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Expired   bool       `json:"expired"`
+}
+
+// ListClaims returns the claims held on open tasks, the soonest to expire
+// first and claims that never expire last. Claims left on finished (terminal
+// or archived) tasks are not work in progress and are left out.
+func ListClaims(cfg *config.Config, tasks []*task.Task, now time.Time) []Claim {
+	return claimsOf(cfg, tasks, false, now)
+}
+
+// claimsOf returns the claims held on tasks, sorted as for ListClaims, and
+// includes the claims on finished tasks if finished is set.
+func claimsOf(cfg *config.Config, tasks []*task.Task, finished bool, now time.Time) []Claim {
+	timeout := cfg.ClaimTimeoutDuration()
+	claims := []Claim{}
+	for _, t := range tasks {
+		if t.ClaimedBy == "" || (!finished && cfg.IsTerminalStatus(t.Status)) {
+			continue
+		}
+		c := Claim{ID: t.ID, Title: t.Title, Status: t.Status, ClaimedBy: t.ClaimedBy, ClaimedAt: t.ClaimedAt}
+		if timeout > 0 && t.ClaimedAt != nil {
+			expires := t.ClaimedAt.Add(timeout)
+			c.ExpiresAt = &expires
+			c.Expired = now.After(expires)
+		}
+		claims = append(claims, c)
+	}
+	slices.SortStableFunc(claims, func(a, b Claim) int {
+		switch {
+		case a.ExpiresAt == nil && b.ExpiresAt == nil:
+			return a.ID - b.ID
+		case a.ExpiresAt == nil:
+			return 1
+		case b.ExpiresAt == nil:
+			return -1
+		}
+		if c := a.ExpiresAt.Compare(*b.ExpiresAt); c != 0 {
+			return c
+		}
+		return a.ID - b.ID
+	})
+	return claims
+}
+
+// ExpireClaimsParams holds the options for ExpireClaims.
+type ExpireClaimsParams struct {
+	MoveTo string // status to move tasks to; empty leaves them in place
+	DryRun bool
+}
+
+// ExpireClaims clears every expired claim on the board. Each task gets a note
+// recording whose claim expired, is moved to params.MoveTo (when set and
+// different), and is logged as a "claim-expired" entry that undo can revert.
+// Finished (terminal or archived) tasks only lose their claim; they are never
+// moved, so expiring a claim cannot reopen finished work.
+// Moves are cleanup rather than work, so WIP limits and workflow transitions
+// do not apply; the target status must not require a claim. With DryRun,
+// nothing is written and the claims that would expire are returned.
+func ExpireClaims(cfg *config.Config, params ExpireClaimsParams, now time.Time) ([]Claim, error) {
+	if params.MoveTo != "" {
+		if err := task.ValidateStatus(params.MoveTo, cfg.BoardStatuses()); err != nil {
+			return nil, err
+		}
+		if cfg.StatusRequiresClaim(params.MoveTo) {
+			return nil, clierr.Newf(clierr.InvalidInput,
+				"cannot move tasks with expired claims to %q: it requires a claim", params.MoveTo).
+				WithDetails(map[string]any{"status": params.MoveTo})
+		}
+	}
+
+	unlock, err := Lock(cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock() //nolint:errcheck // best-effort unlock
+
+	tasks, _, err := task.ReadAllLenient(cfg.TasksPath())
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int]*task.Task, len(tasks))
+	for _, t := range tasks {
+		byID[t.ID] = t
+	}
+
+	expired := []Claim{}
+	for _, c := range claimsOf(cfg, tasks, true, now) {
+		if !c.Expired {
+			continue
+		}
+		if !params.DryRun {
+			if err := releaseClaim(cfg, byID[c.ID], actionClaimExpired, expireApply(cfg, c, params.MoveTo), now); err != nil {
+				return expired, err
+			}
+		}
+		expired = append(expired, c)
+	}
+	return expired, nil
+}
+
+// expireApply returns the changes ExpireClaims makes to a task besides
+// clearing its claim: the note and the optional move.
+func expireApply(cfg *config.Config, c Claim, moveTo string) func(*task.Task) {
+	return func(t *task.Task) {
+		note := fmt.Sprintf("Claim by %s expired (claimed %s, expired %s).",
+			c.ClaimedBy, c.ClaimedAt.Format(time.RFC3339), c.ExpiresAt.Format(time.RFC3339))
+		if moveTo != "" && moveTo != t.Status && !cfg.IsTerminalStatus(t.Status) {
+			note += fmt.Sprintf(" Moved from %s to %s.", t.Status, moveTo)
+			oldStatus := t.Status
+			t.Status = moveTo
+			task.UpdateTimestamps(t, oldStatus, moveTo, cfg)
+		}
+		t.Body = AppendBody(t.Body, note, false)
+	}
+}
@@ -0,0 +1,109 @@
+package board_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/antopolskiy/kanban-md/internal/board"
+	"github.com/antopolskiy/kanban-md/internal/clierr"
+	"github.com/antopolskiy/kanban-md/internal/task"
+)
+
+func TestListClaims_OrdersByExpiry(t *testing.T) {
+	now := time.Now()
+	old, recent := now.Add(-2*time.Hour), now.Add(-10*time.Minute)
+	cfg := setupWorkflowBoard(t,
+		&task.Task{ID: 1, Title: "recent", Status: "in-progress", ClaimedBy: "a", ClaimedAt: &recent},
+		&task.Task{ID: 2, Title: "old", Status: "in-progress", ClaimedBy: "b", ClaimedAt: &old},
+		&task.Task{ID: 3, Title: "free", Status: "todo"},
+	)
+
+	claims := board.ListClaims(cfg, readTasks(t, cfg.TasksPath()), now)
+	if len(claims) != 2 || claims[0].ID != 2 || claims[1].ID != 1 {
+		t.Fatalf("claims = %+v, want #2 then #1", claims)
+	}
+	if !claims[0].Expired || claims[1].Expired {
+		t.Errorf("expired = %v, %v; want only #2 expired", claims[0].Expired, claims[1].Expired)
+	}
+	if want := recent.Add(time.Hour); !claims[1].ExpiresAt.Equal(want) {
+		t.Errorf("ExpiresAt = %v, want %v", claims[1].ExpiresAt, want)
+	}
+}
+
+func TestExpireClaims_ClearsAndMoves(t *testing.T) {
+	now := time.Now()
+	old, recent := now.Add(-2*time.Hour), now.Add(-10*time.Minute)
+	cfg := setupWorkflowBoard(t,
+		&task.Task{ID: 1, Title: "abandoned", Status: "in-progress", ClaimedBy: "gone", ClaimedAt: &old},
+		&task.Task{ID: 2, Title: "active", Status: "in-progress", ClaimedBy: transitionAgent, ClaimedAt: &recent},
+	)
+
+	expired, err := board.ExpireClaims(cfg, board.ExpireClaimsParams{MoveTo: "todo"}, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(expired) != 1 || expired[0].ID != 1 || expired[0].ClaimedBy != "gone" {
+		t.Fatalf("expired = %+v, want #1 held by gone", expired)
+	}
+
+	tk := readTask(t, cfg.TasksPath(), 1)
+	if tk.ClaimedBy != "" || tk.Status != "todo" {
+		t.Errorf("task #1 = %q claimed by %q, want todo and released", tk.Status, tk.ClaimedBy)
+	}
+	if !strings.Contains(tk.Body, "Claim by gone expired") || !strings.Contains(tk.Body, "Moved from in-progress to todo") {
+		t.Errorf("body = %q, want expiry note", tk.Body)
+	}
+	if tk := readTask(t, cfg.TasksPath(), 2); tk.ClaimedBy != transitionAgent {
+		t.Errorf("task #2 claim = %q, live claim must be kept", tk.ClaimedBy)
+	}
+
+	entries, err := board.ReadLog(cfg.Dir(), board.LogFilterOptions{Action: "claim-expired"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Detail != "gone" || !board.Undoable(entries[0]) {
+		t.Errorf("log = %+v, want one undoable claim-expired entry", entries)
+	}
+}
+
+func TestExpireClaims_KeepsFinishedTasksInPlace(t *testing.T) {
+	now := time.Now()
+	old := now.Add(-2 * time.Hour)
+	cfg := setupWorkflowBoard(t,
+		&task.Task{ID: 1, Title: "finished", Status: "done", ClaimedBy: "gone", ClaimedAt: &old},
+		&task.Task{ID: 2, Title: "shelved", Status: "archived", ClaimedBy: "gone", ClaimedAt: &old},
+	)
+
+	if claims := board.ListClaims(cfg, readTasks(t, cfg.TasksPath()), now); len(claims) != 0 {
+		t.Errorf("claims = %+v, want claims on finished tasks left out", claims)
+	}
+
+	expired, err := board.ExpireClaims(cfg, board.ExpireClaimsParams{MoveTo: "todo"}, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(expired) != 2 {
+		t.Fatalf("expired = %+v, want both claims cleared", expired)
+	}
+	for id, status := range map[int]string{1: "done", 2: "archived"} {
+		tk := readTask(t, cfg.TasksPath(), id)
+		if tk.ClaimedBy != "" || tk.Status != status {
+			t.Errorf("task #%d = %q claimed by %q, want %s and released", id, tk.Status, tk.ClaimedBy, status)
+		}
+		if strings.Contains(tk.Body, "Moved from") {
+			t.Errorf("task #%d body = %q, want no move", id, tk.Body)
+		}
+	}
+}
+
+func TestExpireClaims_RejectsClaimRequiredTarget(t *testing.T) {
+	cfg := setupWorkflowBoard(t, &task.Task{ID: 1, Title: "a", Status: "todo"})
+
+	_, err := board.ExpireClaims(cfg, board.ExpireClaimsParams{MoveTo: "review"}, time.Now())
+	var cliErr *clierr.Error
+	if !errors.As(err, &cliErr) || cliErr.Code != clierr.InvalidInput {
+		t.Errorf("err = %v, want INVALID_INPUT", err)
+	}
+}
@@ -0,0 +1,81 @@
+package board
+
+import (
+	"slices"
+	"time"
+
+	"github.com/antopolskiy/kanban-md/internal/task"
+)
+
+// maxHistogramBins caps the number of bins of a cycle time histogram; wider
+// ranges get bins of several days.
+const maxHistogramBins = 30
+
+// CycleTimePoint is the cycle time of one completed task: a dot on a cycle
+// time scatter plot.
+type CycleTimePoint struct {
+	ID             int       `json:"id"`
+	Title          string    `json:"title"`
+	Class          string    `json:"class"`
+	Tags           []string  `json:"tags,omitempty"`
+	Completed      time.Time `json:"completed"`
+	CycleTimeHours float64   `json:"cycle_time_hours"`
+	LeadTimeHours  float64   `json:"lead_time_hours"`
+}
+
+// HistogramBin counts the tasks with a cycle time of at least FromDays and
+// less than ToDays days.
+type HistogramBin struct {
+	FromDays int `json:"from_days"`
+	ToDays   int `json:"to_days"`
+	Count    int `json:"count"`
+}
+
+// CycleTimes holds the cycle times of completed tasks, oldest completion
+// first, with their histogram and percentiles.
+type CycleTimes struct {
+	Points    []CycleTimePoint `json:"points"`
+	Histogram []HistogramBin   `json:"histogram"`
+	P50Hours  *float64         `json:"p50_hours,omitempty"`
+	P85Hours  *float64         `json:"p85_hours,omitempty"`
+	P95Hours  *float64         `json:"p95_hours,omitempty"`
+}
+
+// ComputeCycleTimes collects the cycle times of the completed tasks among
+// tasks. Tasks completed without a Started timestamp are left out.
+func ComputeCycleTimes(tasks []*task.Task) CycleTimes {
+	ct := CycleTimes{Points: []CycleTimePoint{}, Histogram: []HistogramBin{}}
+	var hours []float64
+	for _, t := range tasks {
+		if t.Completed == nil || t.Started == nil {
+			continue
+		}
+		p := CycleTimePoint{
+			ID:             t.ID,
+			Title:          t.Title,
+			Class:          taskClass(t),
+			Tags:           t.Tags,
+			Completed:      *t.Completed,
+			CycleTimeHours: t.Completed.Sub(*t.Started).Hours(),
+			LeadTimeHours:  t.Completed.Sub(t.Created).Hours(),
+		}
+		ct.Points = append(ct.Points, p)
+		hours = append(hours, p.CycleTimeHours)
+	}
+	if len(ct.Points) == 0 {
+		return ct
+	}
+	slices.SortStableFunc(ct.Points, func(a, b CycleTimePoint) int { return a.Completed.Compare(b.Completed) })
+	_, ct.P50Hours, ct.P85Hours, ct.P95Hours = stats(hours)
+
+	maxDays := max(int(hours[len(hours)-1]/hoursPerDay), 0) // stats sorted hours
+	width := maxDays/maxHistogramBins + 1
+	ct.Histogram = make([]HistogramBin, maxDays/width+1)
+	for i := range ct.Histogram {
+		ct.Histogram[i] = HistogramBin{FromDays: i * width, ToDays: (i + 1) * width}
+	}
+	for _, h := range hours {
+		ct.Histogram[max(int(h/hoursPerDay), 0)/width].Count++
+	}
+	return ct
+}
@@ -0,0 +1,65 @@
+package board_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/antopolskiy/kanban-md/internal/board"
+	"github.com/antopolskiy/kanban-md/internal/task"
+)
+
+func TestComputeCycleTimes(t *testing.T) {
+	t0 := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	done := func(id int, cycle time.Duration, completedDay int) *task.Task {
+		completed := t0.AddDate(0, 0, completedDay)
+		started := completed.Add(-cycle)
+		return &task.Task{ID: id, Created: started, Started: &started, Completed: &completed}
+	}
+	tasks := []*task.Task{
+		done(1, 50*time.Hour, 3),
+		done(2, 5*time.Hour, 1),
+		done(3, 30*time.Hour, 2),
+		{ID: 4, Created: t0}, // not completed
+	}
+
+	ct := board.ComputeCycleTimes(tasks)
+
+	if len(ct.Points) != 3 || ct.Points[0].ID != 2 || ct.Points[2].ID != 1 {
+		t.Fatalf("points = %+v, want tasks 2, 3, 1 (by completion)", ct.Points)
+	}
+	if ct.Points[0].Class != "standard" || ct.Points[0].CycleTimeHours != 5 {
+		t.Errorf("point = %+v, want standard class and 5h", ct.Points[0])
+	}
+	want := []board.HistogramBin{{0, 1, 1}, {1, 2, 1}, {2, 3, 1}}
+	if len(ct.Histogram) != len(want) {
+		t.Fatalf("histogram = %+v, want %+v", ct.Histogram, want)
+	}
+	for i := range want {
+		if ct.Histogram[i] != want[i] {
+			t.Errorf("bin %d = %+v, want %+v", i, ct.Histogram[i], want[i])
+		}
+	}
+	if *ct.P50Hours != 30 || *ct.P95Hours != 50 {
+		t.Errorf("p50/p95 = %v/%v, want 30/50", *ct.P50Hours, *ct.P95Hours)
+	}
+}
+
+func TestComputeCycleTimes_WideBins(t *testing.T) {
+	t0 := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	completed := t0.AddDate(0, 0, 100)
+	long, short := t0, completed.Add(-time.Hour)
+	tasks := []*task.Task{
+		{ID: 1, Created: long, Started: &long, Completed: &completed},   // 100 days
+		{ID: 2, Created: short, Started: &short, Completed: &completed}, // 1 hour
+	}
+
+	ct := board.ComputeCycleTimes(tasks)
+
+	if len(ct.Histogram) > 30 {
+		t.Fatalf("histogram has %d bins, want at most 30", len(ct.Histogram))
+	}
+	first, last := ct.Histogram[0], ct.Histogram[len(ct.Histogram)-1]
+	if first.Count != 1 || last.Count != 1 || last.FromDays > 100 || last.ToDays <= 100 {
+		t.Errorf("histogram = %+v, want the 1h and 100d tasks in the first and last bins", ct.Histogram)
+	}
+}
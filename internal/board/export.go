@@ -0,0 +1,71 @@
+package board
+
+import (
+	"slices"
+	"time"
+
+	"github.com/antopolskiy/kanban-md/internal/config"
+	"github.com/antopolskiy/kanban-md/internal/task"
+)
+
+// Export is a snapshot of the board for publishing: its columns with their
+// tasks and the board summary.
+type Export struct {
+	BoardName string         `json:"board_name"`
+	Generated time.Time      `json:"generated"`
+	Summary   Overview       `json:"summary"`
+	Columns   []ExportColumn `json:"columns"`
+}
+
+// ExportColumn is one board column of an Export.
+type ExportColumn struct {
+	StatusSummary
+	Tasks []*task.Task `json:"tasks"`
+}
+
+// OverWIP reports whether the column holds more tasks than its WIP limit.
+func (c ExportColumn) OverWIP() bool {
+	return c.WIPLimit > 0 && c.Count > c.WIPLimit
+}
+
+// BuildExport lays out the tasks matching filter in the board columns
+// (cfg.BoardStatuses), highest priority first. The summary, including each
+// column's WIP usage, covers all tasks, so a filtered export still shows how
+// full the board is. Tasks in other statuses, such as archived ones, are left
+// out.
+func BuildExport(cfg *config.Config, tasks []*task.Task, filter FilterOptions, now time.Time) *Export {
+	summary := Summary(cfg, tasks, now)
+	e := &Export{
+		BoardName: summary.BoardName,
+		Generated: now,
+		Summary:   summary,
+		Columns:   make([]ExportColumn, 0, len(summary.Statuses)),
+	}
+	byStatus := make(map[string][]*task.Task, len(summary.Statuses))
+	for _, t := range Filter(tasks, filter) {
+		byStatus[t.Status] = append(byStatus[t.Status], t)
+	}
+	for _, ss := range summary.Statuses {
+		col := byStatus[ss.Status]
+		slices.SortStableFunc(col, func(a, b *task.Task) int {
+			if d := cfg.PriorityIndex(b.Priority) - cfg.PriorityIndex(a.Priority); d != 0 {
+				return d
+			}
+			return a.ID - b.ID
+		})
+		if col == nil {
+			col = []*task.Task{}
+		}
+		e.Columns = append(e.Columns, ExportColumn{StatusSummary: ss, Tasks: col})
+	}
+	return e
+}
+
+// Tasks returns the exported tasks, column by column.
+func (e *Export) Tasks() []*task.Task {
+	var tasks []*task.Task
+	for _, c := range e.Columns {
+		tasks = append(tasks, c.Tasks...)
+	}
+	return tasks
+}
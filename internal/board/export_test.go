@@ -0,0 +1,58 @@
+package board_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/antopolskiy/kanban-md/internal/board"
+	"github.com/antopolskiy/kanban-md/internal/config"
+	"github.com/antopolskiy/kanban-md/internal/task"
+)
+
+func TestBuildExport(t *testing.T) {
+	cfg := config.NewDefault("Test")
+	cfg.WIPLimits = map[string]int{"in-progress": 1}
+	tasks := []*task.Task{
+		{ID: 1, Title: "Low", Status: "todo", Priority: "low"},
+		{ID: 2, Title: "High", Status: "todo", Priority: "high"},
+		{ID: 3, Title: "Also high", Status: "todo", Priority: "high"},
+		{ID: 4, Title: "Working", Status: "in-progress", Priority: "medium"},
+		{ID: 5, Title: "Working too", Status: "in-progress", Priority: "medium"},
+		{ID: 6, Title: "Old", Status: config.ArchivedStatus, Priority: "medium"},
+	}
+
+	e := board.BuildExport(cfg, tasks, board.FilterOptions{}, time.Now())
+	if len(e.Columns) != len(cfg.BoardStatuses()) {
+		t.Fatalf("columns = %d, want one per board status", len(e.Columns))
+	}
+	byStatus := map[string]board.ExportColumn{}
+	for _, c := range e.Columns {
+		byStatus[c.Status] = c
+	}
+	todo := byStatus["todo"]
+	if len(todo.Tasks) != 3 || todo.Tasks[0].ID != 2 || todo.Tasks[1].ID != 3 || todo.Tasks[2].ID != 1 {
+		t.Errorf("todo = %v, want #2, #3, #1 (priority, then ID)", todo.Tasks)
+	}
+	if wip := byStatus["in-progress"]; !wip.OverWIP() || wip.Count != 2 {
+		t.Errorf("in-progress = %+v, want 2 tasks over the limit of 1", wip.StatusSummary)
+	}
+	if done := byStatus["done"]; done.Tasks == nil || done.OverWIP() {
+		t.Errorf("done = %+v, want an empty, non-nil column", done)
+	}
+	if got := len(e.Tasks()); got != 5 {
+		t.Errorf("exported %d tasks, want 5 without the archived one", got)
+	}
+
+	// A filter selects the cards, but WIP usage still counts every task.
+	e = board.BuildExport(cfg, tasks, board.FilterOptions{Search: "Working too"}, time.Now())
+	for _, c := range e.Columns {
+		byStatus[c.Status] = c
+	}
+	if wip := byStatus["in-progress"]; len(wip.Tasks) != 1 || wip.Count != 2 || !wip.OverWIP() {
+		t.Errorf("filtered in-progress = %d cards, %+v; want 1 card and the column over its limit",
+			len(wip.Tasks), wip.StatusSummary)
+	}
+	if got := len(e.Tasks()); got != 1 || e.Summary.TotalTasks != len(tasks) {
+		t.Errorf("filtered export = %d cards, %d total; want 1 card of %d", got, e.Summary.TotalTasks, len(tasks))
+	}
+}
@@ -0,0 +1,146 @@
+package board
+
+import (
+	"strings"
+
+	"github.com/antopolskiy/kanban-md/internal/clierr"
+	"github.com/antopolskiy/kanban-md/internal/config"
+	"github.com/antopolskiy/kanban-md/internal/task"
+)
+
+// ParseFieldAssignments parses NAME=VALUE custom field assignments (from
+// --set). Values of fields declared in the config schema are converted to the
+// field's type; other names are stored as strings.
+func ParseFieldAssignments(cfg *config.Config, assignments []string) (map[string]any, error) {
+	if len(assignments) == 0 {
+		return nil, nil
+	}
+	values := make(map[string]any, len(assignments))
+	for _, a := range assignments {
+		name, raw, ok := strings.Cut(a, "=")
+		if !ok {
+			return nil, clierr.Newf(clierr.InvalidField, "invalid field assignment %q: expected NAME=VALUE", a).
+				WithDetails(map[string]any{"input": a})
+		}
+		name = strings.TrimSpace(name)
+		if err := validateFieldName(name); err != nil {
+			return nil, err
+		}
+		v, err := parseFieldValue(cfg, name, raw)
+		if err != nil {
+			return nil, err
+		}
+		values[name] = v
+	}
+	return values, nil
+}
+
+// ParseFieldFilters parses NAME=VALUE custom field filters (from --field).
+// A task matches a filter when the field equals VALUE or, for list fields,
+// contains it.
+func ParseFieldFilters(filters []string) (map[string]string, error) {
+	if len(filters) == 0 {
+		return nil, nil
+	}
+	out := make(map[string]string, len(filters))
+	for _, f := range filters {
+		name, value, ok := strings.Cut(f, "=")
+		if !ok {
+			return nil, clierr.Newf(clierr.InvalidField, "invalid field filter %q: expected NAME=VALUE", f).
+				WithDetails(map[string]any{"input": f})
+		}
+		name = strings.TrimSpace(name)
+		if err := validateFieldName(name); err != nil {
+			return nil, err
+		}
+		out[name] = value
+	}
+	return out, nil
+}
+
+// ValidateFieldUnset checks that custom fields may be removed (--unset):
+// required schema fields cannot be.
+func ValidateFieldUnset(cfg *config.Config, names []string) error {
+	for _, name := range names {
+		if err := validateFieldName(name); err != nil {
+			return err
+		}
+		if f := cfg.FieldByName(name); f != nil && f.Required {
+			return clierr.Newf(clierr.InvalidField, "field %q is required and cannot be unset", name).
+				WithDetails(map[string]any{"field": name})
+		}
+	}
+	return nil
+}
+
+func validateFieldName(name string) error {
+	if err := config.ValidateCustomFieldName(name); err != nil {
+		return clierr.New(clierr.InvalidField, err.Error()).
+			WithDetails(map[string]any{"field": name})
+	}
+	return nil
+}
+
+func parseFieldValue(cfg *config.Config, name, raw string) (any, error) {
+	f := cfg.FieldByName(name)
+	if f == nil {
+		return raw, nil
+	}
+	v, err := f.ParseValue(raw)
+	if err != nil {
+		details := map[string]any{"field": name, "value": raw, "type": f.Type}
+		if f.Type == config.FieldTypeEnum {
+			details["allowed"] = f.Values
+		}
+		return nil, clierr.Newf(clierr.InvalidField, "invalid value for field %q: %v", name, err).
+			WithDetails(details)
+	}
+	return v, nil
+}
+
+// applyFieldDefaults sets schema defaults for custom fields the task does
+// not have, then checks that every required field is set.
+func applyFieldDefaults(cfg *config.Config, t *task.Task) error {
+	for i := range cfg.Fields {
+		f := &cfg.Fields[i]
+		if _, ok := t.CustomValue(f.Name); ok {
+			continue
+		}
+		if f.Default != "" {
+			v, err := parseFieldValue(cfg, f.Name, f.Default)
+			if err != nil {
+				return err
+			}
+			t.SetCustom(f.Name, v)
+			continue
+		}
+		if f.Required {
+			return clierr.Newf(clierr.InvalidField, "missing required field %q (use --set %s=VALUE)", f.Name, f.Name).
+				WithDetails(map[string]any{"field": f.Name, "type": f.Type})
+		}
+	}
+	return nil
+}
+
+// compareCustom orders two tasks by a custom schema field: numerically for
+// int fields, by declared value order for enums, false before true for bools,
+// and case-insensitively otherwise. Tasks without a value sort last.
+func compareCustom(a, b *task.Task, f *config.FieldConfig) bool {
+	av, aok := a.CustomValue(f.Name)
+	bv, bok := b.CustomValue(f.Name)
+	if !aok || !bok {
+		return aok && !bok
+	}
+	switch f.Type {
+	case config.FieldTypeInt:
+		an, _ := a.CustomInt(f.Name)
+		bn, _ := b.CustomInt(f.Name)
+		return an < bn
+	case config.FieldTypeEnum:
+		return config.IndexOf(f.Values, task.FormatCustomValue(av)) < config.IndexOf(f.Values, task.FormatCustomValue(bv))
+	case config.FieldTypeBool:
+		return av != true && bv == true
+	default:
+		return strings.ToLower(task.FormatCustomValue(av)) < strings.ToLower(task.FormatCustomValue(bv))
+	}
+}
@@ -0,0 +1,171 @@
+package board
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/antopolskiy/kanban-md/internal/clierr"
+	"github.com/antopolskiy/kanban-md/internal/config"
+	"github.com/antopolskiy/kanban-md/internal/task"
+)
+
+func fieldsConfig() *config.Config {
+	cfg := config.NewDefault("Test")
+	cfg.Fields = []config.FieldConfig{
+		{Name: "severity", Type: config.FieldTypeEnum, Values: []string{"s1", "s2", "s3"}, Default: "s3"},
+		{Name: "points", Type: config.FieldTypeInt},
+		{Name: "reviewed", Type: config.FieldTypeDate},
+		{Name: "flagged", Type: config.FieldTypeBool},
+		{Name: "areas", Type: config.FieldTypeList},
+	}
+	return cfg
+}
+
+func makeCustomTasks() []*task.Task {
+	return []*task.Task{
+		{ID: 1, Title: "One", Custom: map[string]any{"severity": "s2", "points": 8, "areas": []any{"api", "ui"}}},
+		{ID: 2, Title: "Two", Custom: map[string]any{"severity": "s1", "points": 3, "flagged": true}},
+		{ID: 3, Title: "Three", Custom: map[string]any{"severity": "s3", "reviewed": "2026-03-01"}},
+		{ID: 4, Title: "Four"},
+	}
+}
+
+func wantFieldError(t *testing.T, err error) {
+	t.Helper()
+	var cliErr *clierr.Error
+	if !errors.As(err, &cliErr) || cliErr.Code != clierr.InvalidField {
+		t.Fatalf("err = %v, want INVALID_FIELD", err)
+	}
+}
+
+func TestParseFieldAssignments(t *testing.T) {
+	cfg := fieldsConfig()
+	got, err := ParseFieldAssignments(cfg, []string{
+		"points=5", "severity=s1", "areas=api, ui", "flagged=true", "reviewed=2026-03-01", "jira=ABC-1",
+	})
+	if err != nil {
+		t.Fatalf("ParseFieldAssignments: %v", err)
+	}
+	if got["points"] != 5 || got["severity"] != "s1" || got["flagged"] != true ||
+		got["reviewed"] != "2026-03-01" || got["jira"] != "ABC-1" {
+		t.Errorf("values = %v", got)
+	}
+	if areas, ok := got["areas"].([]string); !ok || !slices.Equal(areas, []string{"api", "ui"}) {
+		t.Errorf("areas = %#v, want [api ui]", got["areas"])
+	}
+}
+
+func TestParseFieldAssignments_Errors(t *testing.T) {
+	cfg := fieldsConfig()
+	for _, in := range []string{"points", "points=many", "severity=s9", "status=done", "a b=1", "=x"} {
+		t.Run(in, func(t *testing.T) {
+			_, err := ParseFieldAssignments(cfg, []string{in})
+			wantFieldError(t, err)
+		})
+	}
+}
+
+func TestValidateFieldUnset(t *testing.T) {
+	cfg := fieldsConfig()
+	cfg.Fields[1].Required = true
+
+	if err := ValidateFieldUnset(cfg, []string{"severity", "jira"}); err != nil {
+		t.Errorf("unset optional fields: %v", err)
+	}
+	wantFieldError(t, ValidateFieldUnset(cfg, []string{"points"}))
+}
+
+func TestApplyFieldDefaults(t *testing.T) {
+	cfg := fieldsConfig()
+	tk := &task.Task{}
+	if err := applyFieldDefaults(cfg, tk); err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := tk.CustomValue("severity"); v != "s3" {
+		t.Errorf("severity = %v, want default s3", v)
+	}
+
+	cfg.Fields[1].Required = true
+	wantFieldError(t, applyFieldDefaults(cfg, &task.Task{}))
+	if err := applyFieldDefaults(cfg, &task.Task{Custom: map[string]any{"points": 1}}); err != nil {
+		t.Errorf("required field set: %v", err)
+	}
+}
+
+func TestFilterByCustomField(t *testing.T) {
+	tests := []struct {
+		fields map[string]string
+		want   []int
+	}{
+		{map[string]string{"severity": "s1"}, []int{2}},
+		{map[string]string{"areas": "ui"}, []int{1}},
+		{map[string]string{"points": "8"}, []int{1}},
+		{map[string]string{"flagged": "true"}, []int{2}},
+		{map[string]string{"severity": "s2", "points": "3"}, nil},
+	}
+	for _, tt := range tests {
+		var ids []int
+		for _, tk := range Filter(makeCustomTasks(), FilterOptions{Fields: tt.fields}) {
+			ids = append(ids, tk.ID)
+		}
+		if !sameIDs(ids, tt.want) {
+			t.Errorf("Fields %v = %v, want %v", tt.fields, ids, tt.want)
+		}
+	}
+}
+
+func TestSortByCustomField(t *testing.T) {
+	cfg := fieldsConfig()
+	tests := []struct {
+		field string
+		want  []int
+	}{
+		{"points", []int{2, 1, 3, 4}},   // numeric, missing last
+		{"severity", []int{2, 1, 3, 4}}, // enum order
+		{"flagged", []int{2, 1, 3, 4}},  // only #2 has a value
+		{"reviewed", []int{3, 1, 2, 4}},
+	}
+	for _, tt := range tests {
+		tasks := makeCustomTasks()
+		Sort(tasks, tt.field, false, cfg)
+		ids := make([]int, len(tasks))
+		for i, tk := range tasks {
+			ids[i] = tk.ID
+		}
+		if !sameIDs(ids, tt.want) {
+			t.Errorf("sort by %s = %v, want %v", tt.field, ids, tt.want)
+		}
+	}
+}
+
+func TestQuery_CustomFields(t *testing.T) {
+	cfg := fieldsConfig()
+	tests := []struct {
+		query string
+		want  []int
+	}{
+		{"points>=5", []int{1}},
+		{"severity<=s2", []int{1, 2}},
+		{"areas:api", []int{1}},
+		{"flagged", []int{2}},
+		{"reviewed<2026-04-01", []int{3}},
+		{"NOT severity:s3 AND points<5", []int{2}},
+	}
+	for _, tt := range tests {
+		q, err := ParseQuery(tt.query, cfg)
+		if err != nil {
+			t.Fatalf("ParseQuery(%q): %v", tt.query, err)
+		}
+		var ids []int
+		for _, tk := range Filter(makeCustomTasks(), FilterOptions{Query: q}) {
+			ids = append(ids, tk.ID)
+		}
+		if !sameIDs(ids, tt.want) {
+			t.Errorf("%q = %v, want %v", tt.query, ids, tt.want)
+		}
+	}
+
+	_, err := ParseQuery("severity:s9", cfg)
+	wantFieldError(t, err)
+}
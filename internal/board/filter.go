@@ -2,6 +2,7 @@
 package board
 
 import (
+	"slices"
 	"strings"
 	"time"
 
@@ -23,6 +24,10 @@ type FilterOptions struct {
 	ClaimedBy       string        // filter to specific claimant
 	ClaimTimeout    time.Duration // claim expiration for unclaimed filter
 	Class           string        // filter by class of service
+	Query           *Query        // structured query (see ParseQuery); nil=no filter
+	// Fields maps custom field names to a required value; a list field
+	// matches when any of its items equals the value.
+	Fields map[string]string
 }
 
 // Filter returns tasks matching all specified criteria (AND logic).
@@ -105,6 +110,14 @@ func matchesExtendedFilter(t *task.Task, opts FilterOptions) bool {
 	if opts.Class != "" && t.Class != opts.Class {
 		return false
 	}
+	if opts.Query != nil && !opts.Query.Match(t) {
+		return false
+	}
+	for name, want := range opts.Fields {
+		if !slices.Contains(t.CustomStrings(name), want) {
+			return false
+		}
+	}
 	return true
 }
 
@@ -0,0 +1,186 @@
+package board
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/antopolskiy/kanban-md/internal/config"
+	"github.com/antopolskiy/kanban-md/internal/date"
+	"github.com/antopolskiy/kanban-md/internal/task"
+)
+
+// TaskTransition is a status change of a task, as recorded in the activity
+// log. From is empty when the task was created and To when it was removed
+// (an undone create).
+type TaskTransition struct {
+	TaskID int
+	From   string
+	To     string
+	At     time.Time
+}
+
+// Transitions extracts the status changes from log entries, oldest first.
+// Entries written before transitions were recorded (which have no ID)
+// contribute their "move" details ("todo -> done"). Newer "move" entries
+// without a transition accompany the pick or handoff entry that carries it,
+// so they are skipped.
+func Transitions(entries []LogEntry) []TaskTransition {
+	var out []TaskTransition
+	for _, e := range entries {
+		tr := TaskTransition{TaskID: e.TaskID, At: e.Timestamp}
+		switch {
+		case e.Transition != nil:
+			tr.From, tr.To = e.Transition.From, e.Transition.To
+		case e.Action == "move" && e.ID == "":
+			from, to, ok := strings.Cut(e.Detail, " -> ")
+			if !ok {
+				continue
+			}
+			tr.From, _, _ = strings.Cut(from, " ")
+			tr.To, _, _ = strings.Cut(to, " ")
+		default:
+			continue
+		}
+		out = append(out, tr)
+	}
+	slices.SortStableFunc(out, func(a, b TaskTransition) int { return a.At.Compare(b.At) })
+	return out
+}
+
+// FlowDay holds the state of the board at the end of one day and the flow
+// of tasks during it.
+type FlowDay struct {
+	Date      date.Date      `json:"date"`
+	Counts    map[string]int `json:"counts"`    // tasks per board status at the end of the day
+	Total     int            `json:"total"`     // tasks on the board
+	WIP       int            `json:"wip"`       // tasks started but not finished
+	Created   int            `json:"created"`   // tasks added to the board
+	Started   int            `json:"started"`   // arrivals: tasks that entered WIP
+	Completed int            `json:"completed"` // departures: tasks finished (throughput)
+}
+
+// Flow is a daily time series of the board: the data of a cumulative flow
+// diagram, WIP over time and arrival and departure rates.
+type Flow struct {
+	Since         date.Date `json:"since"`
+	Until         date.Date `json:"until"`
+	Statuses      []string  `json:"statuses"`
+	Days          []FlowDay `json:"days"`
+	ArrivalRate   float64   `json:"arrival_rate"`   // tasks started per day
+	DepartureRate float64   `json:"departure_rate"` // tasks finished per day
+	AvgWIP        float64   `json:"avg_wip"`
+}
+
+// ComputeFlow reconstructs the daily status counts between since and until
+// (inclusive, local days) by replaying the log's status transitions
+// backwards from the tasks' current statuses. Tasks that predate the log
+// count from their created time in the status they had when the log begins.
+// Work in progress is every status after the first that is not terminal,
+// matching when tasks get their Started timestamp.
+func ComputeFlow(cfg *config.Config, tasks []*task.Task, transitions []TaskTransition, since, until date.Date) Flow {
+	statuses := cfg.BoardStatuses()
+	f := Flow{Since: since, Until: until, Statuses: statuses}
+	status := make(map[int]string, len(tasks))
+	created := make(map[int]time.Time, len(tasks))
+	for _, t := range tasks {
+		status[t.ID] = t.Status
+		created[t.ID] = t.Created
+	}
+
+	days := dayRange(since, until)
+	f.Days = make([]FlowDay, len(days))
+	next := len(transitions) - 1
+	for i := len(days) - 1; i >= 0; i-- {
+		end := dayStart(days[i]).AddDate(0, 0, 1)
+		for ; next >= 0 && !transitions[next].At.Before(end); next-- {
+			if tr := transitions[next]; tr.From == "" {
+				delete(status, tr.TaskID)
+			} else {
+				status[tr.TaskID] = tr.From
+			}
+		}
+		f.Days[i] = flowDay(cfg, days[i], status, created, end)
+	}
+
+	index := make(map[string]int, len(days))
+	for i, d := range days {
+		index[d.String()] = i
+	}
+	for _, tr := range transitions {
+		d, _ := localDate(&tr.At)
+		i, ok := index[d.String()]
+		if !ok {
+			continue
+		}
+		day := &f.Days[i]
+		if tr.From == "" && tr.To != "" {
+			day.Created++
+		}
+		if isWIP(cfg, tr.To) && !isWIP(cfg, tr.From) {
+			day.Started++
+		}
+		if isDone(cfg, tr.To) && !cfg.IsTerminalStatus(tr.From) {
+			day.Completed++
+		}
+	}
+
+	for _, d := range f.Days {
+		f.ArrivalRate += float64(d.Started)
+		f.DepartureRate += float64(d.Completed)
+		f.AvgWIP += float64(d.WIP)
+	}
+	if n := float64(len(f.Days)); n > 0 {
+		f.ArrivalRate /= n
+		f.DepartureRate /= n
+		f.AvgWIP /= n
+	}
+	return f
+}
+
+// flowDay counts the tasks per status at end, the end of day d.
+func flowDay(cfg *config.Config, d date.Date, status map[int]string, created map[int]time.Time, end time.Time) FlowDay {
+	day := FlowDay{Date: d, Counts: make(map[string]int, len(cfg.BoardStatuses()))}
+	for _, s := range cfg.BoardStatuses() {
+		day.Counts[s] = 0
+	}
+	for id, s := range status {
+		if c, ok := created[id]; ok && !c.Before(end) {
+			continue
+		}
+		if _, ok := day.Counts[s]; !ok {
+			continue // archived or no longer a status
+		}
+		day.Counts[s]++
+		day.Total++
+		if isWIP(cfg, s) {
+			day.WIP++
+		}
+	}
+	return day
+}
+
+// isWIP reports whether tasks in status s are work in progress: started
+// (past the first status) but not finished.
+func isWIP(cfg *config.Config, s string) bool {
+	return s != "" && cfg.StatusIndex(s) > 0 && !cfg.IsTerminalStatus(s)
+}
+
+// isDone reports whether s is a finished status (terminal, but not archived).
+func isDone(cfg *config.Config, s string) bool {
+	return s != "" && cfg.IsTerminalStatus(s) && !cfg.IsArchivedStatus(s)
+}
+
+// dayRange returns the dates from since to until, inclusive.
+func dayRange(since, until date.Date) []date.Date {
+	var days []date.Date
+	for d := since; !d.After(until.Time); d = date.New(d.Year(), d.Month(), d.Day()+1) {
+		days = append(days, d)
+	}
+	return days
+}
+
+// dayStart returns the start of local day d.
+func dayStart(d date.Date) time.Time {
+	return time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, time.Local)
+}
@@ -0,0 +1,105 @@
+package board_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/antopolskiy/kanban-md/internal/board"
+	"github.com/antopolskiy/kanban-md/internal/config"
+	"github.com/antopolskiy/kanban-md/internal/date"
+	"github.com/antopolskiy/kanban-md/internal/task"
+)
+
+func TestTransitions_LegacyMoveDetail(t *testing.T) {
+	at := time.Date(2026, 5, 4, 10, 0, 0, 0, time.Local)
+	got := board.Transitions([]board.LogEntry{
+		{Timestamp: at.Add(time.Hour), Action: "create", TaskID: 1, Transition: &board.StatusTransition{To: "todo"}},
+		{Timestamp: at, Action: "move", TaskID: 2, Detail: "todo -> in-progress"},
+		{Timestamp: at, Action: "edit", TaskID: 2, Detail: "title"},
+		// A pick that moved task 3: the claim entry carries the transition,
+		// the accompanying move entry must not count it again.
+		{ID: "a", Timestamp: at.Add(2 * time.Hour), Action: "claim", TaskID: 3,
+			Transition: &board.StatusTransition{From: "todo", To: "in-progress"}},
+		{ID: "b", Timestamp: at.Add(2 * time.Hour), Action: "move", TaskID: 3, Detail: "todo -> in-progress"},
+	})
+	want := []board.TaskTransition{
+		{TaskID: 2, From: "todo", To: "in-progress", At: at},
+		{TaskID: 1, To: "todo", At: at.Add(time.Hour)},
+		{TaskID: 3, From: "todo", To: "in-progress", At: at.Add(2 * time.Hour)},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d transitions, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("transition %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestComputeFlow_ReplaysTransitions(t *testing.T) {
+	cfg := config.NewDefault("Test")
+	day := func(d int, hour int) time.Time { return time.Date(2026, 5, d, hour, 0, 0, 0, time.Local) }
+	tasks := []*task.Task{
+		{ID: 1, Status: "done", Created: day(1, 9)},
+		{ID: 2, Status: "in-progress", Created: day(2, 9)},
+		{ID: 3, Status: "todo", Created: day(3, 9)},
+		{ID: 4, Status: "backlog", Created: day(1, 9)}, // predates the log
+	}
+	transitions := []board.TaskTransition{
+		{TaskID: 1, To: "todo", At: day(1, 9)},
+		{TaskID: 1, From: "todo", To: "in-progress", At: day(1, 12)},
+		{TaskID: 2, To: "todo", At: day(2, 9)},
+		{TaskID: 2, From: "todo", To: "in-progress", At: day(3, 10)},
+		{TaskID: 1, From: "in-progress", To: "done", At: day(3, 11)},
+		{TaskID: 3, To: "todo", At: day(3, 9)},
+	}
+
+	f := board.ComputeFlow(cfg, tasks, transitions, date.New(2026, 5, 1), date.New(2026, 5, 3))
+
+	if len(f.Days) != 3 {
+		t.Fatalf("days = %d, want 3", len(f.Days))
+	}
+	tests := []struct {
+		counts                     map[string]int
+		total, wip, started, compl int
+	}{
+		{map[string]int{"backlog": 1, "in-progress": 1}, 2, 1, 1, 0},
+		{map[string]int{"backlog": 1, "todo": 1, "in-progress": 1}, 3, 2, 1, 0},
+		{map[string]int{"backlog": 1, "todo": 1, "in-progress": 1, "done": 1}, 4, 2, 1, 1},
+	}
+	for i, tt := range tests {
+		d := f.Days[i]
+		for _, s := range cfg.BoardStatuses() {
+			if d.Counts[s] != tt.counts[s] {
+				t.Errorf("%s %s = %d, want %d", d.Date, s, d.Counts[s], tt.counts[s])
+			}
+		}
+		if d.Total != tt.total || d.WIP != tt.wip || d.Started != tt.started || d.Completed != tt.compl {
+			t.Errorf("%s total/wip/started/completed = %d/%d/%d/%d, want %d/%d/%d/%d", d.Date,
+				d.Total, d.WIP, d.Started, d.Completed, tt.total, tt.wip, tt.started, tt.compl)
+		}
+	}
+	if f.ArrivalRate != 1 || f.DepartureRate != 1.0/3 {
+		t.Errorf("arrival/departure rate = %v/%v, want 1 and 1/3", f.ArrivalRate, f.DepartureRate)
+	}
+}
+
+func TestComputeFlow_ExcludesArchived(t *testing.T) {
+	cfg := config.NewDefault("Test")
+	created := time.Date(2026, 5, 1, 9, 0, 0, 0, time.Local)
+	tasks := []*task.Task{{ID: 1, Status: config.ArchivedStatus, Created: created}}
+	transitions := []board.TaskTransition{
+		{TaskID: 1, To: "todo", At: created},
+		{TaskID: 1, From: "todo", To: config.ArchivedStatus, At: created.AddDate(0, 0, 1)},
+	}
+
+	f := board.ComputeFlow(cfg, tasks, transitions, date.New(2026, 5, 1), date.New(2026, 5, 2))
+
+	if f.Days[0].Counts["todo"] != 1 || f.Days[0].Total != 1 {
+		t.Errorf("day 1 = %+v, want the task in todo", f.Days[0])
+	}
+	if f.Days[1].Total != 0 || f.Days[1].Completed != 0 {
+		t.Errorf("day 2 = %+v, want the archived task gone and not completed", f.Days[1])
+	}
+}
@@ -0,0 +1,137 @@
+package board
+
+import (
+	"math/rand/v2"
+	"slices"
+
+	"github.com/antopolskiy/kanban-md/internal/clierr"
+	"github.com/antopolskiy/kanban-md/internal/config"
+	"github.com/antopolskiy/kanban-md/internal/date"
+	"github.com/antopolskiy/kanban-md/internal/task"
+)
+
+// Forecast modes.
+const (
+	ForecastWhen    = "when"     // when will N tasks be done?
+	ForecastHowMany = "how_many" // how many tasks will be done by a date?
+)
+
+// maxForecastDays bounds a simulated run, so a throughput history of mostly
+// empty days cannot simulate forever.
+const maxForecastDays = 3650
+
+// forecastConfidences are the confidence levels reported, in percent.
+var forecastConfidences = []int{50, 85, 95}
+
+// ForecastParams configures a Monte Carlo forecast. Set Tasks to forecast
+// when that many tasks will be done, or By to forecast how many tasks will be
+// done by the end of that day.
+type ForecastParams struct {
+	Tasks int
+	By    *date.Date
+	Today date.Date
+	Runs  int
+}
+
+// ForecastOutcome is the forecast at one confidence level: in Confidence
+// percent of the runs, the tasks were done by Date (Days days from today), or
+// at least Tasks tasks were done by the target date.
+type ForecastOutcome struct {
+	Confidence int        `json:"confidence"`
+	Date       *date.Date `json:"date,omitempty"`
+	Days       *int       `json:"days,omitempty"`
+	Tasks      *int       `json:"tasks,omitempty"`
+}
+
+// Forecast is the result of a Monte Carlo forecast on historical daily
+// throughput.
+type Forecast struct {
+	Mode          string            `json:"mode"`
+	Tasks         int               `json:"tasks,omitempty"`
+	By            *date.Date        `json:"by,omitempty"`
+	Runs          int               `json:"runs"`
+	HistoryDays   int               `json:"history_days"`
+	AvgThroughput float64           `json:"avg_throughput"` // tasks per day
+	Outcomes      []ForecastOutcome `json:"outcomes"`
+}
+
+// DailyThroughput returns the number of tasks completed on each day from
+// since to until (inclusive, local days). Archived tasks are left out, like
+// in ComputeMetrics.
+func DailyThroughput(cfg *config.Config, tasks []*task.Task, since, until date.Date) []int {
+	days := dayRange(since, until)
+	index := make(map[string]int, len(days))
+	for i, d := range days {
+		index[d.String()] = i
+	}
+	counts := make([]int, len(days))
+	for _, t := range tasks {
+		if cfg.IsArchivedStatus(t.Status) {
+			continue
+		}
+		if d, ok := localDate(t.Completed); ok {
+			if i, ok := index[d.String()]; ok {
+				counts[i]++
+			}
+		}
+	}
+	return counts
+}
+
+// RunForecast simulates p.Runs futures, starting tomorrow, by drawing each
+// day's throughput at random from the history, and reports the outcome at 50,
+// 85 and 95 percent confidence.
+func RunForecast(p ForecastParams, history []int, rng *rand.Rand) (*Forecast, error) {
+	f := &Forecast{Mode: ForecastWhen, Tasks: p.Tasks, By: p.By, Runs: p.Runs, HistoryDays: len(history)}
+	total := 0
+	for _, n := range history {
+		total += n
+	}
+	if total == 0 {
+		return nil, clierr.Newf(clierr.NoThroughput,
+			"no tasks completed in the last %d days; nothing to forecast from", len(history))
+	}
+	f.AvgThroughput = float64(total) / float64(len(history))
+	draw := func() int { return history[rng.IntN(len(history))] }
+
+	if p.By != nil {
+		f.Mode = ForecastHowMany
+		days := len(dayRange(date.New(p.Today.Year(), p.Today.Month(), p.Today.Day()+1), *p.By))
+		done := make([]int, p.Runs)
+		for r := range done {
+			for range days {
+				done[r] += draw()
+			}
+		}
+		slices.Sort(done)
+		for _, c := range forecastConfidences {
+			// c% of runs finished at least this many tasks.
+			n := done[percentileIndex(len(done), 100-c)]
+			f.Outcomes = append(f.Outcomes, ForecastOutcome{Confidence: c, Tasks: &n})
+		}
+		return f, nil
+	}
+
+	took := make([]int, p.Runs)
+	for r := range took {
+		for remaining := p.Tasks; remaining > 0 && took[r] < maxForecastDays; {
+			remaining -= draw()
+			took[r]++
+		}
+	}
+	slices.Sort(took)
+	for _, c := range forecastConfidences {
+		// c% of runs finished within this many days.
+		days := took[percentileIndex(len(took), c)]
+		d := date.New(p.Today.Year(), p.Today.Month(), p.Today.Day()+days)
+		f.Outcomes = append(f.Outcomes, ForecastOutcome{Confidence: c, Date: &d, Days: &days})
+	}
+	return f, nil
+}
+
+// percentileIndex returns the index of the pct-th percentile (nearest rank) in
+// a sorted slice of length n.
+func percentileIndex(n, pct int) int {
+	i := (pct*n+99)/100 - 1 //nolint:mnd // ceil(pct*n/100) - 1
+	return min(max(i, 0), n-1)
+}
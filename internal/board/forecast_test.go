@@ -0,0 +1,97 @@
+package board_test
+
+import (
+	"errors"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/antopolskiy/kanban-md/internal/board"
+	"github.com/antopolskiy/kanban-md/internal/clierr"
+	"github.com/antopolskiy/kanban-md/internal/config"
+	"github.com/antopolskiy/kanban-md/internal/date"
+	"github.com/antopolskiy/kanban-md/internal/task"
+)
+
+func TestDailyThroughput(t *testing.T) {
+	cfg := config.NewDefault("Test")
+	at := func(d int) *time.Time {
+		tm := time.Date(2026, 5, d, 15, 0, 0, 0, time.Local)
+		return &tm
+	}
+	tasks := []*task.Task{
+		{ID: 1, Status: "done", Completed: at(2)},
+		{ID: 2, Status: "done", Completed: at(2)},
+		{ID: 3, Status: "done", Completed: at(3)},
+		{ID: 4, Status: config.ArchivedStatus, Completed: at(3)},
+		{ID: 5, Status: "done", Completed: at(9)}, // outside the window
+		{ID: 6, Status: "todo"},
+	}
+
+	got := board.DailyThroughput(cfg, tasks, date.New(2026, 5, 1), date.New(2026, 5, 3))
+
+	want := []int{0, 2, 1}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] || got[2] != want[2] {
+		t.Errorf("throughput = %v, want %v", got, want)
+	}
+}
+
+func TestRunForecast_When(t *testing.T) {
+	today := date.New(2026, 5, 1)
+	rng := rand.New(rand.NewPCG(1, 1)) //nolint:gosec // test
+
+	// Exactly two tasks a day: 10 tasks always take 5 days.
+	f, err := board.RunForecast(board.ForecastParams{Tasks: 10, Today: today, Runs: 500}, []int{2, 2, 2}, rng)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Mode != board.ForecastWhen || f.AvgThroughput != 2 || len(f.Outcomes) != 3 {
+		t.Fatalf("forecast = %+v", f)
+	}
+	for _, o := range f.Outcomes {
+		if *o.Days != 5 || o.Date.String() != "2026-05-06" {
+			t.Errorf("%d%%: %s in %d days, want 2026-05-06 in 5 days", o.Confidence, o.Date, *o.Days)
+		}
+	}
+}
+
+func TestRunForecast_ConfidenceIsConservative(t *testing.T) {
+	today := date.New(2026, 5, 1)
+	rng := rand.New(rand.NewPCG(7, 7)) //nolint:gosec // test
+	history := []int{0, 1, 3, 0, 2, 5, 1}
+
+	f, err := board.RunForecast(board.ForecastParams{Tasks: 20, Today: today, Runs: 2000}, history, rng)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !(*f.Outcomes[0].Days <= *f.Outcomes[1].Days && *f.Outcomes[1].Days <= *f.Outcomes[2].Days) {
+		t.Errorf("days at 50/85/95%% = %d/%d/%d, want non-decreasing",
+			*f.Outcomes[0].Days, *f.Outcomes[1].Days, *f.Outcomes[2].Days)
+	}
+
+	by := date.New(2026, 5, 31)
+	f, err = board.RunForecast(board.ForecastParams{By: &by, Today: today, Runs: 2000}, history, rng)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Mode != board.ForecastHowMany {
+		t.Fatalf("mode = %q, want %q", f.Mode, board.ForecastHowMany)
+	}
+	if !(*f.Outcomes[0].Tasks >= *f.Outcomes[1].Tasks && *f.Outcomes[1].Tasks >= *f.Outcomes[2].Tasks) {
+		t.Errorf("tasks at 50/85/95%% = %d/%d/%d, want non-increasing",
+			*f.Outcomes[0].Tasks, *f.Outcomes[1].Tasks, *f.Outcomes[2].Tasks)
+	}
+	// 30 days at 12/7 tasks a day: the median is close to 51.
+	if n := *f.Outcomes[0].Tasks; n < 45 || n > 57 {
+		t.Errorf("median = %d tasks, want about 51", n)
+	}
+}
+
+func TestRunForecast_NoThroughput(t *testing.T) {
+	_, err := board.RunForecast(board.ForecastParams{Tasks: 3, Today: date.New(2026, 5, 1), Runs: 10},
+		[]int{0, 0}, rand.New(rand.NewPCG(1, 1))) //nolint:gosec // test
+	var cliErr *clierr.Error
+	if !errors.As(err, &cliErr) || cliErr.Code != clierr.NoThroughput {
+		t.Errorf("err = %v, want NO_THROUGHPUT", err)
+	}
+}
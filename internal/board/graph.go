@@ -0,0 +1,356 @@
+package board
+
+import (
+	"slices"
+
+	"github.com/antopolskiy/kanban-md/internal/clierr"
+	"github.com/antopolskiy/kanban-md/internal/config"
+	"github.com/antopolskiy/kanban-md/internal/task"
+)
+
+// Graph edge kinds.
+const (
+	EdgeDependsOn = "depends_on"
+	EdgeParent    = "parent"
+)
+
+// Graph is the dependency and parent graph of a set of tasks, with the
+// analysis `graph` can highlight: the critical path, blocked chains and
+// dependency cycles.
+type Graph struct {
+	Nodes        []GraphNode `json:"nodes"`
+	Edges        []GraphEdge `json:"edges"`
+	CriticalPath []int       `json:"critical_path"` // prerequisites first
+	Cycles       [][]int     `json:"cycles"`
+}
+
+// GraphNode is a task in a Graph. DependsOn and Parent only reference tasks
+// in the graph, so together they form its adjacency lists.
+type GraphNode struct {
+	ID           int    `json:"id"`
+	Title        string `json:"title"`
+	Status       string `json:"status"`
+	Priority     string `json:"priority"`
+	DependsOn    []int  `json:"depends_on"`
+	Parent       *int   `json:"parent,omitempty"`
+	Done         bool   `json:"done"`
+	Blocked      bool   `json:"blocked"`
+	BlockedChain bool   `json:"blocked_chain"` // waits, directly or not, on a blocked task
+	Critical     bool   `json:"critical"`
+	InCycle      bool   `json:"in_cycle"`
+}
+
+// GraphEdge points from a prerequisite to the task depending on it, or from
+// a parent to its child.
+type GraphEdge struct {
+	From     int    `json:"from"`
+	To       int    `json:"to"`
+	Kind     string `json:"kind"`
+	Critical bool   `json:"critical,omitempty"`
+	Cycle    bool   `json:"cycle,omitempty"`
+}
+
+// BuildGraph builds the graph of tasks. References to tasks outside the set
+// are dropped. A non-zero root limits the graph to that task, its
+// dependencies and dependents (transitively) and its subtasks.
+func BuildGraph(cfg *config.Config, tasks []*task.Task, root int) (*Graph, error) {
+	byID := make(map[int]*task.Task, len(tasks))
+	for _, t := range tasks {
+		byID[t.ID] = t
+	}
+	if root != 0 {
+		if _, ok := byID[root]; !ok {
+			return nil, clierr.Newf(clierr.TaskNotFound, "task #%d not found", root).
+				WithDetails(map[string]any{"id": root})
+		}
+		byID = graphScope(byID, root)
+	}
+
+	ids := make([]int, 0, len(byID))
+	for id := range byID {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	g := &Graph{Nodes: make([]GraphNode, 0, len(ids)), Edges: []GraphEdge{}}
+	index := make(map[int]int, len(ids))
+	deps := make(map[int][]int, len(ids))
+	for _, id := range ids {
+		t := byID[id]
+		n := GraphNode{
+			ID: id, Title: t.Title, Status: t.Status, Priority: t.Priority, DependsOn: []int{},
+			Done: cfg.IsTerminalStatus(t.Status), Blocked: t.Blocked,
+		}
+		for _, dep := range t.DependsOn {
+			if _, ok := byID[dep]; ok && !slices.Contains(n.DependsOn, dep) {
+				n.DependsOn = append(n.DependsOn, dep)
+			}
+		}
+		if t.Parent != nil {
+			if _, ok := byID[*t.Parent]; ok {
+				parent := *t.Parent
+				n.Parent = &parent
+			}
+		}
+		deps[id] = n.DependsOn
+		index[id] = len(g.Nodes)
+		g.Nodes = append(g.Nodes, n)
+	}
+
+	g.Cycles = dependencyCycles(ids, deps)
+	for _, cycle := range g.Cycles {
+		for _, id := range cycle {
+			g.Nodes[index[id]].InCycle = true
+		}
+	}
+	g.markBlockedChains(index)
+	g.CriticalPath = g.criticalPath(index)
+	for _, id := range g.CriticalPath {
+		g.Nodes[index[id]].Critical = true
+	}
+	g.buildEdges()
+	return g, nil
+}
+
+// graphScope returns the tasks related to root: its dependencies and
+// dependents, transitively, and its subtasks at any depth.
+func graphScope(byID map[int]*task.Task, root int) map[int]*task.Task {
+	dependents := make(map[int][]int)
+	children := make(map[int][]int)
+	for _, t := range byID {
+		for _, dep := range t.DependsOn {
+			dependents[dep] = append(dependents[dep], t.ID)
+		}
+		if t.Parent != nil {
+			children[*t.Parent] = append(children[*t.Parent], t.ID)
+		}
+	}
+
+	scope := map[int]*task.Task{root: byID[root]}
+	walk := func(next func(id int) []int) {
+		queue := []int{root}
+		seen := map[int]bool{root: true}
+		for len(queue) > 0 {
+			id := queue[0]
+			queue = queue[1:]
+			for _, n := range next(id) {
+				if t, ok := byID[n]; ok && !seen[n] {
+					seen[n] = true
+					scope[n] = t
+					queue = append(queue, n)
+				}
+			}
+		}
+	}
+	walk(func(id int) []int { return byID[id].DependsOn })
+	walk(func(id int) []int { return dependents[id] })
+	walk(func(id int) []int { return children[id] })
+	return scope
+}
+
+// markBlockedChains flags unfinished tasks that wait, through unfinished
+// dependencies, on a blocked task.
+func (g *Graph) markBlockedChains(index map[int]int) {
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[int]int)
+	var visit func(id int) bool
+	visit = func(id int) bool {
+		n := &g.Nodes[index[id]]
+		if state[id] == visiting || n.Done {
+			return false
+		}
+		if state[id] == visited {
+			return n.Blocked || n.BlockedChain
+		}
+		state[id] = visiting
+		for _, dep := range n.DependsOn {
+			if visit(dep) {
+				n.BlockedChain = true
+			}
+		}
+		state[id] = visited
+		return n.Blocked || n.BlockedChain
+	}
+	for _, n := range g.Nodes {
+		visit(n.ID)
+	}
+}
+
+// criticalPath returns the longest chain of unfinished tasks linked by
+// dependencies, prerequisites first. Tasks in cycles are left out, and a
+// "chain" of a single task is not reported.
+func (g *Graph) criticalPath(index map[int]int) []int {
+	eligible := func(id int) bool {
+		n := g.Nodes[index[id]]
+		return !n.Done && !n.InCycle
+	}
+	memo := make(map[int][]int)
+	var longest func(id int) []int
+	longest = func(id int) []int {
+		if p, ok := memo[id]; ok {
+			return p
+		}
+		var best []int
+		for _, dep := range g.Nodes[index[id]].DependsOn {
+			if !eligible(dep) {
+				continue
+			}
+			if p := longest(dep); len(p) > len(best) {
+				best = p
+			}
+		}
+		memo[id] = append(slices.Clone(best), id)
+		return memo[id]
+	}
+
+	var path []int
+	for _, n := range g.Nodes {
+		if !eligible(n.ID) {
+			continue
+		}
+		if p := longest(n.ID); len(p) > len(path) {
+			path = p
+		}
+	}
+	if len(path) < 2 { //nolint:mnd // a path needs at least one dependency
+		return []int{}
+	}
+	return path
+}
+
+// buildEdges lists the dependency and parent edges, marking those on the
+// critical path and inside cycles.
+func (g *Graph) buildEdges() {
+	next := make(map[int]int, len(g.CriticalPath))
+	for i := 1; i < len(g.CriticalPath); i++ {
+		next[g.CriticalPath[i-1]] = g.CriticalPath[i]
+	}
+	cycleOf := make(map[int]int)
+	for i, cycle := range g.Cycles {
+		for _, id := range cycle {
+			cycleOf[id] = i + 1
+		}
+	}
+
+	for _, n := range g.Nodes {
+		for _, dep := range n.DependsOn {
+			to, onPath := next[dep]
+			g.Edges = append(g.Edges, GraphEdge{
+				From: dep, To: n.ID, Kind: EdgeDependsOn,
+				Critical: onPath && to == n.ID,
+				Cycle:    cycleOf[dep] != 0 && cycleOf[dep] == cycleOf[n.ID],
+			})
+		}
+		if n.Parent != nil {
+			g.Edges = append(g.Edges, GraphEdge{From: *n.Parent, To: n.ID, Kind: EdgeParent})
+		}
+	}
+}
+
+// dependencyCycles returns the groups of tasks that depend on each other in
+// a cycle (strongly connected components of more than one task, or a task
+// depending on itself), each sorted by ID.
+func dependencyCycles(ids []int, deps map[int][]int) [][]int {
+	// Tarjan's algorithm.
+	var (
+		counter int
+		stack   []int
+		cycles  = [][]int{}
+		order   = make(map[int]int)
+		low     = make(map[int]int)
+		onStack = make(map[int]bool)
+	)
+	var connect func(id int)
+	connect = func(id int) {
+		counter++
+		order[id], low[id] = counter, counter
+		stack = append(stack, id)
+		onStack[id] = true
+		for _, dep := range deps[id] {
+			if order[dep] == 0 {
+				connect(dep)
+				low[id] = min(low[id], low[dep])
+			} else if onStack[dep] {
+				low[id] = min(low[id], order[dep])
+			}
+		}
+		if low[id] != order[id] {
+			return
+		}
+		var component []int
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			component = append(component, top)
+			if top == id {
+				break
+			}
+		}
+		if len(component) > 1 || slices.Contains(deps[id], id) {
+			slices.Sort(component)
+			cycles = append(cycles, component)
+		}
+	}
+	for _, id := range ids {
+		if order[id] == 0 {
+			connect(id)
+		}
+	}
+	slices.SortFunc(cycles, func(a, b []int) int { return a[0] - b[0] })
+	return cycles
+}
+
+// dependencyCycle returns a dependency path from start back to itself, or
+// nil if start is not part of a cycle.
+func dependencyCycle(deps map[int][]int, start int) []int {
+	seen := make(map[int]bool)
+	var path []int
+	var find func(id int) bool
+	find = func(id int) bool {
+		path = append(path, id)
+		for _, dep := range deps[id] {
+			if dep == start {
+				path = append(path, start)
+				return true
+			}
+			if !seen[dep] {
+				seen[dep] = true
+				if find(dep) {
+					return true
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		return false
+	}
+	if find(start) {
+		return path
+	}
+	return nil
+}
+
+// parentCycle returns the parent chain from start back to itself, or nil if
+// start is not its own ancestor.
+func parentCycle(parents map[int]int, start int) []int {
+	path := []int{start}
+	seen := map[int]bool{start: true}
+	for id := start; ; {
+		parent, ok := parents[id]
+		if !ok {
+			return nil
+		}
+		path = append(path, parent)
+		if parent == start {
+			return path
+		}
+		if seen[parent] {
+			return nil // a cycle above start, not through it
+		}
+		seen[parent] = true
+		id = parent
+	}
+}
@@ -0,0 +1,123 @@
+package board_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/antopolskiy/kanban-md/internal/board"
+	"github.com/antopolskiy/kanban-md/internal/clierr"
+	"github.com/antopolskiy/kanban-md/internal/config"
+	"github.com/antopolskiy/kanban-md/internal/task"
+)
+
+func intPtr(v int) *int { return &v }
+
+func graphNode(t *testing.T, g *board.Graph, id int) board.GraphNode {
+	t.Helper()
+	i := slices.IndexFunc(g.Nodes, func(n board.GraphNode) bool { return n.ID == id })
+	if i < 0 {
+		t.Fatalf("node #%d not in graph", id)
+	}
+	return g.Nodes[i]
+}
+
+func TestBuildGraph_Analysis(t *testing.T) {
+	cfg := config.NewDefault("Test")
+	tasks := []*task.Task{
+		{ID: 1, Title: "design", Status: "done"},
+		{ID: 2, Title: "schema", Status: "todo", DependsOn: []int{1}, Blocked: true},
+		{ID: 3, Title: "api", Status: "todo", DependsOn: []int{2}},
+		{ID: 4, Title: "docs", Status: "todo", DependsOn: []int{3, 99}}, // #99 is not in the graph
+		{ID: 5, Title: "side", Status: "todo", DependsOn: []int{1}, Parent: intPtr(3)},
+		{ID: 6, Title: "loop a", Status: "todo", DependsOn: []int{7}},
+		{ID: 7, Title: "loop b", Status: "todo", DependsOn: []int{6}},
+	}
+
+	g, err := board.BuildGraph(cfg, tasks, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(g.CriticalPath, []int{2, 3, 4}) {
+		t.Errorf("CriticalPath = %v, want [2 3 4] (done tasks excluded)", g.CriticalPath)
+	}
+	if len(g.Cycles) != 1 || !slices.Equal(g.Cycles[0], []int{6, 7}) {
+		t.Errorf("Cycles = %v, want [[6 7]]", g.Cycles)
+	}
+	if n := graphNode(t, g, 4); !n.BlockedChain || !slices.Equal(n.DependsOn, []int{3}) {
+		t.Errorf("#4 = %+v, want blocked chain and dangling dependency dropped", n)
+	}
+	if n := graphNode(t, g, 5); n.BlockedChain || n.Parent == nil || *n.Parent != 3 {
+		t.Errorf("#5 = %+v, want parent 3 and not waiting on a blocked task", n)
+	}
+
+	var critical, cycle, parent int
+	for _, e := range g.Edges {
+		switch {
+		case e.Critical:
+			critical++
+		case e.Cycle:
+			cycle++
+		case e.Kind == board.EdgeParent:
+			parent++
+		}
+	}
+	if critical != 2 || cycle != 2 || parent != 1 {
+		t.Errorf("edges: %d critical, %d cycle, %d parent; want 2, 2, 1", critical, cycle, parent)
+	}
+}
+
+func TestBuildGraph_Root(t *testing.T) {
+	cfg := config.NewDefault("Test")
+	tasks := []*task.Task{
+		{ID: 1, Title: "a", Status: "todo"},
+		{ID: 2, Title: "b", Status: "todo", DependsOn: []int{1}},
+		{ID: 3, Title: "c", Status: "todo", DependsOn: []int{2}},
+		{ID: 4, Title: "child", Status: "todo", Parent: intPtr(2)},
+		{ID: 5, Title: "unrelated", Status: "todo", DependsOn: []int{1}},
+	}
+
+	g, err := board.BuildGraph(cfg, tasks, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []int
+	for _, n := range g.Nodes {
+		ids = append(ids, n.ID)
+	}
+	if !slices.Equal(ids, []int{1, 2, 3, 4}) {
+		t.Errorf("nodes = %v, want [1 2 3 4]", ids)
+	}
+
+	_, err = board.BuildGraph(cfg, tasks, 42)
+	var cliErr *clierr.Error
+	if !errors.As(err, &cliErr) || cliErr.Code != clierr.TaskNotFound {
+		t.Errorf("err = %v, want TASK_NOT_FOUND", err)
+	}
+}
+
+func TestEdit_RejectsDependencyCycle(t *testing.T) {
+	cfg := setupWorkflowBoard(t,
+		&task.Task{ID: 1, Title: "a", Status: "todo"},
+		&task.Task{ID: 2, Title: "b", Status: "todo", DependsOn: []int{1}, Parent: intPtr(1)},
+		&task.Task{ID: 3, Title: "c", Status: "todo", DependsOn: []int{2}},
+	)
+
+	for name, apply := range map[string]func(*task.Task){
+		"dependency": func(tk *task.Task) { tk.DependsOn = []int{3} },
+		"parent":     func(tk *task.Task) { tk.Parent = intPtr(2) },
+	} {
+		_, err := board.Edit(cfg, 1, "", "", false, func(tk *task.Task) (bool, error) {
+			apply(tk)
+			return true, nil
+		}, time.Now())
+		var cliErr *clierr.Error
+		if !errors.As(err, &cliErr) || cliErr.Code != clierr.DependencyCycle {
+			t.Errorf("%s: err = %v, want DEPENDENCY_CYCLE", name, err)
+		}
+	}
+	if got := readTask(t, cfg.TasksPath(), 1); len(got.DependsOn) != 0 || got.Parent != nil {
+		t.Errorf("task #1 = %+v, rejected edits must not be written", got)
+	}
+}
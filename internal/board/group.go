@@ -2,6 +2,8 @@ package board
 
 import (
 	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/antopolskiy/kanban-md/internal/config"
 	"github.com/antopolskiy/kanban-md/internal/task"
@@ -10,7 +12,9 @@ import (
 const (
 	fieldPriority = "priority"
 	fieldStatus   = "status"
+	fieldParent   = "parent"
 	classStandard = "standard"
+	noParentKey   = "(no parent)"
 )
 
 // GroupedSummary holds tasks grouped by a field.
@@ -37,6 +41,13 @@ func GroupBy(tasks []*task.Task, field string, cfg *config.Config) GroupedSummar
 	}
 
 	sortedKeys := sortGroupKeys(groups, field, cfg)
+	var titles map[int]string
+	if field == fieldParent {
+		titles = make(map[int]string, len(tasks))
+		for _, t := range tasks {
+			titles[t.ID] = t.Title
+		}
+	}
 
 	result := GroupedSummary{
 		Groups: make([]GroupSummary, 0, len(sortedKeys)),
@@ -44,8 +55,12 @@ func GroupBy(tasks []*task.Task, field string, cfg *config.Config) GroupedSummar
 	for _, key := range sortedKeys {
 		groupTasks := groups[key]
 		statuses := groupStatusSummary(groupTasks, cfg)
+		label := key
+		if title, ok := titles[parentKeyID(key)]; ok {
+			label += " " + title
+		}
 		result.Groups = append(result.Groups, GroupSummary{
-			Key:      key,
+			Key:      label,
 			Statuses: statuses,
 			Total:    len(groupTasks),
 		})
@@ -75,6 +90,11 @@ func extractGroupKeys(t *task.Task, field string) []string {
 		return []string{t.Priority}
 	case fieldStatus:
 		return []string{t.Status}
+	case fieldParent:
+		if t.Parent == nil {
+			return []string{noParentKey}
+		}
+		return []string{"#" + strconv.Itoa(*t.Parent)}
 	default:
 		return []string{"(all)"}
 	}
@@ -99,6 +119,15 @@ func sortGroupKeys(groups map[string][]*task.Task, field string, cfg *config.Con
 		sort.SliceStable(keys, func(i, j int) bool {
 			return cfg.ClassIndex(keys[i]) < cfg.ClassIndex(keys[j])
 		})
+	case fieldParent:
+		// Parents in ID order; tasks without a parent last.
+		sort.SliceStable(keys, func(i, j int) bool {
+			a, b := parentKeyID(keys[i]), parentKeyID(keys[j])
+			if a == 0 || b == 0 {
+				return b == 0 && a != 0
+			}
+			return a < b
+		})
 	default:
 		sort.Strings(keys)
 	}
@@ -122,7 +151,16 @@ func groupStatusSummary(tasks []*task.Task, cfg *config.Config) []StatusSummary
 	return statuses
 }
 
+// parentKeyID returns the parent ID of a "#ID" group key, or 0.
+func parentKeyID(key string) int {
+	id, err := strconv.Atoi(strings.TrimPrefix(key, "#"))
+	if err != nil || !strings.HasPrefix(key, "#") {
+		return 0
+	}
+	return id
+}
+
 // ValidGroupByFields returns the list of valid --group-by field names.
 func ValidGroupByFields() []string {
-	return []string{"assignee", "tag", "class", "priority", "status"}
+	return []string{"assignee", "tag", "class", "priority", "status", "parent"}
 }
@@ -0,0 +1,64 @@
+package board
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/antopolskiy/kanban-md/internal/clierr"
+	"github.com/antopolskiy/kanban-md/internal/config"
+	"github.com/antopolskiy/kanban-md/internal/task"
+)
+
+// actionHeartbeat is the activity log action recorded for claim renewals.
+const actionHeartbeat = "heartbeat"
+
+// Heartbeat renews claimant's claim on a task by resetting its claim time,
+// so the claim does not expire while work continues. Only the current holder
+// can renew: if the claim was released or taken over by another agent,
+// Heartbeat fails with CLAIM_LOST. An expired claim that nobody else has
+// taken is renewed.
+//
+// Renewals change nothing but claimed_at, so the task's updated time is left
+// alone and the renewal is logged as a "heartbeat" entry rather than an edit.
+func Heartbeat(cfg *config.Config, id int, claimant string, now time.Time) (*task.Task, error) {
+	if claimant == "" {
+		return nil, clierr.New(clierr.InvalidInput, "claim name is required")
+	}
+
+	unlock, err := Lock(cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock() //nolint:errcheck // best-effort unlock
+
+	path, err := task.FindByID(cfg.TasksPath(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := task.Read(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.ClaimedBy != claimant {
+		return nil, task.ValidateClaimLost(t.ID, claimant, t.ClaimedBy)
+	}
+
+	t.ClaimedAt = &now
+
+	if err := task.Write(path, t); err != nil {
+		return nil, fmt.Errorf("writing task: %w", err)
+	}
+
+	_ = AppendLog(cfg.Dir(), LogEntry{
+		Timestamp: now,
+		Action:    actionHeartbeat,
+		TaskID:    t.ID,
+		Actor:     claimant,
+		Detail:    claimant,
+	})
+	recordPresence(cfg.Dir(), claimant, actionHeartbeat, t.ID, now)
+
+	return t, nil
+}
@@ -0,0 +1,78 @@
+package board_test
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/antopolskiy/kanban-md/internal/board"
+	"github.com/antopolskiy/kanban-md/internal/clierr"
+	"github.com/antopolskiy/kanban-md/internal/task"
+)
+
+func TestHeartbeat_RenewsClaim(t *testing.T) {
+	claimed := time.Now().Add(-2 * time.Hour) // older than the 1h default timeout
+	updated := claimed.Truncate(time.Second)
+	cfg := setupWorkflowBoard(t, &task.Task{
+		ID: 1, Title: "a", Status: "in-progress", ClaimedBy: transitionAgent, ClaimedAt: &claimed, Updated: updated,
+	})
+
+	now := time.Now()
+	renewed, err := board.Heartbeat(cfg, 1, transitionAgent, now)
+	if err != nil {
+		t.Fatalf("Heartbeat: %v", err)
+	}
+	if renewed.ClaimedAt == nil || !renewed.ClaimedAt.Equal(now) {
+		t.Errorf("ClaimedAt = %v, want %v", renewed.ClaimedAt, now)
+	}
+
+	onDisk, err := task.Read(filepath.Join(cfg.TasksPath(), "1.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !onDisk.Updated.Equal(updated) {
+		t.Errorf("Updated = %v, heartbeat must not change it (%v)", onDisk.Updated, updated)
+	}
+
+	entries, err := board.ReadLog(cfg.Dir(), board.LogFilterOptions{Action: "heartbeat"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Actor != transitionAgent || len(entries[0].Changes) != 0 {
+		t.Errorf("log = %+v, want one heartbeat entry without field changes", entries)
+	}
+}
+
+func TestHeartbeat_KeepsRev(t *testing.T) {
+	claimed := time.Now().Add(-time.Minute)
+	cfg := setupWorkflowBoard(t, &task.Task{ID: 1, Title: "a", Status: "in-progress", ClaimedBy: transitionAgent, ClaimedAt: &claimed})
+	before := readTask(t, cfg.TasksPath(), 1).Rev
+
+	renewed, err := board.Heartbeat(cfg, 1, transitionAgent, time.Now())
+	if err != nil {
+		t.Fatalf("Heartbeat: %v", err)
+	}
+	if renewed.Rev != before {
+		t.Fatalf("rev after heartbeat = %q, want %q unchanged", renewed.Rev, before)
+	}
+	if _, err := board.Move(cfg, board.MoveParams{ID: 1, NewStatus: "review", Claimant: transitionAgent, IfRev: before}, time.Now()); err != nil {
+		t.Errorf("move with the rev read before the heartbeat: %v", err)
+	}
+}
+
+func TestHeartbeat_ClaimLost(t *testing.T) {
+	now := time.Now()
+	cfg := setupWorkflowBoard(t,
+		&task.Task{ID: 1, Title: "stolen", Status: "in-progress", ClaimedBy: "agent-b", ClaimedAt: &now},
+		&task.Task{ID: 2, Title: "released", Status: "todo"},
+	)
+
+	for _, id := range []int{1, 2} {
+		_, err := board.Heartbeat(cfg, id, transitionAgent, now)
+		var cliErr *clierr.Error
+		if !errors.As(err, &cliErr) || cliErr.Code != clierr.ClaimLost {
+			t.Errorf("task #%d: err = %v, want CLAIM_LOST", id, err)
+		}
+	}
+}
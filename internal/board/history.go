@@ -0,0 +1,210 @@
+package board
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/antopolskiy/kanban-md/internal/task"
+)
+
+// FieldChange records one task field's value before and after a mutation.
+// Values are the field's JSON encoding; a nil value means the field was unset.
+// Body changes leave Before and After unset and record a BodyEdit instead, so
+// that a long body does not make every log entry as long (entries written by
+// older versions carry the full bodies).
+type FieldChange struct {
+	Field  string          `json:"field"`
+	Before json.RawMessage `json:"before,omitempty"`
+	After  json.RawMessage `json:"after,omitempty"`
+	Body   *BodyEdit       `json:"body,omitempty"`
+}
+
+// BodyEdit records a change to a task body as the span that changed: Removed,
+// at byte offset At of the old body, was replaced by Inserted. The hashes
+// identify both bodies, so the edit can be checked against the current body
+// and reverted without storing either in full. Bodies are compared without
+// their surrounding newlines, which task files add and drop.
+type BodyEdit struct {
+	At          int    `json:"at"`
+	Removed     string `json:"removed,omitempty"`
+	Inserted    string `json:"inserted,omitempty"`
+	BeforeHash  string `json:"before_sha256,omitempty"` // unset for an empty body
+	AfterHash   string `json:"after_sha256,omitempty"`
+	BeforeLines int    `json:"before_lines"`
+	AfterLines  int    `json:"after_lines"`
+}
+
+// StatusTransition records a status change. From is empty for a new task.
+type StatusTransition struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// untrackedFields are task fields that are not recorded in field changes:
+// id and created never change (the entry's task_id and timestamp carry them),
+// updated changes on every write, and file/rev describe the file on disk
+// rather than the task.
+var untrackedFields = map[string]bool{"id": true, "created": true, "updated": true, "file": true, "rev": true}
+
+// trackedFields lists the JSON names of the task fields recorded in field
+// changes, in struct order.
+var trackedFields = func() []string {
+	var names []string
+	rt := reflect.TypeFor[task.Task]()
+	for i := range rt.NumField() {
+		name, _, _ := strings.Cut(rt.Field(i).Tag.Get("json"), ",")
+		if name == "" || name == "-" || untrackedFields[name] {
+			continue
+		}
+		names = append(names, name)
+	}
+	return names
+}()
+
+// Snapshot captures a task's field values so they can later be compared with
+// Diff. A nil task yields an empty snapshot (every field unset).
+type Snapshot map[string]json.RawMessage
+
+// TakeSnapshot records the current field values of t.
+func TakeSnapshot(t *task.Task) Snapshot {
+	snap := Snapshot{}
+	if t == nil {
+		return snap
+	}
+	data, err := json.Marshal(t)
+	if err != nil {
+		return snap
+	}
+	_ = json.Unmarshal(data, &snap)
+	return snap
+}
+
+// Diff returns the fields whose values differ between two snapshots, in task
+// field order.
+func Diff(before, after Snapshot) []FieldChange {
+	var changes []FieldChange
+	for _, f := range trackedFields {
+		b, a := before[f], after[f]
+		if bytes.Equal(b, a) {
+			continue
+		}
+		if f == "body" {
+			changes = append(changes, FieldChange{Field: f, Body: diffBody(rawBody(b), rawBody(a))})
+			continue
+		}
+		changes = append(changes, FieldChange{Field: f, Before: b, After: a})
+	}
+	return changes
+}
+
+// diffBody returns the edit turning body before into after: the span between
+// their common prefix and suffix.
+func diffBody(before, after string) *BodyEdit {
+	prefix := 0
+	for prefix < len(before) && prefix < len(after) && before[prefix] == after[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(before)-prefix && suffix < len(after)-prefix &&
+		before[len(before)-1-suffix] == after[len(after)-1-suffix] {
+		suffix++
+	}
+	return &BodyEdit{
+		At:          prefix,
+		Removed:     before[prefix : len(before)-suffix],
+		Inserted:    after[prefix : len(after)-suffix],
+		BeforeHash:  bodyHash(before),
+		AfterHash:   bodyHash(after),
+		BeforeLines: bodyLines(before),
+		AfterLines:  bodyLines(after),
+	}
+}
+
+// Revert applies the edit backwards to body, which must be the body the edit
+// produced. ok is false if it is not.
+func (e *BodyEdit) Revert(body string) (before string, ok bool) {
+	body = strings.Trim(body, "\n")
+	if bodyHash(body) != e.AfterHash || e.At+len(e.Inserted) > len(body) {
+		return "", false
+	}
+	before = body[:e.At] + e.Removed + body[e.At+len(e.Inserted):]
+	return before, bodyHash(before) == e.BeforeHash
+}
+
+// rawBody decodes the body in a snapshot value, without its surrounding
+// newlines.
+func rawBody(raw json.RawMessage) string {
+	var body string
+	if len(raw) > 0 {
+		_ = json.Unmarshal(raw, &body)
+	}
+	return strings.Trim(body, "\n")
+}
+
+// bodyHash returns the hex SHA-256 of body, or "" for an empty body.
+func bodyHash(body string) string {
+	if body == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(body))
+	return hex.EncodeToString(sum[:])
+}
+
+// bodyLines counts the lines of body.
+func bodyLines(body string) int {
+	if body == "" {
+		return 0
+	}
+	return strings.Count(body, "\n") + 1
+}
+
+// LogChange appends an activity log entry for a mutation of t, recording the
+// acting agent and the field-level changes since before. A status transition
+// is recorded when the status changed. Like LogMutation, errors are discarded.
+func LogChange(kanbanDir, action, actor string, before Snapshot, t *task.Task, detail string) {
+	logChangeEntry(kanbanDir, LogEntry{Action: action, Actor: actor, Detail: detail}, before, TakeSnapshot(t))
+}
+
+// logChangeEntry completes entry with the task ID, timestamp, field changes
+// and status transition between two snapshots of the same task, and appends
+// it to the log.
+func logChangeEntry(kanbanDir string, entry LogEntry, before, after Snapshot) {
+	entry.Timestamp = time.Now()
+	entry.Changes = Diff(before, after)
+	if entry.TaskID == 0 {
+		_ = json.Unmarshal(after["id"], &entry.TaskID)
+	}
+	from, to := before.status(), after.status()
+	if from != to {
+		entry.Transition = &StatusTransition{From: from, To: to}
+	}
+	_ = AppendLog(kanbanDir, entry)
+}
+
+// status returns the status recorded in the snapshot, or "" if none.
+func (s Snapshot) status() string {
+	var status string
+	if raw, ok := s["status"]; ok {
+		_ = json.Unmarshal(raw, &status)
+	}
+	return status
+}
+
+// Actor returns the name recorded as the actor of a mutation: the claimant
+// performing it if given, otherwise whoever holds the task's claim, otherwise
+// its assignee. It may be empty.
+func Actor(claimant string, t *task.Task) string {
+	switch {
+	case claimant != "":
+		return claimant
+	case t.ClaimedBy != "":
+		return t.ClaimedBy
+	default:
+		return t.Assignee
+	}
+}
@@ -0,0 +1,141 @@
+package board_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/antopolskiy/kanban-md/internal/board"
+	"github.com/antopolskiy/kanban-md/internal/task"
+)
+
+func TestDiff_ReportsChangedFieldsOnly(t *testing.T) {
+	before := &task.Task{ID: 1, Title: "A", Status: "todo", Priority: "low", Tags: []string{"x"}}
+	after := *before
+	after.Priority = "high"
+	after.Tags = []string{"x", "y"}
+	after.Assignee = "alice"
+	after.Updated = time.Now() // untracked
+
+	changes := board.Diff(board.TakeSnapshot(before), board.TakeSnapshot(&after))
+
+	got := map[string][2]string{}
+	for _, c := range changes {
+		got[c.Field] = [2]string{string(c.Before), string(c.After)}
+	}
+	want := map[string][2]string{
+		"priority": {`"low"`, `"high"`},
+		"assignee": {"", `"alice"`},
+		"tags":     {`["x"]`, `["x","y"]`},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("changes = %v, want %v", got, want)
+	}
+	for f, w := range want {
+		if got[f] != w {
+			t.Errorf("%s = %v, want %v", f, got[f], w)
+		}
+	}
+	// Changes follow task field order.
+	if changes[0].Field != "priority" || changes[2].Field != "tags" {
+		t.Errorf("order = %v", changes)
+	}
+}
+
+func findEntry(t *testing.T, dir, action string) board.LogEntry {
+	t.Helper()
+	entries, err := board.ReadLog(dir, board.LogFilterOptions{Action: action})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("got %d %q entries, want 1", len(entries), action)
+	}
+	return entries[0]
+}
+
+func changeOf(e board.LogEntry, field string) (before, after string, ok bool) {
+	for _, c := range e.Changes {
+		if c.Field == field {
+			return string(c.Before), string(c.After), true
+		}
+	}
+	return "", "", false
+}
+
+func TestMutations_RecordFieldChanges(t *testing.T) {
+	cfg, dir := setupMutateBoard(t)
+	now := time.Now()
+
+	if _, err := board.Create(cfg, board.CreateParams{Title: "Audit me", Assignee: "alice"}, now); err != nil {
+		t.Fatal(err)
+	}
+	created := findEntry(t, dir, "create")
+	if created.Actor != "alice" {
+		t.Errorf("create actor = %q, want assignee alice", created.Actor)
+	}
+	if created.Transition == nil || created.Transition.From != "" || created.Transition.To != cfg.Defaults.Status {
+		t.Errorf("create transition = %+v", created.Transition)
+	}
+
+	_, err := board.Edit(cfg, 1, "bot", "", false, func(tk *task.Task) (bool, error) {
+		tk.Priority = "high"
+		tk.ClaimedBy = "bot"
+		return true, nil
+	}, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edited := findEntry(t, dir, "edit")
+	if edited.Actor != "bot" || edited.Transition != nil {
+		t.Errorf("edit entry = %+v", edited)
+	}
+	if b, a, ok := changeOf(edited, "priority"); !ok || b != `"medium"` || a != `"high"` {
+		t.Errorf("priority change = %s -> %s (found %v)", b, a, ok)
+	}
+	if _, _, ok := changeOf(edited, "title"); ok {
+		t.Error("unchanged title recorded as a change")
+	}
+
+	if _, err := board.Move(cfg, board.MoveParams{ID: 1, NewStatus: "todo", Claimant: "bot"}, now); err != nil {
+		t.Fatal(err)
+	}
+	moved := findEntry(t, dir, "move")
+	if moved.Transition == nil || moved.Transition.To != "todo" {
+		t.Errorf("move transition = %+v", moved.Transition)
+	}
+	var status string
+	_, after, _ := changeOf(moved, "status")
+	if err := json.Unmarshal([]byte(after), &status); err != nil || status != "todo" {
+		t.Errorf("status change after = %s", after)
+	}
+}
+
+func TestHandoff_RecordsOneTransition(t *testing.T) {
+	cfg, dir := setupMutateBoard(t)
+	now := time.Now()
+	if _, err := board.Create(cfg, board.CreateParams{Title: "Hand me", Status: "todo", Claimant: "bot"}, now); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := board.Handoff(cfg, board.HandoffParams{ID: 1, Claimant: "bot", Note: "done", Release: true}, now); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := board.ReadLog(dir, board.LogFilterOptions{TaskID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var transitions []string
+	for _, e := range entries {
+		if e.Transition != nil {
+			transitions = append(transitions, e.Action+":"+e.Transition.From+"->"+e.Transition.To)
+		}
+	}
+	want := []string{"create:->todo", "handoff:todo->review"}
+	if len(transitions) != len(want) || transitions[0] != want[0] || transitions[1] != want[1] {
+		t.Errorf("transitions = %v, want %v", transitions, want)
+	}
+	if handoff := findEntry(t, dir, "handoff"); handoff.Actor != "bot" {
+		t.Errorf("handoff actor = %q", handoff.Actor)
+	}
+}
@@ -0,0 +1,215 @@
+package board
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/antopolskiy/kanban-md/internal/clierr"
+	"github.com/antopolskiy/kanban-md/internal/config"
+	"github.com/antopolskiy/kanban-md/internal/task"
+)
+
+// hookEnvMarker is set in the environment of hook commands. Mutations made
+// by a hook (for example a post-create hook running `kanban-md create`) do
+// not run hooks themselves, so hooks cannot trigger each other in a loop.
+const hookEnvMarker = "KANBAN_HOOK"
+
+// hookBoardDirEnv tells hook commands which board the hook belongs to.
+const hookBoardDirEnv = "KANBAN_BOARD_DIR"
+
+// preHookLockShare caps pre-hook timeouts at this fraction of the lock
+// timeout: pre-hooks run under the board lock, and other processes waiting
+// for it must still get it before their own lock timeout.
+const preHookLockShare = 2
+
+// hookWaitDelay bounds how long a finished hook's output pipes are drained,
+// so a command that leaves a background process running cannot hang us.
+const hookWaitDelay = time.Second
+
+// Post-hooks run in the background; each failure is reported as it happens,
+// to stderr unless SetPostHookReporter says otherwise.
+var (
+	postHooks      sync.WaitGroup
+	postHookMu     sync.Mutex
+	postHookReport = func(err error) { fmt.Fprintf(os.Stderr, "Warning: %v\n", err) }
+)
+
+// SetPostHookReporter sets the function post-hook failures are reported to,
+// for processes where stderr is not seen (such as the TUI). It is called from
+// the hook's goroutine.
+func SetPostHookReporter(report func(err error)) {
+	postHookMu.Lock()
+	defer postHookMu.Unlock()
+	postHookReport = report
+}
+
+// WaitForHooks waits for the post-hooks started by earlier mutations to
+// finish. Short-lived callers such as the CLI call it before exiting so that
+// hooks are not cut off with the process.
+func WaitForHooks() {
+	postHooks.Wait()
+}
+
+// taskHooks runs the configured hooks for one mutation of a task.
+type taskHooks struct {
+	cfg       *config.Config
+	events    []string
+	oldStatus string
+	actor     string
+}
+
+// newTaskHooks prepares the hooks for a change of kind event that took a
+// task from the before snapshot to t. Besides event itself, the change emits
+// move, block, unblock, claim and release as implied by the fields it changed.
+func newTaskHooks(cfg *config.Config, event string, before Snapshot, t *task.Task, actor string) *taskHooks {
+	var was struct {
+		Status    string `json:"status"`
+		Blocked   bool   `json:"blocked"`
+		ClaimedBy string `json:"claimed_by"`
+	}
+	if data, err := json.Marshal(before); err == nil {
+		_ = json.Unmarshal(data, &was)
+	}
+
+	events := []string{event}
+	add := func(e string, ok bool) {
+		if ok && !slices.Contains(events, e) {
+			events = append(events, e)
+		}
+	}
+	add(config.HookMove, was.Status != "" && was.Status != t.Status)
+	add(config.HookBlock, !was.Blocked && t.Blocked)
+	add(config.HookUnblock, was.Blocked && !t.Blocked)
+	add(config.HookClaim, was.ClaimedBy == "" && t.ClaimedBy != "")
+	add(config.HookRelease, was.ClaimedBy != "" && t.ClaimedBy == "")
+
+	return &taskHooks{cfg: cfg, events: events, oldStatus: was.Status, actor: actor}
+}
+
+// runPre runs the pre-hooks matching the change, in config order, against t
+// as it will be written. The first failing hook rejects the mutation with a
+// HOOK_REJECTED error.
+func (h *taskHooks) runPre(t *task.Task) error {
+	for _, event := range h.events {
+		for _, hook := range h.matching(event, config.HookPre, t) {
+			out, err := h.run(hook, event, config.HookPre, t)
+			if err != nil {
+				return hookRejected(t, event, hook, out, err)
+			}
+		}
+	}
+	return nil
+}
+
+// runPost starts the post-hooks matching the change in the background.
+// Their failures do not affect the mutation; each is reported on its own
+// (see SetPostHookReporter).
+func (h *taskHooks) runPost(t *task.Task) {
+	snapshot := *t
+	for _, event := range h.events {
+		for _, hook := range h.matching(event, config.HookPost, t) {
+			postHooks.Add(1)
+			go func() {
+				defer postHooks.Done()
+				out, err := h.run(hook, event, config.HookPost, &snapshot)
+				if err == nil {
+					return
+				}
+				if out != "" {
+					err = fmt.Errorf("%w: %s", err, out)
+				}
+				postHookMu.Lock()
+				report := postHookReport
+				postHookMu.Unlock()
+				report(fmt.Errorf("post-%s hook %q for task #%d failed: %w", event, hook.Command, snapshot.ID, err))
+			}()
+		}
+	}
+}
+
+// matching returns the hooks configured for event in phase, unless hooks
+// are disabled because we are running inside a hook.
+func (h *taskHooks) matching(event, phase string, t *task.Task) []config.HookConfig {
+	if os.Getenv(hookEnvMarker) != "" {
+		return nil
+	}
+	var hooks []config.HookConfig
+	for _, hook := range h.cfg.Hooks {
+		if hook.Matches(event, phase, t.Status) {
+			hooks = append(hooks, hook)
+		}
+	}
+	return hooks
+}
+
+// run executes one hook command in the directory containing the board, with
+// the task as JSON on stdin, and returns its combined output.
+func (h *taskHooks) run(hook config.HookConfig, event, phase string, t *task.Task) (string, error) {
+	input, err := json.Marshal(t)
+	if err != nil {
+		return "", fmt.Errorf("encoding task for hook: %w", err)
+	}
+
+	timeout := hook.TimeoutDuration()
+	if lockTimeout := h.cfg.LockTimeoutDuration(); phase == config.HookPre && lockTimeout > 0 {
+		timeout = min(timeout, lockTimeout/preHookLockShare)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	shell, flag := "sh", "-c"
+	if runtime.GOOS == "windows" {
+		shell, flag = "cmd", "/C"
+	}
+	cmd := exec.CommandContext(ctx, shell, flag, hook.Command) //nolint:gosec // hook commands come from the board config
+	cmd.Dir = filepath.Dir(h.cfg.Dir())
+	cmd.Env = append(os.Environ(),
+		hookEnvMarker+"="+phase,
+		"KANBAN_EVENT="+event,
+		hookBoardDirEnv+"="+h.cfg.Dir(),
+		"KANBAN_TASK_ID="+strconv.Itoa(t.ID),
+		"KANBAN_TASK_TITLE="+t.Title,
+		"KANBAN_STATUS="+t.Status,
+		"KANBAN_OLD_STATUS="+h.oldStatus,
+		"KANBAN_ACTOR="+h.actor,
+	)
+	cmd.Stdin = bytes.NewReader(input)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	cmd.WaitDelay = hookWaitDelay
+
+	err = cmd.Run()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("timed out after %s", timeout)
+	}
+	return strings.TrimSpace(out.String()), err
+}
+
+// hookRejected builds the error for a pre-hook that vetoed a mutation. When
+// the hook exited non-zero, the last line of its output is the reason.
+func hookRejected(t *task.Task, event string, hook config.HookConfig, out string, err error) error {
+	reason := err.Error()
+	details := map[string]any{"id": t.ID, "event": event, "command": hook.Command, "output": out}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		details["exit_code"] = exitErr.ExitCode()
+		if out != "" {
+			reason = out[strings.LastIndex(out, "\n")+1:]
+		}
+	}
+	return clierr.Newf(clierr.HookRejected, "pre-%s hook rejected task #%d: %s", event, t.ID, reason).
+		WithDetails(details)
+}
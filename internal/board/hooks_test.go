@@ -0,0 +1,182 @@
+package board_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/antopolskiy/kanban-md/internal/board"
+	"github.com/antopolskiy/kanban-md/internal/clierr"
+	"github.com/antopolskiy/kanban-md/internal/config"
+	"github.com/antopolskiy/kanban-md/internal/task"
+)
+
+// setupHookBoard creates a board with the given hooks and one backlog task.
+func setupHookBoard(t *testing.T, hooks ...config.HookConfig) *config.Config {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("hook commands in these tests use sh syntax")
+	}
+	cfg := setupWorkflowBoard(t, &task.Task{ID: 1, Title: "a", Status: "backlog"})
+	cfg.Transitions = nil
+	cfg.Hooks = hooks
+	if err := cfg.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	return cfg
+}
+
+func TestPreHookRejectsMove(t *testing.T) {
+	cfg := setupHookBoard(t, config.HookConfig{
+		Event: config.HookMove, When: config.HookPre, Statuses: []string{"todo"},
+		Command: `echo "checking"; echo "tests failed" >&2; exit 3`,
+	})
+
+	_, err := board.Move(cfg, board.MoveParams{ID: 1, NewStatus: "todo"}, time.Now())
+	var cliErr *clierr.Error
+	if !errors.As(err, &cliErr) || cliErr.Code != clierr.HookRejected {
+		t.Fatalf("err = %v, want HOOK_REJECTED", err)
+	}
+	if !strings.HasSuffix(cliErr.Message, "tests failed") || cliErr.Details["exit_code"] != 3 {
+		t.Errorf("error = %q %v, want last output line and exit code 3", cliErr.Message, cliErr.Details)
+	}
+
+	tk, err := task.Read(filepath.Join(cfg.TasksPath(), "1.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tk.Status != "backlog" {
+		t.Errorf("status = %q, rejected move must not be written", tk.Status)
+	}
+
+	// The status filter leaves other moves alone.
+	if _, err := board.Move(cfg, board.MoveParams{ID: 1, NewStatus: "in-progress", Claimant: "bot"}, time.Now()); err != nil {
+		t.Fatalf("move to unfiltered status: %v", err)
+	}
+}
+
+func TestPreHookTimeout(t *testing.T) {
+	cfg := setupHookBoard(t, config.HookConfig{
+		Event: config.HookCreate, When: config.HookPre, Command: "exec sleep 5", Timeout: "100ms",
+	})
+
+	_, err := board.Create(cfg, board.CreateParams{Title: "b"}, time.Now())
+	var cliErr *clierr.Error
+	if !errors.As(err, &cliErr) || cliErr.Code != clierr.HookRejected || !strings.Contains(cliErr.Message, "timed out") {
+		t.Fatalf("err = %v, want HOOK_REJECTED timeout", err)
+	}
+}
+
+func TestPreHookTimeoutCappedByLockTimeout(t *testing.T) {
+	cfg := setupHookBoard(t, config.HookConfig{
+		Event: config.HookCreate, When: config.HookPre, Command: "exec sleep 5", Timeout: "1m",
+	})
+	cfg.LockTimeout = "200ms"
+
+	_, err := board.Create(cfg, board.CreateParams{Title: "b"}, time.Now())
+	var cliErr *clierr.Error
+	if !errors.As(err, &cliErr) || !strings.Contains(cliErr.Message, "timed out after 100ms") {
+		t.Fatalf("err = %v, want a timeout at half the lock timeout", err)
+	}
+}
+
+func TestPostHookReceivesTask(t *testing.T) {
+	cfg := setupHookBoard(t, config.HookConfig{
+		Event:   config.HookRelease,
+		Command: `cat > "$KANBAN_BOARD_DIR/task.json"; echo "$KANBAN_EVENT $KANBAN_OLD_STATUS $KANBAN_STATUS $KANBAN_ACTOR" > "$KANBAN_BOARD_DIR/env.txt"`,
+	})
+	if _, err := board.Move(cfg, board.MoveParams{ID: 1, NewStatus: "in-progress", Claimant: "bot", SetClaim: true}, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	// Handoff emits handoff, move and release.
+	if _, err := board.Handoff(cfg, board.HandoffParams{ID: 1, Claimant: "bot", Release: true}, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	board.WaitForHooks()
+
+	env, err := os.ReadFile(filepath.Join(cfg.Dir(), "env.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(string(env)); got != "release in-progress review bot" {
+		t.Errorf("env = %q, want %q", got, "release in-progress review bot")
+	}
+	data, err := os.ReadFile(filepath.Join(cfg.Dir(), "task.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tk task.Task
+	if err := json.Unmarshal(data, &tk); err != nil || tk.ID != 1 || tk.Status != "review" || tk.ClaimedBy != "" {
+		t.Errorf("stdin task = %+v (%v), want #1 in review, released", tk, err)
+	}
+}
+
+func TestUndoRunsHooks(t *testing.T) {
+	cfg := setupHookBoard(t,
+		config.HookConfig{Event: config.HookMove, When: config.HookPre, Statuses: []string{"backlog"}, Command: "echo no going back; exit 1"},
+		config.HookConfig{Event: config.HookArchive, Command: `echo "$KANBAN_TASK_ID" > "$KANBAN_BOARD_DIR/archived.txt"`},
+	)
+	if _, err := board.Move(cfg, board.MoveParams{ID: 1, NewStatus: "todo"}, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	// Undoing the move is a status change back to backlog.
+	_, err := board.Undo(cfg, pending(t, cfg.Dir())[0], "", time.Now())
+	var cliErr *clierr.Error
+	if !errors.As(err, &cliErr) || cliErr.Code != clierr.HookRejected {
+		t.Fatalf("undo err = %v, want HOOK_REJECTED from the move hook", err)
+	}
+	if tk := readTask(t, cfg.TasksPath(), 1); tk.Status != "todo" {
+		t.Errorf("status = %q, want todo after the rejected undo", tk.Status)
+	}
+
+	// Undoing a create removes the task like a delete.
+	res, err := board.Create(cfg, board.CreateParams{Title: "b"}, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := board.Undo(cfg, pending(t, cfg.Dir())[0], "", time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	board.WaitForHooks()
+	data, err := os.ReadFile(filepath.Join(cfg.Dir(), "archived.txt"))
+	if err != nil || strings.TrimSpace(string(data)) != strconv.Itoa(res.Task.ID) {
+		t.Errorf("archive hook output = %q (%v), want %d", data, err, res.Task.ID)
+	}
+}
+
+func TestPostHookFailureIsReported(t *testing.T) {
+	cfg := setupHookBoard(t, config.HookConfig{Event: config.HookEdit, Command: "echo oops; exit 1"})
+	var (
+		mu   sync.Mutex
+		errs []error
+	)
+	board.SetPostHookReporter(func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		errs = append(errs, err)
+	})
+	t.Cleanup(func() { board.SetPostHookReporter(func(error) {}) })
+
+	_, err := board.Edit(cfg, 1, "", "", false, func(tk *task.Task) (bool, error) {
+		tk.Priority = "high"
+		return true, nil
+	}, time.Now())
+	if err != nil {
+		t.Fatalf("post-hook failure must not fail the edit: %v", err)
+	}
+	board.WaitForHooks()
+	mu.Lock()
+	defer mu.Unlock()
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "oops") {
+		t.Errorf("reported %v, want one failure with output", errs)
+	}
+}
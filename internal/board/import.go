@@ -0,0 +1,141 @@
+package board
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/antopolskiy/kanban-md/internal/clierr"
+	"github.com/antopolskiy/kanban-md/internal/config"
+	"github.com/antopolskiy/kanban-md/internal/task"
+)
+
+// Import actions reported in ImportResult.
+const (
+	ImportCreated = "created"
+	ImportExists  = "exists"
+	ImportCreate  = "create" // would be created (dry run)
+)
+
+// ImportItem is a task to import: the task to create and the ID it has in
+// the system it comes from.
+type ImportItem struct {
+	ExternalID string
+	Params     CreateParams
+}
+
+// ImportResult reports what Import did with one item.
+type ImportResult struct {
+	ExternalID string `json:"external_id,omitempty"`
+	ID         int    `json:"id,omitempty"` // the created or existing task; unset on a dry run
+	Title      string `json:"title"`
+	Status     string `json:"status,omitempty"`
+	Action     string `json:"action"`
+}
+
+// Import creates a task for every item whose external ID is not on the board
+// yet, in order. Items already imported (same external ID) are reported as
+// existing and left alone, so importing the same export again only adds what
+// is new. It holds the board lock throughout; tasks are created like Create
+// would. Every new task is validated first, with the earlier items counting
+// toward WIP limits, so an invalid item fails the import before anything is
+// written; with dryRun, nothing is written either way. Only a pre-hook can
+// still reject an item partway; the tasks created so far are then kept and
+// reported alongside the error.
+func Import(cfg *config.Config, items []ImportItem, dryRun bool, now time.Time) ([]ImportResult, error) {
+	results := []ImportResult{}
+	if len(items) == 0 {
+		return results, nil
+	}
+
+	unlock, err := Lock(cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock() //nolint:errcheck // best-effort unlock
+
+	plan, err := planImport(cfg, items, now)
+	if err != nil {
+		return nil, err
+	}
+	created := make(map[string]int) // external ID -> task created by this import
+	for i, item := range items {
+		r := plan[i]
+		switch {
+		case r.Action == ImportExists && r.ID == 0:
+			r.ID = created[r.ExternalID] // a repeat of an earlier item
+		case r.Action == ImportCreate && !dryRun:
+			item.Params.ExternalID = item.ExternalID
+			result, err := create(cfg, item.Params, now)
+			if err != nil {
+				return results, err
+			}
+			r.ID, r.Status, r.Action = result.Task.ID, result.Task.Status, ImportCreated
+			created[r.ExternalID] = r.ID
+		}
+		results = append(results, r)
+	}
+	return results, nil
+}
+
+// planImport decides what Import does with each item: existing items are
+// reported with their task, and new ones are validated as create would,
+// counting the new tasks before them toward WIP limits. The first invalid
+// item fails the whole plan.
+func planImport(cfg *config.Config, items []ImportItem, now time.Time) ([]ImportResult, error) {
+	existing, err := externalIDs(cfg)
+	if err != nil {
+		return nil, err
+	}
+	refreshNextID(cfg)
+	plan := make([]ImportResult, 0, len(items))
+	var pending []*task.Task
+	for _, item := range items {
+		r := ImportResult{ExternalID: item.ExternalID, Title: item.Params.Title, Status: item.Params.Status}
+		if id, ok := existing[item.ExternalID]; ok && item.ExternalID != "" {
+			r.ID, r.Action = id, ImportExists
+			plan = append(plan, r)
+			continue
+		}
+		t, err := validateCreate(cfg, cfg.NextID+len(pending), item.Params, pending, now)
+		if err != nil {
+			return nil, importItemError(item, err)
+		}
+		pending = append(pending, t)
+		r.Status, r.Action = t.Status, ImportCreate
+		if item.ExternalID != "" {
+			existing[item.ExternalID] = 0 // not created yet; see Import
+		}
+		plan = append(plan, r)
+	}
+	return plan, nil
+}
+
+// importItemError names the item that failed validation in err.
+func importItemError(item ImportItem, err error) error {
+	name := fmt.Sprintf("%q", item.Params.Title)
+	if item.ExternalID != "" {
+		name = item.ExternalID + " " + name
+	}
+	var cliErr *clierr.Error
+	if errors.As(err, &cliErr) {
+		cliErr.Message = "importing " + name + ": " + cliErr.Message
+		return cliErr
+	}
+	return fmt.Errorf("importing %s: %w", name, err)
+}
+
+// externalIDs maps the external IDs of the board's tasks to their task IDs.
+func externalIDs(cfg *config.Config) (map[string]int, error) {
+	tasks, _, err := task.ReadAllLenient(cfg.TasksPath())
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[string]int)
+	for _, t := range tasks {
+		if t.ExternalID != "" {
+			ids[t.ExternalID] = t.ID
+		}
+	}
+	return ids, nil
+}
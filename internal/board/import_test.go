@@ -0,0 +1,95 @@
+package board_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/antopolskiy/kanban-md/internal/board"
+	"github.com/antopolskiy/kanban-md/internal/clierr"
+)
+
+func TestImport_SkipsImportedExternalIDs(t *testing.T) {
+	cfg := setupRecurBoard(t)
+	now := time.Now()
+	items := []board.ImportItem{
+		{ExternalID: "github:#1", Params: board.CreateParams{Title: "One"}},
+		{ExternalID: "github:#2", Params: board.CreateParams{Title: "Two", Status: "done"}},
+		{Params: board.CreateParams{Title: "No external ID"}},
+	}
+
+	results, err := board.Import(cfg, items, false, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 3 || results[0].Action != board.ImportCreated || results[1].Status != "done" {
+		t.Fatalf("results = %+v, want three created tasks", results)
+	}
+	if tk := readTask(t, cfg.TasksPath(), results[0].ID); tk.ExternalID != "github:#1" {
+		t.Errorf("external_id = %q, want github:#1", tk.ExternalID)
+	}
+
+	items = append(items, board.ImportItem{ExternalID: "github:#3", Params: board.CreateParams{Title: "Three"}})
+	again, err := board.Import(cfg, items, false, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again[0].Action != board.ImportExists || again[0].ID != results[0].ID || again[3].Action != board.ImportCreated {
+		t.Errorf("second import = %+v, want #1 existing and #3 created", again)
+	}
+	// Items without an external ID cannot be matched and are created again.
+	if again[2].Action != board.ImportCreated {
+		t.Errorf("item without external ID = %+v, want created", again[2])
+	}
+}
+
+func TestImport_DryRun(t *testing.T) {
+	cfg := setupRecurBoard(t)
+	items := []board.ImportItem{
+		{ExternalID: "csv:1", Params: board.CreateParams{Title: "One"}},
+		{ExternalID: "csv:1", Params: board.CreateParams{Title: "One again"}},
+	}
+	results, err := board.Import(cfg, items, true, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 || results[0].Action != board.ImportCreate || results[1].Action != board.ImportExists {
+		t.Errorf("dry run = %+v, want one to create and the duplicate existing", results)
+	}
+	if results[0].Status != "backlog" {
+		t.Errorf("dry run status = %q, want the default status the task would get", results[0].Status)
+	}
+	if tasks := readTasks(t, cfg.TasksPath()); len(tasks) != 0 {
+		t.Errorf("dry run wrote %d tasks", len(tasks))
+	}
+}
+
+func TestImport_ValidatesBatchBeforeWriting(t *testing.T) {
+	cfg := setupRecurBoard(t)
+	cfg.WIPLimits = map[string]int{"in-progress": 2}
+	items := []board.ImportItem{
+		{ExternalID: "csv:1", Params: board.CreateParams{Title: "One", Status: "in-progress"}},
+		{ExternalID: "csv:2", Params: board.CreateParams{Title: "Two", Status: "in-progress"}},
+		{ExternalID: "csv:3", Params: board.CreateParams{Title: "Three", Status: "in-progress"}},
+	}
+
+	for _, dryRun := range []bool{true, false} {
+		_, err := board.Import(cfg, items, dryRun, time.Now())
+		var cliErr *clierr.Error
+		if !errors.As(err, &cliErr) || cliErr.Code != clierr.WIPLimitExceeded {
+			t.Fatalf("dry run %v: err = %v, want WIP_LIMIT_EXCEEDED", dryRun, err)
+		}
+		if !strings.Contains(cliErr.Message, `csv:3 "Three"`) {
+			t.Errorf("dry run %v: message = %q, want the failing item named", dryRun, cliErr.Message)
+		}
+	}
+	if tasks := readTasks(t, cfg.TasksPath()); len(tasks) != 0 {
+		t.Errorf("failed import wrote %d tasks, want none", len(tasks))
+	}
+
+	results, err := board.Import(cfg, items[:2], false, time.Now())
+	if err != nil || len(results) != 2 || results[1].Action != board.ImportCreated {
+		t.Fatalf("import within the limit = %+v, %v", results, err)
+	}
+}
@@ -0,0 +1,61 @@
+package board
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/antopolskiy/kanban-md/internal/clierr"
+	"github.com/antopolskiy/kanban-md/internal/config"
+	"github.com/antopolskiy/kanban-md/internal/filelock"
+)
+
+// lockFileName is the board-wide mutation lock inside the kanban directory.
+const lockFileName = ".lock"
+
+// Lock acquires the board-wide mutation lock, waiting at most
+// cfg.LockTimeoutDuration(). Every read-check-write sequence on task files or
+// config.yml must run while holding it, so that concurrent processes cannot
+// interleave (e.g. two agents claiming the same task or racing past a WIP
+// limit). If the lock is not acquired in time, a LOCK_TIMEOUT error is returned.
+//
+// The lock is not reentrant: the mutation functions in this package (Create,
+// Move, Edit, Handoff, Archive, Delete, PickAndClaim) acquire it themselves,
+// so callers must not hold it when calling them.
+//
+// A pre-hook runs while the process that started it holds the lock and waits
+// for it, so a kanban-md command run by a pre-hook of the same board could
+// never get the lock. Lock fails at once there instead of timing out; reads
+// do not need the lock, so pre-hooks can still inspect the board.
+func Lock(cfg *config.Config) (unlock func() error, err error) {
+	if inPreHookOf(cfg) {
+		return nil, clierr.New(clierr.InvalidInput,
+			"cannot modify the board from one of its pre-hooks: the command running the hook holds the board lock; use a post-hook instead")
+	}
+	timeout := cfg.LockTimeoutDuration()
+	unlock, err = filelock.LockTimeout(filepath.Join(cfg.Dir(), lockFileName), timeout)
+	if errors.Is(err, filelock.ErrTimeout) {
+		return nil, clierr.Newf(clierr.LockTimeout,
+			"timed out after %s waiting for the board lock (another kanban-md process is mutating the board)", timeout).
+			WithDetails(map[string]any{"timeout": timeout.String()})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("acquiring lock: %w", err)
+	}
+	return unlock, nil
+}
+
+// inPreHookOf reports whether this process was started by a pre-hook of the
+// board in cfg.
+func inPreHookOf(cfg *config.Config) bool {
+	if os.Getenv(hookEnvMarker) != config.HookPre {
+		return false
+	}
+	hookDir, err := os.Stat(os.Getenv(hookBoardDirEnv))
+	if err != nil {
+		return false
+	}
+	dir, err := os.Stat(cfg.Dir())
+	return err == nil && os.SameFile(hookDir, dir)
+}
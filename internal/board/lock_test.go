@@ -0,0 +1,210 @@
+package board_test
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/antopolskiy/kanban-md/internal/board"
+	"github.com/antopolskiy/kanban-md/internal/clierr"
+	"github.com/antopolskiy/kanban-md/internal/config"
+	"github.com/antopolskiy/kanban-md/internal/task"
+)
+
+// setupLockBoard creates a board with n unclaimed todo tasks and a lock
+// timeout generous enough for heavily contended stress tests.
+func setupLockBoard(t *testing.T, n int) *config.Config {
+	t.Helper()
+	cfg, _ := setupMutateBoard(t)
+	cfg.LockTimeout = "2m"
+	for i := 1; i <= n; i++ {
+		tk := &task.Task{ID: i, Title: fmt.Sprintf("task %d", i), Status: "todo", Priority: "medium"}
+		path := filepath.Join(cfg.TasksPath(), task.GenerateFilename(i, task.GenerateSlug(tk.Title)))
+		if err := task.Write(path, tk); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return cfg
+}
+
+func TestPickAndClaim_ConcurrentNoDoubleClaims(t *testing.T) {
+	const (
+		tasks   = 40
+		pickers = 300
+	)
+	cfg := setupLockBoard(t, tasks)
+
+	var (
+		mu          sync.Mutex
+		claimedBy   = make(map[int]string, tasks)
+		doubleClaim []string
+		nothingLeft int
+		wg          sync.WaitGroup
+	)
+	start := make(chan struct{})
+	wg.Add(pickers)
+	for i := range pickers {
+		go func(agent string) {
+			defer wg.Done()
+			<-start
+			picked, _, _, err := board.PickAndClaim(cfg, board.PickAndClaimParams{Claimant: agent}, time.Now())
+
+			mu.Lock()
+			defer mu.Unlock()
+			var cliErr *clierr.Error
+			switch {
+			case errors.As(err, &cliErr) && cliErr.Code == clierr.NothingToPick:
+				nothingLeft++
+			case err != nil:
+				t.Errorf("%s: PickAndClaim() error: %v", agent, err)
+			default:
+				if prev, ok := claimedBy[picked.ID]; ok {
+					doubleClaim = append(doubleClaim, fmt.Sprintf("#%d by %s and %s", picked.ID, prev, agent))
+				}
+				claimedBy[picked.ID] = agent
+			}
+		}(fmt.Sprintf("agent-%03d", i))
+	}
+	close(start)
+	wg.Wait()
+
+	if len(doubleClaim) > 0 {
+		t.Fatalf("tasks claimed more than once: %v", doubleClaim)
+	}
+	if len(claimedBy) != tasks {
+		t.Errorf("claimed %d tasks, want %d", len(claimedBy), tasks)
+	}
+	if nothingLeft != pickers-tasks {
+		t.Errorf("NOTHING_TO_PICK count = %d, want %d", nothingLeft, pickers-tasks)
+	}
+
+	// The files on disk must agree with what each picker was told.
+	all, _, err := task.ReadAllLenient(cfg.TasksPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tk := range all {
+		if tk.ClaimedBy != claimedBy[tk.ID] {
+			t.Errorf("task #%d claimed_by on disk = %q, picker reported %q", tk.ID, tk.ClaimedBy, claimedBy[tk.ID])
+		}
+	}
+}
+
+func TestMove_ConcurrentRespectsWIPLimit(t *testing.T) {
+	const (
+		tasks    = 30
+		wipLimit = 3
+	)
+	cfg := setupLockBoard(t, tasks)
+	cfg.WIPLimits = map[string]int{"done": wipLimit}
+
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	wg.Add(tasks)
+	for id := 1; id <= tasks; id++ {
+		go func(id int) {
+			defer wg.Done()
+			<-start
+			_, err := board.Move(cfg, board.MoveParams{ID: id, NewStatus: "done"}, time.Now())
+			var cliErr *clierr.Error
+			if err != nil && (!errors.As(err, &cliErr) || cliErr.Code != clierr.WIPLimitExceeded) {
+				t.Errorf("Move(#%d) unexpected error: %v", id, err)
+			}
+		}(id)
+	}
+	close(start)
+	wg.Wait()
+
+	all, _, err := task.ReadAllLenient(cfg.TasksPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := board.CountByStatus(all)["done"]; got != wipLimit {
+		t.Errorf("done count = %d, want exactly the WIP limit %d", got, wipLimit)
+	}
+}
+
+func TestLock_TimeoutReturnsLockTimeoutCode(t *testing.T) {
+	cfg := setupLockBoard(t, 1)
+
+	unlock, err := board.Lock(cfg)
+	if err != nil {
+		t.Fatalf("Lock() error: %v", err)
+	}
+	defer unlock() //nolint:errcheck // test cleanup
+
+	cfg.LockTimeout = "50ms"
+	_, err = board.Move(cfg, board.MoveParams{ID: 1, NewStatus: "backlog"}, time.Now())
+
+	var cliErr *clierr.Error
+	if !errors.As(err, &cliErr) {
+		t.Fatalf("expected clierr.Error, got %v", err)
+	}
+	if cliErr.Code != clierr.LockTimeout {
+		t.Errorf("code = %q, want %q", cliErr.Code, clierr.LockTimeout)
+	}
+
+	// The task must be untouched.
+	path, err := task.FindByID(cfg.TasksPath(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tk, err := task.Read(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tk.Status != "todo" {
+		t.Errorf("status = %q, want todo (move must not run without the lock)", tk.Status)
+	}
+}
+
+func TestCreate_RefreshesNextIDFromDisk(t *testing.T) {
+	cfg, err := config.Init(t.TempDir(), "Test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Another process bumps next_id on disk after we loaded cfg.
+	other, err := config.Load(cfg.Dir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	other.NextID = 7
+	if err := other.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := board.Create(cfg, board.CreateParams{Title: "fresh"}, time.Now())
+	if err != nil {
+		t.Fatalf("Create() error: %v", err)
+	}
+	if result.Task.ID != 7 {
+		t.Errorf("created ID = %d, want 7 (next_id from disk)", result.Task.ID)
+	}
+	if cfg.NextID != 8 {
+		t.Errorf("cfg.NextID = %d, want 8", cfg.NextID)
+	}
+}
+
+func TestLock_FailsInPreHookOfSameBoard(t *testing.T) {
+	cfg := setupLockBoard(t, 1)
+	t.Setenv("KANBAN_HOOK", config.HookPre)
+	t.Setenv("KANBAN_BOARD_DIR", cfg.Dir())
+
+	_, err := board.Lock(cfg)
+	var cliErr *clierr.Error
+	if !errors.As(err, &cliErr) || cliErr.Code != clierr.InvalidInput {
+		t.Fatalf("Lock in a pre-hook = %v, want INVALID_INPUT", err)
+	}
+
+	// A pre-hook may still mutate another board.
+	other := setupLockBoard(t, 1)
+	unlock, err := board.Lock(other)
+	if err != nil {
+		t.Fatalf("Lock of another board: %v", err)
+	}
+	_ = unlock()
+}
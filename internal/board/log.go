@@ -2,26 +2,45 @@ package board
 
 import (
 	"bufio"
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
+
+	"github.com/antopolskiy/kanban-md/internal/atomicfile"
 )
 
 const (
 	logFileName   = "activity.jsonl"
 	logFileMode   = 0o600
 	maxLogEntries = 10000 // truncate oldest entries when log exceeds this size
+	entryIDBytes  = 6     // random bytes in a log entry ID (12 hex characters)
 )
 
 // LogEntry represents a single activity log entry.
+//
+// ID, Actor, Changes and Transition are recorded for mutations made through
+// the board package; entries written by older versions leave them empty. Each
+// status change is recorded as exactly one Transition, on the entry of the
+// operation that made it (e.g. "handoff", not the accompanying "move").
+// Undoes is set on "undo" entries to the ID of the entry they reverted.
 type LogEntry struct {
-	Timestamp time.Time `json:"timestamp"`
-	Action    string    `json:"action"`
-	TaskID    int       `json:"task_id"`
-	Detail    string    `json:"detail"`
+	ID         string            `json:"id,omitempty"`
+	Timestamp  time.Time         `json:"timestamp"`
+	Action     string            `json:"action"`
+	TaskID     int               `json:"task_id"`
+	Detail     string            `json:"detail"`
+	Actor      string            `json:"actor,omitempty"`
+	Changes    []FieldChange     `json:"changes,omitempty"`
+	Transition *StatusTransition `json:"transition,omitempty"`
+	Undoes     string            `json:"undoes,omitempty"`
 }
 
 // LogFilterOptions controls how log entries are filtered.
@@ -32,10 +51,14 @@ type LogFilterOptions struct {
 	TaskID int
 }
 
-// AppendLog appends a log entry to the activity log file.
-// If the log exceeds maxLogEntries, the oldest entries are truncated.
+// AppendLog appends a log entry to the activity log file, assigning it an ID
+// if it has none. If the log exceeds maxLogEntries, the oldest entries are
+// truncated.
 func AppendLog(kanbanDir string, entry LogEntry) error {
 	path := filepath.Join(kanbanDir, logFileName)
+	if entry.ID == "" {
+		entry.ID = newEntryID()
+	}
 
 	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, logFileMode) //nolint:gosec // log path from trusted kanban dir
 	if err != nil {
@@ -59,7 +82,7 @@ func AppendLog(kanbanDir string, entry LogEntry) error {
 }
 
 // truncateLogIfNeeded reads the log file and, if it exceeds maxLogEntries,
-// rewrites it keeping only the most recent entries.
+// atomically rewrites it keeping only the most recent entries.
 func truncateLogIfNeeded(path string) error {
 	f, err := os.Open(path) //nolint:gosec // trusted path
 	if err != nil {
@@ -67,13 +90,11 @@ func truncateLogIfNeeded(path string) error {
 	}
 
 	var lines []string
-	scanner := bufio.NewScanner(f)
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
-	}
+	err = forEachLine(f, func(line []byte) {
+		lines = append(lines, string(line))
+	})
 	_ = f.Close()
-
-	if err := scanner.Err(); err != nil {
+	if err != nil {
 		return err
 	}
 
@@ -90,7 +111,7 @@ func truncateLogIfNeeded(path string) error {
 		buf.WriteByte('\n')
 	}
 
-	return os.WriteFile(path, []byte(buf.String()), logFileMode)
+	return atomicfile.Write(path, []byte(buf.String()), logFileMode)
 }
 
 // ReadLog reads and filters log entries from the activity log file.
@@ -107,26 +128,23 @@ func ReadLog(kanbanDir string, opts LogFilterOptions) ([]LogEntry, error) {
 	defer f.Close()
 
 	var entries []LogEntry
-	scanner := bufio.NewScanner(f)
-	for scanner.Scan() {
-		line := scanner.Bytes()
+	err = forEachLine(f, func(line []byte) {
 		if len(line) == 0 {
-			continue
+			return
 		}
 
 		var entry LogEntry
 		if err := json.Unmarshal(line, &entry); err != nil {
-			continue // skip malformed lines
+			return // skip malformed lines
 		}
 
 		if !matchesLogFilter(entry, opts) {
-			continue
+			return
 		}
 
 		entries = append(entries, entry)
-	}
-
-	if err := scanner.Err(); err != nil {
+	})
+	if err != nil {
 		return nil, fmt.Errorf("reading log file: %w", err)
 	}
 
@@ -137,6 +155,24 @@ func ReadLog(kanbanDir string, opts LogFilterOptions) ([]LogEntry, error) {
 	return entries, nil
 }
 
+// forEachLine calls fn with every line read from r, without its line ending.
+// Unlike bufio.Scanner, it accepts lines of any length.
+func forEachLine(r io.Reader, fn func(line []byte)) error {
+	br := bufio.NewReader(r)
+	for {
+		line, err := br.ReadBytes('\n')
+		if len(line) > 0 {
+			fn(bytes.TrimRight(line, "\r\n"))
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
 // LogMutation appends an activity log entry. Errors are silently discarded
 // because logging should never fail a command.
 func LogMutation(kanbanDir, action string, taskID int, detail string) {
@@ -149,6 +185,13 @@ func LogMutation(kanbanDir, action string, taskID int, detail string) {
 	_ = AppendLog(kanbanDir, entry)
 }
 
+// newEntryID returns a random identifier for a log entry.
+func newEntryID() string {
+	b := make([]byte, entryIDBytes)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
 func matchesLogFilter(entry LogEntry, opts LogFilterOptions) bool {
 	if !opts.Since.IsZero() && entry.Timestamp.Before(opts.Since) {
 		return false
@@ -256,6 +256,21 @@ func TestAppendLogTruncatesWhenOverLimit(t *testing.T) {
 	}
 }
 
+func TestReadLogLongLines(t *testing.T) {
+	dir := t.TempDir()
+	long := strings.Repeat("x", 200*1024) // longer than bufio.Scanner's default limit
+	mustAppend(t, dir, LogEntry{Timestamp: time.Now(), Action: "edit", TaskID: 1, Detail: long})
+	mustAppend(t, dir, LogEntry{Timestamp: time.Now(), Action: "move", TaskID: 1})
+
+	entries, err := ReadLog(dir, LogFilterOptions{})
+	if err != nil {
+		t.Fatalf("ReadLog: %v", err)
+	}
+	if len(entries) != 2 || entries[0].Detail != long || entries[1].Action != "move" {
+		t.Fatalf("got %d entries, want the long entry and the one after it", len(entries))
+	}
+}
+
 func mustAppend(t *testing.T, dir string, entry LogEntry) {
 	t.Helper()
 	if err := AppendLog(dir, entry); err != nil {
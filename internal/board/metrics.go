@@ -1,6 +1,9 @@
 package board
 
 import (
+	"math"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/antopolskiy/kanban-md/internal/config"
@@ -11,6 +14,10 @@ const (
 	hoursPerDay = 24
 	days7       = 7
 	days30      = 30
+
+	p50 = 0.50
+	p85 = 0.85
+	p95 = 0.95
 )
 
 // Metrics holds aggregate board metrics.
@@ -21,6 +28,23 @@ type Metrics struct {
 	AvgCycleTimeHours *float64    `json:"avg_cycle_time_hours,omitempty"`
 	FlowEfficiency    *float64    `json:"flow_efficiency,omitempty"`
 	AgingItems        []AgingItem `json:"aging_items,omitempty"`
+
+	// Lead and cycle time percentiles: the long tail the averages hide.
+	LeadTimeP50Hours  *float64 `json:"lead_time_p50_hours,omitempty"`
+	LeadTimeP85Hours  *float64 `json:"lead_time_p85_hours,omitempty"`
+	LeadTimeP95Hours  *float64 `json:"lead_time_p95_hours,omitempty"`
+	CycleTimeP50Hours *float64 `json:"cycle_time_p50_hours,omitempty"`
+	CycleTimeP85Hours *float64 `json:"cycle_time_p85_hours,omitempty"`
+	CycleTimeP95Hours *float64 `json:"cycle_time_p95_hours,omitempty"`
+
+	// ByClass and ByTag break the lead and cycle times of completed tasks
+	// down by class of service and by tag.
+	ByClass []GroupMetrics `json:"by_class,omitempty"`
+	ByTag   []GroupMetrics `json:"by_tag,omitempty"`
+
+	// StatusTimes is set by callers that have the activity log (see
+	// ComputeStatusTimes).
+	StatusTimes []StatusTime `json:"status_times,omitempty"`
 }
 
 // AgingItem represents a work item that has started but not completed.
@@ -31,6 +55,61 @@ type AgingItem struct {
 	AgeHours float64 `json:"age_hours"`
 }
 
+// FlowTimes holds the lead and cycle time statistics of completed tasks.
+type FlowTimes struct {
+	AvgLeadTimeHours  *float64 `json:"avg_lead_time_hours,omitempty"`
+	LeadTimeP50Hours  *float64 `json:"lead_time_p50_hours,omitempty"`
+	LeadTimeP85Hours  *float64 `json:"lead_time_p85_hours,omitempty"`
+	LeadTimeP95Hours  *float64 `json:"lead_time_p95_hours,omitempty"`
+	AvgCycleTimeHours *float64 `json:"avg_cycle_time_hours,omitempty"`
+	CycleTimeP50Hours *float64 `json:"cycle_time_p50_hours,omitempty"`
+	CycleTimeP85Hours *float64 `json:"cycle_time_p85_hours,omitempty"`
+	CycleTimeP95Hours *float64 `json:"cycle_time_p95_hours,omitempty"`
+}
+
+// GroupMetrics holds the flow times of the completed tasks of one class or
+// tag.
+type GroupMetrics struct {
+	Name      string `json:"name"`
+	Completed int    `json:"completed"`
+	FlowTimes
+}
+
+// flowSample collects the lead and cycle times of completed tasks, in hours.
+type flowSample struct {
+	lead, cycle []float64
+}
+
+func (fs *flowSample) add(t *task.Task) {
+	fs.lead = append(fs.lead, t.Completed.Sub(t.Created).Hours())
+	if t.Started != nil {
+		fs.cycle = append(fs.cycle, t.Completed.Sub(*t.Started).Hours())
+	}
+}
+
+func (fs *flowSample) times() FlowTimes {
+	var ft FlowTimes
+	ft.AvgLeadTimeHours, ft.LeadTimeP50Hours, ft.LeadTimeP85Hours, ft.LeadTimeP95Hours = stats(fs.lead)
+	ft.AvgCycleTimeHours, ft.CycleTimeP50Hours, ft.CycleTimeP85Hours, ft.CycleTimeP95Hours = stats(fs.cycle)
+	return ft
+}
+
+// stats returns the average and the 50th, 85th and 95th percentiles of
+// values, or nils for no values. It sorts values.
+func stats(values []float64) (avg, pct50, pct85, pct95 *float64) {
+	if len(values) == 0 {
+		return nil, nil, nil, nil
+	}
+	slices.Sort(values)
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	a := sum / float64(len(values))
+	q50, q85, q95 := percentile(values, p50), percentile(values, p85), percentile(values, p95)
+	return &a, &q50, &q85, &q95
+}
+
 // ComputeMetrics computes aggregate flow metrics from all tasks.
 func ComputeMetrics(cfg *config.Config, tasks []*task.Task, now time.Time) Metrics {
 	m := Metrics{}
@@ -38,9 +117,9 @@ func ComputeMetrics(cfg *config.Config, tasks []*task.Task, now time.Time) Metri
 	window7 := now.AddDate(0, 0, -days7)
 	window30 := now.AddDate(0, 0, -days30)
 
-	var leadSum, cycleSum float64
-	var leadCount, cycleCount int
-
+	var all flowSample
+	byClass := make(map[string]*flowSample)
+	byTag := make(map[string]*flowSample)
 	for _, t := range tasks {
 		if t.Completed != nil {
 			if t.Completed.After(window7) {
@@ -49,15 +128,10 @@ func ComputeMetrics(cfg *config.Config, tasks []*task.Task, now time.Time) Metri
 			if t.Completed.After(window30) {
 				m.Throughput30d++
 			}
-
-			leadHours := t.Completed.Sub(t.Created).Hours()
-			leadSum += leadHours
-			leadCount++
-
-			if t.Started != nil {
-				cycleHours := t.Completed.Sub(*t.Started).Hours()
-				cycleSum += cycleHours
-				cycleCount++
+			all.add(t)
+			groupSample(byClass, taskClass(t)).add(t)
+			for _, tag := range t.Tags {
+				groupSample(byTag, tag).add(t)
 			}
 		}
 
@@ -72,18 +146,67 @@ func ComputeMetrics(cfg *config.Config, tasks []*task.Task, now time.Time) Metri
 		}
 	}
 
-	if leadCount > 0 {
-		avg := leadSum / float64(leadCount)
-		m.AvgLeadTimeHours = &avg
-	}
-	if cycleCount > 0 {
-		avg := cycleSum / float64(cycleCount)
-		m.AvgCycleTimeHours = &avg
-	}
+	ft := all.times()
+	m.AvgLeadTimeHours, m.LeadTimeP50Hours, m.LeadTimeP85Hours, m.LeadTimeP95Hours =
+		ft.AvgLeadTimeHours, ft.LeadTimeP50Hours, ft.LeadTimeP85Hours, ft.LeadTimeP95Hours
+	m.AvgCycleTimeHours, m.CycleTimeP50Hours, m.CycleTimeP85Hours, m.CycleTimeP95Hours =
+		ft.AvgCycleTimeHours, ft.CycleTimeP50Hours, ft.CycleTimeP85Hours, ft.CycleTimeP95Hours
 	if m.AvgLeadTimeHours != nil && m.AvgCycleTimeHours != nil && *m.AvgLeadTimeHours > 0 {
 		eff := *m.AvgCycleTimeHours / *m.AvgLeadTimeHours
 		m.FlowEfficiency = &eff
 	}
 
+	m.ByClass = groupMetrics(byClass, func(a, b string) int { return cfg.ClassIndex(a) - cfg.ClassIndex(b) })
+	m.ByTag = groupMetrics(byTag, nil)
 	return m
 }
+
+// taskClass returns t's class of service; tasks without one are standard.
+func taskClass(t *task.Task) string {
+	if t.Class == "" {
+		return classStandard
+	}
+	return t.Class
+}
+
+func groupSample(groups map[string]*flowSample, name string) *flowSample {
+	fs, ok := groups[name]
+	if !ok {
+		fs = &flowSample{}
+		groups[name] = fs
+	}
+	return fs
+}
+
+// groupMetrics returns the metrics of each group, ordered by cmp, or by most
+// completed tasks then name when cmp is nil.
+func groupMetrics(groups map[string]*flowSample, cmp func(a, b string) int) []GroupMetrics {
+	out := make([]GroupMetrics, 0, len(groups))
+	for name, fs := range groups {
+		out = append(out, GroupMetrics{Name: name, Completed: len(fs.lead), FlowTimes: fs.times()})
+	}
+	slices.SortFunc(out, func(a, b GroupMetrics) int {
+		if cmp != nil {
+			if c := cmp(a.Name, b.Name); c != 0 {
+				return c
+			}
+		} else if a.Completed != b.Completed {
+			return b.Completed - a.Completed
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+// percentile returns the p-th percentile (0 < p <= 1) of sorted values by the
+// nearest-rank method, or 0 for no values.
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	i := int(math.Ceil(p*float64(len(sorted)))) - 1
+	return sorted[max(i, 0)]
+}
@@ -193,3 +193,54 @@ func TestMetricsMultipleTasksAverages(t *testing.T) {
 		t.Errorf("FlowEfficiency = %v, want 0.5", m.FlowEfficiency)
 	}
 }
+
+func TestMetricsPercentilesAndGroups(t *testing.T) {
+	cfg := config.NewDefault("Test")
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	// Ten tasks with cycle times of 1..10 days; the last one is expedited.
+	var tasks []*task.Task
+	for i := 1; i <= 10; i++ {
+		completed := now.Add(-time.Hour)
+		started := completed.Add(-time.Duration(i) * 24 * time.Hour)
+		tk := &task.Task{
+			ID: i, Status: "done", Created: started, Started: &started, Completed: &completed,
+			Tags: []string{"api"},
+		}
+		if i == 10 {
+			tk.Class = "expedite"
+			tk.Tags = nil
+		}
+		tasks = append(tasks, tk)
+	}
+
+	m := ComputeMetrics(cfg, tasks, now)
+
+	for name, got := range map[string]*float64{
+		"CycleTimeP50Hours": m.CycleTimeP50Hours,
+		"CycleTimeP85Hours": m.CycleTimeP85Hours,
+		"CycleTimeP95Hours": m.CycleTimeP95Hours,
+		"LeadTimeP95Hours":  m.LeadTimeP95Hours,
+	} {
+		if got == nil {
+			t.Fatalf("%s = nil", name)
+		}
+	}
+	if *m.CycleTimeP50Hours != 5*24 || *m.CycleTimeP85Hours != 9*24 || *m.CycleTimeP95Hours != 10*24 {
+		t.Errorf("cycle p50/p85/p95 = %v/%v/%v hours, want 5, 9 and 10 days",
+			*m.CycleTimeP50Hours, *m.CycleTimeP85Hours, *m.CycleTimeP95Hours)
+	}
+
+	if len(m.ByClass) != 2 || m.ByClass[0].Name != "expedite" || m.ByClass[1].Name != classStandard {
+		t.Fatalf("ByClass = %+v, want expedite then standard (config order)", m.ByClass)
+	}
+	if m.ByClass[0].Completed != 1 || *m.ByClass[0].CycleTimeP50Hours != 10*24 {
+		t.Errorf("expedite = %+v, want 1 task with a 10-day cycle time", m.ByClass[0])
+	}
+	if m.ByClass[1].Completed != 9 || *m.ByClass[1].CycleTimeP95Hours != 9*24 {
+		t.Errorf("standard = %+v, want 9 tasks with a p95 of 9 days", m.ByClass[1])
+	}
+	if len(m.ByTag) != 1 || m.ByTag[0].Name != "api" || m.ByTag[0].Completed != 9 {
+		t.Errorf("ByTag = %+v, want api with 9 tasks", m.ByTag)
+	}
+}
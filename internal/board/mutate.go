@@ -18,10 +18,17 @@ type DeleteResult struct {
 	Warnings []string // dependent task warnings
 }
 
-// Delete soft-deletes (archives) a task. It validates claim ownership and
-// collects warnings about dependent tasks. The operation is idempotent —
-// archiving an already-archived task is a no-op.
-func Delete(cfg *config.Config, id int, claimant string, now time.Time) (*DeleteResult, error) {
+// Delete soft-deletes (archives) a task. It validates the expected revision
+// (if ifRev is non-empty) and claim ownership, and collects warnings about
+// dependent tasks. The operation is idempotent — archiving an
+// already-archived task is a no-op.
+func Delete(cfg *config.Config, id int, claimant, ifRev string, now time.Time) (*DeleteResult, error) {
+	unlock, err := Lock(cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock() //nolint:errcheck // best-effort unlock
+
 	path, err := task.FindByID(cfg.TasksPath(), id)
 	if err != nil {
 		return nil, err
@@ -32,6 +39,10 @@ func Delete(cfg *config.Config, id int, claimant string, now time.Time) (*Delete
 		return nil, err
 	}
 
+	if err := task.CheckRev(t, ifRev); err != nil {
+		return nil, err
+	}
+
 	// Validate claim ownership.
 	if err := task.CheckClaim(t, claimant, cfg.ClaimTimeoutDuration()); err != nil {
 		return nil, err
@@ -45,16 +56,24 @@ func Delete(cfg *config.Config, id int, claimant string, now time.Time) (*Delete
 		return &DeleteResult{Task: t, Warnings: warnings}, nil
 	}
 
+	before := TakeSnapshot(t)
 	oldStatus := t.Status
 	t.Status = config.ArchivedStatus
 	task.UpdateTimestamps(t, oldStatus, t.Status, cfg)
 	t.Updated = now
 
+	hooks := newTaskHooks(cfg, config.HookArchive, before, t, Actor(claimant, t))
+	if err := hooks.runPre(t); err != nil {
+		return nil, err
+	}
+
 	if err := task.Write(path, t); err != nil {
 		return nil, fmt.Errorf("writing task: %w", err)
 	}
 
-	LogMutation(cfg.Dir(), "delete", t.ID, t.Title)
+	LogChange(cfg.Dir(), "delete", Actor(claimant, t), before, t, t.Title)
+	recordPresence(cfg.Dir(), claimant, "delete", t.ID, now)
+	hooks.runPost(t)
 
 	return &DeleteResult{Task: t, Warnings: warnings}, nil
 }
@@ -65,16 +84,19 @@ type MoveParams struct {
 	NewStatus string
 	Claimant  string // for claim validation; also set as claim if SetClaim is true
 	SetClaim  bool   // whether to update the task's claim fields
+	IfRev     string // if non-empty, fail with CONFLICT unless the task is at this revision
 }
 
 // MoveResult is returned after a successful move.
 type MoveResult struct {
-	Task      *task.Task
-	OldStatus string   // empty if idempotent (already at target status)
-	Warnings  []string // e.g., "task is blocked"
+	Task        *task.Task
+	OldStatus   string   // empty if idempotent (already at target status)
+	Warnings    []string // e.g., "task is blocked"
+	ParentsDone []int    // parents moved to done by parent_done: auto
 }
 
-// Move changes a task's status. It validates claim ownership, enforces WIP
+// Move changes a task's status. It validates the expected revision and claim
+// ownership, enforces configured transitions, WIP
 // limits (including class-of-service awareness), and checks require_claim
 // for the target status. The operation is idempotent — moving to the current
 // status is a no-op.
@@ -83,6 +105,12 @@ func Move(cfg *config.Config, params MoveParams, now time.Time) (*MoveResult, er
 		return nil, err
 	}
 
+	unlock, err := Lock(cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock() //nolint:errcheck // best-effort unlock
+
 	path, err := task.FindByID(cfg.TasksPath(), params.ID)
 	if err != nil {
 		return nil, err
@@ -93,6 +121,10 @@ func Move(cfg *config.Config, params MoveParams, now time.Time) (*MoveResult, er
 		return nil, err
 	}
 
+	if err := task.CheckRev(t, params.IfRev); err != nil {
+		return nil, err
+	}
+
 	// Validate claim ownership.
 	if err := task.CheckClaim(t, params.Claimant, cfg.ClaimTimeoutDuration()); err != nil {
 		return nil, err
@@ -108,6 +140,11 @@ func Move(cfg *config.Config, params MoveParams, now time.Time) (*MoveResult, er
 		return nil, task.ValidateClaimRequired(params.NewStatus)
 	}
 
+	// Workflow transitions and guards.
+	if err := CheckTransition(cfg, t, t.Status, params.NewStatus); err != nil {
+		return nil, err
+	}
+
 	// WIP limit enforcement (class-aware).
 	if err := enforceMoveWIP(cfg, t, params.NewStatus); err != nil {
 		return nil, err
@@ -119,6 +156,7 @@ func Move(cfg *config.Config, params MoveParams, now time.Time) (*MoveResult, er
 		warnings = append(warnings, fmt.Sprintf("task #%d is blocked (%s)", t.ID, t.BlockReason))
 	}
 
+	before := TakeSnapshot(t)
 	oldStatus := t.Status
 	t.Status = params.NewStatus
 	task.UpdateTimestamps(t, oldStatus, params.NewStatus, cfg)
@@ -131,13 +169,23 @@ func Move(cfg *config.Config, params MoveParams, now time.Time) (*MoveResult, er
 
 	t.Updated = now
 
+	hooks := newTaskHooks(cfg, config.HookMove, before, t, Actor(params.Claimant, t))
+	if err := hooks.runPre(t); err != nil {
+		return nil, err
+	}
+
 	if err := task.Write(path, t); err != nil {
 		return nil, fmt.Errorf("writing task: %w", err)
 	}
 
-	LogMutation(cfg.Dir(), "move", t.ID, oldStatus+" -> "+params.NewStatus)
+	LogChange(cfg.Dir(), "move", Actor(params.Claimant, t), before, t, oldStatus+" -> "+params.NewStatus)
+	recordPresence(cfg.Dir(), params.Claimant, "move", t.ID, now)
+	hooks.runPost(t)
 
-	return &MoveResult{Task: t, OldStatus: oldStatus, Warnings: warnings}, nil
+	parentsDone, parentWarnings := completeParents(cfg, t, oldStatus, params.Claimant, now)
+	return &MoveResult{
+		Task: t, OldStatus: oldStatus, Warnings: append(warnings, parentWarnings...), ParentsDone: parentsDone,
+	}, nil
 }
 
 // enforceMoveWIP checks WIP limits, considering class of service.
@@ -185,14 +233,18 @@ func enforceClassWIP(cfg *config.Config, t *task.Task, newStatus string) error {
 }
 
 // enforceCreateWIP checks WIP limits for a new task (currentStatus is empty).
-func enforceCreateWIP(cfg *config.Config, t *task.Task) error {
+// pending are new tasks not written yet (earlier items of an import), which
+// count toward the limits like the tasks on disk.
+func enforceCreateWIP(cfg *config.Config, t *task.Task, pending []*task.Task) error {
+	allTasks, _, err := task.ReadAllLenient(cfg.TasksPath())
+	if err != nil {
+		return fmt.Errorf("reading tasks for WIP check: %w", err)
+	}
+	allTasks = append(allTasks, pending...)
+
 	if t.Class != "" && len(cfg.Classes) > 0 {
 		classConf := cfg.ClassByName(t.Class)
 		if classConf != nil && classConf.WIPLimit > 0 {
-			allTasks, _, err := task.ReadAllLenient(cfg.TasksPath())
-			if err != nil {
-				return fmt.Errorf("reading tasks for class WIP check: %w", err)
-			}
 			count := countByClass(allTasks, t.Class, t.ID)
 			if count >= classConf.WIPLimit {
 				return task.ValidateClassWIPExceeded(t.Class, classConf.WIPLimit, count)
@@ -203,10 +255,6 @@ func enforceCreateWIP(cfg *config.Config, t *task.Task) error {
 		}
 	}
 
-	allTasks, _, err := task.ReadAllLenient(cfg.TasksPath())
-	if err != nil {
-		return fmt.Errorf("reading tasks for WIP check: %w", err)
-	}
 	counts := CountByStatus(allTasks)
 	// Empty currentStatus: new task is not in any column yet.
 	return CheckWIPLimit(cfg, counts, t.Status, "")
@@ -237,7 +285,10 @@ type CreateParams struct {
 	Estimate  string
 	Parent    *int
 	DependsOn []int
-	Claimant  string // if non-empty, sets claim on the task
+	Claimant  string         // if non-empty, sets claim on the task
+	Custom    map[string]any // custom field values (see ParseFieldAssignments)
+
+	ExternalID string // ID of the task in the system it was imported from
 }
 
 // CreateResult is returned after a successful create.
@@ -246,35 +297,32 @@ type CreateResult struct {
 	Path string
 }
 
-// Create creates a new task. The caller is responsible for:
-//   - Acquiring a file lock to prevent concurrent creates
-//   - Loading/reloading config to get the current NextID
+// Create creates a new task. It holds the board lock for the whole operation
+// and refreshes cfg.NextID from disk first, so concurrent creates from other
+// processes never hand out duplicate IDs.
 //
 // After Create returns, cfg.NextID is incremented and cfg is saved to disk.
 func Create(cfg *config.Config, params CreateParams, now time.Time) (*CreateResult, error) {
-	t := &task.Task{
-		ID:       cfg.NextID,
-		Title:    params.Title,
-		Status:   cfg.Defaults.Status,
-		Priority: cfg.Defaults.Priority,
-		Class:    cfg.Defaults.Class,
-		Created:  now,
-		Updated:  now,
-	}
-
-	// Apply non-zero params, validating against config.
-	if err := applyCreateParams(cfg, t, params, now); err != nil {
+	unlock, err := Lock(cfg)
+	if err != nil {
 		return nil, err
 	}
+	defer unlock() //nolint:errcheck // best-effort unlock
 
-	// Validate dependency references.
-	if err := validateDeps(cfg, t); err != nil {
+	return create(cfg, params, now)
+}
+
+// create is Create for callers that already hold the board lock.
+func create(cfg *config.Config, params CreateParams, now time.Time) (*CreateResult, error) {
+	refreshNextID(cfg)
+
+	t, err := validateCreate(cfg, cfg.NextID, params, nil, now)
+	if err != nil {
 		return nil, err
 	}
 
-	// WIP limit enforcement (class-aware). For new tasks, the "current"
-	// status is empty because the task doesn't exist in any column yet.
-	if err := enforceCreateWIP(cfg, t); err != nil {
+	hooks := newTaskHooks(cfg, config.HookCreate, nil, t, Actor(params.Claimant, t))
+	if err := hooks.runPre(t); err != nil {
 		return nil, err
 	}
 
@@ -294,11 +342,63 @@ func Create(cfg *config.Config, params CreateParams, now time.Time) (*CreateResu
 		return nil, fmt.Errorf("saving config: %w", err)
 	}
 
-	LogMutation(cfg.Dir(), "create", t.ID, t.Title)
+	LogChange(cfg.Dir(), "create", Actor(params.Claimant, t), nil, t, t.Title)
+	recordPresence(cfg.Dir(), params.Claimant, "create", t.ID, now)
+	hooks.runPost(t)
 
 	return &CreateResult{Task: t, Path: path}, nil
 }
 
+// validateCreate builds the task that create would write with ID id and
+// checks it against the board: field values, dependency references and WIP
+// limits. pending are tasks validated earlier in the same batch but not
+// written yet; they count toward WIP limits. Must be called with the board
+// lock held.
+func validateCreate(cfg *config.Config, id int, params CreateParams, pending []*task.Task, now time.Time) (*task.Task, error) {
+	t := &task.Task{
+		ID:       id,
+		Title:    params.Title,
+		Status:   cfg.Defaults.Status,
+		Priority: cfg.Defaults.Priority,
+		Class:    cfg.Defaults.Class,
+		Created:  now,
+		Updated:  now,
+	}
+
+	// Apply non-zero params, validating against config.
+	if err := applyCreateParams(cfg, t, params, now); err != nil {
+		return nil, err
+	}
+	if err := applyFieldDefaults(cfg, t); err != nil {
+		return nil, err
+	}
+
+	// Validate dependency references.
+	if err := validateDeps(cfg, t); err != nil {
+		return nil, err
+	}
+
+	// WIP limit enforcement (class-aware). For new tasks, the "current"
+	// status is empty because the task doesn't exist in any column yet.
+	if err := enforceCreateWIP(cfg, t, pending); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// refreshNextID advances cfg.NextID to the value on disk if another process
+// has created tasks since cfg was loaded. Must be called with the board lock
+// held. A missing or unreadable config leaves cfg untouched.
+func refreshNextID(cfg *config.Config) {
+	onDisk, err := config.Load(cfg.Dir())
+	if err != nil {
+		return
+	}
+	if onDisk.NextID > cfg.NextID {
+		cfg.NextID = onDisk.NextID
+	}
+}
+
 // applyCreateParams applies non-zero CreateParams fields to the task.
 func applyCreateParams(cfg *config.Config, t *task.Task, p CreateParams, now time.Time) error {
 	if p.Status != "" {
@@ -344,6 +444,10 @@ func applyCreateParams(cfg *config.Config, t *task.Task, p CreateParams, now tim
 		t.ClaimedBy = p.Claimant
 		t.ClaimedAt = &now
 	}
+	for name, v := range p.Custom {
+		t.SetCustom(name, v)
+	}
+	t.ExternalID = p.ExternalID
 	return nil
 }
 
@@ -359,13 +463,48 @@ func validateDeps(cfg *config.Config, t *task.Task) error {
 			return err
 		}
 	}
+	if t.Parent == nil && len(t.DependsOn) == 0 {
+		return nil
+	}
+	return validateNoCycles(cfg, t)
+}
+
+// validateNoCycles rejects references from t that would make it (indirectly)
+// depend on itself or be its own ancestor, given the other tasks on disk.
+func validateNoCycles(cfg *config.Config, t *task.Task) error {
+	tasks, _, err := task.ReadAllLenient(cfg.TasksPath())
+	if err != nil {
+		return err
+	}
+	deps := make(map[int][]int, len(tasks))
+	parents := make(map[int]int, len(tasks))
+	for _, other := range tasks {
+		deps[other.ID] = other.DependsOn
+		if other.Parent != nil {
+			parents[other.ID] = *other.Parent
+		}
+	}
+	deps[t.ID] = t.DependsOn
+	delete(parents, t.ID)
+	if t.Parent != nil {
+		parents[t.ID] = *t.Parent
+	}
+
+	if cycle := dependencyCycle(deps, t.ID); cycle != nil {
+		return task.ValidateDependencyCycle(cycle)
+	}
+	if cycle := parentCycle(parents, t.ID); cycle != nil {
+		return fmt.Errorf("invalid parent: %w", task.ValidateDependencyCycle(cycle))
+	}
 	return nil
 }
 
 // EditResult is returned after a successful edit.
 type EditResult struct {
-	Task    *task.Task
-	NewPath string
+	Task        *task.Task
+	NewPath     string
+	Warnings    []string // e.g., a parent that parent_done: auto could not move
+	ParentsDone []int    // parents moved to done by parent_done: auto
 }
 
 // Edit modifies an existing task. It handles find, read, claim validation,
@@ -376,9 +515,17 @@ type EditResult struct {
 // changes were made. If no changes were made, Edit returns a NoChanges error.
 //
 // If release is true, claim checks are bypassed (intent is to release a claim).
-func Edit(cfg *config.Config, id int, claimant string, release bool,
+// If ifRev is non-empty, Edit fails with a Conflict error unless the task is
+// still at that revision.
+func Edit(cfg *config.Config, id int, claimant, ifRev string, release bool,
 	applyFn func(t *task.Task) (changed bool, err error), now time.Time,
 ) (*EditResult, error) {
+	unlock, err := Lock(cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock() //nolint:errcheck // best-effort unlock
+
 	path, err := task.FindByID(cfg.TasksPath(), id)
 	if err != nil {
 		return nil, err
@@ -389,6 +536,10 @@ func Edit(cfg *config.Config, id int, claimant string, release bool,
 		return nil, err
 	}
 
+	if err = task.CheckRev(t, ifRev); err != nil {
+		return nil, err
+	}
+
 	// Claim validation (release bypasses this).
 	if !release {
 		if err = task.CheckClaim(t, claimant, cfg.ClaimTimeoutDuration()); err != nil {
@@ -400,6 +551,8 @@ func Edit(cfg *config.Config, id int, claimant string, release bool,
 		}
 	}
 
+	before := TakeSnapshot(t)
+	actor := Actor(claimant, t)
 	oldTitle := t.Title
 	oldStatus := t.Status
 	wasBlocked := t.Blocked
@@ -414,23 +567,37 @@ func Edit(cfg *config.Config, id int, claimant string, release bool,
 		return nil, clierr.New(clierr.NoChanges, "no changes specified")
 	}
 
-	// Post-validation.
+	// Post-validation. Transitions are checked here rather than in
+	// validateEditPost so that undo can restore any earlier status.
+	if t.Status != oldStatus {
+		if err = CheckTransition(cfg, t, oldStatus, t.Status); err != nil {
+			return nil, err
+		}
+	}
 	if err = validateEditPost(cfg, t, oldStatus, claimant); err != nil {
 		return nil, err
 	}
 
 	t.Updated = now
 
+	hooks := newTaskHooks(cfg, config.HookEdit, before, t, actor)
+	if err = hooks.runPre(t); err != nil {
+		return nil, err
+	}
+
 	newPath, err := task.WriteAndRename(path, t, oldTitle)
 	if err != nil {
 		return nil, err
 	}
 
 	// Log transitions.
-	LogMutation(cfg.Dir(), "edit", t.ID, t.Title)
+	LogChange(cfg.Dir(), "edit", actor, before, t, t.Title)
 	logEditTransitions(cfg, t, wasBlocked, wasClaimedBy)
+	recordPresence(cfg.Dir(), claimant, "edit", t.ID, now)
+	hooks.runPost(t)
 
-	return &EditResult{Task: t, NewPath: newPath}, nil
+	parentsDone, warnings := completeParents(cfg, t, oldStatus, claimant, now)
+	return &EditResult{Task: t, NewPath: newPath, Warnings: warnings, ParentsDone: parentsDone}, nil
 }
 
 // validateEditPost runs post-edit validations: deps, require_claim for new
@@ -480,6 +647,7 @@ type HandoffParams struct {
 	BlockReason  string
 	Note         string
 	AddTimestamp bool
+	IfRev        string // if non-empty, fail with CONFLICT unless the task is at this revision
 }
 
 // Handoff executes the handoff workflow for a task.
@@ -490,6 +658,12 @@ func Handoff(cfg *config.Config, params HandoffParams, now time.Time) (*task.Tas
 		return nil, clierr.New(clierr.InvalidInput, "claim name is required")
 	}
 
+	unlock, err := Lock(cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock() //nolint:errcheck // best-effort unlock
+
 	path, err := task.FindByID(cfg.TasksPath(), params.ID)
 	if err != nil {
 		return nil, err
@@ -500,6 +674,10 @@ func Handoff(cfg *config.Config, params HandoffParams, now time.Time) (*task.Tas
 		return nil, err
 	}
 
+	if err = task.CheckRev(t, params.IfRev); err != nil {
+		return nil, err
+	}
+
 	// Validate claim ownership.
 	if err = task.CheckClaim(t, params.Claimant, cfg.ClaimTimeoutDuration()); err != nil {
 		return nil, err
@@ -511,6 +689,8 @@ func Handoff(cfg *config.Config, params HandoffParams, now time.Time) (*task.Tas
 		return nil, clierr.New(clierr.InvalidInput, "board has no 'review' status; add one to use handoff")
 	}
 
+	before := TakeSnapshot(t)
+
 	// Move to review (skip if already there).
 	oldStatus := t.Status
 	if t.Status != reviewStatus {
@@ -525,29 +705,22 @@ func Handoff(cfg *config.Config, params HandoffParams, now time.Time) (*task.Tas
 		task.UpdateTimestamps(t, oldStatus, reviewStatus, cfg)
 	}
 
-	// Apply claim (refresh).
-	t.ClaimedBy = params.Claimant
-	t.ClaimedAt = &now
+	applyHandoffChanges(t, params, now)
 
-	// Optionally block.
-	if params.BlockReason != "" {
-		t.Blocked = true
-		t.BlockReason = params.BlockReason
+	// Transition guards see the task with the note appended.
+	if oldStatus != t.Status {
+		if err = CheckTransition(cfg, t, oldStatus, t.Status); err != nil {
+			return nil, err
+		}
 	}
 
-	// Append note.
-	if params.Note != "" {
-		t.Body = AppendBody(t.Body, params.Note, params.AddTimestamp)
-	}
+	t.Updated = now
 
-	// Release claim if requested.
-	if params.Release {
-		t.ClaimedBy = ""
-		t.ClaimedAt = nil
+	hooks := newTaskHooks(cfg, config.HookHandoff, before, t, params.Claimant)
+	if err = hooks.runPre(t); err != nil {
+		return nil, err
 	}
 
-	t.Updated = now
-
 	if err = task.Write(path, t); err != nil {
 		return nil, fmt.Errorf("writing task: %w", err)
 	}
@@ -556,17 +729,38 @@ func Handoff(cfg *config.Config, params HandoffParams, now time.Time) (*task.Tas
 	if oldStatus != t.Status {
 		LogMutation(cfg.Dir(), "move", t.ID, oldStatus+" -> "+t.Status)
 	}
-	LogMutation(cfg.Dir(), "handoff", t.ID, t.Title)
+	LogChange(cfg.Dir(), "handoff", params.Claimant, before, t, t.Title)
+	recordPresence(cfg.Dir(), params.Claimant, "handoff", t.ID, now)
 	if t.Blocked {
 		LogMutation(cfg.Dir(), "block", t.ID, t.BlockReason)
 	}
 	if t.ClaimedBy == "" {
 		LogMutation(cfg.Dir(), "release", t.ID, t.Title)
 	}
+	hooks.runPost(t)
 
 	return t, nil
 }
 
+// applyHandoffChanges refreshes the claim, then blocks the task, appends the
+// note and releases the claim as requested.
+func applyHandoffChanges(t *task.Task, params HandoffParams, now time.Time) {
+	t.ClaimedBy = params.Claimant
+	t.ClaimedAt = &now
+
+	if params.BlockReason != "" {
+		t.Blocked = true
+		t.BlockReason = params.BlockReason
+	}
+	if params.Note != "" {
+		t.Body = AppendBody(t.Body, params.Note, params.AddTimestamp)
+	}
+	if params.Release {
+		t.ClaimedBy = ""
+		t.ClaimedAt = nil
+	}
+}
+
 // AppendBody appends text to the existing body, optionally prefixed with a timestamp line.
 func AppendBody(existing, text string, addTimestamp bool) string {
 	var b strings.Builder
@@ -588,9 +782,11 @@ type PickAndClaimParams struct {
 	StatusFilter string
 	MoveTarget   string
 	Tags         []string
+	Query        string // optional query the picked task must match (see ParseQuery)
 }
 
-// PickAndClaim finds the highest-priority task and atomically claims it. Any
+// PickAndClaim finds the highest-priority task and atomically claims it under
+// the board lock. Any
 // warnings from reading malformed task files are returned so the caller can
 // surface them.
 func PickAndClaim(cfg *config.Config, params PickAndClaimParams, now time.Time) (*task.Task, string, []task.ReadWarning, error) {
@@ -607,6 +803,21 @@ func PickAndClaim(cfg *config.Config, params PickAndClaimParams, now time.Time)
 			return nil, "", nil, err
 		}
 	}
+	var query *Query
+	if params.Query != "" {
+		var err error
+		if query, err = ParseQuery(params.Query, cfg); err != nil {
+			return nil, "", nil, err
+		}
+	}
+
+	// Hold the lock across selection and write so two agents picking at the
+	// same moment can never claim the same task.
+	unlock, err := Lock(cfg)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	defer unlock() //nolint:errcheck // best-effort unlock
 
 	allTasks, warnings, err := task.ReadAllLenient(cfg.TasksPath())
 	if err != nil {
@@ -616,6 +827,7 @@ func PickAndClaim(cfg *config.Config, params PickAndClaimParams, now time.Time)
 	opts := PickOptions{
 		ClaimTimeout: cfg.ClaimTimeoutDuration(),
 		Tags:         params.Tags,
+		Query:        query,
 	}
 	if params.StatusFilter != "" {
 		opts.Statuses = []string{params.StatusFilter}
@@ -626,23 +838,25 @@ func PickAndClaim(cfg *config.Config, params PickAndClaimParams, now time.Time)
 		return nil, "", warnings, clierr.New(clierr.NothingToPick, "no unblocked, unclaimed tasks found")
 	}
 
+	before := TakeSnapshot(picked)
+
 	// Claim the task.
 	picked.ClaimedBy = params.Claimant
 	picked.ClaimedAt = &now
 
 	// Optionally move the task.
-	oldStatus := ""
-	if params.MoveTarget != "" && picked.Status != params.MoveTarget {
-		if enforceErr := enforceClassWIP(cfg, picked, params.MoveTarget); enforceErr != nil {
-			return nil, "", warnings, enforceErr
-		}
-		oldStatus = picked.Status
-		task.UpdateTimestamps(picked, oldStatus, params.MoveTarget, cfg)
-		picked.Status = params.MoveTarget
+	oldStatus, err := movePicked(cfg, picked, params.MoveTarget)
+	if err != nil {
+		return nil, "", warnings, err
 	}
 
 	picked.Updated = now
 
+	hooks := newTaskHooks(cfg, config.HookClaim, before, picked, params.Claimant)
+	if err = hooks.runPre(picked); err != nil {
+		return nil, "", warnings, err
+	}
+
 	// Write the task back.
 	path, err := task.FindByID(cfg.TasksPath(), picked.ID)
 	if err != nil {
@@ -652,10 +866,31 @@ func PickAndClaim(cfg *config.Config, params PickAndClaimParams, now time.Time)
 		return nil, "", warnings, fmt.Errorf("writing task: %w", err)
 	}
 
-	LogMutation(cfg.Dir(), "claim", picked.ID, params.Claimant)
+	LogChange(cfg.Dir(), "claim", params.Claimant, before, picked, params.Claimant)
+	recordPresence(cfg.Dir(), params.Claimant, "pick", picked.ID, now)
 	if oldStatus != "" {
 		LogMutation(cfg.Dir(), "move", picked.ID, oldStatus+" -> "+picked.Status)
 	}
+	hooks.runPost(picked)
 
 	return picked, oldStatus, warnings, nil
 }
+
+// movePicked moves a picked task to target (if set and different), enforcing
+// transitions and WIP limits. It returns the old status, or "" if the task
+// was not moved.
+func movePicked(cfg *config.Config, picked *task.Task, target string) (string, error) {
+	if target == "" || picked.Status == target {
+		return "", nil
+	}
+	if err := CheckTransition(cfg, picked, picked.Status, target); err != nil {
+		return "", err
+	}
+	if err := enforceClassWIP(cfg, picked, target); err != nil {
+		return "", err
+	}
+	oldStatus := picked.Status
+	task.UpdateTimestamps(picked, oldStatus, target, cfg)
+	picked.Status = target
+	return oldStatus, nil
+}
@@ -1,6 +1,7 @@
 package board_test
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
@@ -8,6 +9,7 @@ import (
 	"time"
 
 	"github.com/antopolskiy/kanban-md/internal/board"
+	"github.com/antopolskiy/kanban-md/internal/clierr"
 	"github.com/antopolskiy/kanban-md/internal/config"
 	"github.com/antopolskiy/kanban-md/internal/task"
 )
@@ -162,3 +164,68 @@ func TestPickAndClaim_NoCandidates(t *testing.T) {
 		t.Errorf("expected nil task, got %v", picked)
 	}
 }
+
+func TestMutations_IfRevConflict(t *testing.T) {
+	cfg, _ := setupMutateBoard(t)
+	path := filepath.Join(cfg.TasksPath(), "1.md")
+	tk := &task.Task{ID: 1, Title: "test", Status: "todo"}
+	if err := task.Write(path, tk); err != nil {
+		t.Fatal(err)
+	}
+	stale := tk.Rev
+
+	// Someone else changes the task after stale was observed.
+	tk.Priority = "high"
+	if err := task.Write(path, tk); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	ops := map[string]func() error{
+		"edit": func() error {
+			_, err := board.Edit(cfg, 1, "", stale, false, func(t *task.Task) (bool, error) {
+				t.Title = "changed"
+				return true, nil
+			}, now)
+			return err
+		},
+		"move": func() error {
+			_, err := board.Move(cfg, board.MoveParams{ID: 1, NewStatus: "done", IfRev: stale}, now)
+			return err
+		},
+		"handoff": func() error {
+			_, err := board.Handoff(cfg, board.HandoffParams{ID: 1, Claimant: "agent-a", IfRev: stale}, now)
+			return err
+		},
+		"delete": func() error {
+			_, err := board.Delete(cfg, 1, "", stale, now)
+			return err
+		},
+	}
+	for name, op := range ops {
+		t.Run(name, func(t *testing.T) {
+			err := op()
+			var cliErr *clierr.Error
+			if !errors.As(err, &cliErr) || cliErr.Code != clierr.Conflict {
+				t.Fatalf("expected CONFLICT, got %v", err)
+			}
+		})
+	}
+
+	got, err := task.Read(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Rev != tk.Rev {
+		t.Error("task file changed despite conflicts")
+	}
+
+	// The current revision is accepted.
+	res, err := board.Move(cfg, board.MoveParams{ID: 1, NewStatus: "done", IfRev: tk.Rev}, now)
+	if err != nil {
+		t.Fatalf("Move with current rev: %v", err)
+	}
+	if res.Task.Rev == tk.Rev {
+		t.Error("Move should return the task's new rev")
+	}
+}
@@ -13,6 +13,7 @@ type PickOptions struct {
 	Statuses     []string      // status columns to pick from (empty = all non-terminal)
 	ClaimTimeout time.Duration // claim expiration for filtering
 	Tags         []string      // optional tag filter (OR logic: task must have at least one)
+	Query        *Query        // optional structured query the task must match
 }
 
 // Pick finds the highest-priority unclaimed, unblocked task matching criteria.
@@ -29,7 +30,7 @@ func Pick(cfg *config.Config, tasks []*task.Task, opts PickOptions) *task.Task {
 	return candidates[0]
 }
 
-// pickCandidates filters tasks by status, claim, block, and tag.
+// pickCandidates filters tasks by status, claim, block, tag, and query.
 func pickCandidates(cfg *config.Config, tasks []*task.Task, opts PickOptions) []*task.Task {
 	statuses := opts.Statuses
 	if len(statuses) == 0 {
@@ -50,6 +51,9 @@ func pickCandidates(cfg *config.Config, tasks []*task.Task, opts PickOptions) []
 		if len(opts.Tags) > 0 && !hasAnyTag(t.Tags, opts.Tags) {
 			continue
 		}
+		if opts.Query != nil && !opts.Query.Match(t) {
+			continue
+		}
 		candidates = append(candidates, t)
 	}
 	return candidates
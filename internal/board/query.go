@@ -0,0 +1,775 @@
+package board
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/antopolskiy/kanban-md/internal/clierr"
+	"github.com/antopolskiy/kanban-md/internal/config"
+	"github.com/antopolskiy/kanban-md/internal/date"
+	"github.com/antopolskiy/kanban-md/internal/task"
+)
+
+// Query is a parsed task query such as
+//
+//	status:todo,review AND (tag:backend OR priority>=high) AND due<2026-11-01 AND NOT claimed
+//
+// Terms are FIELD OP VALUE, where OP is one of : = != < <= > >=. ":" and "="
+// match any of a comma-separated list of values, "!=" matches none of them,
+// and the ordering operators compare numbers, dates (YYYY-MM-DD or "today"),
+// and statuses and priorities by their configured order. has:FIELD matches
+// tasks where FIELD is set. A bare boolean field name (blocked, claimed,
+// overdue) is true for tasks with that property; any other bare word or
+// quoted string is a case-insensitive search across title, body and tags.
+//
+// Terms combine with AND, OR and NOT (case-insensitive) and parentheses; NOT
+// binds tightest, then AND, then OR. Adjacent terms without an operator are
+// ANDed.
+type Query struct {
+	src    string
+	root   queryNode
+	fields map[string]bool // fields referenced by the query
+	cfg    *config.Config
+}
+
+// ParseQuery parses src into a Query evaluated against cfg. Status, priority
+// and class values are validated against the board configuration.
+func ParseQuery(src string, cfg *config.Config) (*Query, error) {
+	tokens, err := lexQuery(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &queryParser{src: src, tokens: tokens, cfg: cfg, fields: make(map[string]bool)}
+	if p.peek().kind == tokEOF {
+		return nil, p.errorf(p.peek(), "empty query")
+	}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, p.errorf(tok, "unexpected %s", tok)
+	}
+	return &Query{src: src, root: root, fields: p.fields, cfg: cfg}, nil
+}
+
+// Match reports whether t satisfies the query.
+func (q *Query) Match(t *task.Task) bool {
+	return q.root.match(t, q.cfg)
+}
+
+// UsesField reports whether the query references the named field, e.g. to
+// decide whether the default exclusion of archived tasks should apply.
+func (q *Query) UsesField(name string) bool {
+	return q.fields[name]
+}
+
+// String returns the query source.
+func (q *Query) String() string {
+	return q.src
+}
+
+// --- Lexer ---
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokWord
+	tokString
+	tokOp
+	tokComma
+	tokLParen
+	tokRParen
+)
+
+type queryToken struct {
+	kind tokenKind
+	text string
+	pos  int // byte offset in the source
+}
+
+func (t queryToken) String() string {
+	switch t.kind {
+	case tokEOF:
+		return "end of query"
+	case tokString:
+		return strconv.Quote(t.text)
+	default:
+		return fmt.Sprintf("%q", t.text)
+	}
+}
+
+const querySpecial = " \t\r\n():=!<>,\""
+
+// singleCharTokens are the tokens consisting of exactly one character.
+var singleCharTokens = map[byte]tokenKind{
+	'(': tokLParen,
+	')': tokRParen,
+	',': tokComma,
+	':': tokOp,
+	'=': tokOp,
+}
+
+func lexQuery(src string) ([]queryToken, error) {
+	var tokens []queryToken
+	for i := 0; i < len(src); {
+		c := src[i]
+		if kind, ok := singleCharTokens[c]; ok {
+			tokens = append(tokens, queryToken{kind, string(c), i})
+			i++
+			continue
+		}
+		switch {
+		case strings.IndexByte(" \t\r\n", c) >= 0:
+			i++
+		case strings.IndexByte("!<>", c) >= 0:
+			tok, err := lexComparison(src, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, tok)
+			i += len(tok.text)
+		case c == '"':
+			end := strings.IndexByte(src[i+1:], '"')
+			if end < 0 {
+				return nil, queryError(src, i, "unterminated string")
+			}
+			tokens = append(tokens, queryToken{tokString, src[i+1 : i+1+end], i})
+			i += end + 2
+		default:
+			start := i
+			for i < len(src) && strings.IndexByte(querySpecial, src[i]) < 0 {
+				i++
+			}
+			tokens = append(tokens, queryToken{tokWord, src[start:i], start})
+		}
+	}
+	return append(tokens, queryToken{tokEOF, "", len(src)}), nil
+}
+
+// lexComparison lexes one of != < <= > >= starting at src[i].
+func lexComparison(src string, i int) (queryToken, error) {
+	if i+1 < len(src) && src[i+1] == '=' {
+		return queryToken{tokOp, src[i : i+2], i}, nil
+	}
+	if src[i] == '!' {
+		return queryToken{}, queryError(src, i, `expected "!="`)
+	}
+	return queryToken{tokOp, src[i : i+1], i}, nil
+}
+
+func queryError(src string, pos int, msg string) *clierr.Error {
+	return clierr.Newf(clierr.InvalidQuery, "invalid query: %s at position %d", msg, pos+1).
+		WithDetails(map[string]any{"query": src, "position": pos + 1})
+}
+
+// --- Parser ---
+
+type queryParser struct {
+	src    string
+	tokens []queryToken
+	pos    int
+	cfg    *config.Config
+	fields map[string]bool
+}
+
+func (p *queryParser) peek() queryToken { return p.tokens[p.pos] }
+
+func (p *queryParser) next() queryToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *queryParser) errorf(tok queryToken, format string, args ...any) *clierr.Error {
+	return queryError(p.src, tok.pos, fmt.Sprintf(format, args...))
+}
+
+// isKeyword reports whether tok is the given boolean keyword.
+func isKeyword(tok queryToken, kw string) bool {
+	return tok.kind == tokWord && strings.EqualFold(tok.text, kw)
+}
+
+func (p *queryParser) parseOr() (queryNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for isKeyword(p.peek(), "or") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *queryParser) parseAnd() (queryNode, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok := p.peek()
+		if isKeyword(tok, "and") {
+			p.next()
+		} else if tok.kind == tokEOF || tok.kind == tokRParen || isKeyword(tok, "or") {
+			return left, nil
+		}
+		// Adjacent terms are implicitly ANDed.
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left, right}
+	}
+}
+
+func (p *queryParser) parseNot() (queryNode, error) {
+	if isKeyword(p.peek(), "not") {
+		p.next()
+		x, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{x}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *queryParser) parsePrimary() (queryNode, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokLParen:
+		x, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokRParen {
+			return nil, p.errorf(closing, "expected \")\", got %s", closing)
+		}
+		return x, nil
+	case tokString:
+		return textNode{tok.text}, nil
+	case tokWord:
+		if isKeyword(tok, "and") || isKeyword(tok, "or") {
+			return nil, p.errorf(tok, "unexpected %s", tok)
+		}
+		if p.peek().kind == tokOp {
+			return p.parseTerm(tok)
+		}
+		if f, ok := p.lookupField(tok.text); ok && f.flag != nil {
+			p.fields[f.name] = true
+			return flagNode{f, true}, nil
+		}
+		return textNode{tok.text}, nil
+	default:
+		return nil, p.errorf(tok, "unexpected %s", tok)
+	}
+}
+
+// parseTerm parses OP VALUE[,VALUE...] after the field name tok.
+func (p *queryParser) parseTerm(nameTok queryToken) (queryNode, error) {
+	op := p.next().text
+	values, err := p.parseValues()
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.EqualFold(nameTok.text, "has") {
+		return p.hasTerm(nameTok, op, values)
+	}
+	f, ok := p.lookupField(nameTok.text)
+	if !ok {
+		valid := queryFieldNames()
+		valid = append(valid, p.cfg.FieldNames()...)
+		return nil, p.errorf(nameTok, "unknown field %q (valid: %s)", nameTok.text, strings.Join(valid, ", "))
+	}
+	p.fields[f.name] = true
+
+	if isOrdering(op) {
+		if f.compare == nil {
+			return nil, p.errorf(nameTok, "operator %s is not supported for %s", op, f.name)
+		}
+		if len(values) != 1 {
+			return nil, p.errorf(values[1], "operator %s takes a single value", op)
+		}
+	}
+	if f.flag != nil {
+		return p.flagTerm(f, op, values)
+	}
+
+	node := compareNode{field: f, op: op}
+	for _, v := range values {
+		cv, err := p.compileValue(f, v)
+		if err != nil {
+			return nil, err
+		}
+		node.values = append(node.values, cv)
+	}
+	return node, nil
+}
+
+func (p *queryParser) parseValues() ([]queryToken, error) {
+	var values []queryToken
+	for {
+		tok := p.next()
+		if tok.kind != tokWord && tok.kind != tokString {
+			return nil, p.errorf(tok, "expected a value, got %s", tok)
+		}
+		values = append(values, tok)
+		if p.peek().kind != tokComma {
+			return values, nil
+		}
+		p.next()
+	}
+}
+
+func (p *queryParser) hasTerm(nameTok queryToken, op string, values []queryToken) (queryNode, error) {
+	if op != ":" && op != "=" {
+		return nil, p.errorf(nameTok, "has only supports \":\"")
+	}
+	var node queryNode
+	for _, v := range values {
+		f, ok := p.lookupField(v.text)
+		if !ok {
+			return nil, p.errorf(v, "has: unknown field %q", v.text)
+		}
+		if !f.supportsHas() {
+			return nil, p.errorf(v, "has: not supported for %s", f.name)
+		}
+		p.fields[f.name] = true
+		var h queryNode = hasNode{f}
+		if node != nil {
+			h = orNode{node, h}
+		}
+		node = h
+	}
+	return node, nil
+}
+
+func (p *queryParser) flagTerm(f *queryField, op string, values []queryToken) (queryNode, error) {
+	if len(values) != 1 || (op != ":" && op != "=" && op != "!=") {
+		return nil, p.errorf(values[0], "%s takes a single true or false value", f.name)
+	}
+	want, err := strconv.ParseBool(values[0].text)
+	if err != nil {
+		return nil, p.errorf(values[0], "%s takes true or false, got %q", f.name, values[0].text)
+	}
+	if op == "!=" {
+		want = !want
+	}
+	return flagNode{f, want}, nil
+}
+
+// compileValue converts a value token to the field's comparison type,
+// validating it against the board configuration.
+func (p *queryParser) compileValue(f *queryField, tok queryToken) (queryValue, error) {
+	v := queryValue{text: tok.text}
+	switch f.kind {
+	case kindInt:
+		n, err := strconv.Atoi(tok.text)
+		if err != nil {
+			return v, p.errorf(tok, "%s takes a number, got %q", f.name, tok.text)
+		}
+		v.num = n
+	case kindDate:
+		d, err := parseQueryDate(tok.text)
+		if err != nil {
+			return v, task.ValidateDate(f.name, tok.text, err)
+		}
+		v.date = d
+	case kindString, kindText:
+	}
+	if f.validate != nil {
+		if err := f.validate(p.cfg, tok.text); err != nil {
+			return v, err
+		}
+	}
+	return v, nil
+}
+
+func parseQueryDate(s string) (date.Date, error) {
+	if strings.EqualFold(s, "today") {
+		return date.Today(), nil
+	}
+	return date.Parse(s)
+}
+
+func isOrdering(op string) bool {
+	return op == "<" || op == "<=" || op == ">" || op == ">="
+}
+
+// --- Fields ---
+
+type fieldKind int
+
+const (
+	kindString fieldKind = iota // exact match against one or more strings
+	kindText                    // case-insensitive substring match
+	kindInt
+	kindDate
+)
+
+// queryField describes how a task field is read and compared in a query.
+type queryField struct {
+	name     string
+	aliases  []string
+	kind     fieldKind
+	strs     func(t *task.Task) []string            // kindString, kindText
+	ints     func(t *task.Task) []int               // kindInt
+	date     func(t *task.Task) (date.Date, bool)   // kindDate
+	contains func(t *task.Task, needle string) bool // kindText matcher override
+	// compare orders two values of a single-valued field; nil means the
+	// ordering operators are not supported.
+	compare  func(cfg *config.Config, a, b queryValue) int
+	validate func(cfg *config.Config, value string) error
+	flag     func(t *task.Task, cfg *config.Config) bool // boolean fields
+}
+
+type queryValue struct {
+	text string
+	num  int
+	date date.Date
+}
+
+func optionalStr(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return []string{s}
+}
+
+func optionalInt(p *int) []int {
+	if p == nil {
+		return nil
+	}
+	return []int{*p}
+}
+
+// localDate returns the calendar date of ts in local time.
+func localDate(ts *time.Time) (date.Date, bool) {
+	if ts == nil {
+		return date.Date{}, false
+	}
+	l := ts.Local()
+	return date.New(l.Year(), l.Month(), l.Day()), true
+}
+
+func compareNums(_ *config.Config, a, b queryValue) int { return a.num - b.num }
+
+func compareDates(_ *config.Config, a, b queryValue) int { return a.date.Compare(b.date.Time) }
+
+// strField adapts a single-valued string getter; an empty string is unset.
+func strField(get func(t *task.Task) string) func(t *task.Task) []string {
+	return func(t *task.Task) []string { return optionalStr(get(t)) }
+}
+
+// timeField describes a timestamp field, compared by its local calendar date.
+func timeField(name string, get func(t *task.Task) *time.Time) *queryField {
+	return &queryField{
+		name: name, kind: kindDate, compare: compareDates,
+		date: func(t *task.Task) (date.Date, bool) { return localDate(get(t)) },
+	}
+}
+
+var queryFields = []*queryField{
+	{name: "id", kind: kindInt, compare: compareNums, ints: func(t *task.Task) []int { return []int{t.ID} }},
+	{name: "title", kind: kindText, strs: strField(func(t *task.Task) string { return t.Title })},
+	{name: "body", kind: kindText, strs: strField(func(t *task.Task) string { return t.Body })},
+	{name: "text", aliases: []string{"search"}, kind: kindText, contains: matchesSearch},
+	{
+		name: "status", kind: kindString, strs: strField(func(t *task.Task) string { return t.Status }),
+		compare: func(cfg *config.Config, a, b queryValue) int {
+			return cfg.StatusIndex(a.text) - cfg.StatusIndex(b.text)
+		},
+		validate: func(cfg *config.Config, v string) error { return task.ValidateStatus(v, cfg.StatusNames()) },
+	},
+	{
+		name: "priority", kind: kindString, strs: strField(func(t *task.Task) string { return t.Priority }),
+		compare: func(cfg *config.Config, a, b queryValue) int {
+			return cfg.PriorityIndex(a.text) - cfg.PriorityIndex(b.text)
+		},
+		validate: func(cfg *config.Config, v string) error { return task.ValidatePriority(v, cfg.Priorities) },
+	},
+	{
+		name: "class", kind: kindString,
+		strs: func(t *task.Task) []string {
+			if t.Class == "" {
+				return []string{classStandard}
+			}
+			return []string{t.Class}
+		},
+		validate: func(cfg *config.Config, v string) error {
+			if len(cfg.Classes) == 0 {
+				return nil
+			}
+			return task.ValidateClass(v, cfg.ClassNames())
+		},
+	},
+	{name: "assignee", kind: kindString, strs: strField(func(t *task.Task) string { return t.Assignee })},
+	{name: "tag", aliases: []string{"tags"}, kind: kindString, strs: func(t *task.Task) []string { return t.Tags }},
+	{name: "estimate", kind: kindString, strs: strField(func(t *task.Task) string { return t.Estimate })},
+	{
+		name: "external_id", aliases: []string{"external-id"}, kind: kindString,
+		strs: strField(func(t *task.Task) string { return t.ExternalID }),
+	},
+	{
+		name: "claimed_by", aliases: []string{"claimed-by"}, kind: kindString,
+		strs: strField(func(t *task.Task) string { return t.ClaimedBy }),
+	},
+	{name: "parent", kind: kindInt, compare: compareNums, ints: func(t *task.Task) []int { return optionalInt(t.Parent) }},
+	{
+		name: "depends_on", aliases: []string{"depends-on", "depends"}, kind: kindInt,
+		ints: func(t *task.Task) []int { return t.DependsOn },
+	},
+	{
+		name: "due", kind: kindDate, compare: compareDates,
+		date: func(t *task.Task) (date.Date, bool) {
+			if t.Due == nil {
+				return date.Date{}, false
+			}
+			return *t.Due, true
+		},
+	},
+	timeField("created", func(t *task.Task) *time.Time { return &t.Created }),
+	timeField("updated", func(t *task.Task) *time.Time { return &t.Updated }),
+	timeField("started", func(t *task.Task) *time.Time { return t.Started }),
+	timeField("completed", func(t *task.Task) *time.Time { return t.Completed }),
+	{name: "blocked", flag: func(t *task.Task, _ *config.Config) bool { return t.Blocked }},
+	{
+		name: "claimed",
+		flag: func(t *task.Task, cfg *config.Config) bool { return !IsUnclaimed(t, cfg.ClaimTimeoutDuration()) },
+	},
+	{
+		name: "overdue",
+		flag: func(t *task.Task, cfg *config.Config) bool {
+			return t.Due != nil && t.Due.Before(date.Today().Time) && !cfg.IsTerminalStatus(t.Status)
+		},
+	},
+}
+
+// supportsHas reports whether has:FIELD can be used with f. Boolean fields
+// are used directly instead, and the text pseudo-field is never "set".
+func (f *queryField) supportsHas() bool {
+	return f.flag == nil && f.contains == nil
+}
+
+// isSet reports whether f has a value on t.
+func (f *queryField) isSet(t *task.Task) bool {
+	switch f.kind {
+	case kindInt:
+		return len(f.ints(t)) > 0
+	case kindDate:
+		_, ok := f.date(t)
+		return ok
+	case kindString, kindText:
+	}
+	return len(f.strs(t)) > 0
+}
+
+func lookupQueryField(name string) (*queryField, bool) {
+	name = strings.ToLower(name)
+	for _, f := range queryFields {
+		if f.name == name || slices.Contains(f.aliases, name) {
+			return f, true
+		}
+	}
+	return nil, false
+}
+
+// lookupField resolves a field name: built-in fields first, then custom
+// fields declared in the config's fields schema.
+func (p *queryParser) lookupField(name string) (*queryField, bool) {
+	if f, ok := lookupQueryField(name); ok {
+		return f, true
+	}
+	if cf := p.cfg.FieldByName(name); cf != nil {
+		return customQueryField(cf), true
+	}
+	return nil, false
+}
+
+// customQueryField describes a custom schema field: int and date fields
+// support ordering, enums are ordered by their declared values, bool fields
+// act like the built-in flags, and list fields match any item.
+func customQueryField(cf *config.FieldConfig) *queryField {
+	name := cf.Name
+	strs := func(t *task.Task) []string { return t.CustomStrings(name) }
+	switch cf.Type {
+	case config.FieldTypeInt:
+		return &queryField{
+			name: name, kind: kindInt, compare: compareNums,
+			ints: func(t *task.Task) []int {
+				if n, ok := t.CustomInt(name); ok {
+					return []int{n}
+				}
+				return nil
+			},
+		}
+	case config.FieldTypeDate:
+		return &queryField{
+			name: name, kind: kindDate, compare: compareDates,
+			date: func(t *task.Task) (date.Date, bool) {
+				vals := strs(t)
+				if len(vals) != 1 {
+					return date.Date{}, false
+				}
+				d, err := date.Parse(vals[0])
+				return d, err == nil
+			},
+		}
+	case config.FieldTypeBool:
+		return &queryField{
+			name: name,
+			flag: func(t *task.Task, _ *config.Config) bool {
+				vals := strs(t)
+				b, _ := strconv.ParseBool(strings.Join(vals, ""))
+				return b
+			},
+		}
+	case config.FieldTypeEnum:
+		return &queryField{
+			name: name, kind: kindString, strs: strs,
+			compare: func(_ *config.Config, a, b queryValue) int {
+				return config.IndexOf(cf.Values, a.text) - config.IndexOf(cf.Values, b.text)
+			},
+			validate: func(cfg *config.Config, v string) error {
+				_, err := parseFieldValue(cfg, name, v)
+				return err
+			},
+		}
+	default:
+		return &queryField{name: name, kind: kindString, strs: strs}
+	}
+}
+
+func queryFieldNames() []string {
+	names := make([]string, 0, len(queryFields))
+	for _, f := range queryFields {
+		names = append(names, f.name)
+	}
+	return names
+}
+
+// --- Evaluation ---
+
+type queryNode interface {
+	match(t *task.Task, cfg *config.Config) bool
+}
+
+type andNode struct{ left, right queryNode }
+
+func (n andNode) match(t *task.Task, cfg *config.Config) bool {
+	return n.left.match(t, cfg) && n.right.match(t, cfg)
+}
+
+type orNode struct{ left, right queryNode }
+
+func (n orNode) match(t *task.Task, cfg *config.Config) bool {
+	return n.left.match(t, cfg) || n.right.match(t, cfg)
+}
+
+type notNode struct{ x queryNode }
+
+func (n notNode) match(t *task.Task, cfg *config.Config) bool { return !n.x.match(t, cfg) }
+
+type textNode struct{ text string }
+
+func (n textNode) match(t *task.Task, _ *config.Config) bool { return matchesSearch(t, n.text) }
+
+type flagNode struct {
+	field *queryField
+	want  bool
+}
+
+func (n flagNode) match(t *task.Task, cfg *config.Config) bool { return n.field.flag(t, cfg) == n.want }
+
+type hasNode struct{ field *queryField }
+
+func (n hasNode) match(t *task.Task, _ *config.Config) bool { return n.field.isSet(t) }
+
+type compareNode struct {
+	field  *queryField
+	op     string
+	values []queryValue
+}
+
+func (n compareNode) match(t *task.Task, cfg *config.Config) bool {
+	if isOrdering(n.op) {
+		return n.matchOrdering(t, cfg)
+	}
+	matched := slices.ContainsFunc(n.values, func(v queryValue) bool { return n.field.equals(t, v) })
+	if n.op == "!=" {
+		return !matched
+	}
+	return matched
+}
+
+// matchOrdering compares the task's value with the single query value. Tasks
+// without a value never match an ordering comparison.
+func (n compareNode) matchOrdering(t *task.Task, cfg *config.Config) bool {
+	f, want := n.field, n.values[0]
+	var got queryValue
+	switch f.kind {
+	case kindInt:
+		ints := f.ints(t)
+		if len(ints) == 0 {
+			return false
+		}
+		got.num = ints[0]
+	case kindDate:
+		d, ok := f.date(t)
+		if !ok {
+			return false
+		}
+		got.date = d
+	case kindString, kindText:
+		strs := f.strs(t)
+		if len(strs) == 0 {
+			return false
+		}
+		got.text = strs[0]
+	}
+	c := f.compare(cfg, got, want)
+	switch n.op {
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	default:
+		return c >= 0
+	}
+}
+
+// equals reports whether the task's value for f matches v. For multi-valued
+// fields (tags, depends_on) any element may match.
+func (f *queryField) equals(t *task.Task, v queryValue) bool {
+	switch f.kind {
+	case kindInt:
+		return slices.Contains(f.ints(t), v.num)
+	case kindDate:
+		d, ok := f.date(t)
+		return ok && d.Equal(v.date.Time)
+	case kindText:
+		if f.contains != nil {
+			return f.contains(t, v.text)
+		}
+		needle := strings.ToLower(v.text)
+		return slices.ContainsFunc(f.strs(t), func(s string) bool {
+			return strings.Contains(strings.ToLower(s), needle)
+		})
+	default:
+		return slices.Contains(f.strs(t), v.text)
+	}
+}
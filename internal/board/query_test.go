@@ -0,0 +1,173 @@
+package board
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/antopolskiy/kanban-md/internal/clierr"
+	"github.com/antopolskiy/kanban-md/internal/config"
+	"github.com/antopolskiy/kanban-md/internal/date"
+	"github.com/antopolskiy/kanban-md/internal/task"
+)
+
+func queryIDs(t *testing.T, src string, tasks []*task.Task) []int {
+	t.Helper()
+	q, err := ParseQuery(src, config.NewDefault("Test"))
+	if err != nil {
+		t.Fatalf("ParseQuery(%q): %v", src, err)
+	}
+	var ids []int
+	for _, tk := range Filter(tasks, FilterOptions{Query: q}) {
+		ids = append(ids, tk.ID)
+	}
+	return ids
+}
+
+func sameIDs(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestQuery_Match(t *testing.T) {
+	due := date.New(2026, 10, 15)
+	now := time.Now()
+	tasks := makeTasks()
+	tasks[0].Due = &due
+	tasks[1].ClaimedBy = "agent"
+	tasks[1].ClaimedAt = &now
+	tasks[3].Blocked = true
+	tasks[3].Body = "Needs a database migration"
+
+	tests := []struct {
+		query string
+		want  []int
+	}{
+		{"status:backlog", []int{1, 4}},
+		{"status:backlog,done", []int{1, 3, 4}},
+		{"status!=backlog,done", []int{2}},
+		{"priority>=medium", []int{1, 2, 4}},
+		{"priority<high", []int{2, 3}},
+		{"status>=in-progress", []int{2, 3}},
+		{"tag:api", []int{3}},
+		{"tag:backend AND assignee:alice", []int{1, 3}},
+		{"status:backlog AND (tag:backend OR priority>=high)", []int{1, 4}},
+		{"status:backlog tag:frontend", []int{4}},
+		{"tag:backend OR tag:frontend AND priority:medium", []int{1, 2, 3}},
+		{"NOT tag:backend", []int{2, 4}},
+		{"not not tag:api", []int{3}},
+		{"claimed", []int{2}},
+		{"NOT claimed AND NOT blocked", []int{1, 3}},
+		{"blocked:false AND priority:high", []int{1}},
+		{"due<2026-11-01", []int{1}},
+		{"due:2026-10-15", []int{1}},
+		{"has:due", []int{1}},
+		{"NOT has:assignee", []int{4}},
+		{"id>=2 AND id<4", []int{2, 3}},
+		{"id:1,3", []int{1, 3}},
+		{"migration", []int{4}},
+		{`"task 3"`, []int{3}},
+		{"title:TASK", []int{1, 2, 3, 4}},
+		{"body:database", []int{4}},
+		{"class:standard", []int{1, 2, 3, 4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			if got := queryIDs(t, tt.query, tasks); !sameIDs(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuery_OverdueExcludesTerminal(t *testing.T) {
+	past := date.New(2020, 1, 1)
+	tasks := makeTasks()
+	tasks[0].Due = &past // backlog
+	tasks[2].Due = &past // done
+	if got := queryIDs(t, "overdue", tasks); !sameIDs(got, []int{1}) {
+		t.Errorf("got %v, want [1]", got)
+	}
+}
+
+func TestQuery_UsesField(t *testing.T) {
+	q, err := ParseQuery("tag:a OR (NOT status:done)", config.NewDefault("Test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !q.UsesField("status") || !q.UsesField("tag") || q.UsesField("priority") {
+		t.Errorf("fields = %v", q.fields)
+	}
+	if q.String() != "tag:a OR (NOT status:done)" {
+		t.Errorf("String() = %q", q.String())
+	}
+}
+
+func TestParseQuery_Errors(t *testing.T) {
+	tests := []struct {
+		query string
+		code  string
+	}{
+		{"", clierr.InvalidQuery},
+		{"status:", clierr.InvalidQuery},
+		{"(tag:a", clierr.InvalidQuery},
+		{"tag:a)", clierr.InvalidQuery},
+		{"tag:a AND", clierr.InvalidQuery},
+		{"OR tag:a", clierr.InvalidQuery},
+		{`"unterminated`, clierr.InvalidQuery},
+		{"tag!a", clierr.InvalidQuery},
+		{"colour:red", clierr.InvalidQuery},
+		{"tag>a", clierr.InvalidQuery},
+		{"priority>=low,high", clierr.InvalidQuery},
+		{"id:abc", clierr.InvalidQuery},
+		{"blocked:maybe", clierr.InvalidQuery},
+		{"has:claimed", clierr.InvalidQuery},
+		{"status:doing", clierr.InvalidStatus},
+		{"priority>=urgent", clierr.InvalidPriority},
+		{"due<next-week", clierr.InvalidDate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := ParseQuery(tt.query, config.NewDefault("Test"))
+			var cliErr *clierr.Error
+			if !errors.As(err, &cliErr) || cliErr.Code != tt.code {
+				t.Fatalf("err = %v, want %s", err, tt.code)
+			}
+		})
+	}
+}
+
+func TestParseQuery_ErrorPosition(t *testing.T) {
+	_, err := ParseQuery("tag:a AND colour:red", config.NewDefault("Test"))
+	var cliErr *clierr.Error
+	if !errors.As(err, &cliErr) {
+		t.Fatalf("err = %v", err)
+	}
+	if cliErr.Details["position"] != 11 {
+		t.Errorf("position = %v, want 11", cliErr.Details["position"])
+	}
+}
+
+func TestPick_Query(t *testing.T) {
+	cfg := config.NewDefault("Test")
+	tasks := []*task.Task{
+		{ID: 1, Status: "todo", Priority: "critical", Tags: []string{"frontend"}},
+		{ID: 2, Status: "todo", Priority: "high", Tags: []string{"backend"}},
+		{ID: 3, Status: "todo", Priority: "low", Tags: []string{"backend"}},
+	}
+	q, err := ParseQuery("tag:backend AND priority>=medium", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	picked := Pick(cfg, tasks, PickOptions{Query: q})
+	if picked == nil || picked.ID != 2 {
+		t.Errorf("picked = %v, want #2", picked)
+	}
+}
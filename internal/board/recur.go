@@ -0,0 +1,183 @@
+package board
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/antopolskiy/kanban-md/internal/atomicfile"
+	"github.com/antopolskiy/kanban-md/internal/clierr"
+	"github.com/antopolskiy/kanban-md/internal/config"
+	"github.com/antopolskiy/kanban-md/internal/date"
+)
+
+const (
+	recurStateFileName = "recurring.json"
+	recurStateFileMode = 0o600
+)
+
+// recurState records the last occurrence a recurring rule created a task
+// for. The state of every rule is stored by name in recurring.json in the
+// kanban directory.
+type recurState struct {
+	Last   time.Time `json:"last"`
+	TaskID int       `json:"task_id"`
+}
+
+// RecurredTask is a task created from a recurring rule by RunRecurring, or
+// the rule's failure to create it (Error and Code set, ID unset).
+type RecurredTask struct {
+	Rule       string    `json:"rule"`
+	Occurrence time.Time `json:"occurrence"`
+	ID         int       `json:"id,omitempty"` // unset on a dry run
+	Title      string    `json:"title"`
+	Error      string    `json:"error,omitempty"`
+	Code       string    `json:"code,omitempty"`
+}
+
+// RunRecurring creates a task for every recurring rule that is due: one task
+// for the latest occurrence since the rule last created one (missed
+// occurrences are not backfilled). A rule whose task cannot be created (for
+// example over a WIP limit) is reported with its error and tried again on
+// the next run; the other rules go on. It holds the board lock throughout
+// and records each occurrence as its task is created. Each task also carries
+// the occurrence as its external ID, so an occurrence whose record was lost
+// is recognized rather than created again: running it again, or from several
+// processes at once, never creates a task twice. With dryRun, nothing is
+// written and the tasks that would be created are returned.
+func RunRecurring(cfg *config.Config, dryRun bool, now time.Time) ([]RecurredTask, error) {
+	created := []RecurredTask{}
+	if len(cfg.Recurring) == 0 {
+		return created, nil
+	}
+
+	unlock, err := Lock(cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock() //nolint:errcheck // best-effort unlock
+
+	state, err := readRecurState(cfg.Dir())
+	if err != nil {
+		return nil, err
+	}
+	existing, err := externalIDs(cfg)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range cfg.Recurring {
+		occ, ok := r.Occurrence(state[r.Name].Last, now)
+		if !ok {
+			continue
+		}
+		params := recurParams(r, occ)
+		if id, done := existing[params.ExternalID]; done {
+			if !dryRun {
+				state[r.Name] = recurState{Last: occ, TaskID: id}
+				_ = writeRecurState(cfg.Dir(), state) // retried on the next run
+			}
+			continue
+		}
+		rt := RecurredTask{Rule: r.Name, Occurrence: occ, Title: params.Title}
+		if !dryRun {
+			rt.ID, err = createRecurred(cfg, r.Name, params, state, occ, now)
+			if err != nil {
+				rt.Error = err.Error()
+				var cliErr *clierr.Error
+				if errors.As(err, &cliErr) {
+					rt.Error, rt.Code = cliErr.Message, cliErr.Code
+				}
+			}
+		}
+		created = append(created, rt)
+	}
+	return created, nil
+}
+
+// createRecurred creates the task of occurrence occ of a rule and records it
+// in state. The task exists once it is created, so a failure to record it is
+// not reported: the task's external ID stands in for the record.
+func createRecurred(cfg *config.Config, rule string, params CreateParams, state map[string]recurState, occ, now time.Time) (int, error) {
+	result, err := create(cfg, params, now)
+	if err != nil {
+		return 0, err
+	}
+	state[rule] = recurState{Last: occ, TaskID: result.Task.ID}
+	_ = writeRecurState(cfg.Dir(), state)
+	return result.Task.ID, nil
+}
+
+// recurExternalID identifies occurrence occ of a rule.
+func recurExternalID(rule string, occ time.Time) string {
+	return "recur:" + rule + "@" + occ.UTC().Format(time.RFC3339)
+}
+
+// recurParams builds the task for occurrence occ of rule r.
+func recurParams(r config.RecurringConfig, occ time.Time) CreateParams {
+	p := CreateParams{
+		ExternalID: recurExternalID(r.Name, occ),
+		Title:      expandDate(r.Title, occ),
+		Status:     r.Status,
+		Priority:   r.Priority,
+		Class:      r.Class,
+		Assignee:   r.Assignee,
+		Tags:       slices.Clone(r.Tags),
+		Body:       expandDate(r.Body, occ),
+		Estimate:   r.Estimate,
+	}
+	if d, err := config.ParseInterval(r.DueIn); err == nil {
+		due := occ.Add(d)
+		dueDate := date.New(due.Year(), due.Month(), due.Day())
+		p.Due = &dueDate
+	}
+	return p
+}
+
+// expandDate fills in the date placeholders of a recurring title or body:
+// {date} (YYYY-MM-DD), {year}, {month}, {day} and {week} (ISO week).
+func expandDate(s string, t time.Time) string {
+	_, week := t.ISOWeek()
+	return strings.NewReplacer(
+		"{date}", t.Format(time.DateOnly),
+		"{year}", strconv.Itoa(t.Year()),
+		"{month}", fmt.Sprintf("%02d", int(t.Month())),
+		"{day}", fmt.Sprintf("%02d", t.Day()),
+		"{week}", fmt.Sprintf("%02d", week),
+	).Replace(s)
+}
+
+// readRecurState reads the recurring state. A missing file yields an empty
+// state.
+func readRecurState(kanbanDir string) (map[string]recurState, error) {
+	state := map[string]recurState{}
+	data, err := os.ReadFile(filepath.Join(kanbanDir, recurStateFileName)) //nolint:gosec // state path from trusted kanban dir
+	if errors.Is(err, fs.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading recurring state: %w", err)
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", recurStateFileName, err)
+	}
+	return state, nil
+}
+
+// writeRecurState writes the recurring state.
+func writeRecurState(kanbanDir string, state map[string]recurState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding recurring state: %w", err)
+	}
+	if err := atomicfile.Write(filepath.Join(kanbanDir, recurStateFileName), append(data, '\n'), recurStateFileMode); err != nil {
+		return fmt.Errorf("writing recurring state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package board_test
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/antopolskiy/kanban-md/internal/board"
+	"github.com/antopolskiy/kanban-md/internal/clierr"
+	"github.com/antopolskiy/kanban-md/internal/config"
+)
+
+func setupRecurBoard(t *testing.T, rules ...config.RecurringConfig) *config.Config {
+	t.Helper()
+	dir := t.TempDir()
+	cfg := config.NewDefault("Test")
+	cfg.SetDir(dir)
+	cfg.Recurring = rules
+	if err := os.MkdirAll(cfg.TasksPath(), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.Save(); err != nil {
+		t.Fatal(err)
+	}
+	return cfg
+}
+
+func TestRunRecurring_CreatesOncePerOccurrence(t *testing.T) {
+	cfg := setupRecurBoard(t,
+		config.RecurringConfig{
+			Name: "audit", Title: "Audit {date} (week {week})", Cron: "0 9 * * mon",
+			Priority: "high", Tags: []string{"chore"}, DueIn: "2d",
+		},
+		config.RecurringConfig{Name: "notes", Title: "Notes {month}/{year}", Every: "1w", Start: "2026-03-02T00:00:00Z"},
+	)
+	wed := time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)
+
+	created, err := board.RunRecurring(cfg, false, wed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(created) != 2 || created[0].Title != "Audit 2026-03-02 (week 10)" || created[1].Title != "Notes 03/2026" {
+		t.Fatalf("created = %+v, want the audit and notes tasks", created)
+	}
+	audit := readTask(t, cfg.TasksPath(), created[0].ID)
+	if audit.Priority != "high" || len(audit.Tags) != 1 || audit.Due == nil || audit.Due.String() != "2026-03-04" {
+		t.Errorf("audit task = %+v, want high priority, tag chore, due 2026-03-04", audit)
+	}
+
+	if again, err := board.RunRecurring(cfg, false, wed.Add(time.Hour)); err != nil || len(again) != 0 {
+		t.Errorf("second run = %+v, %v; want nothing due", again, err)
+	}
+
+	nextWeek := wed.AddDate(0, 0, 7)
+	created, err = board.RunRecurring(cfg, false, nextWeek)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(created) != 2 || created[0].Title != "Audit 2026-03-09 (week 11)" || created[0].ID != 3 {
+		t.Errorf("next week = %+v, want #3 for 2026-03-09 and the notes task", created)
+	}
+}
+
+func TestRunRecurring_DryRun(t *testing.T) {
+	cfg := setupRecurBoard(t, config.RecurringConfig{Name: "daily", Title: "Daily", Every: "1d"})
+	now := time.Now()
+
+	for range 2 {
+		would, err := board.RunRecurring(cfg, true, now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(would) != 1 || would[0].ID != 0 {
+			t.Fatalf("dry run = %+v, want one task without an ID", would)
+		}
+	}
+	if tasks := readTasks(t, cfg.TasksPath()); len(tasks) != 0 {
+		t.Errorf("dry run wrote %d tasks", len(tasks))
+	}
+}
+
+func TestRunRecurring_ConcurrentRunsCreateOnce(t *testing.T) {
+	cfg := setupRecurBoard(t, config.RecurringConfig{Name: "weekly", Title: "Weekly", Cron: "@weekly"})
+	cfg.LockTimeout = "2m"
+	if err := cfg.Save(); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+
+	const runners = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, runners)
+	for range runners {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			own, err := config.Load(cfg.Dir())
+			if err == nil {
+				_, err = board.RunRecurring(own, false, now)
+			}
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if tasks := readTasks(t, cfg.TasksPath()); len(tasks) != 1 {
+		t.Errorf("%d runs created %d tasks, want 1", runners, len(tasks))
+	}
+}
+
+func TestRunRecurring_FailedRuleDoesNotBlockOthers(t *testing.T) {
+	cfg := setupRecurBoard(t,
+		config.RecurringConfig{Name: "standup", Title: "Standup", Every: "1d", Status: "in-progress"},
+		config.RecurringConfig{Name: "review", Title: "Review", Every: "1d"},
+	)
+	now := time.Now()
+	if _, err := board.Create(cfg, board.CreateParams{Title: "Busy", Status: "in-progress"}, now); err != nil {
+		t.Fatal(err)
+	}
+	cfg.WIPLimits = map[string]int{"in-progress": 1}
+
+	created, err := board.RunRecurring(cfg, false, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(created) != 2 || created[0].Code != clierr.WIPLimitExceeded || created[0].ID != 0 {
+		t.Fatalf("created = %+v, want standup failing over the WIP limit", created)
+	}
+	if created[1].Error != "" || created[1].ID == 0 {
+		t.Errorf("review = %+v, want it created despite the failed rule", created[1])
+	}
+
+	// The failed rule is tried again on the next run.
+	cfg.WIPLimits = nil
+	again, err := board.RunRecurring(cfg, false, now.Add(time.Minute))
+	if err != nil || len(again) != 1 || again[0].Rule != "standup" || again[0].ID == 0 {
+		t.Errorf("second run = %+v, %v; want standup created", again, err)
+	}
+}
+
+func TestRunRecurring_LostStateDoesNotDuplicate(t *testing.T) {
+	cfg := setupRecurBoard(t, config.RecurringConfig{Name: "weekly", Title: "Weekly", Cron: "@weekly"})
+	now := time.Now()
+	if _, err := board.RunRecurring(cfg, false, now); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(cfg.Dir(), "recurring.json")); err != nil {
+		t.Fatal(err)
+	}
+
+	again, err := board.RunRecurring(cfg, false, now)
+	if err != nil || len(again) != 0 {
+		t.Errorf("run after losing the state = %+v, %v; want nothing created", again, err)
+	}
+	if tasks := readTasks(t, cfg.TasksPath()); len(tasks) != 1 {
+		t.Errorf("got %d tasks, want the occurrence created once", len(tasks))
+	}
+}
@@ -0,0 +1,194 @@
+package board
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/antopolskiy/kanban-md/internal/clierr"
+	"github.com/antopolskiy/kanban-md/internal/config"
+	"github.com/antopolskiy/kanban-md/internal/task"
+)
+
+// Rollup summarizes the progress of a parent task: its direct subtasks and
+// how many of them are done. Archived subtasks are left out.
+type Rollup struct {
+	Children []RollupChild `json:"children"`
+	Done     int           `json:"done"`
+	Total    int           `json:"total"`
+	Percent  int           `json:"percent"`
+}
+
+// RollupChild is a subtask in a Rollup.
+type RollupChild struct {
+	ID     int    `json:"id"`
+	Title  string `json:"title"`
+	Status string `json:"status"`
+	Done   bool   `json:"done"`
+}
+
+// ParentRollup returns the roll-up of parentID's subtasks, ordered by ID, or
+// nil if it has none.
+func ParentRollup(cfg *config.Config, tasks []*task.Task, parentID int) *Rollup {
+	var r Rollup
+	for _, t := range tasks {
+		if t.Parent == nil || *t.Parent != parentID || cfg.IsArchivedStatus(t.Status) {
+			continue
+		}
+		done := cfg.IsTerminalStatus(t.Status)
+		r.Children = append(r.Children, RollupChild{ID: t.ID, Title: t.Title, Status: t.Status, Done: done})
+		if done {
+			r.Done++
+		}
+	}
+	if len(r.Children) == 0 {
+		return nil
+	}
+	slices.SortFunc(r.Children, func(a, b RollupChild) int { return a.ID - b.ID })
+	r.Total = len(r.Children)
+	r.Percent = r.Done * 100 / r.Total //nolint:mnd // percentage
+	return &r
+}
+
+// TaskNode is a task in a TaskTree, with its subtasks.
+type TaskNode struct {
+	*task.Task
+	Subtasks []*TaskNode `json:"subtasks,omitempty"`
+}
+
+// TaskTree arranges tasks under their parents, keeping the order of tasks
+// among siblings. Subtasks whose parent is not in tasks are placed at the
+// top level.
+func TaskTree(tasks []*task.Task) []*TaskNode {
+	nodes := make(map[int]*TaskNode, len(tasks))
+	for _, t := range tasks {
+		nodes[t.ID] = &TaskNode{Task: t}
+	}
+	var roots []*TaskNode
+	for _, t := range tasks {
+		n := nodes[t.ID]
+		if t.Parent != nil && *t.Parent != t.ID {
+			if parent, ok := nodes[*t.Parent]; ok && !isAncestor(nodes, n, parent) {
+				parent.Subtasks = append(parent.Subtasks, n)
+				continue
+			}
+		}
+		roots = append(roots, n)
+	}
+	return roots
+}
+
+// isAncestor reports whether node is an ancestor of other in nodes, following
+// parent links; it keeps TaskTree from linking a parent cycle.
+func isAncestor(nodes map[int]*TaskNode, node, other *TaskNode) bool {
+	seen := make(map[int]bool)
+	for cur := other; cur != nil && !seen[cur.ID]; {
+		if cur == node {
+			return true
+		}
+		seen[cur.ID] = true
+		if cur.Parent == nil {
+			return false
+		}
+		cur = nodes[*cur.Parent]
+	}
+	return false
+}
+
+// checkParentDone enforces parent_done: require, rejecting a move of t to
+// the done status while any of its subtasks is open.
+func checkParentDone(cfg *config.Config, t *task.Task, from, to string,
+	loadAll func() ([]*task.Task, error),
+) error {
+	if cfg.ParentDone != config.ParentDoneRequire || to != cfg.DoneStatus() {
+		return nil
+	}
+	all, err := loadAll()
+	if err != nil {
+		return err
+	}
+	if open := openIDs(cfg, all, func(o *task.Task) bool { return o.Parent != nil && *o.Parent == t.ID }); open != "" {
+		return clierr.Newf(clierr.TransitionNotAllowed,
+			"task #%d cannot move from %s to %s: subtasks not done: %s", t.ID, from, to, open).
+			WithDetails(map[string]any{"id": t.ID, "from": from, "to": to, "parent_done": cfg.ParentDone})
+	}
+	return nil
+}
+
+// completeParents applies parent_done: auto after t was written. When t has
+// just moved from oldStatus to a terminal status and every other subtask of
+// its parent is terminal too, the parent moves to the done status, and so on
+// up the chain. The move is logged and runs move
+// hooks like any other; a parent whose move is not allowed (by the workflow,
+// a claim, a WIP limit, or a pre hook) is left in place with a warning. It returns the IDs
+// of the parents moved.
+func completeParents(cfg *config.Config, t *task.Task, oldStatus, claimant string, now time.Time) ([]int, []string) {
+	if cfg.ParentDone != config.ParentDoneAuto || t.Parent == nil ||
+		t.Status == oldStatus || !cfg.IsTerminalStatus(t.Status) {
+		return nil, nil
+	}
+	all, _, err := task.ReadAllLenient(cfg.TasksPath())
+	if err != nil {
+		return nil, []string{fmt.Sprintf("parent #%d not checked: %v", *t.Parent, err)}
+	}
+	byID := make(map[int]*task.Task, len(all))
+	for _, o := range all {
+		byID[o.ID] = o
+	}
+
+	var moved []int
+	var warnings []string
+	seen := map[int]bool{t.ID: true}
+	for cur := t; cur.Parent != nil && !seen[*cur.Parent]; {
+		parent := byID[*cur.Parent]
+		if parent == nil || cfg.IsTerminalStatus(parent.Status) {
+			break
+		}
+		seen[parent.ID] = true
+		if openIDs(cfg, all, func(o *task.Task) bool { return o.Parent != nil && *o.Parent == parent.ID }) != "" {
+			break
+		}
+		if err := completeParent(cfg, parent, claimant, now); err != nil {
+			warnings = append(warnings, fmt.Sprintf("parent #%d not moved to %s: %v", parent.ID, cfg.DoneStatus(), err))
+			break
+		}
+		moved = append(moved, parent.ID)
+		cur = parent
+	}
+	return moved, warnings
+}
+
+// completeParent moves parent to the done status on behalf of claimant.
+func completeParent(cfg *config.Config, parent *task.Task, claimant string, now time.Time) error {
+	done := cfg.DoneStatus()
+	if err := task.CheckClaim(parent, claimant, cfg.ClaimTimeoutDuration()); err != nil {
+		return err
+	}
+	if cfg.StatusRequiresClaim(done) && claimant == "" {
+		return task.ValidateClaimRequired(done)
+	}
+	if err := CheckTransition(cfg, parent, parent.Status, done); err != nil {
+		return err
+	}
+	if err := enforceMoveWIP(cfg, parent, done); err != nil {
+		return err
+	}
+
+	before := TakeSnapshot(parent)
+	oldStatus := parent.Status
+	parent.Status = done
+	task.UpdateTimestamps(parent, oldStatus, done, cfg)
+	parent.Updated = now
+
+	hooks := newTaskHooks(cfg, config.HookMove, before, parent, Actor(claimant, parent))
+	if err := hooks.runPre(parent); err != nil {
+		return err
+	}
+	if err := task.Write(parent.File, parent); err != nil {
+		return fmt.Errorf("writing task: %w", err)
+	}
+
+	LogChange(cfg.Dir(), "move", Actor(claimant, parent), before, parent, oldStatus+" -> "+done+" (subtasks done)")
+	hooks.runPost(parent)
+	return nil
+}
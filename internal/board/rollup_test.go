@@ -0,0 +1,208 @@
+package board_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/antopolskiy/kanban-md/internal/board"
+	"github.com/antopolskiy/kanban-md/internal/config"
+	"github.com/antopolskiy/kanban-md/internal/task"
+)
+
+func TestParentRollup(t *testing.T) {
+	cfg := config.NewDefault("Test")
+	tasks := []*task.Task{
+		{ID: 1, Title: "epic", Status: "in-progress"},
+		{ID: 4, Title: "d", Status: "todo", Parent: intPtr(1)},
+		{ID: 2, Title: "b", Status: "done", Parent: intPtr(1)},
+		{ID: 3, Title: "c", Status: config.ArchivedStatus, Parent: intPtr(1)},
+		{ID: 5, Title: "e", Status: "done", Parent: intPtr(4)},
+	}
+
+	r := board.ParentRollup(cfg, tasks, 1)
+	if r == nil {
+		t.Fatal("ParentRollup(1) = nil, want a roll-up")
+	}
+	if r.Total != 2 || r.Done != 1 || r.Percent != 50 {
+		t.Errorf("roll-up = %d/%d (%d%%), want 1/2 (50%%)", r.Done, r.Total, r.Percent)
+	}
+	if len(r.Children) != 2 || r.Children[0].ID != 2 || !r.Children[0].Done || r.Children[1].ID != 4 {
+		t.Errorf("children = %+v, want #2 (done) then #4, archived #3 left out", r.Children)
+	}
+
+	if r := board.ParentRollup(cfg, tasks, 2); r != nil {
+		t.Errorf("ParentRollup(2) = %+v, want nil for a task without subtasks", r)
+	}
+}
+
+func TestTaskTree(t *testing.T) {
+	tasks := []*task.Task{
+		{ID: 3, Title: "child", Parent: intPtr(1)},
+		{ID: 1, Title: "epic"},
+		{ID: 4, Title: "grandchild", Parent: intPtr(3)},
+		{ID: 2, Title: "orphan", Parent: intPtr(9)},
+		{ID: 5, Title: "child 2", Parent: intPtr(1)},
+	}
+
+	roots := board.TaskTree(tasks)
+	if len(roots) != 2 || roots[0].ID != 1 || roots[1].ID != 2 {
+		t.Fatalf("roots = %v, want #1 and the orphan #2", nodeIDs(roots))
+	}
+	if got := nodeIDs(roots[0].Subtasks); len(got) != 2 || got[0] != 3 || got[1] != 5 {
+		t.Errorf("subtasks of #1 = %v, want [3 5]", got)
+	}
+	if got := nodeIDs(roots[0].Subtasks[0].Subtasks); len(got) != 1 || got[0] != 4 {
+		t.Errorf("subtasks of #3 = %v, want [4]", got)
+	}
+}
+
+func nodeIDs(nodes []*board.TaskNode) []int {
+	ids := make([]int, len(nodes))
+	for i, n := range nodes {
+		ids[i] = n.ID
+	}
+	return ids
+}
+
+func TestMove_ParentDoneRequire(t *testing.T) {
+	cfg := setupWorkflowBoard(t,
+		&task.Task{ID: 1, Title: "epic", Status: "todo"},
+		&task.Task{ID: 2, Title: "a", Status: "done", Parent: intPtr(1)},
+		&task.Task{ID: 3, Title: "b", Status: "todo", Parent: intPtr(1)},
+	)
+	cfg.Transitions = nil
+	cfg.ParentDone = config.ParentDoneRequire
+
+	_, err := board.Move(cfg, board.MoveParams{ID: 1, NewStatus: "done"}, time.Now())
+	if cliErr := wantTransitionError(t, err); !strings.Contains(cliErr.Message, "#3") {
+		t.Errorf("message = %q, want the open subtask #3", cliErr.Message)
+	}
+
+	if _, err := board.Move(cfg, board.MoveParams{ID: 3, NewStatus: "done"}, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := board.Move(cfg, board.MoveParams{ID: 1, NewStatus: "done"}, time.Now()); err != nil {
+		t.Fatalf("move with all subtasks done: %v", err)
+	}
+}
+
+func TestMove_ParentDoneAuto(t *testing.T) {
+	cfg := setupWorkflowBoard(t,
+		&task.Task{ID: 1, Title: "initiative", Status: "in-progress"},
+		&task.Task{ID: 2, Title: "epic", Status: "todo", Parent: intPtr(1)},
+		&task.Task{ID: 3, Title: "a", Status: "done", Parent: intPtr(2)},
+		&task.Task{ID: 4, Title: "b", Status: "todo", Parent: intPtr(2)},
+	)
+	cfg.Transitions = nil
+	cfg.ParentDone = config.ParentDoneAuto
+
+	result, err := board.Move(cfg, board.MoveParams{ID: 4, NewStatus: "done"}, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.ParentsDone) != 2 || result.ParentsDone[0] != 2 || result.ParentsDone[1] != 1 {
+		t.Errorf("ParentsDone = %v, want [2 1]", result.ParentsDone)
+	}
+	for _, id := range []int{1, 2} {
+		if tk := readTask(t, cfg.TasksPath(), id); tk.Status != "done" || tk.Completed == nil {
+			t.Errorf("task #%d status = %q (completed %v), want done", id, tk.Status, tk.Completed)
+		}
+	}
+
+	entries, err := board.ReadLog(cfg.Dir(), board.LogFilterOptions{TaskID: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Action != "move" || !strings.Contains(entries[0].Detail, "subtasks done") {
+		t.Errorf("log for #2 = %+v, want a move for finished subtasks", entries)
+	}
+}
+
+func TestMove_ParentDoneAutoRespectsTransitions(t *testing.T) {
+	cfg := setupWorkflowBoard(t,
+		&task.Task{ID: 1, Title: "epic", Status: "todo"},
+		&task.Task{ID: 2, Title: "a", Status: "review", Parent: intPtr(1), Body: "handoff"},
+	)
+	cfg.ParentDone = config.ParentDoneAuto
+
+	result, err := board.Move(cfg, board.MoveParams{ID: 2, NewStatus: "done"}, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.ParentsDone) != 0 || len(result.Warnings) != 1 || !strings.Contains(result.Warnings[0], "parent #1") {
+		t.Errorf("ParentsDone = %v, Warnings = %v, want a warning for #1", result.ParentsDone, result.Warnings)
+	}
+	if tk := readTask(t, cfg.TasksPath(), 1); tk.Status != "todo" {
+		t.Errorf("epic status = %q, want todo (todo -> done is not allowed)", tk.Status)
+	}
+}
+
+func TestMove_ParentDoneAutoRespectsWIPLimit(t *testing.T) {
+	cfg := setupWorkflowBoard(t,
+		&task.Task{ID: 1, Title: "epic", Status: "todo"},
+		&task.Task{ID: 2, Title: "a", Status: "todo", Parent: intPtr(1)},
+		&task.Task{ID: 3, Title: "shipped", Status: "done"},
+	)
+	cfg.Transitions = nil
+	cfg.ParentDone = config.ParentDoneAuto
+	cfg.WIPLimits = map[string]int{"done": 2}
+
+	// #2 fills the done column, so the epic would exceed its limit.
+	result, err := board.Move(cfg, board.MoveParams{ID: 2, NewStatus: "done"}, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.ParentsDone) != 0 || len(result.Warnings) != 1 || !strings.Contains(result.Warnings[0], "WIP limit") {
+		t.Errorf("ParentsDone = %v, Warnings = %v, want a WIP warning for #1", result.ParentsDone, result.Warnings)
+	}
+	if tk := readTask(t, cfg.TasksPath(), 1); tk.Status != "todo" {
+		t.Errorf("epic status = %q, want todo", tk.Status)
+	}
+}
+
+func TestEdit_ParentDoneAuto(t *testing.T) {
+	cfg := setupWorkflowBoard(t,
+		&task.Task{ID: 1, Title: "epic", Status: "todo"},
+		&task.Task{ID: 2, Title: "a", Status: "todo", Parent: intPtr(1)},
+	)
+	cfg.Transitions = nil
+	cfg.ParentDone = config.ParentDoneAuto
+
+	result, err := board.Edit(cfg, 2, "", "", false, func(tk *task.Task) (bool, error) {
+		tk.Status = "done"
+		return true, nil
+	}, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.ParentsDone) != 1 || result.ParentsDone[0] != 1 {
+		t.Errorf("ParentsDone = %v, want [1]", result.ParentsDone)
+	}
+}
+
+func TestGroupBy_Parent(t *testing.T) {
+	cfg := config.NewDefault("Test")
+	tasks := []*task.Task{
+		{ID: 1, Title: "epic", Status: "todo"},
+		{ID: 10, Title: "other", Status: "todo"},
+		{ID: 2, Title: "a", Status: "todo", Parent: intPtr(10)},
+		{ID: 3, Title: "b", Status: "done", Parent: intPtr(1)},
+		{ID: 4, Title: "c", Status: "todo", Parent: intPtr(1)},
+		{ID: 5, Title: "d", Status: "todo", Parent: intPtr(99)},
+	}
+
+	groups := board.GroupBy(tasks, "parent", cfg).Groups
+	want := []string{"#1 epic", "#10 other", "#99", "(no parent)"}
+	if len(groups) != len(want) {
+		t.Fatalf("groups = %+v, want keys %v", groups, want)
+	}
+	for i, g := range groups {
+		if g.Key != want[i] {
+			t.Errorf("group %d key = %q, want %q", i, g.Key, want[i])
+		}
+	}
+	if groups[0].Total != 2 {
+		t.Errorf("#1 total = %d, want 2", groups[0].Total)
+	}
+}
@@ -45,10 +45,10 @@ func TestTruncateLogIfNeeded_FileNotFound(t *testing.T) {
 }
 
 // ---------------------------------------------------------------------------
-// truncateLogIfNeeded — scanner.Err() path (simulate with very long line)
+// truncateLogIfNeeded — lines longer than bufio.Scanner's buffer
 // ---------------------------------------------------------------------------
 
-func TestTruncateLogIfNeeded_ScannerError(t *testing.T) {
+func TestTruncateLogIfNeeded_LongLine(t *testing.T) {
 	dir := t.TempDir()
 	logPath := filepath.Join(dir, logFileName)
 
@@ -59,9 +59,8 @@ func TestTruncateLogIfNeeded_ScannerError(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	err := truncateLogIfNeeded(logPath)
-	if err == nil {
-		t.Error("expected scanner error for oversized line")
+	if err := truncateLogIfNeeded(logPath); err != nil {
+		t.Errorf("truncateLogIfNeeded: %v, want long lines accepted", err)
 	}
 }
 
@@ -20,6 +20,12 @@ func Sort(tasks []*task.Task, field string, reverse bool, cfg *config.Config) {
 	})
 }
 
+// ValidSortFields returns the built-in fields accepted by Sort. Custom fields
+// declared in the config schema are accepted too.
+func ValidSortFields() []string {
+	return []string{"id", "title", fieldStatus, fieldPriority, "created", "updated", "due"}
+}
+
 func compareTasks(a, b *task.Task, field string, cfg *config.Config) bool {
 	switch field {
 	case "id":
@@ -37,6 +43,9 @@ func compareTasks(a, b *task.Task, field string, cfg *config.Config) bool {
 	case "due":
 		return compareDue(a, b)
 	default:
+		if f := cfg.FieldByName(field); f != nil {
+			return compareCustom(a, b, f)
+		}
 		return a.ID < b.ID
 	}
 }
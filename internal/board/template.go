@@ -0,0 +1,137 @@
+package board
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/antopolskiy/kanban-md/internal/clierr"
+	"github.com/antopolskiy/kanban-md/internal/config"
+	"github.com/antopolskiy/kanban-md/internal/date"
+	"github.com/antopolskiy/kanban-md/internal/task"
+)
+
+// TemplatesDirName is the directory of task templates inside the kanban
+// directory.
+const TemplatesDirName = "templates"
+
+// ListTemplates returns the task templates of the board, ordered by name. A
+// missing templates directory yields no templates.
+func ListTemplates(cfg *config.Config) ([]*task.Template, error) {
+	dir := filepath.Join(cfg.Dir(), TemplatesDirName)
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading templates: %w", err)
+	}
+	var templates []*task.Template
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), task.TemplateExt) {
+			continue
+		}
+		tmpl, err := task.ReadTemplate(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, tmpl)
+	}
+	slices.SortFunc(templates, func(a, b *task.Template) int { return strings.Compare(a.Name, b.Name) })
+	return templates, nil
+}
+
+// FindTemplate returns the named template or a TEMPLATE_NOT_FOUND error
+// listing the templates that do exist.
+func FindTemplate(cfg *config.Config, name string) (*task.Template, error) {
+	templates, err := ListTemplates(cfg)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(templates))
+	for i, tmpl := range templates {
+		if tmpl.Name == name {
+			return tmpl, nil
+		}
+		names[i] = tmpl.Name
+	}
+	return nil, clierr.Newf(clierr.TemplateNotFound, "template %q not found", name).
+		WithDetails(map[string]any{"template": name, "templates": names})
+}
+
+// ApplyTemplate merges tmpl into p. Values already set in p win, except tags,
+// which are added to the template's; the template's title pattern wraps
+// p.Title. Placeholders in the title and body are filled in with p.Title and
+// the date of now.
+func ApplyTemplate(cfg *config.Config, tmpl *task.Template, p CreateParams, now time.Time) (CreateParams, error) {
+	title := p.Title
+	expand := func(s string) string {
+		return expandDate(strings.ReplaceAll(s, "{title}", title), now)
+	}
+	if tmpl.Title != "" {
+		p.Title = expand(tmpl.Title)
+	}
+	if p.Body == "" {
+		p.Body = expand(tmpl.Body)
+	}
+	p.Status = defaultString(p.Status, tmpl.Status)
+	p.Priority = defaultString(p.Priority, tmpl.Priority)
+	p.Class = defaultString(p.Class, tmpl.Class)
+	p.Assignee = defaultString(p.Assignee, tmpl.Assignee)
+	p.Estimate = defaultString(p.Estimate, tmpl.Estimate)
+	tags := slices.Clone(tmpl.Tags)
+	for _, tag := range p.Tags {
+		if !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	p.Tags = tags
+
+	if p.Due == nil && tmpl.DueIn != "" {
+		d, err := config.ParseInterval(tmpl.DueIn)
+		if err != nil {
+			return p, clierr.Newf(clierr.InvalidInput, "template %q: due_in: %v", tmpl.Name, err)
+		}
+		due := now.Add(d)
+		dueDate := date.New(due.Year(), due.Month(), due.Day())
+		p.Due = &dueDate
+	}
+	return p, applyTemplateFields(cfg, tmpl, &p)
+}
+
+// applyTemplateFields adds the template's custom field values to p, parsed
+// like --set, without overriding values already in p.
+func applyTemplateFields(cfg *config.Config, tmpl *task.Template, p *CreateParams) error {
+	if len(tmpl.Fields) == 0 {
+		return nil
+	}
+	assignments := make([]string, 0, len(tmpl.Fields))
+	for name, v := range tmpl.Fields {
+		assignments = append(assignments, name+"="+task.FormatCustomValue(v))
+	}
+	values, err := ParseFieldAssignments(cfg, assignments)
+	if err != nil {
+		return err
+	}
+	if p.Custom == nil {
+		p.Custom = make(map[string]any, len(values))
+	}
+	for name, v := range values {
+		if _, ok := p.Custom[name]; !ok {
+			p.Custom[name] = v
+		}
+	}
+	return nil
+}
+
+func defaultString(v, fallback string) string {
+	if v != "" {
+		return v
+	}
+	return fallback
+}
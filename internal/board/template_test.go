@@ -0,0 +1,97 @@
+package board_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/antopolskiy/kanban-md/internal/board"
+	"github.com/antopolskiy/kanban-md/internal/clierr"
+	"github.com/antopolskiy/kanban-md/internal/config"
+)
+
+func writeTemplate(t *testing.T, cfg *config.Config, name, content string) {
+	t.Helper()
+	dir := filepath.Join(cfg.Dir(), board.TemplatesDirName)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestListTemplates(t *testing.T) {
+	cfg := setupRecurBoard(t)
+	if templates, err := board.ListTemplates(cfg); err != nil || templates != nil {
+		t.Fatalf("no templates dir = %v, %v; want nil", templates, err)
+	}
+
+	writeTemplate(t, cfg, "spike.md", "Time-boxed investigation.\n")
+	writeTemplate(t, cfg, "bug.md", "---\ndescription: Report a defect\npriority: high\n---\n## Steps\n")
+	writeTemplate(t, cfg, "notes.txt", "not a template")
+
+	templates, err := board.ListTemplates(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(templates) != 2 || templates[0].Name != "bug" || templates[1].Name != "spike" {
+		t.Fatalf("templates = %+v, want bug and spike", templates)
+	}
+	if templates[0].Priority != "high" || templates[0].Body != "## Steps\n" {
+		t.Errorf("bug = %+v, want high priority and the steps body", templates[0])
+	}
+	if templates[1].Body != "Time-boxed investigation.\n" {
+		t.Errorf("spike body = %q, want the whole file", templates[1].Body)
+	}
+}
+
+func TestFindTemplate_NotFound(t *testing.T) {
+	cfg := setupRecurBoard(t)
+	writeTemplate(t, cfg, "bug.md", "body\n")
+
+	_, err := board.FindTemplate(cfg, "feature")
+	var cliErr *clierr.Error
+	if !errors.As(err, &cliErr) || cliErr.Code != clierr.TemplateNotFound {
+		t.Fatalf("err = %v, want TEMPLATE_NOT_FOUND", err)
+	}
+}
+
+func TestApplyTemplate(t *testing.T) {
+	cfg := setupRecurBoard(t)
+	writeTemplate(t, cfg, "bug.md", `---
+title: "Bug: {title}"
+priority: high
+assignee: triage
+tags: [bug]
+due_in: 3d
+---
+Reported {date}: {title}
+`)
+	tmpl, err := board.FindTemplate(cfg, "bug")
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)
+
+	p, err := board.ApplyTemplate(cfg, tmpl, board.CreateParams{
+		Title: "Login fails", Priority: "critical", Tags: []string{"auth", "bug"},
+	}, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Title != "Bug: Login fails" || p.Body != "Reported 2026-03-04: Login fails\n" {
+		t.Errorf("title, body = %q, %q; want the expanded template", p.Title, p.Body)
+	}
+	if p.Priority != "critical" || p.Assignee != "triage" {
+		t.Errorf("priority, assignee = %q, %q; want the given priority and the template assignee", p.Priority, p.Assignee)
+	}
+	if len(p.Tags) != 2 || p.Tags[0] != "bug" || p.Tags[1] != "auth" {
+		t.Errorf("tags = %v, want [bug auth]", p.Tags)
+	}
+	if p.Due == nil || p.Due.String() != "2026-03-07" {
+		t.Errorf("due = %v, want 2026-03-07", p.Due)
+	}
+}
@@ -0,0 +1,132 @@
+package board
+
+import (
+	"slices"
+	"time"
+
+	"github.com/antopolskiy/kanban-md/internal/config"
+	"github.com/antopolskiy/kanban-md/internal/task"
+)
+
+// StatusPeriod is a stretch of time a task spent in one status. Exited is nil
+// for the task's current status.
+type StatusPeriod struct {
+	Status  string     `json:"status"`
+	Entered time.Time  `json:"entered"`
+	Exited  *time.Time `json:"exited,omitempty"`
+	Hours   float64    `json:"hours"`
+}
+
+// Timeline returns the statuses t has been in, oldest first, from the status
+// transitions of the activity log. The status a task had before its first
+// logged transition is counted from its created time. When the log does not
+// cover the task's current status (it was truncated, or the file was edited by
+// hand), that status is counted from the Completed or Updated timestamp.
+func Timeline(t *task.Task, transitions []TaskTransition, now time.Time) []StatusPeriod {
+	var periods []StatusPeriod
+	enter := func(status string, at time.Time) {
+		if n := len(periods); n > 0 {
+			last := &periods[n-1]
+			if at.Before(last.Entered) {
+				at = last.Entered
+			}
+			last.Exited = &at
+			last.Hours = at.Sub(last.Entered).Hours()
+		}
+		periods = append(periods, StatusPeriod{Status: status, Entered: at})
+	}
+
+	for _, tr := range transitions {
+		if tr.TaskID != t.ID || tr.To == "" {
+			continue
+		}
+		if len(periods) == 0 && tr.From != "" {
+			enter(tr.From, t.Created)
+		}
+		if len(periods) > 0 && periods[len(periods)-1].Status == tr.To {
+			continue
+		}
+		enter(tr.To, tr.At)
+	}
+	if len(periods) == 0 || periods[len(periods)-1].Status != t.Status {
+		enter(t.Status, statusEnteredFallback(t))
+	}
+
+	last := &periods[len(periods)-1]
+	last.Hours = now.Sub(last.Entered).Hours()
+	return periods
+}
+
+// statusEnteredFallback estimates when t entered its current status: when
+// it was completed for a finished task, otherwise when it was last updated
+// (the latest it can have changed status).
+func statusEnteredFallback(t *task.Task) time.Time {
+	switch {
+	case t.Completed != nil:
+		return *t.Completed
+	case !t.Updated.IsZero():
+		return t.Updated
+	default:
+		return t.Created
+	}
+}
+
+// Timelines returns the Timeline of every task, by task ID.
+func Timelines(tasks []*task.Task, transitions []TaskTransition, now time.Time) map[int][]StatusPeriod {
+	byTask := make(map[int][]TaskTransition, len(tasks))
+	for _, tr := range transitions {
+		byTask[tr.TaskID] = append(byTask[tr.TaskID], tr)
+	}
+	out := make(map[int][]StatusPeriod, len(tasks))
+	for _, t := range tasks {
+		out[t.ID] = Timeline(t, byTask[t.ID], now)
+	}
+	return out
+}
+
+// StatusTime summarizes how long tasks stayed in a status, over the periods
+// that have ended.
+type StatusTime struct {
+	Status   string  `json:"status"`
+	Count    int     `json:"count"` // periods spent in the status
+	AvgHours float64 `json:"avg_hours"`
+	P50Hours float64 `json:"p50_hours"`
+	P85Hours float64 `json:"p85_hours"`
+	P95Hours float64 `json:"p95_hours"`
+}
+
+// ComputeStatusTimes returns the time spent in each board status, in board
+// order. Only finished periods count (a task still in a status has not
+// finished its time there); statuses no task has left are omitted.
+func ComputeStatusTimes(cfg *config.Config, tasks []*task.Task, transitions []TaskTransition, now time.Time) []StatusTime {
+	hours := make(map[string][]float64)
+	for _, periods := range Timelines(tasks, transitions, now) {
+		for _, p := range periods {
+			if p.Exited != nil {
+				hours[p.Status] = append(hours[p.Status], p.Hours)
+			}
+		}
+	}
+
+	var out []StatusTime
+	for _, s := range cfg.BoardStatuses() {
+		h := hours[s]
+		if len(h) == 0 {
+			continue
+		}
+		slices.Sort(h)
+		var sum float64
+		for _, v := range h {
+			sum += v
+		}
+		out = append(out, StatusTime{
+			Status:   s,
+			Count:    len(h),
+			AvgHours: sum / float64(len(h)),
+			P50Hours: percentile(h, p50),
+			P85Hours: percentile(h, p85),
+			P95Hours: percentile(h, p95),
+		})
+	}
+	return out
+}
@@ -0,0 +1,101 @@
+package board_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/antopolskiy/kanban-md/internal/board"
+	"github.com/antopolskiy/kanban-md/internal/config"
+	"github.com/antopolskiy/kanban-md/internal/task"
+)
+
+func TestTimeline_FromTransitions(t *testing.T) {
+	t0 := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	tk := &task.Task{ID: 1, Status: "review", Created: t0, Updated: t0.Add(5 * time.Hour)}
+	transitions := []board.TaskTransition{
+		{TaskID: 1, To: "todo", At: t0},
+		{TaskID: 2, From: "todo", To: "done", At: t0.Add(time.Hour)},
+		{TaskID: 1, From: "todo", To: "in-progress", At: t0.Add(2 * time.Hour)},
+		{TaskID: 1, From: "in-progress", To: "review", At: t0.Add(5 * time.Hour)},
+	}
+
+	got := board.Timeline(tk, transitions, t0.Add(8*time.Hour))
+
+	want := []struct {
+		status string
+		hours  float64
+		open   bool
+	}{
+		{"todo", 2, false},
+		{"in-progress", 3, false},
+		{"review", 3, true},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d periods, want %d: %+v", len(got), len(want), got)
+	}
+	for i, w := range want {
+		if got[i].Status != w.status || got[i].Hours != w.hours || (got[i].Exited == nil) != w.open {
+			t.Errorf("period %d = %+v, want %s for %vh (open %v)", i, got[i], w.status, w.hours, w.open)
+		}
+	}
+}
+
+func TestTimeline_WithoutLogHistory(t *testing.T) {
+	t0 := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	completed := t0.Add(4 * time.Hour)
+	tk := &task.Task{ID: 1, Status: "done", Created: t0, Updated: t0.Add(6 * time.Hour), Completed: &completed}
+
+	// The log begins after the task was created, and misses its last move.
+	transitions := []board.TaskTransition{
+		{TaskID: 1, From: "todo", To: "in-progress", At: t0.Add(time.Hour)},
+	}
+	got := board.Timeline(tk, transitions, t0.Add(10*time.Hour))
+
+	if len(got) != 3 {
+		t.Fatalf("got %d periods, want 3: %+v", len(got), got)
+	}
+	if got[0].Status != "todo" || !got[0].Entered.Equal(t0) {
+		t.Errorf("first period = %+v, want todo from the created time", got[0])
+	}
+	if got[2].Status != "done" || !got[2].Entered.Equal(completed) || got[2].Hours != 6 {
+		t.Errorf("last period = %+v, want done from the completed time", got[2])
+	}
+
+	// No log at all: the current status counts from the last update.
+	tk = &task.Task{ID: 2, Status: "todo", Created: t0, Updated: t0.Add(time.Hour)}
+	got = board.Timeline(tk, nil, t0.Add(3*time.Hour))
+	if len(got) != 1 || got[0].Hours != 2 {
+		t.Errorf("timeline without log = %+v, want 2h in todo", got)
+	}
+}
+
+func TestComputeStatusTimes(t *testing.T) {
+	cfg := config.NewDefault("Test")
+	t0 := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	var tasks []*task.Task
+	var transitions []board.TaskTransition
+	// Tasks 1-4 spend 1, 2, 3 and 10 hours in review; task 4 is still there.
+	for i, h := range []int{1, 2, 3, 10} {
+		id := i + 1
+		tasks = append(tasks, &task.Task{ID: id, Status: "done", Created: t0})
+		transitions = append(transitions,
+			board.TaskTransition{TaskID: id, To: "review", At: t0},
+			board.TaskTransition{TaskID: id, From: "review", To: "done", At: t0.Add(time.Duration(h) * time.Hour)},
+		)
+	}
+	tasks = append(tasks, &task.Task{ID: 5, Status: "review", Created: t0})
+	transitions = append(transitions, board.TaskTransition{TaskID: 5, To: "review", At: t0})
+
+	got := board.ComputeStatusTimes(cfg, tasks, transitions, t0.Add(100*time.Hour))
+
+	if len(got) != 1 {
+		t.Fatalf("got %+v, want only review (done has no finished periods)", got)
+	}
+	st := got[0]
+	if st.Status != "review" || st.Count != 4 || st.AvgHours != 4 {
+		t.Errorf("review = %+v, want 4 periods averaging 4h", st)
+	}
+	if st.P50Hours != 2 || st.P85Hours != 10 || st.P95Hours != 10 {
+		t.Errorf("percentiles = %v/%v/%v, want 2/10/10", st.P50Hours, st.P85Hours, st.P95Hours)
+	}
+}
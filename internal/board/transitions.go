@@ -0,0 +1,117 @@
+package board
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/antopolskiy/kanban-md/internal/clierr"
+	"github.com/antopolskiy/kanban-md/internal/config"
+	"github.com/antopolskiy/kanban-md/internal/task"
+)
+
+// CheckTransition enforces the configured workflow when t changes status
+// from `from` to `to`: the move must match a transition rule, and the task
+// must pass the rule's guards. Guards see t as it will be written, so callers
+// apply their other changes first. Boards without transitions allow any move,
+// except that parent_done: require keeps parents with open subtasks out of
+// the done status.
+func CheckTransition(cfg *config.Config, t *task.Task, from, to string) error {
+	rule, ok := cfg.TransitionRule(from, to, t.Class)
+	if !ok {
+		allowed := cfg.AllowedTransitions(from, t.Class)
+		list := strings.Join(allowed, ", ")
+		if list == "" {
+			list = "none"
+		}
+		return clierr.Newf(clierr.TransitionNotAllowed,
+			"task #%d cannot move from %s to %s (allowed: %s)", t.ID, from, to, list).
+			WithDetails(map[string]any{"id": t.ID, "from": from, "to": to, "allowed": allowed})
+	}
+
+	var all []*task.Task
+	loadAll := func() ([]*task.Task, error) {
+		if all == nil {
+			tasks, _, err := task.ReadAllLenient(cfg.TasksPath())
+			if err != nil {
+				return nil, fmt.Errorf("reading tasks for transition guards: %w", err)
+			}
+			all = tasks
+		}
+		return all, nil
+	}
+
+	if err := checkParentDone(cfg, t, from, to, loadAll); err != nil {
+		return err
+	}
+	if rule == nil {
+		return nil
+	}
+
+	for _, guard := range rule.Guards {
+		reason, err := guardFailure(cfg, t, guard, loadAll)
+		if err != nil {
+			return err
+		}
+		if reason != "" {
+			return clierr.Newf(clierr.TransitionNotAllowed,
+				"task #%d cannot move from %s to %s: %s", t.ID, from, to, reason).
+				WithDetails(map[string]any{"id": t.ID, "from": from, "to": to, "guard": guard})
+		}
+	}
+	return nil
+}
+
+// guardFailure returns why t fails guard, or "" if it passes.
+func guardFailure(cfg *config.Config, t *task.Task, guard string,
+	loadAll func() ([]*task.Task, error),
+) (string, error) {
+	name, arg := config.ParseGuard(guard)
+	switch name {
+	case config.GuardNotBlocked:
+		if t.Blocked {
+			return "task is blocked", nil
+		}
+	case config.GuardHasAssignee:
+		if t.Assignee == "" {
+			return "task has no assignee", nil
+		}
+	case config.GuardBodyContains:
+		if !strings.Contains(strings.ToLower(t.Body), strings.ToLower(arg)) {
+			return fmt.Sprintf("body must contain %q", arg), nil
+		}
+	case config.GuardDepsDone:
+		if len(t.DependsOn) == 0 {
+			return "", nil
+		}
+		all, err := loadAll()
+		if err != nil {
+			return "", err
+		}
+		if open := openIDs(cfg, all, func(o *task.Task) bool { return slices.Contains(t.DependsOn, o.ID) }); open != "" {
+			return "dependencies not done: " + open, nil
+		}
+	case config.GuardSubtasksDone:
+		all, err := loadAll()
+		if err != nil {
+			return "", err
+		}
+		if open := openIDs(cfg, all, func(o *task.Task) bool { return o.Parent != nil && *o.Parent == t.ID }); open != "" {
+			return "subtasks not done: " + open, nil
+		}
+	}
+	return "", nil
+}
+
+// openIDs lists the non-terminal tasks selected by match as "#1, #2".
+// Dependencies that no longer exist count as done, as in `list --unblocked`.
+func openIDs(cfg *config.Config, all []*task.Task, match func(*task.Task) bool) string {
+	var ids []string
+	for _, o := range all {
+		if match(o) && !cfg.IsTerminalStatus(o.Status) {
+			ids = append(ids, "#"+strconv.Itoa(o.ID))
+		}
+	}
+	return strings.Join(ids, ", ")
+}
@@ -0,0 +1,177 @@
+package board_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/antopolskiy/kanban-md/internal/board"
+	"github.com/antopolskiy/kanban-md/internal/clierr"
+	"github.com/antopolskiy/kanban-md/internal/config"
+	"github.com/antopolskiy/kanban-md/internal/task"
+)
+
+const transitionAgent = "agent-a"
+
+// setupWorkflowBoard creates a board where tasks must go through review
+// before done, and review requires a handoff note.
+func setupWorkflowBoard(t *testing.T, tasks ...*task.Task) *config.Config {
+	t.Helper()
+	dir := t.TempDir()
+	cfg := config.NewDefault(dir)
+	cfg.SetDir(dir)
+	cfg.Transitions = []config.TransitionConfig{
+		{From: "backlog", To: []string{"todo"}},
+		{From: "todo", To: []string{"in-progress", "backlog"}},
+		{From: "in-progress", To: []string{"review", "todo"}, Guards: []string{config.GuardDepsDone}},
+		{From: "review", To: []string{"done", "in-progress"}, Guards: []string{"body_contains:handoff"}},
+		{From: "in-progress", To: []string{"done"}, Class: "expedite"},
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(cfg.TasksPath(), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	for _, tk := range tasks {
+		if err := task.Write(filepath.Join(cfg.TasksPath(), strconv.Itoa(tk.ID)+".md"), tk); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return cfg
+}
+
+func wantTransitionError(t *testing.T, err error) *clierr.Error {
+	t.Helper()
+	var cliErr *clierr.Error
+	if !errors.As(err, &cliErr) || cliErr.Code != clierr.TransitionNotAllowed {
+		t.Fatalf("err = %v, want TRANSITION_NOT_ALLOWED", err)
+	}
+	return cliErr
+}
+
+func TestMove_TransitionNotAllowed(t *testing.T) {
+	cfg := setupWorkflowBoard(t, &task.Task{ID: 1, Title: "a", Status: "backlog"})
+
+	_, err := board.Move(cfg, board.MoveParams{ID: 1, NewStatus: "done"}, time.Now())
+	cliErr := wantTransitionError(t, err)
+	if allowed, _ := cliErr.Details["allowed"].([]string); len(allowed) != 2 || allowed[0] != "todo" {
+		t.Errorf("allowed = %v, want [todo archived]", cliErr.Details["allowed"])
+	}
+
+	if _, err := board.Move(cfg, board.MoveParams{ID: 1, NewStatus: "todo"}, time.Now()); err != nil {
+		t.Fatalf("allowed move: %v", err)
+	}
+	if _, err := board.Move(cfg, board.MoveParams{ID: 1, NewStatus: config.ArchivedStatus}, time.Now()); err != nil {
+		t.Fatalf("archiving is always allowed: %v", err)
+	}
+}
+
+func TestMove_GuardDepsDone(t *testing.T) {
+	cfg := setupWorkflowBoard(t,
+		&task.Task{ID: 1, Title: "dep", Status: "todo"},
+		&task.Task{ID: 2, Title: "a", Status: "in-progress", DependsOn: []int{1}},
+	)
+
+	_, err := board.Move(cfg, board.MoveParams{ID: 2, NewStatus: "review", Claimant: transitionAgent}, time.Now())
+	if cliErr := wantTransitionError(t, err); cliErr.Details["guard"] != config.GuardDepsDone {
+		t.Errorf("guard = %v, want deps_done", cliErr.Details["guard"])
+	}
+}
+
+func TestMove_ClassRule(t *testing.T) {
+	cfg := setupWorkflowBoard(t,
+		&task.Task{ID: 1, Title: "a", Status: "in-progress", Class: "expedite"},
+		&task.Task{ID: 2, Title: "b", Status: "in-progress", Class: "standard"},
+	)
+
+	if _, err := board.Move(cfg, board.MoveParams{ID: 1, NewStatus: "done", Claimant: transitionAgent}, time.Now()); err != nil {
+		t.Fatalf("expedite may skip review: %v", err)
+	}
+	_, err := board.Move(cfg, board.MoveParams{ID: 2, NewStatus: "done", Claimant: transitionAgent}, time.Now())
+	wantTransitionError(t, err)
+}
+
+func TestEdit_TransitionGuardSeesEditedBody(t *testing.T) {
+	cfg := setupWorkflowBoard(t, &task.Task{ID: 1, Title: "a", Status: "review"})
+
+	_, err := board.Edit(cfg, 1, transitionAgent, "", false, func(tk *task.Task) (bool, error) {
+		tk.Status = "done"
+		return true, nil
+	}, time.Now())
+	wantTransitionError(t, err)
+
+	_, err = board.Edit(cfg, 1, transitionAgent, "", false, func(tk *task.Task) (bool, error) {
+		tk.Status = "done"
+		tk.Body = board.AppendBody(tk.Body, "Handoff: ready to ship", false)
+		return true, nil
+	}, time.Now())
+	if err != nil {
+		t.Fatalf("edit with note: %v", err)
+	}
+}
+
+func TestHandoff_TransitionNotAllowed(t *testing.T) {
+	cfg := setupWorkflowBoard(t, &task.Task{ID: 1, Title: "a", Status: "todo", ClaimedBy: transitionAgent})
+
+	_, err := board.Handoff(cfg, board.HandoffParams{ID: 1, Claimant: transitionAgent}, time.Now())
+	wantTransitionError(t, err)
+}
+
+func TestPickAndClaim_TransitionNotAllowed(t *testing.T) {
+	cfg := setupWorkflowBoard(t, &task.Task{ID: 1, Title: "a", Status: "backlog"})
+
+	_, _, _, err := board.PickAndClaim(cfg, board.PickAndClaimParams{
+		Claimant: transitionAgent, StatusFilter: "backlog", MoveTarget: "in-progress",
+	}, time.Now())
+	wantTransitionError(t, err)
+
+	picked, _, _, err := board.PickAndClaim(cfg, board.PickAndClaimParams{
+		Claimant: transitionAgent, StatusFilter: "backlog", MoveTarget: "todo",
+	}, time.Now())
+	if err != nil || picked.Status != "todo" {
+		t.Fatalf("pick --move todo = %v, %v", picked, err)
+	}
+}
+
+func TestCheckTransition_SubtasksDone(t *testing.T) {
+	parent := 1
+	cfg := setupWorkflowBoard(t,
+		&task.Task{ID: 1, Title: "epic", Status: "in-progress"},
+		&task.Task{ID: 2, Title: "child", Status: "todo", Parent: &parent},
+	)
+	cfg.Transitions = []config.TransitionConfig{
+		{From: config.AnyStatus, To: []string{"done"}, Guards: []string{config.GuardSubtasksDone, config.GuardNotBlocked}},
+	}
+
+	epic := &task.Task{ID: 1, Status: "in-progress"}
+	cliErr := wantTransitionError(t, board.CheckTransition(cfg, epic, "in-progress", "done"))
+	if cliErr.Details["guard"] != config.GuardSubtasksDone {
+		t.Errorf("guard = %v, want subtasks_done", cliErr.Details["guard"])
+	}
+
+	leaf := &task.Task{ID: 2, Status: "todo", Blocked: true}
+	cliErr = wantTransitionError(t, board.CheckTransition(cfg, leaf, "todo", "done"))
+	if cliErr.Details["guard"] != config.GuardNotBlocked {
+		t.Errorf("guard = %v, want not_blocked", cliErr.Details["guard"])
+	}
+}
+
+func TestUndo_IgnoresTransitions(t *testing.T) {
+	cfg := setupWorkflowBoard(t, &task.Task{ID: 1, Title: "a", Status: "backlog"})
+	if _, err := board.Move(cfg, board.MoveParams{ID: 1, NewStatus: "todo"}, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	cfg.Transitions = []config.TransitionConfig{{From: "backlog", To: []string{"todo"}}}
+
+	restored, err := board.Undo(cfg, pending(t, cfg.Dir())[0], "", time.Now())
+	if err != nil {
+		t.Fatalf("Undo: %v", err)
+	}
+	if restored.Status != "backlog" {
+		t.Errorf("status = %q, want backlog", restored.Status)
+	}
+}
@@ -0,0 +1,277 @@
+package board
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/antopolskiy/kanban-md/internal/clierr"
+	"github.com/antopolskiy/kanban-md/internal/config"
+	"github.com/antopolskiy/kanban-md/internal/task"
+)
+
+const (
+	actionCreate = "create"
+	actionUndo   = "undo"
+)
+
+// Undoable reports whether e records field changes that Undo can revert.
+// Entries without changes (e.g. the "claim" entry accompanying a "pick", or
+// entries written by older versions) cannot be undone, nor can the undo of a
+// create, which removed the task file.
+func Undoable(e LogEntry) bool {
+	if e.ID == "" || len(e.Changes) == 0 {
+		return false
+	}
+	return e.Transition == nil || e.Transition.To != ""
+}
+
+// PendingUndo returns the undoable entries that have not been undone yet,
+// newest first. Undo entries themselves are excluded, so repeatedly undoing
+// the most recent pending entry walks back through history instead of
+// toggling the last change.
+func PendingUndo(entries []LogEntry) []LogEntry {
+	undone := make(map[string]bool)
+	for _, e := range entries {
+		if e.Undoes != "" {
+			undone[e.Undoes] = true
+		}
+	}
+	var pending []LogEntry
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		if e.Action == actionUndo || undone[e.ID] || !Undoable(e) {
+			continue
+		}
+		pending = append(pending, e)
+	}
+	return pending
+}
+
+// FindLogEntry returns the entry with the given ID and whether it has already
+// been undone.
+func FindLogEntry(kanbanDir, id string) (LogEntry, bool, error) {
+	entries, err := ReadLog(kanbanDir, LogFilterOptions{})
+	if err != nil {
+		return LogEntry{}, false, err
+	}
+	idx := slices.IndexFunc(entries, func(e LogEntry) bool { return e.ID == id })
+	if idx < 0 {
+		return LogEntry{}, false, clierr.Newf(clierr.InvalidInput, "log entry %q not found", id).
+			WithDetails(map[string]any{"entry": id})
+	}
+	undone := slices.ContainsFunc(entries[idx+1:], func(e LogEntry) bool { return e.Undoes == id })
+	return entries[idx], undone, nil
+}
+
+// Undo reverts the field changes recorded in entry, restoring each field to
+// its value before the mutation. It refuses with CONFLICT if any of those
+// fields has changed since, so later work is never silently overwritten.
+//
+// The restored task goes through the same validation as an edit: claim
+// ownership (claimant), dependency references, require_claim and WIP limits
+// when the status changes. It also runs the hooks of an edit, so restoring an
+// earlier status fires move hooks like any other status change. Undoing a
+// create removes the task file, running archive hooks like a delete; its ID
+// is not given back, so it is never reused. The undo is logged as an "undo"
+// entry whose Undoes field is entry.ID.
+func Undo(cfg *config.Config, entry LogEntry, claimant string, now time.Time) (*task.Task, error) {
+	if !Undoable(entry) {
+		return nil, clierr.Newf(clierr.InvalidInput, "log entry %s (%s #%d) has no recorded changes to undo",
+			entry.ID, entry.Action, entry.TaskID).WithDetails(map[string]any{"entry": entry.ID})
+	}
+
+	unlock, err := Lock(cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock() //nolint:errcheck // best-effort unlock
+
+	path, err := task.FindByID(cfg.TasksPath(), entry.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	t, err := task.Read(path)
+	if err != nil {
+		return nil, err
+	}
+	if err = task.CheckClaim(t, claimant, cfg.ClaimTimeoutDuration()); err != nil {
+		return nil, err
+	}
+
+	before := TakeSnapshot(t)
+	if err = checkUndoConflict(entry, before); err != nil {
+		return nil, err
+	}
+
+	logEntry := LogEntry{Action: actionUndo, TaskID: t.ID, Actor: claimant, Detail: entry.Action + " " + entry.ID, Undoes: entry.ID}
+	if entry.Action == actionCreate {
+		if err = removeCreated(cfg, t, path, Actor(claimant, t)); err != nil {
+			return nil, err
+		}
+		logChangeEntry(cfg.Dir(), logEntry, before, Snapshot{})
+		recordPresence(cfg.Dir(), claimant, actionUndo, t.ID, now)
+		return t, nil
+	}
+
+	oldTitle, oldStatus := t.Title, t.Status
+	restored, err := restoreFields(before, entry.Changes)
+	if err != nil {
+		return nil, err
+	}
+	restored.File, restored.Rev = t.File, t.Rev
+	t = restored
+
+	if err = validateEditPost(cfg, t, oldStatus, claimant); err != nil {
+		return nil, err
+	}
+	t.Updated = now
+
+	hooks := newTaskHooks(cfg, config.HookEdit, before, t, Actor(claimant, t))
+	if err = hooks.runPre(t); err != nil {
+		return nil, err
+	}
+	if _, err = task.WriteAndRename(path, t, oldTitle); err != nil {
+		return nil, err
+	}
+
+	logChangeEntry(cfg.Dir(), logEntry, before, TakeSnapshot(t))
+	recordPresence(cfg.Dir(), claimant, actionUndo, t.ID, now)
+	hooks.runPost(t)
+	return t, nil
+}
+
+// checkUndoConflict verifies that every field changed by entry still has the
+// value entry left it with. For a create, every tracked field must still match.
+func checkUndoConflict(entry LogEntry, current Snapshot) error {
+	fields := make([]string, 0, len(entry.Changes))
+	for _, c := range entry.Changes {
+		fields = append(fields, c.Field)
+	}
+	if entry.Action == actionCreate {
+		fields = trackedFields
+	}
+	after := make(Snapshot, len(entry.Changes))
+	var bodyEdit *BodyEdit
+	for _, c := range entry.Changes {
+		if c.After != nil {
+			after[c.Field] = c.After
+		}
+		if c.Body != nil {
+			bodyEdit = c.Body
+		}
+	}
+	for _, f := range fields {
+		a, c := after[f], current[f]
+		var changed bool
+		switch {
+		case f == "body" && bodyEdit != nil:
+			changed = bodyHash(rawBody(c)) != bodyEdit.AfterHash
+		case f == "body":
+			changed = !jsonEqual(normalizeBody(a), normalizeBody(c))
+		default:
+			changed = !jsonEqual(a, c)
+		}
+		if changed {
+			return clierr.Newf(clierr.Conflict,
+				"task #%d: %s has changed since log entry %s; undo the later changes first",
+				entry.TaskID, f, entry.ID).
+				WithDetails(map[string]any{"id": entry.TaskID, "entry": entry.ID, "field": f})
+		}
+	}
+	return nil
+}
+
+// jsonEqual compares two JSON values semantically; absent equals null.
+func jsonEqual(a, b json.RawMessage) bool {
+	if bytes.Equal(a, b) {
+		return true
+	}
+	var va, vb any
+	if len(a) > 0 {
+		if json.Unmarshal(a, &va) != nil {
+			return false
+		}
+	}
+	if len(b) > 0 {
+		if json.Unmarshal(b, &vb) != nil {
+			return false
+		}
+	}
+	ja, _ := json.Marshal(va) //nolint:errchkjson // values decoded from JSON
+	jb, _ := json.Marshal(vb) //nolint:errchkjson // values decoded from JSON
+	return bytes.Equal(ja, jb)
+}
+
+// normalizeBody strips the surrounding newlines a body gains or loses when
+// written to and read back from a task file.
+func normalizeBody(raw json.RawMessage) json.RawMessage {
+	var body string
+	if len(raw) == 0 || json.Unmarshal(raw, &body) != nil {
+		return raw
+	}
+	body = strings.Trim(body, "\n")
+	if body == "" {
+		return nil
+	}
+	out, _ := json.Marshal(body) //nolint:errchkjson // string
+	return out
+}
+
+// restoreFields returns the task described by current with each changed
+// field set back to its Before value.
+func restoreFields(current Snapshot, changes []FieldChange) (*task.Task, error) {
+	fields := make(map[string]json.RawMessage, len(current))
+	for k, v := range current {
+		fields[k] = v
+	}
+	for _, c := range changes {
+		before := c.Before
+		if c.Body != nil {
+			body, ok := c.Body.Revert(rawBody(fields[c.Field]))
+			if !ok {
+				return nil, fmt.Errorf("restoring task: body does not match the logged edit")
+			}
+			if body != "" {
+				before, _ = json.Marshal(body) //nolint:errchkjson // string
+			}
+		}
+		if before == nil {
+			delete(fields, c.Field)
+		} else {
+			fields[c.Field] = before
+		}
+	}
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil, fmt.Errorf("restoring task: %w", err)
+	}
+	var t task.Task
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, fmt.Errorf("restoring task: %w", err)
+	}
+	return &t, nil
+}
+
+// removeCreated deletes the file of a task being un-created, running the
+// archive hooks a delete would. next_id is left alone: other processes or
+// external references may already know the ID.
+func removeCreated(cfg *config.Config, t *task.Task, path, actor string) error {
+	if deps := FindDependents(cfg.TasksPath(), t.ID); len(deps) > 0 {
+		return clierr.Newf(clierr.InvalidInput, "cannot undo create of task #%d: other tasks reference it", t.ID).
+			WithDetails(map[string]any{"id": t.ID, "dependents": deps})
+	}
+	hooks := newTaskHooks(cfg, config.HookArchive, nil, t, actor)
+	if err := hooks.runPre(t); err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("removing task file: %w", err)
+	}
+	hooks.runPost(t)
+	return nil
+}
@@ -0,0 +1,186 @@
+package board_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/antopolskiy/kanban-md/internal/board"
+	"github.com/antopolskiy/kanban-md/internal/clierr"
+	"github.com/antopolskiy/kanban-md/internal/config"
+	"github.com/antopolskiy/kanban-md/internal/task"
+)
+
+func pending(t *testing.T, dir string) []board.LogEntry {
+	t.Helper()
+	entries, err := board.ReadLog(dir, board.LogFilterOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return board.PendingUndo(entries)
+}
+
+func editTask(t *testing.T, cfg *config.Config, id int, fn func(*task.Task)) {
+	t.Helper()
+	_, err := board.Edit(cfg, id, "", "", false, func(tk *task.Task) (bool, error) {
+		fn(tk)
+		return true, nil
+	}, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUndo_RestoresPreviousValues(t *testing.T) {
+	cfg, dir := setupMutateBoard(t)
+	if _, err := board.Create(cfg, board.CreateParams{Title: "Undo me", Tags: []string{"a"}}, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	editTask(t, cfg, 1, func(tk *task.Task) {
+		tk.Priority = "critical"
+		tk.Tags = nil
+		tk.Body = board.AppendBody(tk.Body, "rogue note", false)
+	})
+
+	p := pending(t, dir)
+	if len(p) != 2 || p[0].Action != "edit" || p[1].Action != "create" {
+		t.Fatalf("pending = %+v, want [edit create]", p)
+	}
+
+	restored, err := board.Undo(cfg, p[0], "", time.Now())
+	if err != nil {
+		t.Fatalf("Undo: %v", err)
+	}
+	if restored.Priority != "medium" || len(restored.Tags) != 1 || restored.Body != "" {
+		t.Errorf("restored = priority %q tags %v body %q", restored.Priority, restored.Tags, restored.Body)
+	}
+	onDisk, err := task.Read(restored.File)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if onDisk.Priority != "medium" {
+		t.Errorf("priority on disk = %q, want medium", onDisk.Priority)
+	}
+
+	// The undo is logged, and the edit is no longer pending.
+	p = pending(t, dir)
+	if len(p) != 1 || p[0].Action != "create" {
+		t.Errorf("pending after undo = %+v, want [create]", p)
+	}
+	undoEntry := findEntry(t, dir, "undo")
+	if undoEntry.Undoes == "" || undoEntry.TaskID != 1 {
+		t.Errorf("undo entry = %+v", undoEntry)
+	}
+}
+
+func TestUndo_ConflictWhenFieldChangedLater(t *testing.T) {
+	cfg, dir := setupMutateBoard(t)
+	if _, err := board.Create(cfg, board.CreateParams{Title: "Conflict"}, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	editTask(t, cfg, 1, func(tk *task.Task) { tk.Priority = "high" })
+	editEntry := pending(t, dir)[0]
+	editTask(t, cfg, 1, func(tk *task.Task) { tk.Priority = "low" })
+
+	_, err := board.Undo(cfg, editEntry, "", time.Now())
+	var cliErr *clierr.Error
+	if !errors.As(err, &cliErr) || cliErr.Code != clierr.Conflict {
+		t.Fatalf("err = %v, want CONFLICT", err)
+	}
+	if cliErr.Details["field"] != "priority" {
+		t.Errorf("details = %v, want field priority", cliErr.Details)
+	}
+}
+
+func TestUndo_LargeBodyEdit(t *testing.T) {
+	cfg, dir := setupMutateBoard(t)
+	body := strings.Repeat("A long line of the task body.\n", 2000) // about 60KB
+	if _, err := board.Create(cfg, board.CreateParams{Title: "Big", Body: body}, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	editTask(t, cfg, 1, func(tk *task.Task) {
+		tk.Body = board.AppendBody(tk.Body, "a note", false)
+	})
+
+	// The log stores the edited span, not both bodies.
+	data, err := os.ReadFile(filepath.Join(dir, "activity.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if edit := lines[len(lines)-1]; len(edit) > len(body)/2 {
+		t.Errorf("edit entry is %d bytes, want far less than the %d-byte body", len(edit), len(body))
+	}
+
+	p := pending(t, dir)
+	restored, err := board.Undo(cfg, p[0], "", time.Now())
+	if err != nil {
+		t.Fatalf("Undo: %v", err)
+	}
+	if strings.TrimSpace(restored.Body) != strings.TrimSpace(body) {
+		t.Error("undo did not restore the original body")
+	}
+
+	// A body changed since the edit is a conflict.
+	editTask(t, cfg, 1, func(tk *task.Task) { tk.Body += "\nmore" })
+	p = pending(t, dir)
+	_, err = board.Undo(cfg, p[len(p)-1], "", time.Now())
+	var cliErr *clierr.Error
+	if !errors.As(err, &cliErr) || cliErr.Code != clierr.Conflict || cliErr.Details["field"] != "body" {
+		t.Errorf("undoing the create after a body change: err = %v, want a body CONFLICT", err)
+	}
+}
+
+func TestUndo_CreateRemovesTaskAndKeepsNextID(t *testing.T) {
+	// A board that config.Load accepts, so next_id round-trips through disk.
+	cfg, err := config.Init(filepath.Join(t.TempDir(), "kanban"), "Undo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := cfg.Dir()
+	res, err := board.Create(cfg, board.CreateParams{Title: "Oops"}, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := board.Undo(cfg, pending(t, dir)[0], "", time.Now()); err != nil {
+		t.Fatalf("Undo: %v", err)
+	}
+	if _, err := os.Stat(res.Path); !os.IsNotExist(err) {
+		t.Errorf("task file still exists: %v", err)
+	}
+	reloaded, err := config.Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reloaded.NextID != 2 {
+		t.Errorf("next_id = %d, want 2 (IDs are never reused)", reloaded.NextID)
+	}
+	if len(pending(t, dir)) != 0 {
+		t.Error("undo of a create must not itself be undoable")
+	}
+}
+
+func TestUndo_ValidatesWIPLimit(t *testing.T) {
+	cfg, dir := setupMutateBoard(t)
+	for _, title := range []string{"A", "B"} {
+		if _, err := board.Create(cfg, board.CreateParams{Title: title, Status: "todo"}, time.Now()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := board.Move(cfg, board.MoveParams{ID: 1, NewStatus: "backlog"}, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	moveEntry := pending(t, dir)[0]
+
+	// With todo now full, moving #1 back into it would exceed the limit.
+	cfg.WIPLimits = map[string]int{"todo": 1}
+	_, err := board.Undo(cfg, moveEntry, "", time.Now())
+	var cliErr *clierr.Error
+	if !errors.As(err, &cliErr) || cliErr.Code != clierr.WIPLimitExceeded {
+		t.Fatalf("err = %v, want WIP_LIMIT_EXCEEDED", err)
+	}
+}
@@ -0,0 +1,86 @@
+package board
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/antopolskiy/kanban-md/internal/clierr"
+	"github.com/antopolskiy/kanban-md/internal/config"
+)
+
+// FindView returns the named saved view or a VIEW_NOT_FOUND error listing
+// the views that do exist.
+func FindView(cfg *config.Config, name string) (*config.ViewConfig, error) {
+	if v := cfg.ViewByName(name); v != nil {
+		return v, nil
+	}
+	return nil, clierr.Newf(clierr.ViewNotFound, "view %q not found", name).
+		WithDetails(map[string]any{"view": name, "views": cfg.ViewNames()})
+}
+
+// ViewListOptions converts a saved view into list options. The view's query,
+// sort field and group-by field are validated here because config validation
+// does not depend on the board package. Archived tasks are excluded unless the
+// view asks for them or filters on status, matching `list`.
+func ViewListOptions(cfg *config.Config, v *config.ViewConfig) (ListOptions, error) {
+	if err := validateViewFields(cfg, v); err != nil {
+		return ListOptions{}, err
+	}
+
+	filter := FilterOptions{
+		Statuses:     v.Statuses,
+		Priorities:   v.Priorities,
+		Assignee:     v.Assignee,
+		Tag:          v.Tag,
+		Search:       v.Search,
+		Blocked:      v.Blocked,
+		Unclaimed:    v.Unclaimed,
+		ClaimedBy:    v.ClaimedBy,
+		ClaimTimeout: cfg.ClaimTimeoutDuration(),
+		Class:        v.Class,
+	}
+	if v.Query != "" {
+		q, err := ParseQuery(v.Query, cfg)
+		if err != nil {
+			return ListOptions{}, err
+		}
+		filter.Query = q
+	}
+
+	if v.Archived {
+		filter.Statuses = []string{config.ArchivedStatus}
+	} else if len(v.Statuses) == 0 && (filter.Query == nil || !filter.Query.UsesField(fieldStatus)) {
+		filter.ExcludeStatuses = []string{config.ArchivedStatus}
+	}
+
+	sortBy := v.Sort
+	if sortBy == "" {
+		sortBy = "id"
+	}
+	return ListOptions{
+		Filter:    filter,
+		SortBy:    sortBy,
+		Reverse:   v.Reverse,
+		Limit:     v.Limit,
+		Unblocked: v.Unblocked,
+	}, nil
+}
+
+// ValidateView checks everything about a view that config validation cannot:
+// the query syntax, sort field and group-by field.
+func ValidateView(cfg *config.Config, v *config.ViewConfig) error {
+	_, err := ViewListOptions(cfg, v)
+	return err
+}
+
+func validateViewFields(cfg *config.Config, v *config.ViewConfig) error {
+	if v.Sort != "" && !slices.Contains(ValidSortFields(), v.Sort) && cfg.FieldByName(v.Sort) == nil {
+		return clierr.Newf(clierr.InvalidInput, "view %q: invalid sort field %q; valid: %s",
+			v.Name, v.Sort, strings.Join(ValidSortFields(), ", "))
+	}
+	if v.GroupBy != "" && !slices.Contains(ValidGroupByFields(), v.GroupBy) {
+		return clierr.Newf(clierr.InvalidGroupBy, "view %q: invalid group_by field %q; valid: %s",
+			v.Name, v.GroupBy, strings.Join(ValidGroupByFields(), ", "))
+	}
+	return nil
+}
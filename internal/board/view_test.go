@@ -0,0 +1,96 @@
+package board
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/antopolskiy/kanban-md/internal/clierr"
+	"github.com/antopolskiy/kanban-md/internal/config"
+)
+
+func TestViewListOptions(t *testing.T) {
+	cfg := config.NewDefault("Test")
+	blocked := false
+	v := &config.ViewConfig{
+		Name:       "mine",
+		Query:      "tag:backend",
+		Priorities: []string{"high"},
+		Assignee:   "alice",
+		Blocked:    &blocked,
+		Sort:       "priority",
+		Reverse:    true,
+		Limit:      5,
+	}
+
+	opts, err := ViewListOptions(cfg, v)
+	if err != nil {
+		t.Fatalf("ViewListOptions: %v", err)
+	}
+	if opts.SortBy != "priority" || !opts.Reverse || opts.Limit != 5 {
+		t.Errorf("sort/limit = %q %v %d", opts.SortBy, opts.Reverse, opts.Limit)
+	}
+	if len(opts.Filter.ExcludeStatuses) != 1 || opts.Filter.ExcludeStatuses[0] != config.ArchivedStatus {
+		t.Errorf("ExcludeStatuses = %v, want archived excluded", opts.Filter.ExcludeStatuses)
+	}
+
+	got := Filter(makeTasks(), opts.Filter)
+	if len(got) != 1 || got[0].ID != 1 {
+		t.Errorf("filtered = %v, want only #1", got)
+	}
+}
+
+func TestViewListOptions_Archived(t *testing.T) {
+	cfg := config.NewDefault("Test")
+
+	opts, err := ViewListOptions(cfg, &config.ViewConfig{Name: "old", Archived: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(opts.Filter.Statuses) != 1 || opts.Filter.Statuses[0] != config.ArchivedStatus {
+		t.Errorf("Statuses = %v, want [archived]", opts.Filter.Statuses)
+	}
+
+	opts, err = ViewListOptions(cfg, &config.ViewConfig{Name: "any", Query: "status:done"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(opts.Filter.ExcludeStatuses) != 0 {
+		t.Errorf("ExcludeStatuses = %v, want none when query filters status", opts.Filter.ExcludeStatuses)
+	}
+}
+
+func TestValidateView_Errors(t *testing.T) {
+	cfg := config.NewDefault("Test")
+	tests := []struct {
+		name string
+		view config.ViewConfig
+		code string
+	}{
+		{"bad sort", config.ViewConfig{Name: "a", Sort: "size"}, clierr.InvalidInput},
+		{"bad group_by", config.ViewConfig{Name: "a", GroupBy: "due"}, clierr.InvalidGroupBy},
+		{"bad query", config.ViewConfig{Name: "a", Query: "(tag:x"}, clierr.InvalidQuery},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateView(cfg, &tt.view)
+			var cliErr *clierr.Error
+			if !errors.As(err, &cliErr) || cliErr.Code != tt.code {
+				t.Fatalf("err = %v, want %s", err, tt.code)
+			}
+		})
+	}
+}
+
+func TestFindView(t *testing.T) {
+	cfg := config.NewDefault("Test")
+	cfg.Views = []config.ViewConfig{{Name: "mine"}}
+
+	if v, err := FindView(cfg, "mine"); err != nil || v.Name != "mine" {
+		t.Errorf("FindView(mine) = %v, %v", v, err)
+	}
+	_, err := FindView(cfg, "nope")
+	var cliErr *clierr.Error
+	if !errors.As(err, &cliErr) || cliErr.Code != clierr.ViewNotFound {
+		t.Fatalf("err = %v, want VIEW_NOT_FOUND", err)
+	}
+}
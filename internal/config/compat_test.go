@@ -635,11 +635,6 @@ func TestCompatV9ConfigMigratesToV10(t *testing.T) {
 }
 
 func TestCompatV10ConfigMigratesToV11(t *testing.T) {
-	const wantVersion = 11
-	if CurrentVersion != wantVersion {
-		t.Fatalf("CurrentVersion = %d, want %d for narrow_threshold schema", CurrentVersion, wantVersion)
-	}
-
 	tmp := t.TempDir()
 	fixture := filepath.Join("testdata", "compat", "v10")
 	copyDir(t, fixture, tmp)
@@ -648,8 +643,8 @@ func TestCompatV10ConfigMigratesToV11(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Load() v10 fixture: %v", err)
 	}
-	if cfg.Version != wantVersion {
-		t.Errorf("Version = %d, want %d (after migration)", cfg.Version, wantVersion)
+	if cfg.Version != CurrentVersion {
+		t.Errorf("Version = %d, want %d (after migration)", cfg.Version, CurrentVersion)
 	}
 	if cfg.Board.Name != "Test Project v10" {
 		t.Errorf("Board.Name = %q, want %q", cfg.Board.Name, "Test Project v10")
@@ -662,6 +657,203 @@ func TestCompatV10ConfigMigratesToV11(t *testing.T) {
 	}
 }
 
+func TestCompatV11ConfigMigratesToV12(t *testing.T) {
+	tmp := t.TempDir()
+	fixture := filepath.Join("testdata", "compat", "v11")
+	copyDir(t, fixture, tmp)
+
+	cfg, err := Load(tmp)
+	if err != nil {
+		t.Fatalf("Load() v11 fixture: %v", err)
+	}
+	if cfg.Version != CurrentVersion {
+		t.Errorf("Version = %d, want %d (after migration)", cfg.Version, CurrentVersion)
+	}
+	if cfg.Board.Name != "Test Project v11" {
+		t.Errorf("Board.Name = %q, want %q", cfg.Board.Name, "Test Project v11")
+	}
+	if cfg.TUI.NarrowThreshold != 100 {
+		t.Errorf("TUI.NarrowThreshold = %d, want preserved 100", cfg.TUI.NarrowThreshold)
+	}
+	// v11→v12 introduces lock_timeout with the default acquire timeout.
+	if cfg.LockTimeout != DefaultLockTimeout {
+		t.Errorf("LockTimeout = %q, want %q", cfg.LockTimeout, DefaultLockTimeout)
+	}
+}
+
+func TestCompatV12ConfigMigratesToV13(t *testing.T) {
+	tmp := t.TempDir()
+	fixture := filepath.Join("testdata", "compat", "v12")
+	copyDir(t, fixture, tmp)
+
+	cfg, err := Load(tmp)
+	if err != nil {
+		t.Fatalf("Load() v12 fixture: %v", err)
+	}
+	if cfg.Version != CurrentVersion {
+		t.Errorf("Version = %d, want %d (after migration)", cfg.Version, CurrentVersion)
+	}
+	if cfg.Board.Name != "Test Project v12" {
+		t.Errorf("Board.Name = %q, want %q", cfg.Board.Name, "Test Project v12")
+	}
+	if cfg.LockTimeout != "10s" {
+		t.Errorf("LockTimeout = %q, want preserved 10s", cfg.LockTimeout)
+	}
+	// v12→v13 introduces views; existing boards start with none.
+	if len(cfg.Views) != 0 {
+		t.Errorf("Views = %v, want none", cfg.Views)
+	}
+}
+
+func TestCompatV13ConfigMigratesToV14(t *testing.T) {
+	tmp := t.TempDir()
+	fixture := filepath.Join("testdata", "compat", "v13")
+	copyDir(t, fixture, tmp)
+
+	cfg, err := Load(tmp)
+	if err != nil {
+		t.Fatalf("Load() v13 fixture: %v", err)
+	}
+	if cfg.Version != CurrentVersion {
+		t.Errorf("Version = %d, want %d (after migration)", cfg.Version, CurrentVersion)
+	}
+	if cfg.Board.Name != "Test Project v13" {
+		t.Errorf("Board.Name = %q, want %q", cfg.Board.Name, "Test Project v13")
+	}
+	if v := cfg.ViewByName("mine"); v == nil || v.Assignee != "alice" {
+		t.Errorf("view mine = %+v, want preserved", v)
+	}
+	// v13→v14 introduces fields; existing boards start with no schema.
+	if len(cfg.Fields) != 0 {
+		t.Errorf("Fields = %v, want none", cfg.Fields)
+	}
+}
+
+func TestCompatV14ConfigMigratesToV15(t *testing.T) {
+	tmp := t.TempDir()
+	fixture := filepath.Join("testdata", "compat", "v14")
+	copyDir(t, fixture, tmp)
+
+	cfg, err := Load(tmp)
+	if err != nil {
+		t.Fatalf("Load() v14 fixture: %v", err)
+	}
+	if cfg.Version != CurrentVersion {
+		t.Errorf("Version = %d, want %d (after migration)", cfg.Version, CurrentVersion)
+	}
+	if cfg.Board.Name != "Test Project v14" {
+		t.Errorf("Board.Name = %q, want %q", cfg.Board.Name, "Test Project v14")
+	}
+	if f := cfg.FieldByName("severity"); f == nil || f.Default != "s3" {
+		t.Errorf("field severity = %+v, want preserved", f)
+	}
+	// v14→v15 introduces transitions; existing boards stay unrestricted.
+	if cfg.HasTransitions() {
+		t.Errorf("Transitions = %v, want none", cfg.Transitions)
+	}
+}
+
+func TestCompatV15ConfigMigratesToV16(t *testing.T) {
+	tmp := t.TempDir()
+	fixture := filepath.Join("testdata", "compat", "v15")
+	copyDir(t, fixture, tmp)
+
+	cfg, err := Load(tmp)
+	if err != nil {
+		t.Fatalf("Load() v15 fixture: %v", err)
+	}
+	if cfg.Version != CurrentVersion {
+		t.Errorf("Version = %d, want %d (after migration)", cfg.Version, CurrentVersion)
+	}
+	if cfg.Board.Name != "Test Project v15" {
+		t.Errorf("Board.Name = %q, want %q", cfg.Board.Name, "Test Project v15")
+	}
+	if len(cfg.Transitions) != 2 {
+		t.Errorf("Transitions = %v, want 2 preserved", cfg.Transitions)
+	}
+	// v15→v16 introduces hooks; existing boards start with none.
+	if len(cfg.Hooks) != 0 {
+		t.Errorf("Hooks = %v, want none", cfg.Hooks)
+	}
+}
+
+func TestCompatV16ConfigMigratesToV17(t *testing.T) {
+	tmp := t.TempDir()
+	fixture := filepath.Join("testdata", "compat", "v16")
+	copyDir(t, fixture, tmp)
+
+	cfg, err := Load(tmp)
+	if err != nil {
+		t.Fatalf("Load() v16 fixture: %v", err)
+	}
+	if cfg.Version != CurrentVersion {
+		t.Errorf("Version = %d, want %d (after migration)", cfg.Version, CurrentVersion)
+	}
+	if cfg.Board.Name != "Test Project v16" {
+		t.Errorf("Board.Name = %q, want %q", cfg.Board.Name, "Test Project v16")
+	}
+	if len(cfg.Hooks) != 1 {
+		t.Errorf("Hooks = %v, want 1 preserved", cfg.Hooks)
+	}
+	// v16→v17 introduces claim_expired_status; existing boards leave tasks in place.
+	if cfg.ClaimExpiredStatus != "" {
+		t.Errorf("ClaimExpiredStatus = %q, want empty", cfg.ClaimExpiredStatus)
+	}
+}
+
+func TestCompatV17ConfigMigratesToV18(t *testing.T) {
+	tmp := t.TempDir()
+	fixture := filepath.Join("testdata", "compat", "v17")
+	copyDir(t, fixture, tmp)
+
+	cfg, err := Load(tmp)
+	if err != nil {
+		t.Fatalf("Load() v17 fixture: %v", err)
+	}
+	if cfg.Version != CurrentVersion {
+		t.Errorf("Version = %d, want %d (after migration)", cfg.Version, CurrentVersion)
+	}
+	if cfg.Board.Name != "Test Project v17" {
+		t.Errorf("Board.Name = %q, want %q", cfg.Board.Name, "Test Project v17")
+	}
+	if cfg.ClaimExpiredStatus != "todo" {
+		t.Errorf("ClaimExpiredStatus = %q, want %q preserved", cfg.ClaimExpiredStatus, "todo")
+	}
+	// v17→v18 introduces parent_done; existing boards keep parents independent.
+	if cfg.ParentDone != "" {
+		t.Errorf("ParentDone = %q, want empty", cfg.ParentDone)
+	}
+}
+
+func TestCompatV18ConfigMigratesToV19(t *testing.T) {
+	const wantVersion = 19
+	if CurrentVersion != wantVersion {
+		t.Fatalf("CurrentVersion = %d, want %d for recurring", CurrentVersion, wantVersion)
+	}
+
+	tmp := t.TempDir()
+	fixture := filepath.Join("testdata", "compat", "v18")
+	copyDir(t, fixture, tmp)
+
+	cfg, err := Load(tmp)
+	if err != nil {
+		t.Fatalf("Load() v18 fixture: %v", err)
+	}
+	if cfg.Version != wantVersion {
+		t.Errorf("Version = %d, want %d (after migration)", cfg.Version, wantVersion)
+	}
+	if cfg.Board.Name != "Test Project v18" {
+		t.Errorf("Board.Name = %q, want %q", cfg.Board.Name, "Test Project v18")
+	}
+	if cfg.ParentDone != ParentDoneRequire {
+		t.Errorf("ParentDone = %q, want %q preserved", cfg.ParentDone, ParentDoneRequire)
+	}
+	// v18→v19 introduces recurring; existing boards have no schedules.
+	if len(cfg.Recurring) != 0 {
+		t.Errorf("Recurring = %v, want none", cfg.Recurring)
+	}
+}
+
 func TestCompatV1TasksReadable(t *testing.T) {
 	// This test verifies that the current task reader can parse v1 task files.
 	// We only check that files exist and are well-formed here; detailed task
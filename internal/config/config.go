@@ -9,6 +9,7 @@ import (
 
 	"go.yaml.in/yaml/v3"
 
+	"github.com/antopolskiy/kanban-md/internal/atomicfile"
 	"github.com/antopolskiy/kanban-md/internal/clierr"
 )
 
@@ -22,17 +23,25 @@ var (
 
 // Config represents the kanban board configuration.
 type Config struct {
-	Version      int            `yaml:"version"`
-	Board        BoardConfig    `yaml:"board"`
-	TasksDir     string         `yaml:"tasks_dir"`
-	Statuses     []StatusConfig `yaml:"statuses"`
-	Priorities   []string       `yaml:"priorities"`
-	Defaults     DefaultsConfig `yaml:"defaults"`
-	WIPLimits    map[string]int `yaml:"wip_limits,omitempty"`
-	ClaimTimeout string         `yaml:"claim_timeout,omitempty"`
-	Classes      []ClassConfig  `yaml:"classes,omitempty"`
-	TUI          TUIConfig      `yaml:"tui,omitempty"`
-	NextID       int            `yaml:"next_id"`
+	Version            int                `yaml:"version"`
+	Board              BoardConfig        `yaml:"board"`
+	TasksDir           string             `yaml:"tasks_dir"`
+	Statuses           []StatusConfig     `yaml:"statuses"`
+	Priorities         []string           `yaml:"priorities"`
+	Defaults           DefaultsConfig     `yaml:"defaults"`
+	WIPLimits          map[string]int     `yaml:"wip_limits,omitempty"`
+	ClaimTimeout       string             `yaml:"claim_timeout,omitempty"`
+	ClaimExpiredStatus string             `yaml:"claim_expired_status,omitempty"`
+	LockTimeout        string             `yaml:"lock_timeout,omitempty"`
+	Classes            []ClassConfig      `yaml:"classes,omitempty"`
+	TUI                TUIConfig          `yaml:"tui,omitempty"`
+	Views              []ViewConfig       `yaml:"views,omitempty"`
+	Fields             []FieldConfig      `yaml:"fields,omitempty"`
+	Transitions        []TransitionConfig `yaml:"transitions,omitempty"`
+	Hooks              []HookConfig       `yaml:"hooks,omitempty"`
+	ParentDone         string             `yaml:"parent_done,omitempty"`
+	Recurring          []RecurringConfig  `yaml:"recurring,omitempty"`
+	NextID             int                `yaml:"next_id"`
 
 	// dir is the absolute path to the kanban directory (not serialized).
 	dir string `yaml:"-"`
@@ -119,6 +128,7 @@ func NewDefault(name string) *Config {
 		Priorities:   append([]string{}, DefaultPriorities...),
 		Classes:      append([]ClassConfig{}, DefaultClasses...),
 		ClaimTimeout: DefaultClaimTimeout,
+		LockTimeout:  DefaultLockTimeout,
 		TUI: TUIConfig{
 			TitleLines:       DefaultTitleLines,
 			AgeThresholds:    append([]AgeThreshold{}, DefaultAgeThresholds...),
@@ -201,17 +211,14 @@ func (c *Config) Validate() error {
 	if !contains(c.Priorities, c.Defaults.Priority) {
 		return fmt.Errorf("%w: default priority %q not in priorities list", ErrInvalid, c.Defaults.Priority)
 	}
-	if err := c.validateWIPLimits(); err != nil {
-		return err
-	}
-	if err := c.validateClasses(); err != nil {
-		return err
-	}
-	if err := c.validateClaimTimeout(); err != nil {
-		return err
-	}
-	if err := c.validateTUI(); err != nil {
-		return err
+	for _, validate := range []func() error{
+		c.validateWIPLimits, c.validateClasses, c.validateClaimTimeout, c.validateLockTimeout,
+		c.validateTUI, c.validateViews, c.validateFields, c.validateTransitions, c.validateHooks,
+		c.validateParentDone, c.validateRecurring,
+	} {
+		if err := validate(); err != nil {
+			return err
+		}
 	}
 	if c.NextID < 1 {
 		return fmt.Errorf("%w: next_id must be >= 1", ErrInvalid)
@@ -261,6 +268,23 @@ func (c *Config) validateClaimTimeout() error {
 			return fmt.Errorf("%w: invalid claim_timeout %q: %w", ErrInvalid, c.ClaimTimeout, err)
 		}
 	}
+	if c.ClaimExpiredStatus != "" && !contains(c.BoardStatuses(), c.ClaimExpiredStatus) {
+		return fmt.Errorf("%w: claim_expired_status %q not in statuses list", ErrInvalid, c.ClaimExpiredStatus)
+	}
+	return nil
+}
+
+func (c *Config) validateLockTimeout() error {
+	if c.LockTimeout == "" {
+		return nil
+	}
+	d, err := time.ParseDuration(c.LockTimeout)
+	if err != nil {
+		return fmt.Errorf("%w: invalid lock_timeout %q: %w", ErrInvalid, c.LockTimeout, err)
+	}
+	if d < 0 {
+		return fmt.Errorf("%w: lock_timeout must be >= 0", ErrInvalid)
+	}
 	return nil
 }
 
@@ -332,6 +356,21 @@ func (c *Config) ClaimTimeoutDuration() time.Duration {
 	return d
 }
 
+// LockTimeoutDuration returns how long mutations wait for the board lock.
+// Returns the default if the field is empty or unparseable, and 0 (wait
+// indefinitely) if it is explicitly set to "0s".
+func (c *Config) LockTimeoutDuration() time.Duration {
+	if c.LockTimeout == "" {
+		d, _ := time.ParseDuration(DefaultLockTimeout)
+		return d
+	}
+	d, err := time.ParseDuration(c.LockTimeout)
+	if err != nil {
+		d, _ = time.ParseDuration(DefaultLockTimeout)
+	}
+	return d
+}
+
 // TitleLines returns the configured number of title lines for TUI cards.
 // Returns DefaultTitleLines if the value is unset (zero).
 func (c *Config) TitleLines() int {
@@ -394,13 +433,14 @@ func Init(dir, name string) (*Config, error) {
 	return cfg, nil
 }
 
-// Save writes the config to its config file.
+// Save writes the config to its config file. The file is replaced atomically,
+// so concurrent readers never see a partially written config.
 func (c *Config) Save() error {
 	data, err := yaml.Marshal(c)
 	if err != nil {
 		return fmt.Errorf("marshaling config: %w", err)
 	}
-	return os.WriteFile(c.ConfigPath(), data, fileMode)
+	return atomicfile.Write(c.ConfigPath(), data, fileMode)
 }
 
 // Load reads and validates a config from the given kanban directory.
@@ -495,6 +535,16 @@ func (c *Config) IsTerminalStatus(s string) bool {
 	return s == names[lastIdx]
 }
 
+// DoneStatus returns the status finished work moves to: the last board
+// status.
+func (c *Config) DoneStatus() string {
+	statuses := c.BoardStatuses()
+	if len(statuses) == 0 {
+		return ""
+	}
+	return statuses[len(statuses)-1]
+}
+
 // IsArchivedStatus returns true if the given status is the archived status.
 func (c *Config) IsArchivedStatus(s string) bool {
 	return s == ArchivedStatus && contains(c.StatusNames(), ArchivedStatus)
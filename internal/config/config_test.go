@@ -2,9 +2,12 @@ package config
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/antopolskiy/kanban-md/internal/clierr"
 )
@@ -60,6 +63,104 @@ func TestValidate(t *testing.T) {
 		{"tui.title_lines=0", func(c *Config) { c.TUI.TitleLines = 0 }, true},
 		{"tui.title_lines=4", func(c *Config) { c.TUI.TitleLines = 4 }, true},
 		{"tui.title_lines=-1", func(c *Config) { c.TUI.TitleLines = -1 }, true},
+		{"valid view", func(c *Config) {
+			c.Views = []ViewConfig{{Name: "mine", Statuses: []string{"todo"}, Priorities: []string{"high"}}}
+		}, false},
+		{"view empty name", func(c *Config) { c.Views = []ViewConfig{{}} }, true},
+		{"view name with dot", func(c *Config) { c.Views = []ViewConfig{{Name: "a.b"}} }, true},
+		{"view name with space", func(c *Config) { c.Views = []ViewConfig{{Name: "my view"}} }, true},
+		{"duplicate views", func(c *Config) { c.Views = []ViewConfig{{Name: "a"}, {Name: "a"}} }, true},
+		{"view unknown status", func(c *Config) { c.Views = []ViewConfig{{Name: "a", Statuses: []string{"bogus"}}} }, true},
+		{"view unknown priority", func(c *Config) { c.Views = []ViewConfig{{Name: "a", Priorities: []string{"bogus"}}} }, true},
+		{"view unknown class", func(c *Config) { c.Views = []ViewConfig{{Name: "a", Class: "bogus"}} }, true},
+		{"view negative limit", func(c *Config) { c.Views = []ViewConfig{{Name: "a", Limit: -1}} }, true},
+		{"valid fields", func(c *Config) {
+			c.Fields = []FieldConfig{
+				{Name: "severity", Type: FieldTypeEnum, Values: []string{"s1", "s2"}, Default: "s2"},
+				{Name: "points", Type: FieldTypeInt, Required: true},
+				{Name: "story-url", Type: FieldTypeString},
+			}
+		}, false},
+		{"field empty name", func(c *Config) { c.Fields = []FieldConfig{{Type: FieldTypeString}} }, true},
+		{"field reserved name", func(c *Config) { c.Fields = []FieldConfig{{Name: "status", Type: FieldTypeString}} }, true},
+		{"field name with colon", func(c *Config) { c.Fields = []FieldConfig{{Name: "a:b", Type: FieldTypeString}} }, true},
+		{"duplicate fields", func(c *Config) {
+			c.Fields = []FieldConfig{{Name: "a", Type: FieldTypeInt}, {Name: "a", Type: FieldTypeInt}}
+		}, true},
+		{"field bad type", func(c *Config) { c.Fields = []FieldConfig{{Name: "a", Type: "float"}} }, true},
+		{"enum without values", func(c *Config) { c.Fields = []FieldConfig{{Name: "a", Type: FieldTypeEnum}} }, true},
+		{"values on non-enum", func(c *Config) {
+			c.Fields = []FieldConfig{{Name: "a", Type: FieldTypeString, Values: []string{"x"}}}
+		}, true},
+		{"field bad default", func(c *Config) { c.Fields = []FieldConfig{{Name: "a", Type: FieldTypeInt, Default: "many"}} }, true},
+		{"enum default not in values", func(c *Config) {
+			c.Fields = []FieldConfig{{Name: "a", Type: FieldTypeEnum, Values: []string{"x"}, Default: "y"}}
+		}, true},
+		{"valid transitions", func(c *Config) {
+			c.Transitions = []TransitionConfig{
+				{From: "review", To: []string{"done"}, Guards: []string{"deps_done", "body_contains:Handoff"}},
+				{From: AnyStatus, To: []string{"backlog"}, Class: "expedite"},
+			}
+		}, false},
+		{"transition unknown from", func(c *Config) { c.Transitions = []TransitionConfig{{From: "qa", To: []string{"done"}}} }, true},
+		{"transition unknown to", func(c *Config) { c.Transitions = []TransitionConfig{{From: "todo", To: []string{"qa"}}} }, true},
+		{"transition without to", func(c *Config) { c.Transitions = []TransitionConfig{{From: "todo"}} }, true},
+		{"transition unknown class", func(c *Config) {
+			c.Transitions = []TransitionConfig{{From: "todo", To: []string{"done"}, Class: "vip"}}
+		}, true},
+		{"transition unknown guard", func(c *Config) {
+			c.Transitions = []TransitionConfig{{From: "todo", To: []string{"done"}, Guards: []string{"reviewed"}}}
+		}, true},
+		{"body_contains without text", func(c *Config) {
+			c.Transitions = []TransitionConfig{{From: "todo", To: []string{"done"}, Guards: []string{"body_contains"}}}
+		}, true},
+		{"valid hooks", func(c *Config) {
+			c.Hooks = []HookConfig{
+				{Event: HookMove, When: HookPre, Statuses: []string{"review"}, Command: "make test", Timeout: "5m"},
+				{Event: HookBlock, Command: "notify-send blocked"},
+			}
+		}, false},
+		{"hook unknown event", func(c *Config) { c.Hooks = []HookConfig{{Event: "delete", Command: "true"}} }, true},
+		{"hook invalid when", func(c *Config) { c.Hooks = []HookConfig{{Event: HookMove, When: "during", Command: "true"}} }, true},
+		{"hook without command", func(c *Config) { c.Hooks = []HookConfig{{Event: HookMove, Command: " "}} }, true},
+		{"hook unknown status", func(c *Config) {
+			c.Hooks = []HookConfig{{Event: HookMove, Statuses: []string{"qa"}, Command: "true"}}
+		}, true},
+		{"hook invalid timeout", func(c *Config) { c.Hooks = []HookConfig{{Event: HookMove, Command: "true", Timeout: "soon"}} }, true},
+		{"hook zero timeout", func(c *Config) { c.Hooks = []HookConfig{{Event: HookMove, Command: "true", Timeout: "0s"}} }, true},
+		{"valid claim_expired_status", func(c *Config) { c.ClaimExpiredStatus = "todo" }, false},
+		{"unknown claim_expired_status", func(c *Config) { c.ClaimExpiredStatus = "qa" }, true},
+		{"archived claim_expired_status", func(c *Config) { c.ClaimExpiredStatus = ArchivedStatus }, true},
+		{"parent_done auto", func(c *Config) { c.ParentDone = ParentDoneAuto }, false},
+		{"parent_done require", func(c *Config) { c.ParentDone = ParentDoneRequire }, false},
+		{"invalid parent_done", func(c *Config) { c.ParentDone = "always" }, true},
+		{"valid recurring", func(c *Config) {
+			c.Recurring = []RecurringConfig{
+				{Name: "audit", Title: "Audit {date}", Every: "1w", Start: "2026-01-05", Priority: "high"},
+				{Name: "notes", Title: "Release notes", Cron: "0 9 * * mon", DueIn: "2d"},
+			}
+		}, false},
+		{"recurring without name", func(c *Config) {
+			c.Recurring = []RecurringConfig{{Title: "x", Every: "1d"}}
+		}, true},
+		{"duplicate recurring name", func(c *Config) {
+			c.Recurring = []RecurringConfig{{Name: "a", Title: "x", Every: "1d"}, {Name: "a", Title: "y", Every: "1d"}}
+		}, true},
+		{"recurring without schedule", func(c *Config) {
+			c.Recurring = []RecurringConfig{{Name: "a", Title: "x"}}
+		}, true},
+		{"recurring with every and cron", func(c *Config) {
+			c.Recurring = []RecurringConfig{{Name: "a", Title: "x", Every: "1d", Cron: "@daily"}}
+		}, true},
+		{"recurring invalid cron", func(c *Config) {
+			c.Recurring = []RecurringConfig{{Name: "a", Title: "x", Cron: "0 25 * * *"}}
+		}, true},
+		{"recurring unknown status", func(c *Config) {
+			c.Recurring = []RecurringConfig{{Name: "a", Title: "x", Every: "1d", Status: "qa"}}
+		}, true},
+		{"recurring invalid start", func(c *Config) {
+			c.Recurring = []RecurringConfig{{Name: "a", Title: "x", Every: "1d", Start: "monday"}}
+		}, true},
 	}
 
 	for _, tt := range tests {
@@ -106,6 +207,241 @@ func TestSaveAndLoad(t *testing.T) {
 	}
 }
 
+func TestSetAndRemoveView(t *testing.T) {
+	cfg := NewDefault("Test")
+	cfg.SetView(ViewConfig{Name: "a", Tag: "api"})
+	cfg.SetView(ViewConfig{Name: "b"})
+	cfg.SetView(ViewConfig{Name: "a", Tag: "ui"})
+
+	if got := cfg.ViewNames(); len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Fatalf("ViewNames() = %v, want [a b]", got)
+	}
+	if v := cfg.ViewByName("a"); v == nil || v.Tag != "ui" {
+		t.Errorf("ViewByName(a) = %+v, want replaced view with tag ui", v)
+	}
+	if !cfg.RemoveView("a") || cfg.RemoveView("a") {
+		t.Error("RemoveView(a) should succeed once")
+	}
+	if cfg.ViewByName("a") != nil || len(cfg.Views) != 1 {
+		t.Errorf("Views = %v, want only b", cfg.Views)
+	}
+}
+
+func TestFieldParseValue(t *testing.T) {
+	tests := []struct {
+		field   FieldConfig
+		raw     string
+		want    any
+		wantErr bool
+	}{
+		{FieldConfig{Type: FieldTypeString}, "hello", "hello", false},
+		{FieldConfig{Type: FieldTypeInt}, " 42", 42, false},
+		{FieldConfig{Type: FieldTypeInt}, "4.2", nil, true},
+		{FieldConfig{Type: FieldTypeBool}, "true", true, false},
+		{FieldConfig{Type: FieldTypeBool}, "maybe", nil, true},
+		{FieldConfig{Type: FieldTypeDate}, "2026-03-01", "2026-03-01", false},
+		{FieldConfig{Type: FieldTypeDate}, "March", nil, true},
+		{FieldConfig{Type: FieldTypeEnum, Values: []string{"s1", "s2"}}, "s2", "s2", false},
+		{FieldConfig{Type: FieldTypeEnum, Values: []string{"s1", "s2"}}, "s3", nil, true},
+		{FieldConfig{Type: FieldTypeList}, "a, b,,c", []string{"a", "b", "c"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.field.Type+"/"+tt.raw, func(t *testing.T) {
+			got, err := tt.field.ParseValue(tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseValue(%q) error = %v, wantErr %v", tt.raw, err, tt.wantErr)
+			}
+			if !tt.wantErr && fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("ParseValue(%q) = %#v, want %#v", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTransitionRule(t *testing.T) {
+	cfg := NewDefault("Test")
+	if _, ok := cfg.TransitionRule("backlog", "done", ""); !ok {
+		t.Error("without transitions every move should be allowed")
+	}
+
+	cfg.Transitions = []TransitionConfig{
+		{From: "todo", To: []string{"in-progress"}},
+		{From: "in-progress", To: []string{"review", "todo"}},
+		{From: "review", To: []string{"done"}, Guards: []string{GuardDepsDone}},
+		{From: "review", To: []string{"done"}, Class: "expedite"},
+		{From: AnyStatus, To: []string{"backlog"}},
+	}
+	tests := []struct {
+		from, to, class string
+		want            bool
+	}{
+		{"todo", "in-progress", "", true},
+		{"todo", "done", "", false},
+		{"in-progress", "done", "", false},
+		{"done", "backlog", "", true},
+		{"done", ArchivedStatus, "", true},
+		{ArchivedStatus, "todo", "", true},
+	}
+	for _, tt := range tests {
+		if _, ok := cfg.TransitionRule(tt.from, tt.to, tt.class); ok != tt.want {
+			t.Errorf("TransitionRule(%s, %s) allowed = %v, want %v", tt.from, tt.to, ok, tt.want)
+		}
+	}
+
+	if r, _ := cfg.TransitionRule("review", "done", "standard"); r == nil || len(r.Guards) != 1 {
+		t.Errorf("generic rule = %+v, want the guarded rule", r)
+	}
+	if r, _ := cfg.TransitionRule("review", "done", "expedite"); r == nil || r.Class != "expedite" {
+		t.Errorf("class rule = %+v, want the expedite rule", r)
+	}
+	if got := cfg.AllowedTransitions("in-progress", ""); !slices.Equal(got, []string{"backlog", "todo", "review", ArchivedStatus}) {
+		t.Errorf("AllowedTransitions(in-progress) = %v", got)
+	}
+}
+
+func TestAdjacentStatus(t *testing.T) {
+	cfg := NewDefault("Test")
+	names := cfg.StatusNames()
+	if got := cfg.AdjacentStatus(names, "done", "", 1); got != ArchivedStatus {
+		t.Errorf("next after done without transitions = %q, want archived", got)
+	}
+
+	cfg.Transitions = []TransitionConfig{
+		{From: "backlog", To: []string{"in-progress"}},
+		{From: "in-progress", To: []string{"backlog", "review"}},
+	}
+	tests := []struct {
+		from string
+		step int
+		want string
+	}{
+		{"backlog", 1, "in-progress"},
+		{"in-progress", 1, "review"},
+		{"in-progress", -1, "backlog"},
+		{"review", 1, ""},
+		{"done", 1, ""},
+	}
+	for _, tt := range tests {
+		if got := cfg.AdjacentStatus(names, tt.from, "", tt.step); got != tt.want {
+			t.Errorf("AdjacentStatus(%s, %d) = %q, want %q", tt.from, tt.step, got, tt.want)
+		}
+	}
+}
+
+func TestHookMatches(t *testing.T) {
+	h := HookConfig{Event: HookMove, Statuses: []string{"review"}, Command: "true"}
+	if h.Phase() != HookPost || h.TimeoutDuration() != 30*time.Second {
+		t.Errorf("defaults = %s %s, want post 30s", h.Phase(), h.TimeoutDuration())
+	}
+	if !h.Matches(HookMove, HookPost, "review") {
+		t.Error("post move into review should match")
+	}
+	if h.Matches(HookMove, HookPost, "done") || h.Matches(HookMove, HookPre, "review") || h.Matches(HookEdit, HookPost, "review") {
+		t.Error("hook matched the wrong status, phase or event")
+	}
+}
+
+func TestParseInterval(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"1d", 24 * time.Hour, false},
+		{"2w", 14 * 24 * time.Hour, false},
+		{"12h", 12 * time.Hour, false},
+		{"0d", 0, true},
+		{"-1h", 0, true},
+		{"weekly", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseInterval(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseInterval(%q) = %v, %v; want %v (error %v)", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestRecurringOccurrence(t *testing.T) {
+	at := func(s string) time.Time {
+		t.Helper()
+		ts, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ts
+	}
+	monday := RecurringConfig{Cron: "30 9 * * mon"}
+	weekly := RecurringConfig{Every: "1w", Start: "2026-01-05T00:00:00Z"}
+	daily := RecurringConfig{Every: "1d"}
+	tests := []struct {
+		name string
+		rule RecurringConfig
+		last string
+		now  string
+		want string // empty: not due
+	}{
+		{"cron first run", monday, "", "2026-01-14T12:00:00Z", "2026-01-12T09:30:00Z"},
+		{"cron before the time of day", monday, "", "2026-01-12T09:29:00Z", "2026-01-05T09:30:00Z"},
+		{"cron already created", monday, "2026-01-12T09:30:00Z", "2026-01-18T23:59:00Z", ""},
+		{"cron next week", monday, "2026-01-12T09:30:00Z", "2026-01-19T09:30:00Z", "2026-01-19T09:30:00Z"},
+		{"every before start", weekly, "", "2026-01-04T00:00:00Z", ""},
+		{"every catches up to the latest", weekly, "", "2026-01-27T08:00:00Z", "2026-01-26T00:00:00Z"},
+		{"every already created", weekly, "2026-01-26T00:00:00Z", "2026-02-01T00:00:00Z", ""},
+		{"every without start is due now", daily, "", "2026-01-27T08:00:00Z", "2026-01-27T08:00:00Z"},
+		{"every without start follows last", daily, "2026-01-27T08:00:00Z", "2026-01-29T07:00:00Z", "2026-01-28T08:00:00Z"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var last time.Time
+			if tt.last != "" {
+				last = at(tt.last)
+			}
+			got, ok := tt.rule.Occurrence(last, at(tt.now))
+			if tt.want == "" {
+				if ok {
+					t.Errorf("Occurrence = %s, want not due", got)
+				}
+				return
+			}
+			if !ok || !got.Equal(at(tt.want)) {
+				t.Errorf("Occurrence = %s (due %v), want %s", got, ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCron(t *testing.T) {
+	at := time.Date(2026, 2, 27, 12, 0, 0, 0, time.UTC) // a Friday
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"@daily", time.Date(2026, 2, 27, 0, 0, 0, 0, time.UTC)},
+		{"*/15 * * * *", at},
+		{"0 9-17/4 * * *", time.Date(2026, 2, 27, 9, 0, 0, 0, time.UTC)},
+		{"0 0 1 jan,jul *", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2026, 2, 22, 0, 0, 0, 0, time.UTC)},
+		{"0 0 13 * 5", time.Date(2026, 2, 27, 0, 0, 0, 0, time.UTC)}, // day 13 or a Friday
+		{"0 0 29 2 *", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		sched, err := parseCron(tt.expr)
+		if err != nil {
+			t.Fatalf("parseCron(%q): %v", tt.expr, err)
+		}
+		if got := sched.latest(at); !got.Equal(tt.want) {
+			t.Errorf("latest(%q) = %s, want %s", tt.expr, got, tt.want)
+		}
+	}
+
+	for _, expr := range []string{"* * * *", "60 * * * *", "* * * * fri-mon", "*/0 * * * *", "@sometimes"} {
+		if _, err := parseCron(expr); err == nil {
+			t.Errorf("parseCron(%q) = nil error, want an error", expr)
+		}
+	}
+}
+
 func TestLoadNotFound(t *testing.T) {
 	_, err := Load(t.TempDir())
 	if !errors.Is(err, ErrNotFound) {
@@ -0,0 +1,187 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// cronLookback bounds the search for the latest cron occurrence, in
+	// days. It spans two leap days, so "0 0 29 2 *" is always found.
+	cronLookback = 8 * 366
+	lastMinute   = 59
+	lastHour     = 23
+	lastDay      = 31
+	lastMonth    = 12
+	sundayAlias  = 7 // day of week 7 is Sunday, like 0
+)
+
+// cronSchedule is a parsed five-field cron expression. Each field is a bit
+// set of the values it matches.
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	// domAll and dowAll are set when the day fields are "*". As in cron, a
+	// day matches either day field when both are restricted.
+	domAll, dowAll bool
+}
+
+// cronField describes one field of a cron expression.
+type cronField struct {
+	name     string
+	min, max int
+	names    []string // optional names for min, min+1, ...
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: lastMinute},
+	{name: "hour", min: 0, max: lastHour},
+	{name: "day of month", min: 1, max: lastDay},
+	{name: "month", min: 1, max: lastMonth, names: []string{
+		"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec",
+	}},
+	{name: "day of week", min: 0, max: sundayAlias, names: []string{
+		"sun", "mon", "tue", "wed", "thu", "fri", "sat",
+	}},
+}
+
+// cronMacros are the supported shorthand expressions.
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// parseCron parses a cron expression: minute, hour, day of month, month and
+// day of week, each "*", a value, a range "a-b", a list "a,b" or a step
+// "*/n" or "a-b/n". Months and weekdays may be named (jan, mon). The macros
+// @yearly, @monthly, @weekly, @daily and @hourly are also accepted.
+func parseCron(expr string) (*cronSchedule, error) {
+	if macro, ok := cronMacros[strings.ToLower(strings.TrimSpace(expr))]; ok {
+		expr = macro
+	}
+	parts := strings.Fields(expr)
+	if len(parts) != len(cronFields) {
+		return nil, fmt.Errorf("invalid cron %q: expected 5 fields (minute hour day-of-month month day-of-week)", expr)
+	}
+	sets := make([]uint64, len(parts))
+	for i, p := range parts {
+		set, err := cronFields[i].parse(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron %q: %w", expr, err)
+		}
+		sets[i] = set
+	}
+	s := &cronSchedule{
+		minute: sets[0], hour: sets[1], dom: sets[2], month: sets[3], dow: sets[4],
+		domAll: parts[2] == "*", dowAll: parts[4] == "*",
+	}
+	if s.dow&(1<<sundayAlias) != 0 {
+		s.dow |= 1
+	}
+	return s, nil
+}
+
+// parse parses one field into a bit set.
+func (f cronField) parse(field string) (uint64, error) {
+	var set uint64
+	for _, item := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(item, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepPart)
+			if err != nil || n < 1 {
+				return 0, fmt.Errorf("invalid step %q in %s", stepPart, f.name)
+			}
+			step = n
+		}
+		lo, hi := f.min, f.max
+		if rangePart != "*" {
+			var err error
+			loPart, hiPart, isRange := strings.Cut(rangePart, "-")
+			if lo, err = f.value(loPart); err != nil {
+				return 0, err
+			}
+			hi = lo
+			if isRange {
+				if hi, err = f.value(hiPart); err != nil {
+					return 0, err
+				}
+			} else if hasStep {
+				hi = f.max
+			}
+			if hi < lo {
+				return 0, fmt.Errorf("invalid range %q in %s", rangePart, f.name)
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			set |= 1 << v
+		}
+	}
+	return set, nil
+}
+
+// value parses a single number or name in the field's range.
+func (f cronField) value(s string) (int, error) {
+	for i, name := range f.names {
+		if strings.EqualFold(s, name) {
+			return f.min + i, nil
+		}
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < f.min || n > f.max {
+		return 0, fmt.Errorf("invalid %s %q (allowed: %d-%d)", f.name, s, f.min, f.max)
+	}
+	return n, nil
+}
+
+// matchesDay reports whether the schedule runs on t's date.
+func (s *cronSchedule) matchesDay(t time.Time) bool {
+	if s.month&(1<<int(t.Month())) == 0 {
+		return false
+	}
+	dom := s.dom&(1<<t.Day()) != 0
+	dow := s.dow&(1<<int(t.Weekday())) != 0
+	switch {
+	case s.domAll && s.dowAll:
+		return true
+	case s.domAll:
+		return dow
+	case s.dowAll:
+		return dom
+	default:
+		return dom || dow
+	}
+}
+
+// latest returns the latest time at or before now that the schedule runs,
+// in now's location, or the zero time if there is none within cronLookback
+// days.
+func (s *cronSchedule) latest(now time.Time) time.Time {
+	y, m, d := now.Date()
+	for i := range cronLookback {
+		date := time.Date(y, m, d-i, 0, 0, 0, 0, now.Location())
+		if !s.matchesDay(date) {
+			continue
+		}
+		for h := lastHour; h >= 0; h-- {
+			if s.hour&(1<<h) == 0 {
+				continue
+			}
+			for mi := lastMinute; mi >= 0; mi-- {
+				if s.minute&(1<<mi) == 0 {
+					continue
+				}
+				t := time.Date(date.Year(), date.Month(), date.Day(), h, mi, 0, 0, now.Location())
+				if !t.After(now) {
+					return t
+				}
+			}
+		}
+	}
+	return time.Time{}
+}
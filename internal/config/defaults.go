@@ -14,6 +14,8 @@ const (
 	DefaultClass = "standard"
 	// DefaultClaimTimeout is the default claim expiration as a duration string.
 	DefaultClaimTimeout = "1h"
+	// DefaultLockTimeout is how long a mutation waits for the board lock.
+	DefaultLockTimeout = "10s"
 	// DefaultTitleLines is the default number of title lines in TUI cards.
 	DefaultTitleLines = 2
 	// DefaultHideEmptyColumns controls whether TUI hides empty status columns.
@@ -23,7 +25,7 @@ const (
 	ConfigFileName = "config.yml"
 
 	// CurrentVersion is the current config schema version.
-	CurrentVersion = 11
+	CurrentVersion = 19
 
 	// ArchivedStatus is the reserved status name for soft-deleted tasks.
 	ArchivedStatus = "archived"
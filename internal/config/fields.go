@@ -0,0 +1,171 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/antopolskiy/kanban-md/internal/date"
+)
+
+// Custom field types.
+const (
+	FieldTypeString = "string"
+	FieldTypeInt    = "int"
+	FieldTypeEnum   = "enum"
+	FieldTypeDate   = "date"
+	FieldTypeBool   = "bool"
+	FieldTypeList   = "list"
+)
+
+// FieldConfig declares a custom task frontmatter field. Values are set with
+// `create --set` / `edit --set` and stored in the task file next to the
+// built-in fields.
+type FieldConfig struct {
+	Name     string   `yaml:"name" json:"name"`
+	Type     string   `yaml:"type" json:"type"`
+	Values   []string `yaml:"values,omitempty" json:"values,omitempty"` // allowed values for enum fields
+	Required bool     `yaml:"required,omitempty" json:"required,omitempty"`
+	Default  string   `yaml:"default,omitempty" json:"default,omitempty"` // applied on create when unset
+}
+
+// FieldTypes returns the supported custom field types.
+func FieldTypes() []string {
+	return []string{FieldTypeString, FieldTypeInt, FieldTypeEnum, FieldTypeDate, FieldTypeBool, FieldTypeList}
+}
+
+// reservedFieldNames are the built-in task frontmatter keys (plus the JSON-only
+// task keys), which custom fields may not shadow.
+var reservedFieldNames = []string{
+	"id", "title", "status", "priority", "created", "updated", "started", "completed",
+	"assignee", "tags", "due", "estimate", "parent", "depends_on", "blocked",
+	"block_reason", "claimed_by", "claimed_at", "class", "external_id", "body", "file", "rev",
+	"custom",
+}
+
+// IsReservedFieldName reports whether name is a built-in task field.
+func IsReservedFieldName(name string) bool {
+	return slices.Contains(reservedFieldNames, name)
+}
+
+// FieldByName returns the custom field definition with the given name, or nil.
+func (c *Config) FieldByName(name string) *FieldConfig {
+	for i := range c.Fields {
+		if c.Fields[i].Name == name {
+			return &c.Fields[i]
+		}
+	}
+	return nil
+}
+
+// FieldNames returns the names of all custom fields in config order.
+func (c *Config) FieldNames() []string {
+	names := make([]string, len(c.Fields))
+	for i, f := range c.Fields {
+		names[i] = f.Name
+	}
+	return names
+}
+
+// ParseValue converts a command-line value to the field's stored type:
+// string for string, enum and date fields (dates normalized to YYYY-MM-DD),
+// int, bool, or []string for list fields (comma-separated).
+func (f *FieldConfig) ParseValue(raw string) (any, error) {
+	switch f.Type {
+	case FieldTypeInt:
+		n, err := strconv.Atoi(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, fmt.Errorf("%q is not an integer", raw)
+		}
+		return n, nil
+	case FieldTypeBool:
+		b, err := strconv.ParseBool(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, fmt.Errorf("%q is not true or false", raw)
+		}
+		return b, nil
+	case FieldTypeDate:
+		d, err := date.Parse(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, err
+		}
+		return d.String(), nil
+	case FieldTypeEnum:
+		if !slices.Contains(f.Values, raw) {
+			return nil, fmt.Errorf("%q is not one of %s", raw, strings.Join(f.Values, ", "))
+		}
+		return raw, nil
+	case FieldTypeList:
+		var items []string
+		for _, s := range strings.Split(raw, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				items = append(items, s)
+			}
+		}
+		return items, nil
+	default:
+		return raw, nil
+	}
+}
+
+// validateFields checks custom field names, types and defaults.
+func (c *Config) validateFields() error {
+	seen := make(map[string]bool, len(c.Fields))
+	for i := range c.Fields {
+		f := &c.Fields[i]
+		if err := validateFieldName(f.Name); err != nil {
+			return err
+		}
+		if seen[f.Name] {
+			return fmt.Errorf("%w: duplicate field name %q", ErrInvalid, f.Name)
+		}
+		seen[f.Name] = true
+
+		if !slices.Contains(FieldTypes(), f.Type) {
+			return fmt.Errorf("%w: field %q has invalid type %q (valid: %s)",
+				ErrInvalid, f.Name, f.Type, strings.Join(FieldTypes(), ", "))
+		}
+		if f.Type == FieldTypeEnum && len(f.Values) == 0 {
+			return fmt.Errorf("%w: enum field %q requires values", ErrInvalid, f.Name)
+		}
+		if f.Type != FieldTypeEnum && len(f.Values) > 0 {
+			return fmt.Errorf("%w: field %q: values are only allowed for enum fields", ErrInvalid, f.Name)
+		}
+		if f.Default != "" {
+			if _, err := f.ParseValue(f.Default); err != nil {
+				return fmt.Errorf("%w: field %q default: %w", ErrInvalid, f.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+func validateFieldName(name string) error {
+	if problem := fieldNameProblem(name); problem != "" {
+		return fmt.Errorf("%w: %s", ErrInvalid, problem)
+	}
+	return nil
+}
+
+// ValidateCustomFieldName checks a field name given on the command line
+// (`--set NAME=VALUE`), which need not be declared in the schema.
+func ValidateCustomFieldName(name string) error {
+	if problem := fieldNameProblem(name); problem != "" {
+		return errors.New(problem)
+	}
+	return nil
+}
+
+func fieldNameProblem(name string) string {
+	switch {
+	case name == "":
+		return "field name is required"
+	case IsReservedFieldName(name):
+		return fmt.Sprintf("field name %q is a built-in task field", name)
+	case strings.ContainsAny(name, "=,:.()<>!\" \t\n"):
+		return fmt.Sprintf("field name %q must not contain spaces or any of =,:.()<>!\"", name)
+	}
+	return ""
+}
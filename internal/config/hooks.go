@@ -0,0 +1,114 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Hook events. Each names a kind of change to a task; one command can emit
+// several (a handoff that moves and releases a task emits handoff, move and
+// release). Move is emitted for every status change, whichever command made it.
+const (
+	HookCreate  = "create"
+	HookMove    = "move"
+	HookEdit    = "edit"
+	HookBlock   = "block"
+	HookUnblock = "unblock"
+	HookClaim   = "claim"
+	HookRelease = "release"
+	HookHandoff = "handoff"
+	HookArchive = "archive"
+)
+
+// Hook phases.
+const (
+	HookPre  = "pre"  // runs before the change is written; a non-zero exit rejects it
+	HookPost = "post" // runs after the change is written; failures are reported but ignored
+)
+
+// DefaultHookTimeout is how long a hook may run before it is killed.
+const DefaultHookTimeout = "30s"
+
+// HookConfig runs a shell command when a task event happens. The command
+// receives the task as JSON on stdin and KANBAN_* environment variables
+// describing the event, and runs in the directory containing the board.
+type HookConfig struct {
+	Event    string   `yaml:"event" json:"event"`                           // one of HookEvents
+	When     string   `yaml:"when,omitempty" json:"when,omitempty"`         // pre or post (default post)
+	Statuses []string `yaml:"statuses,omitempty" json:"statuses,omitempty"` // only tasks in these statuses (after the change)
+	Command  string   `yaml:"command" json:"command"`                       // run with sh -c
+	Timeout  string   `yaml:"timeout,omitempty" json:"timeout,omitempty"`   // duration (default 30s; pre-hooks at most half the lock timeout)
+}
+
+// HookEvents returns the supported hook event names.
+func HookEvents() []string {
+	return []string{
+		HookCreate, HookMove, HookEdit, HookBlock, HookUnblock,
+		HookClaim, HookRelease, HookHandoff, HookArchive,
+	}
+}
+
+// Phase returns the hook's phase, defaulting to post.
+func (h HookConfig) Phase() string {
+	if h.When == "" {
+		return HookPost
+	}
+	return h.When
+}
+
+// TimeoutDuration returns the hook's timeout, falling back to the default.
+func (h HookConfig) TimeoutDuration() time.Duration {
+	s := h.Timeout
+	if s == "" {
+		s = DefaultHookTimeout
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		d, _ = time.ParseDuration(DefaultHookTimeout)
+	}
+	return d
+}
+
+// Matches reports whether the hook runs for event in phase for a task that
+// is now in status.
+func (h HookConfig) Matches(event, phase, status string) bool {
+	if h.Event != event || h.Phase() != phase {
+		return false
+	}
+	return len(h.Statuses) == 0 || slices.Contains(h.Statuses, status)
+}
+
+// validateHooks checks hook events, phases, statuses and timeouts.
+func (c *Config) validateHooks() error {
+	names := c.StatusNames()
+	for i, h := range c.Hooks {
+		if !slices.Contains(HookEvents(), h.Event) {
+			return fmt.Errorf("%w: hooks[%d] has unknown event %q (valid: %s)",
+				ErrInvalid, i, h.Event, strings.Join(HookEvents(), ", "))
+		}
+		if h.When != "" && h.When != HookPre && h.When != HookPost {
+			return fmt.Errorf("%w: hooks[%d] (%s) has invalid when %q (valid: %s, %s)",
+				ErrInvalid, i, h.Event, h.When, HookPre, HookPost)
+		}
+		if strings.TrimSpace(h.Command) == "" {
+			return fmt.Errorf("%w: hooks[%d] (%s) requires a command", ErrInvalid, i, h.Event)
+		}
+		for _, s := range h.Statuses {
+			if !contains(names, s) {
+				return fmt.Errorf("%w: hooks[%d] (%s) references unknown status %q", ErrInvalid, i, h.Event, s)
+			}
+		}
+		if h.Timeout != "" {
+			d, err := time.ParseDuration(h.Timeout)
+			if err != nil {
+				return fmt.Errorf("%w: hooks[%d] (%s) has invalid timeout %q: %w", ErrInvalid, i, h.Event, h.Timeout, err)
+			}
+			if d <= 0 {
+				return fmt.Errorf("%w: hooks[%d] (%s) timeout must be positive", ErrInvalid, i, h.Event)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package config
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestValidateLockTimeout_Invalid(t *testing.T) {
+	for _, v := range []string{"soon", "-1s"} {
+		cfg := NewDefault("Test")
+		cfg.LockTimeout = v
+
+		err := cfg.Validate()
+		if err == nil {
+			t.Fatalf("expected error for lock_timeout %q", v)
+		}
+		if !errors.Is(err, ErrInvalid) {
+			t.Errorf("error = %v, want ErrInvalid", err)
+		}
+		if want := "lock_timeout"; !containsStr(err.Error(), want) {
+			t.Errorf("error = %v, want to contain %q", err, want)
+		}
+	}
+}
+
+func TestLockTimeoutDuration(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", 10 * time.Second},
+		{"garbage", 10 * time.Second},
+		{"0s", 0},
+		{"250ms", 250 * time.Millisecond},
+		{"1m", time.Minute},
+	}
+	for _, tt := range tests {
+		cfg := NewDefault("Test")
+		cfg.LockTimeout = tt.value
+		if got := cfg.LockTimeoutDuration(); got != tt.want {
+			t.Errorf("LockTimeoutDuration(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
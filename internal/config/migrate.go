@@ -47,6 +47,14 @@ var migrations = map[int]func(*Config) error{
 	8:  migrateV8ToV9,
 	9:  migrateV9ToV10,
 	10: migrateV10ToV11,
+	11: migrateV11ToV12,
+	12: migrateV12ToV13,
+	13: migrateV13ToV14,
+	14: migrateV14ToV15,
+	15: migrateV15ToV16,
+	16: migrateV16ToV17,
+	17: migrateV17ToV18,
+	18: migrateV18ToV19,
 }
 
 // migrateV1ToV2 adds the wip_limits field (defaults to nil/empty = unlimited).
@@ -148,3 +156,61 @@ func migrateV10ToV11(cfg *Config) error { //nolint:unparam // signature must mat
 	cfg.Version = 11
 	return nil
 }
+
+// migrateV11ToV12 adds lock_timeout for the board-wide mutation lock.
+func migrateV11ToV12(cfg *Config) error { //nolint:unparam // signature must match migrations map type
+	if cfg.LockTimeout == "" {
+		cfg.LockTimeout = DefaultLockTimeout
+	}
+	cfg.Version = 12
+	return nil
+}
+
+// migrateV12ToV13 adds the views section for saved list views. Existing
+// boards start with no views.
+func migrateV12ToV13(cfg *Config) error { //nolint:unparam // signature must match migrations map type
+	cfg.Version = 13
+	return nil
+}
+
+// migrateV13ToV14 adds the fields section declaring custom task frontmatter
+// fields. Existing boards start with no schema; unknown task keys are kept.
+func migrateV13ToV14(cfg *Config) error { //nolint:unparam // signature must match migrations map type
+	cfg.Version = 14
+	return nil
+}
+
+// migrateV14ToV15 adds the transitions section restricting status changes.
+// Existing boards start without transitions, so every move stays allowed.
+func migrateV14ToV15(cfg *Config) error { //nolint:unparam // signature must match migrations map type
+	cfg.Version = 15
+	return nil
+}
+
+// migrateV15ToV16 adds the hooks section running commands on board events.
+// Existing boards start without hooks.
+func migrateV15ToV16(cfg *Config) error { //nolint:unparam // signature must match migrations map type
+	cfg.Version = 16
+	return nil
+}
+
+// migrateV16ToV17 adds claim_expired_status, where `claims expire` moves tasks
+// whose claim expired. Existing boards leave such tasks in place.
+func migrateV16ToV17(cfg *Config) error { //nolint:unparam // signature must match migrations map type
+	cfg.Version = 17
+	return nil
+}
+
+// migrateV17ToV18 adds parent_done, which ties a parent task's status to its
+// subtasks. Existing boards keep parents and subtasks independent.
+func migrateV17ToV18(cfg *Config) error { //nolint:unparam // signature must match migrations map type
+	cfg.Version = 18
+	return nil
+}
+
+// migrateV18ToV19 adds recurring, the schedules `recur` creates tasks from.
+// Existing boards have no recurring tasks.
+func migrateV18ToV19(cfg *Config) error { //nolint:unparam // signature must match migrations map type
+	cfg.Version = 19
+	return nil
+}
@@ -222,17 +222,138 @@ func TestMigrateV9ToV10(t *testing.T) {
 }
 
 func TestMigrateV10ToV11(t *testing.T) {
-	const wantVersion = 11
 	cfg := NewDefault("Test")
 	cfg.Version = 10
 
 	if err := migrate(cfg); err != nil {
 		t.Fatalf("migrate() v10→v11: %v", err)
 	}
-	if cfg.Version != wantVersion {
-		t.Errorf("Version = %d, want %d", cfg.Version, wantVersion)
+	if cfg.Version != CurrentVersion {
+		t.Errorf("Version = %d, want %d", cfg.Version, CurrentVersion)
 	}
 	if cfg.TUI.NarrowThreshold != 0 {
 		t.Errorf("NarrowThreshold = %d, want automatic default 0", cfg.TUI.NarrowThreshold)
 	}
 }
+
+func TestMigrateV11ToV12(t *testing.T) {
+	cfg := NewDefault("Test")
+	cfg.Version = 11
+	cfg.LockTimeout = ""
+
+	if err := migrate(cfg); err != nil {
+		t.Fatalf("migrate() v11→v12: %v", err)
+	}
+	if cfg.Version != CurrentVersion {
+		t.Errorf("Version = %d, want %d", cfg.Version, CurrentVersion)
+	}
+	if cfg.LockTimeout != DefaultLockTimeout {
+		t.Errorf("LockTimeout = %q, want %q", cfg.LockTimeout, DefaultLockTimeout)
+	}
+}
+
+func TestMigrateV12ToV13(t *testing.T) {
+	cfg := NewDefault("Test")
+	cfg.Version = 12
+
+	if err := migrate(cfg); err != nil {
+		t.Fatalf("migrate() v12→v13: %v", err)
+	}
+	if cfg.Version != CurrentVersion {
+		t.Errorf("Version = %d, want %d", cfg.Version, CurrentVersion)
+	}
+	if len(cfg.Views) != 0 {
+		t.Errorf("Views = %v, want none after migration", cfg.Views)
+	}
+}
+
+func TestMigrateV13ToV14(t *testing.T) {
+	cfg := NewDefault("Test")
+	cfg.Version = 13
+
+	if err := migrate(cfg); err != nil {
+		t.Fatalf("migrate() v13→v14: %v", err)
+	}
+	if cfg.Version != CurrentVersion {
+		t.Errorf("Version = %d, want %d", cfg.Version, CurrentVersion)
+	}
+	if len(cfg.Fields) != 0 {
+		t.Errorf("Fields = %v, want none after migration", cfg.Fields)
+	}
+}
+
+func TestMigrateV14ToV15(t *testing.T) {
+	cfg := NewDefault("Test")
+	cfg.Version = 14
+
+	if err := migrate(cfg); err != nil {
+		t.Fatalf("migrate() v14→v15: %v", err)
+	}
+	if cfg.Version != CurrentVersion {
+		t.Errorf("Version = %d, want %d", cfg.Version, CurrentVersion)
+	}
+	if cfg.HasTransitions() {
+		t.Errorf("Transitions = %v, want none after migration", cfg.Transitions)
+	}
+}
+
+func TestMigrateV15ToV16(t *testing.T) {
+	cfg := NewDefault("Test")
+	cfg.Version = 15
+
+	if err := migrate(cfg); err != nil {
+		t.Fatalf("migrate() v15→v16: %v", err)
+	}
+	if cfg.Version != CurrentVersion {
+		t.Errorf("Version = %d, want %d", cfg.Version, CurrentVersion)
+	}
+	if len(cfg.Hooks) != 0 {
+		t.Errorf("Hooks = %v, want none after migration", cfg.Hooks)
+	}
+}
+
+func TestMigrateV16ToV17(t *testing.T) {
+	cfg := NewDefault("Test")
+	cfg.Version = 16
+
+	if err := migrate(cfg); err != nil {
+		t.Fatalf("migrate() v16→v17: %v", err)
+	}
+	if cfg.Version != CurrentVersion {
+		t.Errorf("Version = %d, want %d", cfg.Version, CurrentVersion)
+	}
+	if cfg.ClaimExpiredStatus != "" {
+		t.Errorf("ClaimExpiredStatus = %q, want empty after migration", cfg.ClaimExpiredStatus)
+	}
+}
+
+func TestMigrateV17ToV18(t *testing.T) {
+	cfg := NewDefault("Test")
+	cfg.Version = 17
+
+	if err := migrate(cfg); err != nil {
+		t.Fatalf("migrate() v17→v18: %v", err)
+	}
+	if cfg.Version != CurrentVersion {
+		t.Errorf("Version = %d, want %d", cfg.Version, CurrentVersion)
+	}
+	if cfg.ParentDone != "" {
+		t.Errorf("ParentDone = %q, want empty after migration", cfg.ParentDone)
+	}
+}
+
+func TestMigrateV18ToV19(t *testing.T) {
+	const wantVersion = 19
+	cfg := NewDefault("Test")
+	cfg.Version = 18
+
+	if err := migrate(cfg); err != nil {
+		t.Fatalf("migrate() v18→v19: %v", err)
+	}
+	if cfg.Version != wantVersion {
+		t.Errorf("Version = %d, want %d", cfg.Version, wantVersion)
+	}
+	if len(cfg.Recurring) != 0 {
+		t.Errorf("Recurring = %v, want none after migration", cfg.Recurring)
+	}
+}
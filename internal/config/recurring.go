@@ -0,0 +1,198 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RecurringConfig creates a task on a schedule (see `kanban-md recur`). The
+// schedule is either an interval (every) or a cron expression (cron). Title
+// and body may contain the placeholders {date}, {year}, {month}, {week} and
+// {day}, filled in from the occurrence.
+type RecurringConfig struct {
+	Name     string   `yaml:"name" json:"name"`                             // unique; tracks what was created
+	Title    string   `yaml:"title" json:"title"`                           // task title
+	Every    string   `yaml:"every,omitempty" json:"every,omitempty"`       // interval, e.g. 1d, 2w, 12h
+	Cron     string   `yaml:"cron,omitempty" json:"cron,omitempty"`         // minute hour day-of-month month day-of-week
+	Start    string   `yaml:"start,omitempty" json:"start,omitempty"`       // first occurrence (date or RFC 3339)
+	Status   string   `yaml:"status,omitempty" json:"status,omitempty"`     // default: defaults.status
+	Priority string   `yaml:"priority,omitempty" json:"priority,omitempty"` // default: defaults.priority
+	Class    string   `yaml:"class,omitempty" json:"class,omitempty"`       // default: defaults.class
+	Assignee string   `yaml:"assignee,omitempty" json:"assignee,omitempty"`
+	Tags     []string `yaml:"tags,omitempty" json:"tags,omitempty"`
+	Estimate string   `yaml:"estimate,omitempty" json:"estimate,omitempty"`
+	DueIn    string   `yaml:"due_in,omitempty" json:"due_in,omitempty"` // due date relative to the occurrence, e.g. 3d
+	Body     string   `yaml:"body,omitempty" json:"body,omitempty"`
+}
+
+const (
+	day  = 24 * time.Hour
+	week = 7 * day
+)
+
+// ParseInterval parses an interval such as "1d", "2w" or "12h": a whole
+// number of days or weeks, or a Go duration. It must be positive.
+func ParseInterval(s string) (time.Duration, error) {
+	var d time.Duration
+	var err error
+	switch {
+	case strings.HasSuffix(s, "d"), strings.HasSuffix(s, "w"):
+		unit := day
+		if strings.HasSuffix(s, "w") {
+			unit = week
+		}
+		var n int
+		n, err = strconv.Atoi(s[:len(s)-1])
+		d = time.Duration(n) * unit
+	default:
+		d, err = time.ParseDuration(s)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("invalid interval %q (e.g. 1d, 2w, 12h)", s)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("invalid interval %q: must be positive", s)
+	}
+	return d, nil
+}
+
+// StartTime parses the rule's start in loc: a date (midnight) or an RFC 3339
+// timestamp. It returns the zero time when start is unset.
+func (r RecurringConfig) StartTime(loc *time.Location) (time.Time, error) {
+	if r.Start == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, r.Start); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation(time.DateOnly, r.Start, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid start %q: expected YYYY-MM-DD or RFC 3339", r.Start)
+	}
+	return t, nil
+}
+
+// Occurrence returns the latest scheduled time at or before now that is
+// later than last (the occurrence a task was last created for, or zero), and
+// whether there is one. An interval rule without a start or a last
+// occurrence is due immediately; cron rules are evaluated in now's location.
+func (r RecurringConfig) Occurrence(last, now time.Time) (time.Time, bool) {
+	start, err := r.StartTime(now.Location())
+	if err != nil {
+		return time.Time{}, false
+	}
+	var occ time.Time
+	if r.Cron != "" {
+		occ = r.cronOccurrence(start, now)
+	} else {
+		occ = r.intervalOccurrence(start, last, now)
+	}
+	if occ.IsZero() || (!last.IsZero() && !occ.After(last)) {
+		return time.Time{}, false
+	}
+	return occ, true
+}
+
+// cronOccurrence returns the latest cron time at or before now, or zero if
+// there is none since start.
+func (r RecurringConfig) cronOccurrence(start, now time.Time) time.Time {
+	sched, err := parseCron(r.Cron)
+	if err != nil {
+		return time.Time{}
+	}
+	occ := sched.latest(now)
+	if occ.Before(start) {
+		return time.Time{}
+	}
+	return occ
+}
+
+// intervalOccurrence returns the latest time at or before now that is a
+// whole number of intervals after the anchor: start, or else last. Without
+// either, the rule is due now.
+func (r RecurringConfig) intervalOccurrence(start, last, now time.Time) time.Time {
+	every, err := ParseInterval(r.Every)
+	if err != nil {
+		return time.Time{}
+	}
+	anchor := start
+	if anchor.IsZero() {
+		anchor = last
+	}
+	if anchor.IsZero() {
+		return now.Truncate(time.Minute)
+	}
+	if now.Before(anchor) {
+		return time.Time{}
+	}
+	return anchor.Add(now.Sub(anchor) / every * every)
+}
+
+// validateRecurring checks recurring rule names, schedules and task fields.
+func (c *Config) validateRecurring() error {
+	seen := make(map[string]bool, len(c.Recurring))
+	for i, r := range c.Recurring {
+		if strings.TrimSpace(r.Name) == "" {
+			return fmt.Errorf("%w: recurring[%d] requires a name", ErrInvalid, i)
+		}
+		if seen[r.Name] {
+			return fmt.Errorf("%w: duplicate recurring name %q", ErrInvalid, r.Name)
+		}
+		seen[r.Name] = true
+		if strings.TrimSpace(r.Title) == "" {
+			return fmt.Errorf("%w: recurring %q requires a title", ErrInvalid, r.Name)
+		}
+		if err := r.validateSchedule(); err != nil {
+			return fmt.Errorf("%w: recurring %q: %w", ErrInvalid, r.Name, err)
+		}
+		if err := c.validateRecurringFields(r); err != nil {
+			return fmt.Errorf("%w: recurring %q: %w", ErrInvalid, r.Name, err)
+		}
+	}
+	return nil
+}
+
+// validateSchedule checks that exactly one of every and cron is set and
+// that the schedule and start parse.
+func (r RecurringConfig) validateSchedule() error {
+	if (r.Every == "") == (r.Cron == "") {
+		return errors.New("requires exactly one of every or cron")
+	}
+	if r.Every != "" {
+		if _, err := ParseInterval(r.Every); err != nil {
+			return err
+		}
+	}
+	if r.Cron != "" {
+		if _, err := parseCron(r.Cron); err != nil {
+			return err
+		}
+	}
+	if _, err := r.StartTime(time.Local); err != nil {
+		return err
+	}
+	return nil
+}
+
+// validateRecurringFields checks the task fields of a recurring rule against
+// the board.
+func (c *Config) validateRecurringFields(r RecurringConfig) error {
+	if r.Status != "" && !contains(c.BoardStatuses(), r.Status) {
+		return fmt.Errorf("unknown status %q", r.Status)
+	}
+	if r.Priority != "" && !contains(c.Priorities, r.Priority) {
+		return fmt.Errorf("unknown priority %q", r.Priority)
+	}
+	if r.Class != "" && c.ClassByName(r.Class) == nil {
+		return fmt.Errorf("unknown class %q", r.Class)
+	}
+	if r.DueIn != "" {
+		if _, err := ParseInterval(r.DueIn); err != nil {
+			return fmt.Errorf("due_in: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// AnyStatus matches every status in a transition's from or to list.
+const AnyStatus = "*"
+
+// Transition guards. A guard is checked against the task as it will be
+// written, so a status change and the edit that satisfies the guard (for
+// example appending a handoff note) can happen in one command.
+const (
+	GuardDepsDone     = "deps_done"     // every dependency is in a terminal status
+	GuardSubtasksDone = "subtasks_done" // every subtask (parent = this task) is terminal
+	GuardNotBlocked   = "not_blocked"   // the task is not blocked
+	GuardHasAssignee  = "has_assignee"  // the task has an assignee
+	GuardBodyContains = "body_contains" // body_contains:TEXT — the body contains TEXT (case-insensitive)
+)
+
+// Values of parent_done, the rule tying a parent task's status to its
+// subtasks.
+const (
+	ParentDoneAuto    = "auto"    // move a parent to done once every subtask is terminal
+	ParentDoneRequire = "require" // reject moving a parent to done while a subtask is open
+)
+
+// ParentDoneModes returns the supported parent_done values.
+func ParentDoneModes() []string {
+	return []string{ParentDoneAuto, ParentDoneRequire}
+}
+
+// TransitionConfig allows tasks to move from one status to the listed
+// statuses. Once any transitions are configured, status changes that match
+// no rule are rejected. Rules with a class apply only to tasks of that class
+// and take precedence over rules without one.
+type TransitionConfig struct {
+	From   string   `yaml:"from" json:"from"`                         // status name or "*"
+	To     []string `yaml:"to" json:"to"`                             // status names or "*"
+	Class  string   `yaml:"class,omitempty" json:"class,omitempty"`   // only tasks of this class
+	Guards []string `yaml:"guards,omitempty" json:"guards,omitempty"` // checks the task must pass
+}
+
+// TransitionGuards returns the supported guard names.
+func TransitionGuards() []string {
+	return []string{GuardDepsDone, GuardSubtasksDone, GuardNotBlocked, GuardHasAssignee, GuardBodyContains}
+}
+
+// ParseGuard splits a guard into its name and argument ("body_contains:TEXT").
+func ParseGuard(guard string) (name, arg string) {
+	name, arg, _ = strings.Cut(guard, ":")
+	return strings.TrimSpace(name), strings.TrimSpace(arg)
+}
+
+// HasTransitions reports whether the board restricts status changes.
+func (c *Config) HasTransitions() bool {
+	return len(c.Transitions) > 0
+}
+
+// TransitionRule returns the rule allowing a task of the given class to move
+// from one status to another, and whether the move is allowed. Without
+// configured transitions every move is allowed (with a nil rule), as are moves
+// into or out of the archived status, which archive and restore tasks rather
+// than advance them.
+func (c *Config) TransitionRule(from, to, class string) (*TransitionConfig, bool) {
+	if !c.HasTransitions() || from == ArchivedStatus || to == ArchivedStatus {
+		return nil, true
+	}
+	var generic *TransitionConfig
+	for i := range c.Transitions {
+		r := &c.Transitions[i]
+		if !r.matches(from, to) {
+			continue
+		}
+		if r.Class != "" && r.Class == class {
+			return r, true
+		}
+		if r.Class == "" && generic == nil {
+			generic = r
+		}
+	}
+	return generic, generic != nil
+}
+
+// AllowedTransitions returns the statuses a task of the given class may move
+// to from the given status, in board order.
+func (c *Config) AllowedTransitions(from, class string) []string {
+	var out []string
+	for _, s := range c.StatusNames() {
+		if s == from {
+			continue
+		}
+		if _, ok := c.TransitionRule(from, s, class); ok {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// AdjacentStatus returns the nearest status after (step 1) or before (step -1)
+// from in order that a task of the given class may move to, or "" if there is
+// none. Without transitions this is simply the neighbouring status; with
+// transitions the archived status is skipped.
+func (c *Config) AdjacentStatus(order []string, from, class string, step int) string {
+	idx := IndexOf(order, from)
+	if idx < 0 {
+		return ""
+	}
+	for i := idx + step; i >= 0 && i < len(order); i += step {
+		s := order[i]
+		if !c.HasTransitions() {
+			return s
+		}
+		if s == ArchivedStatus {
+			continue
+		}
+		if _, ok := c.TransitionRule(from, s, class); ok {
+			return s
+		}
+	}
+	return ""
+}
+
+func (r *TransitionConfig) matches(from, to string) bool {
+	if r.From != AnyStatus && r.From != from {
+		return false
+	}
+	return slices.Contains(r.To, AnyStatus) || slices.Contains(r.To, to)
+}
+
+// validateTransitions checks that rules reference known statuses, classes
+// and guards.
+func (c *Config) validateTransitions() error {
+	names := c.StatusNames()
+	knownStatus := func(s string) bool { return s == AnyStatus || contains(names, s) }
+	for i, r := range c.Transitions {
+		if r.From == "" || !knownStatus(r.From) {
+			return fmt.Errorf("%w: transitions[%d] has unknown from status %q", ErrInvalid, i, r.From)
+		}
+		if len(r.To) == 0 {
+			return fmt.Errorf("%w: transitions[%d] (from %s) requires at least one to status", ErrInvalid, i, r.From)
+		}
+		for _, to := range r.To {
+			if !knownStatus(to) {
+				return fmt.Errorf("%w: transitions[%d] (from %s) has unknown to status %q", ErrInvalid, i, r.From, to)
+			}
+		}
+		if r.Class != "" && c.ClassByName(r.Class) == nil {
+			return fmt.Errorf("%w: transitions[%d] (from %s) references unknown class %q", ErrInvalid, i, r.From, r.Class)
+		}
+		for _, g := range r.Guards {
+			if err := validateGuard(g); err != nil {
+				return fmt.Errorf("%w: transitions[%d] (from %s): %w", ErrInvalid, i, r.From, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (c *Config) validateParentDone() error {
+	if c.ParentDone != "" && !slices.Contains(ParentDoneModes(), c.ParentDone) {
+		return fmt.Errorf("%w: invalid parent_done %q (valid: %s)",
+			ErrInvalid, c.ParentDone, strings.Join(ParentDoneModes(), ", "))
+	}
+	return nil
+}
+
+func validateGuard(guard string) error {
+	name, arg := ParseGuard(guard)
+	if !slices.Contains(TransitionGuards(), name) {
+		return fmt.Errorf("unknown guard %q (valid: %s)", guard, strings.Join(TransitionGuards(), ", "))
+	}
+	if (name == GuardBodyContains) != (arg != "") {
+		if name == GuardBodyContains {
+			return fmt.Errorf("guard %q requires text (%s:TEXT)", guard, GuardBodyContains)
+		}
+		return fmt.Errorf("guard %q takes no argument", guard)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ViewConfig is a named, saved list definition: filters, sort order and
+// grouping applied by `kanban-md view NAME` and selectable in the TUI.
+// Fields mirror the `list` flags; empty fields do not filter.
+type ViewConfig struct {
+	Name        string   `yaml:"name" json:"name"`
+	Description string   `yaml:"description,omitempty" json:"description,omitempty"`
+	Query       string   `yaml:"query,omitempty" json:"query,omitempty"`
+	Statuses    []string `yaml:"statuses,omitempty" json:"statuses,omitempty"`
+	Priorities  []string `yaml:"priorities,omitempty" json:"priorities,omitempty"`
+	Assignee    string   `yaml:"assignee,omitempty" json:"assignee,omitempty"`
+	Tag         string   `yaml:"tag,omitempty" json:"tag,omitempty"`
+	Search      string   `yaml:"search,omitempty" json:"search,omitempty"`
+	Class       string   `yaml:"class,omitempty" json:"class,omitempty"`
+	ClaimedBy   string   `yaml:"claimed_by,omitempty" json:"claimed_by,omitempty"`
+	Unclaimed   bool     `yaml:"unclaimed,omitempty" json:"unclaimed,omitempty"`
+	Blocked     *bool    `yaml:"blocked,omitempty" json:"blocked,omitempty"`
+	Unblocked   bool     `yaml:"unblocked,omitempty" json:"unblocked,omitempty"`
+	Archived    bool     `yaml:"archived,omitempty" json:"archived,omitempty"`
+	Sort        string   `yaml:"sort,omitempty" json:"sort,omitempty"`
+	Reverse     bool     `yaml:"reverse,omitempty" json:"reverse,omitempty"`
+	Limit       int      `yaml:"limit,omitempty" json:"limit,omitempty"`
+	GroupBy     string   `yaml:"group_by,omitempty" json:"group_by,omitempty"`
+}
+
+// ViewByName returns the view with the given name, or nil if not found.
+func (c *Config) ViewByName(name string) *ViewConfig {
+	for i := range c.Views {
+		if c.Views[i].Name == name {
+			return &c.Views[i]
+		}
+	}
+	return nil
+}
+
+// ViewNames returns the names of all saved views in config order.
+func (c *Config) ViewNames() []string {
+	names := make([]string, len(c.Views))
+	for i, v := range c.Views {
+		names[i] = v.Name
+	}
+	return names
+}
+
+// SetView adds v, or replaces the view with the same name in place.
+func (c *Config) SetView(v ViewConfig) {
+	if existing := c.ViewByName(v.Name); existing != nil {
+		*existing = v
+		return
+	}
+	c.Views = append(c.Views, v)
+}
+
+// RemoveView deletes the named view. It reports whether the view existed.
+func (c *Config) RemoveView(name string) bool {
+	for i := range c.Views {
+		if c.Views[i].Name == name {
+			c.Views = append(c.Views[:i], c.Views[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// validateViews checks view names and the statuses, priorities and classes
+// views filter on. Sort, group-by and query values are checked by the board
+// package when a view is applied.
+func (c *Config) validateViews() error {
+	seen := make(map[string]bool, len(c.Views))
+	for _, v := range c.Views {
+		if err := validateViewName(v.Name); err != nil {
+			return err
+		}
+		if seen[v.Name] {
+			return fmt.Errorf("%w: duplicate view name %q", ErrInvalid, v.Name)
+		}
+		seen[v.Name] = true
+
+		for _, s := range v.Statuses {
+			if !contains(c.StatusNames(), s) {
+				return fmt.Errorf("%w: view %q references unknown status %q", ErrInvalid, v.Name, s)
+			}
+		}
+		for _, p := range v.Priorities {
+			if !contains(c.Priorities, p) {
+				return fmt.Errorf("%w: view %q references unknown priority %q", ErrInvalid, v.Name, p)
+			}
+		}
+		if v.Class != "" && len(c.Classes) > 0 && c.ClassByName(v.Class) == nil {
+			return fmt.Errorf("%w: view %q references unknown class %q", ErrInvalid, v.Name, v.Class)
+		}
+		if v.Limit < 0 {
+			return fmt.Errorf("%w: view %q limit must be >= 0", ErrInvalid, v.Name)
+		}
+	}
+	return nil
+}
+
+// validateViewName requires a non-empty name usable as a config key suffix
+// (views.NAME) and a command-line argument.
+func validateViewName(name string) error {
+	if name == "" {
+		return fmt.Errorf("%w: view name is required", ErrInvalid)
+	}
+	if strings.ContainsAny(name, ". \t\n") {
+		return fmt.Errorf("%w: view name %q must not contain dots or whitespace", ErrInvalid, name)
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package filelock_test
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/antopolskiy/kanban-md/internal/filelock"
 )
@@ -69,3 +70,22 @@ func TestLock_DoubleUnlockReturnsError(t *testing.T) {
 		t.Error("expected error on double unlock (closed fd)")
 	}
 }
+
+func TestLockTimeout_InvalidPath(t *testing.T) {
+	_, err := filelock.LockTimeout("/nonexistent/dir/.lock", time.Second)
+	if err == nil {
+		t.Fatal("expected error for invalid lock path")
+	}
+}
+
+func TestLockTimeout_ZeroWaitsLikeLock(t *testing.T) {
+	lockPath := filepath.Join(t.TempDir(), ".lock")
+
+	unlock, err := filelock.LockTimeout(lockPath, 0)
+	if err != nil {
+		t.Fatalf("LockTimeout() error: %v", err)
+	}
+	if err := unlock(); err != nil {
+		t.Errorf("unlock() error: %v", err)
+	}
+}
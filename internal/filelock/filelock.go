@@ -2,10 +2,21 @@
 // concurrent access to shared resources (e.g., config files).
 package filelock
 
-import "os"
+import (
+	"errors"
+	"os"
+	"time"
+)
 
 const lockFileMode = 0o600
 
+// lockPollInterval is how often LockTimeout retries a contended lock.
+const lockPollInterval = 2 * time.Millisecond
+
+// ErrTimeout is returned by LockTimeout when the lock could not be
+// acquired before the timeout elapsed.
+var ErrTimeout = errors.New("timed out waiting for lock")
+
 // Lock acquires an exclusive advisory lock on the file at path,
 // creating it if it does not exist. The returned function releases
 // the lock and must be called when the critical section is done.
@@ -13,7 +24,7 @@ const lockFileMode = 0o600
 // Only one process can hold the lock at a time; other callers block
 // until the lock is available.
 func Lock(path string) (unlock func() error, err error) {
-	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, lockFileMode) //nolint:gosec // lock file path from trusted source
+	f, err := openLockFile(path)
 	if err != nil {
 		return nil, err
 	}
@@ -23,6 +34,45 @@ func Lock(path string) (unlock func() error, err error) {
 		return nil, err
 	}
 
+	return unlocker(f), nil
+}
+
+// LockTimeout is like Lock but gives up with ErrTimeout if the lock is
+// still held by someone else after timeout. A non-positive timeout waits
+// indefinitely, exactly like Lock.
+func LockTimeout(path string, timeout time.Duration) (unlock func() error, err error) {
+	if timeout <= 0 {
+		return Lock(path)
+	}
+
+	f, err := openLockFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		acquired, err := tryLockFile(f)
+		if err != nil {
+			_ = f.Close()
+			return nil, err
+		}
+		if acquired {
+			return unlocker(f), nil
+		}
+		if time.Now().After(deadline) {
+			_ = f.Close()
+			return nil, ErrTimeout
+		}
+		time.Sleep(lockPollInterval)
+	}
+}
+
+func openLockFile(path string) (*os.File, error) {
+	return os.OpenFile(path, os.O_CREATE|os.O_RDWR, lockFileMode) //nolint:gosec // lock file path from trusted source
+}
+
+func unlocker(f *os.File) func() error {
 	return func() error {
 		unlockErr := unlockFile(f)
 		closeErr := f.Close()
@@ -30,5 +80,5 @@ func Lock(path string) (unlock func() error, err error) {
 			return unlockErr
 		}
 		return closeErr
-	}, nil
+	}
 }
@@ -1,6 +1,7 @@
 package filelock_test
 
 import (
+	"errors"
 	"path/filepath"
 	"sync"
 	"sync/atomic"
@@ -81,3 +82,44 @@ func TestLockConcurrent(t *testing.T) {
 		t.Errorf("max concurrent holders = %d, want 1", mc)
 	}
 }
+
+func TestLockTimeoutExpiresWhileHeld(t *testing.T) {
+	lockPath := filepath.Join(t.TempDir(), ".lock")
+
+	unlock, err := filelock.Lock(lockPath)
+	if err != nil {
+		t.Fatalf("Lock() error: %v", err)
+	}
+	defer unlock() //nolint:errcheck // test cleanup
+
+	const timeout = 50 * time.Millisecond
+	start := time.Now()
+	_, err = filelock.LockTimeout(lockPath, timeout)
+	if !errors.Is(err, filelock.ErrTimeout) {
+		t.Fatalf("LockTimeout() error = %v, want ErrTimeout", err)
+	}
+	if elapsed := time.Since(start); elapsed < timeout {
+		t.Errorf("LockTimeout() returned after %v, want >= %v", elapsed, timeout)
+	}
+}
+
+func TestLockTimeoutAcquiresAfterRelease(t *testing.T) {
+	lockPath := filepath.Join(t.TempDir(), ".lock")
+
+	unlock, err := filelock.Lock(lockPath)
+	if err != nil {
+		t.Fatalf("Lock() error: %v", err)
+	}
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		_ = unlock()
+	}()
+
+	unlock2, err := filelock.LockTimeout(lockPath, 5*time.Second)
+	if err != nil {
+		t.Fatalf("LockTimeout() error: %v", err)
+	}
+	if err := unlock2(); err != nil {
+		t.Errorf("unlock() error: %v", err)
+	}
+}
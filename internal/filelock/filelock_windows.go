@@ -17,30 +17,43 @@ const (
 )
 
 func lockFile(f *os.File) error {
-	ol := new(windows.Overlapped)
 	for {
-		err := windows.LockFileEx(
-			windows.Handle(f.Fd()),
-			lockfileExclusiveLock|lockfileFailImmediately,
-			0, // reserved
-			1, // lock 1 byte
-			0, // high word
-			ol,
-		)
-		if err == nil {
-			return nil
-		}
-		// ERROR_LOCK_VIOLATION means another handle holds the lock.
-		// Sleep briefly to yield to the Go scheduler and retry.
-		// Without LOCKFILE_FAIL_IMMEDIATELY, LockFileEx blocks the OS thread,
-		// which can starve goroutines and cause deadlocks.
-		if !errors.Is(err, windows.ERROR_LOCK_VIOLATION) {
+		acquired, err := tryLockFile(f)
+		if err != nil {
 			return err
 		}
+		if acquired {
+			return nil
+		}
+		// Another handle holds the lock. Sleep briefly to yield to the Go
+		// scheduler and retry. Without LOCKFILE_FAIL_IMMEDIATELY, LockFileEx
+		// blocks the OS thread, which can starve goroutines and cause deadlocks.
 		time.Sleep(lockRetryInterval)
 	}
 }
 
+// tryLockFile attempts to take the lock without blocking. It reports false
+// (and no error) when another handle holds the lock.
+func tryLockFile(f *os.File) (bool, error) {
+	ol := new(windows.Overlapped)
+	err := windows.LockFileEx(
+		windows.Handle(f.Fd()),
+		lockfileExclusiveLock|lockfileFailImmediately,
+		0, // reserved
+		1, // lock 1 byte
+		0, // high word
+		ol,
+	)
+	if err == nil {
+		return true, nil
+	}
+	// ERROR_LOCK_VIOLATION means another handle holds the lock.
+	if errors.Is(err, windows.ERROR_LOCK_VIOLATION) {
+		return false, nil
+	}
+	return false, err
+}
+
 func unlockFile(f *os.File) error {
 	ol := new(windows.Overlapped)
 	return windows.UnlockFileEx(
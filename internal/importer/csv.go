@@ -0,0 +1,85 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/antopolskiy/kanban-md/internal/clierr"
+)
+
+// parseCSV reads a CSV file with a header row. Columns are found by the
+// mapping's headers, which default to the field names (matched case
+// insensitively). Only the title column is required.
+func parseCSV(data []byte, m *Mapping) ([]Issue, error) {
+	if m == nil {
+		m = &Mapping{}
+	}
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = -1
+	header, err := r.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, clierr.Newf(clierr.InvalidInput, "parsing CSV: %v", err)
+	}
+	index := make(map[string]int, len(csvFields))
+	for _, field := range csvFields {
+		for i, h := range header {
+			if strings.EqualFold(strings.TrimSpace(h), m.column(field)) {
+				index[field] = i
+				break
+			}
+		}
+	}
+	if _, ok := index["title"]; !ok {
+		return nil, clierr.Newf(clierr.InvalidInput, "CSV has no title column %q", m.column("title")).
+			WithDetails(map[string]any{"columns": header})
+	}
+
+	var issues []Issue
+	for {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return issues, nil
+		}
+		if err != nil {
+			return nil, clierr.Newf(clierr.InvalidInput, "parsing CSV: %v", err)
+		}
+		issues = append(issues, csvIssue(record, index, m.tagSeparator()))
+	}
+}
+
+// csvIssue builds an issue from a CSV record.
+func csvIssue(record []string, index map[string]int, tagSep string) Issue {
+	get := func(field string) string {
+		if i, ok := index[field]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+	is := Issue{
+		Title:        get("title"),
+		Body:         get("body"),
+		State:        get("status"),
+		Priority:     get("priority"),
+		Due:          get("due"),
+		MilestoneDue: get("milestone_due"),
+		Estimate:     get("estimate"),
+	}
+	if id := get("id"); id != "" {
+		is.ExternalID = "csv:" + id
+	}
+	if a := get("assignee"); a != "" {
+		is.Assignees = []string{a}
+	}
+	for _, tag := range strings.Split(get("tags"), tagSep) {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			is.Labels = append(is.Labels, tag)
+		}
+	}
+	return is
+}
@@ -0,0 +1,88 @@
+package importer
+
+import (
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/antopolskiy/kanban-md/internal/clierr"
+)
+
+// githubIssue is an issue as listed by `gh issue list --json` (camelCase
+// keys) or the GitHub REST API (snake_case keys).
+type githubIssue struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	Body    string `json:"body"`
+	State   string `json:"state"`
+	URL     string `json:"url"`
+	HTMLURL string `json:"html_url"`
+	Labels  []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	Assignees []struct {
+		Login string `json:"login"`
+	} `json:"assignees"`
+	Milestone *struct {
+		DueOn    string `json:"dueOn"`
+		DueOnAPI string `json:"due_on"`
+	} `json:"milestone"`
+	PullRequest json.RawMessage `json:"pull_request"`
+}
+
+func parseGitHub(data []byte) ([]Issue, error) {
+	var raw []githubIssue
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, clierr.Newf(clierr.InvalidInput, "parsing GitHub issues: %v", err)
+	}
+	issues := make([]Issue, 0, len(raw))
+	for _, gh := range raw {
+		if gh.PullRequest != nil {
+			continue // the REST API lists pull requests as issues
+		}
+		is := Issue{
+			ExternalID: "github:" + githubRef(gh),
+			Title:      gh.Title,
+			Body:       gh.Body,
+			State:      gh.State,
+		}
+		for _, l := range gh.Labels {
+			is.Labels = append(is.Labels, l.Name)
+		}
+		for _, a := range gh.Assignees {
+			is.Assignees = append(is.Assignees, a.Login)
+		}
+		if gh.Milestone != nil {
+			is.MilestoneDue = firstNonEmpty(gh.Milestone.DueOn, gh.Milestone.DueOnAPI)
+		}
+		issues = append(issues, is)
+	}
+	return issues, nil
+}
+
+// githubRef identifies an issue as owner/repo#number, from its URL, or as
+// #number when the export has no URLs.
+func githubRef(gh githubIssue) string {
+	ref := "#" + strconv.Itoa(gh.Number)
+	u, err := url.Parse(firstNonEmpty(gh.URL, gh.HTMLURL))
+	if err != nil {
+		return ref
+	}
+	// https://github.com/OWNER/REPO/issues/NUMBER
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	const repoParts = 2
+	if len(parts) < repoParts {
+		return ref
+	}
+	return parts[0] + "/" + parts[1] + ref
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
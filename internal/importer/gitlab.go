@@ -0,0 +1,95 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+
+	"github.com/antopolskiy/kanban-md/internal/clierr"
+)
+
+// gitlabIssue is an issue as returned by the GitLab issues API (and `glab
+// issue list --output json`).
+type gitlabIssue struct {
+	IID         int           `json:"iid"`
+	Title       string        `json:"title"`
+	Description string        `json:"description"`
+	State       string        `json:"state"`
+	Labels      []gitlabLabel `json:"labels"`
+	Assignees   []struct {
+		Username string `json:"username"`
+	} `json:"assignees"`
+	DueDate   string `json:"due_date"`
+	Milestone *struct {
+		DueDate string `json:"due_date"`
+	} `json:"milestone"`
+	References struct {
+		Full string `json:"full"`
+	} `json:"references"`
+}
+
+// gitlabLabel is a label name, or a label object when the API is asked for
+// label details.
+type gitlabLabel string
+
+func (l *gitlabLabel) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*l = gitlabLabel(name)
+		return nil
+	}
+	var obj struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+	*l = gitlabLabel(obj.Name)
+	return nil
+}
+
+// parseGitLab reads a JSON array of issues or newline-delimited JSON with
+// one issue per line.
+func parseGitLab(data []byte) ([]Issue, error) {
+	var raw []gitlabIssue
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &raw); err != nil {
+			return nil, clierr.Newf(clierr.InvalidInput, "parsing GitLab issues: %v", err)
+		}
+	} else {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		for dec.More() {
+			var gl gitlabIssue
+			if err := dec.Decode(&gl); err != nil {
+				return nil, clierr.Newf(clierr.InvalidInput, "parsing GitLab issues: %v", err)
+			}
+			raw = append(raw, gl)
+		}
+	}
+
+	issues := make([]Issue, 0, len(raw))
+	for _, gl := range raw {
+		ref := gl.References.Full
+		if ref == "" {
+			ref = "#" + strconv.Itoa(gl.IID)
+		}
+		is := Issue{
+			ExternalID: "gitlab:" + ref,
+			Title:      gl.Title,
+			Body:       gl.Description,
+			State:      gl.State,
+			Due:        gl.DueDate,
+		}
+		for _, l := range gl.Labels {
+			is.Labels = append(is.Labels, string(l))
+		}
+		for _, a := range gl.Assignees {
+			is.Assignees = append(is.Assignees, a.Username)
+		}
+		if gl.Milestone != nil {
+			is.MilestoneDue = gl.Milestone.DueDate
+		}
+		issues = append(issues, is)
+	}
+	return issues, nil
+}
@@ -0,0 +1,191 @@
+// Package importer reads issue exports from other trackers (GitHub, GitLab,
+// Trello and CSV) and turns them into tasks to import into a board.
+package importer
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/antopolskiy/kanban-md/internal/board"
+	"github.com/antopolskiy/kanban-md/internal/clierr"
+	"github.com/antopolskiy/kanban-md/internal/config"
+	"github.com/antopolskiy/kanban-md/internal/date"
+)
+
+// Supported import formats.
+const (
+	FormatGitHub = "github"
+	FormatGitLab = "gitlab"
+	FormatTrello = "trello"
+	FormatCSV    = "csv"
+)
+
+// Formats returns the supported import formats.
+func Formats() []string {
+	return []string{FormatGitHub, FormatGitLab, FormatTrello, FormatCSV}
+}
+
+// Issue is an issue as read from an export, before it is mapped onto the
+// board's statuses and priorities.
+type Issue struct {
+	ExternalID   string // source-prefixed, e.g. github:owner/repo#12
+	Title        string
+	Body         string
+	State        string // issue state, Trello list or CSV status
+	Priority     string
+	Labels       []string
+	Assignees    []string
+	Due          string // date or RFC 3339 time
+	MilestoneDue string // due date of the issue's milestone
+	Estimate     string
+}
+
+// Parse reads the issues of an export in the given format. The mapping is
+// only used by CSV, for its columns and tag separator.
+func Parse(format string, data []byte, m *Mapping) ([]Issue, error) {
+	switch format {
+	case FormatGitHub:
+		return parseGitHub(data)
+	case FormatGitLab:
+		return parseGitLab(data)
+	case FormatTrello:
+		return parseTrello(data)
+	case FormatCSV:
+		return parseCSV(data, m)
+	}
+	return nil, clierr.Newf(clierr.InvalidInput, "unknown import format %q", format).
+		WithDetails(map[string]any{"format": format, "formats": Formats()})
+}
+
+// Items maps issues onto the board: states to statuses, labels to tags (or to
+// the priority, for labels listed in the mapping's priorities), the first
+// assignee to the assignee, and the issue's due date, or else its
+// milestone's, to the due date. Values that have no counterpart on the board
+// fall back to the board defaults, with a warning.
+func Items(cfg *config.Config, issues []Issue, m *Mapping) ([]board.ImportItem, []string) {
+	if m == nil {
+		m = &Mapping{}
+	}
+	var warnings []string
+	warn := func(is Issue, format string, args ...any) {
+		warnings = append(warnings, fmt.Sprintf("%s: ", issueName(is))+fmt.Sprintf(format, args...))
+	}
+	items := make([]board.ImportItem, 0, len(issues))
+	for _, is := range issues {
+		if strings.TrimSpace(is.Title) == "" {
+			warn(is, "skipped: no title")
+			continue
+		}
+		p := board.CreateParams{Title: strings.TrimSpace(is.Title), Body: is.Body, Estimate: is.Estimate}
+		var ok bool
+		if p.Status, ok = mapStatus(cfg, m, is.State); !ok {
+			warn(is, "unknown state %q, using status %s", is.State, cfg.Defaults.Status)
+		}
+		if p.Priority, ok = mapPriority(cfg, m, is.Priority); !ok {
+			warn(is, "unknown priority %q, using %s", is.Priority, cfg.Defaults.Priority)
+		}
+		for _, label := range is.Labels {
+			if pr, isPriority := m.Priorities[strings.ToLower(label)]; isPriority {
+				p.Priority = pr
+				continue
+			}
+			if !slices.Contains(p.Tags, label) {
+				p.Tags = append(p.Tags, label)
+			}
+		}
+		if len(is.Assignees) > 0 {
+			p.Assignee = is.Assignees[0]
+		}
+		due, err := parseDue(is.Due, is.MilestoneDue)
+		if err != nil {
+			warn(is, "ignoring due date: %v", err)
+		}
+		p.Due = due
+		items = append(items, board.ImportItem{ExternalID: is.ExternalID, Params: p})
+	}
+	return items, warnings
+}
+
+// mapStatus returns the board status for an issue state. States that could
+// not be mapped get the default status and ok false.
+func mapStatus(cfg *config.Config, m *Mapping, state string) (string, bool) {
+	state = strings.TrimSpace(state)
+	if state == "" {
+		return cfg.Defaults.Status, true
+	}
+	if s, found := m.Statuses[strings.ToLower(state)]; found {
+		return s, true
+	}
+	for _, s := range cfg.StatusNames() {
+		if squash(s) == squash(state) {
+			return s, true
+		}
+	}
+	switch strings.ToLower(state) {
+	case "open", "opened":
+		return cfg.Defaults.Status, true
+	case "closed":
+		return cfg.DoneStatus(), true
+	}
+	return cfg.Defaults.Status, false
+}
+
+// squash lowercases s and drops everything but letters and digits, so that
+// "To Do" matches todo and "In Progress" in-progress.
+func squash(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, s)
+}
+
+// mapPriority returns the board priority for a source priority, or "" for
+// the default. ok is false for priorities that could not be mapped.
+func mapPriority(cfg *config.Config, m *Mapping, priority string) (string, bool) {
+	priority = strings.TrimSpace(priority)
+	if priority == "" {
+		return "", true
+	}
+	if p, found := m.Priorities[strings.ToLower(priority)]; found {
+		return p, true
+	}
+	for _, p := range cfg.Priorities {
+		if strings.EqualFold(p, priority) {
+			return p, true
+		}
+	}
+	return "", false
+}
+
+// parseDue parses the due date of an issue, falling back to its milestone's.
+func parseDue(due, milestoneDue string) (*date.Date, error) {
+	s := due
+	if s == "" {
+		s = milestoneDue
+	}
+	if s == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		d := date.New(t.Year(), t.Month(), t.Day())
+		return &d, nil
+	}
+	d, err := date.Parse(s)
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// issueName names an issue in warnings.
+func issueName(is Issue) string {
+	if is.ExternalID != "" {
+		return is.ExternalID
+	}
+	return fmt.Sprintf("%q", is.Title)
+}
@@ -0,0 +1,145 @@
+package importer_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/antopolskiy/kanban-md/internal/clierr"
+	"github.com/antopolskiy/kanban-md/internal/config"
+	"github.com/antopolskiy/kanban-md/internal/importer"
+)
+
+func TestParseGitHub(t *testing.T) {
+	data := `[
+  {"number": 12, "title": "Login fails", "body": "Steps", "state": "OPEN",
+   "labels": [{"name": "bug"}], "assignees": [{"login": "alice"}, {"login": "bob"}],
+   "milestone": {"title": "v1", "dueOn": "2026-03-01T00:00:00Z"},
+   "url": "https://github.com/acme/app/issues/12"},
+  {"number": 13, "title": "A PR", "state": "open", "pull_request": {}}
+]`
+	issues, err := importer.Parse(importer.FormatGitHub, []byte(data), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 1 {
+		t.Fatalf("issues = %+v, want one issue without the pull request", issues)
+	}
+	is := issues[0]
+	if is.ExternalID != "github:acme/app#12" || is.State != "OPEN" || is.MilestoneDue != "2026-03-01T00:00:00Z" {
+		t.Errorf("issue = %+v", is)
+	}
+	if len(is.Labels) != 1 || len(is.Assignees) != 2 {
+		t.Errorf("labels, assignees = %v, %v", is.Labels, is.Assignees)
+	}
+}
+
+func TestParseGitLab_NDJSON(t *testing.T) {
+	data := `{"iid": 3, "title": "One", "state": "opened", "labels": ["a", {"name": "b"}], "due_date": "2026-02-20", "references": {"full": "grp/proj#3"}}
+{"iid": 4, "title": "Two", "state": "closed", "milestone": {"due_date": "2026-03-01"}}
+`
+	issues, err := importer.Parse(importer.FormatGitLab, []byte(data), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 2 || issues[0].ExternalID != "gitlab:grp/proj#3" || issues[1].ExternalID != "gitlab:#4" {
+		t.Fatalf("issues = %+v", issues)
+	}
+	if len(issues[0].Labels) != 2 || issues[0].Labels[1] != "b" || issues[1].MilestoneDue != "2026-03-01" {
+		t.Errorf("issues = %+v, want both label forms and the milestone due date", issues)
+	}
+}
+
+func TestParseTrello(t *testing.T) {
+	data := `{
+  "lists": [{"id": "l1", "name": "Doing"}],
+  "members": [{"id": "m1", "username": "carol"}],
+  "cards": [
+    {"id": "c1", "shortLink": "AbC", "name": "Card", "idList": "l1", "labels": [{"name": "ux"}, {"name": ""}], "idMembers": ["m1"]},
+    {"id": "c2", "name": "Archived", "idList": "l1", "closed": true}
+  ]
+}`
+	issues, err := importer.Parse(importer.FormatTrello, []byte(data), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 2 || issues[0].ExternalID != "trello:AbC" || issues[0].State != "Doing" || issues[1].State != "closed" {
+		t.Fatalf("issues = %+v", issues)
+	}
+	if len(issues[0].Labels) != 1 || len(issues[0].Assignees) != 1 || issues[0].Assignees[0] != "carol" {
+		t.Errorf("card = %+v, want the named label and carol", issues[0])
+	}
+}
+
+func TestParseCSV_Mapping(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mapping.yml")
+	mapping := "columns: {id: Key, title: Summary, tags: Labels}\ntag_separator: \";\"\n"
+	if err := os.WriteFile(path, []byte(mapping), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	m, err := importer.ReadMapping(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := "Key,Summary,Labels,Priority\nK-1,\"First, with comma\",a; b,high\n,No key,,\n"
+	issues, err := importer.Parse(importer.FormatCSV, []byte(data), m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 2 || issues[0].ExternalID != "csv:K-1" || issues[0].Title != "First, with comma" || issues[1].ExternalID != "" {
+		t.Fatalf("issues = %+v", issues)
+	}
+	if len(issues[0].Labels) != 2 || issues[0].Labels[1] != "b" || issues[0].Priority != "high" {
+		t.Errorf("first = %+v, want tags a,b and high priority", issues[0])
+	}
+
+	_, err = importer.Parse(importer.FormatCSV, []byte("Name\nx\n"), m)
+	var cliErr *clierr.Error
+	if !errors.As(err, &cliErr) || cliErr.Code != clierr.InvalidInput {
+		t.Errorf("missing title column: err = %v, want INVALID_INPUT", err)
+	}
+}
+
+func TestItems(t *testing.T) {
+	cfg := config.NewDefault("Test")
+	m := &importer.Mapping{Priorities: map[string]string{"p1": "critical"}}
+	issues := []importer.Issue{
+		{
+			ExternalID: "github:#1", Title: "Open", State: "OPEN", Labels: []string{"bug", "P1"},
+			Assignees: []string{"alice", "bob"}, MilestoneDue: "2026-03-01T00:00:00Z",
+		},
+		{ExternalID: "github:#2", Title: "Closed", State: "closed", Due: "2026-02-01"},
+		{ExternalID: "trello:x", Title: "Listed", State: "In Progress"},
+		{ExternalID: "trello:y", Title: "Unknown", State: "Someday"},
+		{ExternalID: "github:#3", Title: "  "},
+	}
+	items, warnings := importer.Items(cfg, issues, m)
+	if len(items) != 4 || len(warnings) != 2 {
+		t.Fatalf("items = %+v, warnings = %v; want 4 items and warnings for Someday and the empty title", items, warnings)
+	}
+	open := items[0].Params
+	if open.Status != cfg.Defaults.Status || open.Priority != "critical" || open.Assignee != "alice" ||
+		len(open.Tags) != 1 || open.Due == nil || open.Due.String() != "2026-03-01" {
+		t.Errorf("open = %+v, want default status, critical, alice, tag bug, milestone due", open)
+	}
+	if items[1].Params.Status != cfg.DoneStatus() || items[1].Params.Due.String() != "2026-02-01" {
+		t.Errorf("closed = %+v, want done and its own due date", items[1].Params)
+	}
+	if items[2].Params.Status != "in-progress" || items[3].Params.Status != cfg.Defaults.Status {
+		t.Errorf("statuses = %q, %q; want in-progress and the default", items[2].Params.Status, items[3].Params.Status)
+	}
+}
+
+func TestMappingValidate(t *testing.T) {
+	cfg := config.NewDefault("Test")
+	if err := (&importer.Mapping{Statuses: map[string]string{"doing": "in-progress"}}).Validate(cfg); err != nil {
+		t.Errorf("valid mapping: %v", err)
+	}
+	if err := (&importer.Mapping{Statuses: map[string]string{"doing": "nope"}}).Validate(cfg); err == nil {
+		t.Error("unknown status: want error")
+	}
+	if err := (&importer.Mapping{Columns: map[string]string{"summary": "Summary"}}).Validate(cfg); err == nil {
+		t.Error("unknown column field: want error")
+	}
+}
@@ -0,0 +1,98 @@
+package importer
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/antopolskiy/kanban-md/internal/clierr"
+	"github.com/antopolskiy/kanban-md/internal/config"
+	"github.com/antopolskiy/kanban-md/internal/task"
+)
+
+// CSV column fields: the task fields a CSV column can be mapped to.
+var csvFields = []string{
+	"id", "title", "body", "status", "priority", "tags", "assignee", "due", "milestone_due", "estimate",
+}
+
+// Mapping tunes how an export is mapped onto the board. It is read from a
+// YAML file:
+//
+//	columns:         # CSV only: task field -> column header
+//	  id: Key
+//	  title: Summary
+//	statuses:        # source state, list or column value -> board status
+//	  To Do: todo
+//	priorities:      # source priority or label -> board priority
+//	  P1: high
+//	tag_separator: ";"
+type Mapping struct {
+	Columns      map[string]string `yaml:"columns,omitempty"`
+	Statuses     map[string]string `yaml:"statuses,omitempty"`
+	Priorities   map[string]string `yaml:"priorities,omitempty"`
+	TagSeparator string            `yaml:"tag_separator,omitempty"` // CSV tags column; default ","
+}
+
+// ReadMapping reads a mapping file. Source states and priorities match case
+// insensitively.
+func ReadMapping(path string) (*Mapping, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // mapping path given by the user
+	if err != nil {
+		return nil, fmt.Errorf("reading mapping: %w", err)
+	}
+	var m Mapping
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, clierr.Newf(clierr.InvalidInput, "parsing mapping %s: %v", path, err)
+	}
+	m.Statuses = lowerKeys(m.Statuses)
+	m.Priorities = lowerKeys(m.Priorities)
+	return &m, nil
+}
+
+// Validate checks the mapping against the board: columns must name task
+// fields, and statuses and priorities must map to the board's.
+func (m *Mapping) Validate(cfg *config.Config) error {
+	for field := range m.Columns {
+		if !slices.Contains(csvFields, field) {
+			return clierr.Newf(clierr.InvalidInput, "mapping: unknown column field %q", field).
+				WithDetails(map[string]any{"field": field, "fields": csvFields})
+		}
+	}
+	for _, s := range m.Statuses {
+		if err := task.ValidateStatus(s, cfg.StatusNames()); err != nil {
+			return err
+		}
+	}
+	for _, p := range m.Priorities {
+		if err := task.ValidatePriority(p, cfg.Priorities); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// column returns the CSV column header of a task field.
+func (m *Mapping) column(field string) string {
+	if c, ok := m.Columns[field]; ok {
+		return c
+	}
+	return field
+}
+
+func (m *Mapping) tagSeparator() string {
+	if m.TagSeparator != "" {
+		return m.TagSeparator
+	}
+	return ","
+}
+
+func lowerKeys(in map[string]string) map[string]string {
+	out := make(map[string]string, len(in))
+	for k, v := range in {
+		out[strings.ToLower(k)] = v
+	}
+	return out
+}
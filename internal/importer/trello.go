@@ -0,0 +1,75 @@
+package importer
+
+import (
+	"encoding/json"
+
+	"github.com/antopolskiy/kanban-md/internal/clierr"
+)
+
+// trelloBoard is the part of a Trello board JSON export that is imported.
+type trelloBoard struct {
+	Lists []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"lists"`
+	Members []struct {
+		ID       string `json:"id"`
+		Username string `json:"username"`
+	} `json:"members"`
+	Cards []struct {
+		ID        string `json:"id"`
+		ShortLink string `json:"shortLink"`
+		Name      string `json:"name"`
+		Desc      string `json:"desc"`
+		IDList    string `json:"idList"`
+		Closed    bool   `json:"closed"`
+		Due       string `json:"due"`
+		Labels    []struct {
+			Name string `json:"name"`
+		} `json:"labels"`
+		IDMembers []string `json:"idMembers"`
+	} `json:"cards"`
+}
+
+// parseTrello reads a Trello board export. A card's state is the name of its
+// list, or "closed" for archived cards.
+func parseTrello(data []byte) ([]Issue, error) {
+	var b trelloBoard
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, clierr.Newf(clierr.InvalidInput, "parsing Trello board: %v", err)
+	}
+	lists := make(map[string]string, len(b.Lists))
+	for _, l := range b.Lists {
+		lists[l.ID] = l.Name
+	}
+	members := make(map[string]string, len(b.Members))
+	for _, m := range b.Members {
+		members[m.ID] = m.Username
+	}
+
+	issues := make([]Issue, 0, len(b.Cards))
+	for _, c := range b.Cards {
+		is := Issue{
+			ExternalID: "trello:" + firstNonEmpty(c.ShortLink, c.ID),
+			Title:      c.Name,
+			Body:       c.Desc,
+			State:      lists[c.IDList],
+			Due:        c.Due,
+		}
+		if c.Closed {
+			is.State = "closed"
+		}
+		for _, l := range c.Labels {
+			if l.Name != "" { // color-only labels have no name
+				is.Labels = append(is.Labels, l.Name)
+			}
+		}
+		for _, id := range c.IDMembers {
+			if name := members[id]; name != "" {
+				is.Assignees = append(is.Assignees, name)
+			}
+		}
+		issues = append(issues, is)
+	}
+	return issues, nil
+}
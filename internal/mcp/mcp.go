@@ -0,0 +1,160 @@
+// Package mcp implements a Model Context Protocol server over stdio, exposing
+// board operations as MCP tools.
+//
+// Messages are newline-delimited JSON-RPC 2.0. Like the HTTP server, the MCP
+// server keeps no state of its own: every tool call reads the board from disk
+// and mutates it through the internal/board functions under the board lock.
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+)
+
+// latestProtocolVersion is the newest MCP revision this server implements.
+const latestProtocolVersion = "2025-06-18"
+
+// supportedProtocolVersions lists the MCP revisions the server can speak.
+var supportedProtocolVersions = []string{latestProtocolVersion, "2025-03-26", "2024-11-05"}
+
+// maxMessageBytes caps the size of a single JSON-RPC message.
+const maxMessageBytes = 10 << 20
+
+// JSON-RPC 2.0 error codes.
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+)
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Server answers MCP requests for the board in one kanban directory.
+type Server struct {
+	dir     string
+	version string
+	now     func() time.Time
+}
+
+// New creates a Server for the board in dir. version is reported to clients
+// as the server version.
+func New(dir, version string) *Server {
+	return &Server{dir: dir, version: version, now: time.Now}
+}
+
+// Serve reads requests from r and writes responses to w until r is exhausted
+// or ctx is canceled. Requests are handled one at a time, in order.
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxMessageBytes)
+	enc := json.NewEncoder(w)
+
+	for sc.Scan() {
+		if ctx.Err() != nil {
+			return nil
+		}
+		line := sc.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		resp := s.handle(line)
+		if resp == nil {
+			continue // notification
+		}
+		if err := enc.Encode(resp); err != nil {
+			return fmt.Errorf("writing response: %w", err)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return fmt.Errorf("reading request: %w", err)
+	}
+	return nil
+}
+
+// handle processes one message. It returns nil for notifications, which get
+// no response.
+func (s *Server) handle(line []byte) *response {
+	var req request
+	if err := json.Unmarshal(line, &req); err != nil {
+		return errorResponse(json.RawMessage("null"), codeParseError, "parse error: "+err.Error())
+	}
+	if len(req.ID) == 0 {
+		return nil // notifications (e.g. notifications/initialized) need no reply
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		return errorResponse(req.ID, codeInvalidRequest, "invalid JSON-RPC 2.0 request")
+	}
+
+	var (
+		result any
+		rpcErr *rpcError
+	)
+	switch req.Method {
+	case "initialize":
+		result, rpcErr = s.initialize(req.Params)
+	case "ping":
+		result = map[string]any{}
+	case "tools/list":
+		result = map[string]any{"tools": toolList()}
+	case "tools/call":
+		result, rpcErr = s.callTool(req.Params)
+	default:
+		rpcErr = &rpcError{Code: codeMethodNotFound, Message: "method not found: " + req.Method}
+	}
+	if rpcErr != nil {
+		return errorResponse(req.ID, rpcErr.Code, rpcErr.Message)
+	}
+	return &response{JSONRPC: "2.0", ID: req.ID, Result: result}
+}
+
+func (s *Server) initialize(params json.RawMessage) (any, *rpcError) {
+	var p struct {
+		ProtocolVersion string `json:"protocolVersion"`
+	}
+	if len(params) > 0 {
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, &rpcError{Code: codeInvalidParams, Message: "invalid initialize params: " + err.Error()}
+		}
+	}
+	// Answer with the client's version if we speak it, otherwise our latest;
+	// the client decides whether it can continue.
+	version := latestProtocolVersion
+	if slices.Contains(supportedProtocolVersions, p.ProtocolVersion) {
+		version = p.ProtocolVersion
+	}
+	return map[string]any{
+		"protocolVersion": version,
+		"capabilities":    map[string]any{"tools": map[string]any{}},
+		"serverInfo":      map[string]any{"name": "kanban-md", "version": s.version},
+		"instructions": "Tools operate on the kanban-md board in " + s.dir + ". " +
+			"Claim a task with pick_task before working on it, pass the same claim name to later calls, " +
+			"and pass if_rev (the task's rev) to fail with CONFLICT instead of overwriting concurrent changes.",
+	}, nil
+}
+
+func errorResponse(id json.RawMessage, code int, msg string) *response {
+	return &response{JSONRPC: "2.0", ID: id, Error: &rpcError{Code: code, Message: msg}}
+}
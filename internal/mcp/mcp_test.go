@@ -0,0 +1,338 @@
+package mcp_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/antopolskiy/kanban-md/internal/board"
+	"github.com/antopolskiy/kanban-md/internal/config"
+	"github.com/antopolskiy/kanban-md/internal/mcp"
+)
+
+type rpcResponse struct {
+	ID     json.RawMessage `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+type toolResult struct {
+	Content []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"content"`
+	StructuredContent json.RawMessage `json:"structuredContent"`
+	IsError           bool            `json:"isError"`
+}
+
+type mcpTask struct {
+	ID        int    `json:"id"`
+	Title     string `json:"title"`
+	Status    string `json:"status"`
+	Body      string `json:"body"`
+	ClaimedBy string `json:"claimed_by"`
+	Rev       string `json:"rev"`
+}
+
+func setupBoard(t *testing.T) *config.Config {
+	t.Helper()
+	cfg, err := config.Init(filepath.Join(t.TempDir(), "kanban"), "MCP Test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cfg
+}
+
+// exchange sends each message as one line and returns the responses in order.
+func exchange(t *testing.T, cfg *config.Config, msgs ...any) []rpcResponse {
+	t.Helper()
+	var in bytes.Buffer
+	for _, m := range msgs {
+		data, err := json.Marshal(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		in.Write(data)
+		in.WriteByte('\n')
+	}
+	var out bytes.Buffer
+	if err := mcp.New(cfg.Dir(), "test").Serve(t.Context(), &in, &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+
+	var resps []rpcResponse
+	sc := bufio.NewScanner(&out)
+	for sc.Scan() {
+		var r rpcResponse
+		if err := json.Unmarshal(sc.Bytes(), &r); err != nil {
+			t.Fatalf("decoding response %q: %v", sc.Text(), err)
+		}
+		resps = append(resps, r)
+	}
+	return resps
+}
+
+func call(id int, name string, args any) map[string]any {
+	return map[string]any{
+		"jsonrpc": "2.0", "id": id, "method": "tools/call",
+		"params": map[string]any{"name": name, "arguments": args},
+	}
+}
+
+// callTool runs one tools/call and decodes the tool result.
+func callTool(t *testing.T, cfg *config.Config, name string, args any) toolResult {
+	t.Helper()
+	resps := exchange(t, cfg, call(1, name, args))
+	if len(resps) != 1 || resps[0].Error != nil {
+		t.Fatalf("%s: unexpected responses %+v", name, resps)
+	}
+	var res toolResult
+	if err := json.Unmarshal(resps[0].Result, &res); err != nil {
+		t.Fatal(err)
+	}
+	return res
+}
+
+func TestServe_InitializeAndNotifications(t *testing.T) {
+	cfg := setupBoard(t)
+	resps := exchange(t, cfg,
+		map[string]any{"jsonrpc": "2.0", "id": 1, "method": "initialize", "params": map[string]any{
+			"protocolVersion": "2025-03-26", "capabilities": map[string]any{},
+			"clientInfo": map[string]any{"name": "test", "version": "1"},
+		}},
+		map[string]any{"jsonrpc": "2.0", "method": "notifications/initialized"},
+		map[string]any{"jsonrpc": "2.0", "id": 2, "method": "ping"},
+	)
+	if len(resps) != 2 {
+		t.Fatalf("got %d responses, want 2 (notification must not be answered)", len(resps))
+	}
+
+	var init struct {
+		ProtocolVersion string `json:"protocolVersion"`
+		ServerInfo      struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+		} `json:"serverInfo"`
+		Capabilities map[string]any `json:"capabilities"`
+	}
+	if err := json.Unmarshal(resps[0].Result, &init); err != nil {
+		t.Fatal(err)
+	}
+	if init.ProtocolVersion != "2025-03-26" {
+		t.Errorf("protocolVersion = %q, want the client's 2025-03-26", init.ProtocolVersion)
+	}
+	if init.ServerInfo.Name != "kanban-md" || init.ServerInfo.Version != "test" {
+		t.Errorf("serverInfo = %+v", init.ServerInfo)
+	}
+	if _, ok := init.Capabilities["tools"]; !ok {
+		t.Error("capabilities missing tools")
+	}
+	if string(resps[1].ID) != "2" || resps[1].Error != nil {
+		t.Errorf("ping response = %+v", resps[1])
+	}
+}
+
+func TestServe_ProtocolErrors(t *testing.T) {
+	cfg := setupBoard(t)
+
+	var in bytes.Buffer
+	in.WriteString("{not json\n")
+	in.WriteString(`{"jsonrpc":"2.0","id":2,"method":"resources/list"}` + "\n")
+	in.WriteString(`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"nope"}}` + "\n")
+	var out bytes.Buffer
+	if err := mcp.New(cfg.Dir(), "test").Serve(t.Context(), &in, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	wantCodes := []int{-32700, -32601, -32602}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != len(wantCodes) {
+		t.Fatalf("got %d responses, want %d:\n%s", len(lines), len(wantCodes), out.String())
+	}
+	for i, line := range lines {
+		var r rpcResponse
+		if err := json.Unmarshal([]byte(line), &r); err != nil {
+			t.Fatal(err)
+		}
+		if r.Error == nil || r.Error.Code != wantCodes[i] {
+			t.Errorf("response %d = %s, want error code %d", i, line, wantCodes[i])
+		}
+	}
+}
+
+func TestServe_ToolsList(t *testing.T) {
+	cfg := setupBoard(t)
+	resps := exchange(t, cfg, map[string]any{"jsonrpc": "2.0", "id": 1, "method": "tools/list"})
+
+	var list struct {
+		Tools []struct {
+			Name        string `json:"name"`
+			InputSchema struct {
+				Type       string         `json:"type"`
+				Properties map[string]any `json:"properties"`
+				Required   []string       `json:"required"`
+			} `json:"inputSchema"`
+			OutputSchema map[string]any `json:"outputSchema"`
+		} `json:"tools"`
+	}
+	if err := json.Unmarshal(resps[0].Result, &list); err != nil {
+		t.Fatal(err)
+	}
+
+	byName := map[string]int{}
+	for i, tl := range list.Tools {
+		byName[tl.Name] = i
+		if tl.InputSchema.Type != "object" || tl.OutputSchema["type"] != "object" {
+			t.Errorf("%s: schemas must be objects", tl.Name)
+		}
+	}
+	for _, name := range []string{"list_tasks", "pick_task", "move_task", "handoff_task", "heartbeat", "append_note", "board_summary", "context"} {
+		if _, ok := byName[name]; !ok {
+			t.Errorf("tool %s missing", name)
+		}
+	}
+
+	move := list.Tools[byName["move_task"]].InputSchema
+	if strings.Join(move.Required, ",") != "id,status" {
+		t.Errorf("move_task required = %v, want [id status]", move.Required)
+	}
+	if _, ok := move.Properties["if_rev"]; !ok {
+		t.Error("move_task schema missing if_rev")
+	}
+	if _, ok := list.Tools[byName["list_tasks"]].InputSchema.Properties["claimed_by"]; !ok {
+		t.Error("list_tasks schema missing claimed_by")
+	}
+}
+
+func TestServe_TaskTools(t *testing.T) {
+	cfg := setupBoard(t)
+	now := time.Now()
+	for _, title := range []string{"First", "Second"} {
+		if _, err := board.Create(cfg, board.CreateParams{Title: title, Status: "todo"}, now); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	res := callTool(t, cfg, "list_tasks", map[string]any{"status": []string{"todo"}})
+	var list struct {
+		Tasks []mcpTask `json:"tasks"`
+	}
+	if err := json.Unmarshal(res.StructuredContent, &list); err != nil {
+		t.Fatal(err)
+	}
+	if res.IsError || len(list.Tasks) != 2 || list.Tasks[0].Rev == "" {
+		t.Fatalf("list_tasks = %+v", list)
+	}
+
+	res = callTool(t, cfg, "pick_task", map[string]any{"claim": "agent-1", "move": "in-progress"})
+	var picked struct {
+		Task mcpTask `json:"task"`
+	}
+	if err := json.Unmarshal(res.StructuredContent, &picked); err != nil {
+		t.Fatal(err)
+	}
+	if res.IsError || picked.Task.ClaimedBy != "agent-1" || picked.Task.Status != "in-progress" {
+		t.Fatalf("pick_task = %+v (%s)", picked, res.Content[0].Text)
+	}
+	id := picked.Task.ID
+
+	res = callTool(t, cfg, "append_note", map[string]any{"id": id, "text": "halfway there", "claim": "agent-1"})
+	var noted mcpTask
+	if err := json.Unmarshal(res.StructuredContent, &noted); err != nil {
+		t.Fatal(err)
+	}
+	if res.IsError || !strings.Contains(noted.Body, "halfway there") {
+		t.Fatalf("append_note = %+v", noted)
+	}
+
+	res = callTool(t, cfg, "heartbeat", map[string]any{"id": id, "claim": "agent-1"})
+	if res.IsError {
+		t.Fatalf("heartbeat: %s", res.Content[0].Text)
+	}
+
+	res = callTool(t, cfg, "handoff_task", map[string]any{"id": id, "claim": "agent-1", "note": "ready", "release": true})
+	var handed mcpTask
+	if err := json.Unmarshal(res.StructuredContent, &handed); err != nil {
+		t.Fatal(err)
+	}
+	if res.IsError || handed.Status != "review" || handed.ClaimedBy != "" {
+		t.Fatalf("handoff_task = %+v", handed)
+	}
+
+	res = callTool(t, cfg, "move_task", map[string]any{"id": id, "status": "done", "if_rev": handed.Rev})
+	var moved struct {
+		Task    mcpTask `json:"task"`
+		Changed bool    `json:"changed"`
+	}
+	if err := json.Unmarshal(res.StructuredContent, &moved); err != nil {
+		t.Fatal(err)
+	}
+	if res.IsError || !moved.Changed || moved.Task.Status != "done" {
+		t.Fatalf("move_task = %+v", moved)
+	}
+}
+
+func TestServe_ToolErrors(t *testing.T) {
+	cfg := setupBoard(t)
+	if _, err := board.Create(cfg, board.CreateParams{Title: "A"}, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		tool     string
+		args     any
+		wantCode string
+	}{
+		{"stale rev", "move_task", map[string]any{"id": 1, "status": "todo", "if_rev": "000000000000"}, "CONFLICT"},
+		{"missing task", "append_note", map[string]any{"id": 99, "text": "x"}, "TASK_NOT_FOUND"},
+		{"unknown argument", "list_tasks", map[string]any{"bogus": true}, "INVALID_INPUT"},
+		{"bad status", "move_task", map[string]any{"id": 1, "status": "nope"}, "INVALID_STATUS"},
+		{"claim lost", "heartbeat", map[string]any{"id": 1, "claim": "agent-1"}, "CLAIM_LOST"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := callTool(t, cfg, tt.tool, tt.args)
+			if !res.IsError {
+				t.Fatalf("isError = false, want tool error")
+			}
+			var body struct {
+				Code string `json:"code"`
+			}
+			if err := json.Unmarshal([]byte(res.Content[0].Text), &body); err != nil {
+				t.Fatal(err)
+			}
+			if body.Code != tt.wantCode {
+				t.Errorf("code = %q, want %q", body.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestServe_SummaryAndContext(t *testing.T) {
+	cfg := setupBoard(t)
+	if _, err := board.Create(cfg, board.CreateParams{Title: "A"}, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	res := callTool(t, cfg, "board_summary", nil)
+	var overview board.Overview
+	if err := json.Unmarshal(res.StructuredContent, &overview); err != nil {
+		t.Fatal(err)
+	}
+	if res.IsError || overview.BoardName != "MCP Test" || overview.TotalTasks != 1 {
+		t.Errorf("board_summary = %+v", overview)
+	}
+
+	res = callTool(t, cfg, "context", map[string]any{"days": 3})
+	if res.IsError || !strings.Contains(res.Content[0].Text, "MCP Test") {
+		t.Errorf("context text = %q", res.Content[0].Text)
+	}
+}
@@ -0,0 +1,93 @@
+package mcp
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/antopolskiy/kanban-md/internal/date"
+)
+
+var (
+	timeType = reflect.TypeFor[time.Time]()
+	dateType = reflect.TypeFor[date.Date]()
+)
+
+// schemaOf derives a JSON Schema for the Go type of v, following the same
+// `json` struct tags encoding/json uses. Two extra struct tags are read:
+// `desc:"..."` sets the property description, and `required:"true"` marks the
+// property as required.
+func schemaOf(v any) map[string]any {
+	return schemaFor(reflect.TypeOf(v))
+}
+
+func schemaFor(t reflect.Type) map[string]any {
+	switch {
+	case t == timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case t == dateType:
+		return map[string]any{"type": "string", "format": "date"}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return nullable(schemaFor(t.Elem()))
+	case reflect.Struct:
+		return structSchema(t)
+	case reflect.Slice, reflect.Array:
+		return nullable(map[string]any{"type": "array", "items": schemaFor(t.Elem())})
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": schemaFor(t.Elem())}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	default:
+		return map[string]any{}
+	}
+}
+
+func structSchema(t reflect.Type) map[string]any {
+	props := map[string]any{}
+	var required []string
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		s := schemaFor(f.Type)
+		if d := f.Tag.Get("desc"); d != "" {
+			s["description"] = d
+		}
+		props[name] = s
+		if f.Tag.Get("required") == "true" {
+			required = append(required, name)
+		}
+	}
+	schema := map[string]any{"type": "object", "properties": props}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// nullable widens a schema's type to also accept null, matching how
+// encoding/json renders nil pointers and slices.
+func nullable(s map[string]any) map[string]any {
+	if typ, ok := s["type"].(string); ok {
+		s["type"] = []string{typ, "null"}
+	}
+	return s
+}
@@ -0,0 +1,466 @@
+package mcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/antopolskiy/kanban-md/internal/board"
+	"github.com/antopolskiy/kanban-md/internal/clierr"
+	"github.com/antopolskiy/kanban-md/internal/config"
+	"github.com/antopolskiy/kanban-md/internal/task"
+)
+
+// toolResult is what a tool handler produces: a structured value (also sent
+// as JSON text) and, optionally, a human-oriented text rendering.
+type toolResult struct {
+	structured any
+	text       string
+}
+
+// tool describes one MCP tool. args and output are zero values of the
+// argument and result types; their JSON schemas are derived by reflection.
+type tool struct {
+	name        string
+	description string
+	args        any
+	output      any
+	call        func(s *Server, cfg *config.Config, raw json.RawMessage) (toolResult, error)
+}
+
+// listArgs mirrors board.FilterOptions and the `list` flags.
+type listArgs struct {
+	Status    []string `json:"status,omitempty" desc:"Only tasks in these statuses (default: all except archived)"`
+	Priority  []string `json:"priority,omitempty" desc:"Only tasks with these priorities"`
+	Assignee  string   `json:"assignee,omitempty" desc:"Only tasks assigned to this person"`
+	Tag       string   `json:"tag,omitempty" desc:"Only tasks with this tag"`
+	Search    string   `json:"search,omitempty" desc:"Case-insensitive substring match on title, body and tags"`
+	Blocked   *bool    `json:"blocked,omitempty" desc:"true = only blocked tasks, false = only not-blocked tasks"`
+	Parent    *int     `json:"parent,omitempty" desc:"Only children of this task ID"`
+	Unclaimed bool     `json:"unclaimed,omitempty" desc:"Only unclaimed or expired-claim tasks"`
+	ClaimedBy string   `json:"claimed_by,omitempty" desc:"Only tasks claimed by this agent"`
+	Class     string   `json:"class,omitempty" desc:"Only tasks with this class of service"`
+	Query     string   `json:"query,omitempty" desc:"Query such as 'status:todo AND (tag:api OR priority>=high) AND NOT claimed'"`
+	Unblocked bool     `json:"unblocked,omitempty" desc:"Only tasks whose dependencies are all done"`
+	Archived  bool     `json:"archived,omitempty" desc:"Only archived tasks"`
+	Sort      string   `json:"sort,omitempty" desc:"Sort field: id, title, status, priority, created, updated, due"`
+	Reverse   bool     `json:"reverse,omitempty" desc:"Reverse the sort order"`
+	Limit     int      `json:"limit,omitempty" desc:"Maximum number of tasks to return"`
+}
+
+type pickArgs struct {
+	Claim  string   `json:"claim" required:"true" desc:"Agent name to claim the task for"`
+	Status string   `json:"status,omitempty" desc:"Only pick from this status"`
+	Move   string   `json:"move,omitempty" desc:"Also move the picked task to this status"`
+	Tags   []string `json:"tags,omitempty" desc:"Only pick tasks with at least one of these tags"`
+	Query  string   `json:"query,omitempty" desc:"Only pick tasks matching this query, e.g. 'tag:backend AND priority>=high'"`
+}
+
+type moveArgs struct {
+	ID     int    `json:"id" required:"true" desc:"Task ID"`
+	Status string `json:"status" required:"true" desc:"Target status"`
+	Claim  string `json:"claim,omitempty" desc:"Your agent name; required for claimed tasks and claim-required statuses"`
+	IfRev  string `json:"if_rev,omitempty" desc:"Fail with CONFLICT unless the task is still at this rev"`
+}
+
+type handoffArgs struct {
+	ID        int    `json:"id" required:"true" desc:"Task ID"`
+	Claim     string `json:"claim" required:"true" desc:"Your agent name"`
+	Note      string `json:"note,omitempty" desc:"Handoff note appended to the task body"`
+	Timestamp bool   `json:"timestamp,omitempty" desc:"Prefix the note with a timestamp line"`
+	Block     string `json:"block,omitempty" desc:"Mark the task blocked with this reason"`
+	Release   bool   `json:"release,omitempty" desc:"Release the claim after handing off"`
+	IfRev     string `json:"if_rev,omitempty" desc:"Fail with CONFLICT unless the task is still at this rev"`
+}
+
+type heartbeatArgs struct {
+	ID    int    `json:"id" required:"true" desc:"Task ID"`
+	Claim string `json:"claim" required:"true" desc:"Your agent name; must be the current claimant"`
+}
+
+type appendNoteArgs struct {
+	ID        int    `json:"id" required:"true" desc:"Task ID"`
+	Text      string `json:"text" required:"true" desc:"Markdown text appended to the task body"`
+	Timestamp bool   `json:"timestamp,omitempty" desc:"Prefix the note with a timestamp line"`
+	Claim     string `json:"claim,omitempty" desc:"Your agent name; renews your claim on the task"`
+	IfRev     string `json:"if_rev,omitempty" desc:"Fail with CONFLICT unless the task is still at this rev"`
+}
+
+type summaryArgs struct{}
+
+type contextArgs struct {
+	Sections []string `json:"sections,omitempty" desc:"Sections to include: in-progress, blocked, overdue, recently-completed (default: all)"`
+	Days     int      `json:"days,omitempty" desc:"Lookback in days for recently completed tasks (default 7)"`
+}
+
+type taskList struct {
+	Tasks []*task.Task `json:"tasks"`
+}
+
+type pickOutput struct {
+	Task      *task.Task `json:"task"`
+	OldStatus string     `json:"old_status,omitempty"`
+}
+
+type moveOutput struct {
+	Task      *task.Task `json:"task"`
+	Changed   bool       `json:"changed"`
+	OldStatus string     `json:"old_status,omitempty"`
+	Warnings  []string   `json:"warnings,omitempty"`
+}
+
+// tools is the registry of MCP tools in the order they are listed.
+var tools = []tool{
+	{
+		name:        "list_tasks",
+		description: "List tasks on the board with optional filters and sorting. Each task includes its rev.",
+		args:        listArgs{},
+		output:      taskList{},
+		call:        callListTasks,
+	},
+	{
+		name:        "pick_task",
+		description: "Atomically find the highest-priority unblocked, unclaimed task, claim it, and optionally move it.",
+		args:        pickArgs{},
+		output:      pickOutput{},
+		call:        callPickTask,
+	},
+	{
+		name:        "move_task",
+		description: "Move a task to another status, enforcing WIP limits and claims.",
+		args:        moveArgs{},
+		output:      moveOutput{},
+		call:        callMoveTask,
+	},
+	{
+		name:        "handoff_task",
+		description: "Move a task to review with a handoff note, optionally blocking it and releasing the claim.",
+		args:        handoffArgs{},
+		output:      task.Task{},
+		call:        callHandoffTask,
+	},
+	{
+		name:        "heartbeat",
+		description: "Renew your claim on a task so it does not expire; fails with CLAIM_LOST if another agent took it over.",
+		args:        heartbeatArgs{},
+		output:      task.Task{},
+		call:        callHeartbeat,
+	},
+	{
+		name:        "append_note",
+		description: "Append a progress note to a task's body without overwriting it.",
+		args:        appendNoteArgs{},
+		output:      task.Task{},
+		call:        callAppendNote,
+	},
+	{
+		name:        "board_summary",
+		description: "Summarize the board: task counts per status, WIP limits, blocked and overdue counts, priorities.",
+		args:        summaryArgs{},
+		output:      board.Overview{},
+		call:        callBoardSummary,
+	},
+	{
+		name:        "context",
+		description: "Get a compact board context (in-progress, blocked, overdue, recently completed work) for planning.",
+		args:        contextArgs{},
+		output:      board.ContextData{},
+		call:        callContext,
+	},
+}
+
+// toolList renders the registry for tools/list.
+func toolList() []map[string]any {
+	out := make([]map[string]any, 0, len(tools))
+	for _, t := range tools {
+		out = append(out, map[string]any{
+			"name":         t.name,
+			"description":  t.description,
+			"inputSchema":  schemaOf(t.args),
+			"outputSchema": schemaOf(t.output),
+		})
+	}
+	return out
+}
+
+// callTool handles tools/call. Tool failures (including board errors) are
+// reported in the result with isError set, as MCP prescribes; only protocol
+// problems become JSON-RPC errors.
+func (s *Server) callTool(params json.RawMessage) (any, *rpcError) {
+	var p struct {
+		Name      string          `json:"name"`
+		Arguments json.RawMessage `json:"arguments"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, &rpcError{Code: codeInvalidParams, Message: "invalid tools/call params: " + err.Error()}
+	}
+	var found *tool
+	for i := range tools {
+		if tools[i].name == p.Name {
+			found = &tools[i]
+			break
+		}
+	}
+	if found == nil {
+		return nil, &rpcError{Code: codeInvalidParams, Message: "unknown tool: " + p.Name}
+	}
+
+	cfg, err := config.Load(s.dir)
+	if err != nil {
+		return errorResult(err), nil
+	}
+	res, err := found.call(s, cfg, p.Arguments)
+	if err != nil {
+		return errorResult(err), nil
+	}
+
+	data, err := json.MarshalIndent(res.structured, "", "  ")
+	if err != nil {
+		return errorResult(err), nil
+	}
+	content := []map[string]any{{"type": "text", "text": string(data)}}
+	if res.text != "" {
+		content = []map[string]any{{"type": "text", "text": res.text}}
+	}
+	return map[string]any{
+		"content":           content,
+		"structuredContent": res.structured,
+		"isError":           false,
+	}, nil
+}
+
+// errorResult renders err as a tool error in the CLI's --json error shape.
+func errorResult(err error) map[string]any {
+	body := map[string]any{"error": err.Error(), "code": clierr.InternalError}
+	var cliErr *clierr.Error
+	if errors.As(err, &cliErr) {
+		body = map[string]any{"error": cliErr.Message, "code": cliErr.Code}
+		if cliErr.Details != nil {
+			body["details"] = cliErr.Details
+		}
+	}
+	data, _ := json.Marshal(body) //nolint:errchkjson // maps of plain values
+	return map[string]any{
+		"content": []map[string]any{{"type": "text", "text": string(data)}},
+		"isError": true,
+	}
+}
+
+// decodeArgs decodes tool arguments strictly so misspelled fields surface as
+// INVALID_INPUT rather than being silently ignored.
+func decodeArgs(raw json.RawMessage, dst any) error {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(dst); err != nil {
+		return clierr.Newf(clierr.InvalidInput, "invalid arguments: %v", err)
+	}
+	return nil
+}
+
+func requireID(id int) error {
+	if id < 1 {
+		return task.ValidateTaskID(fmt.Sprint(id))
+	}
+	return nil
+}
+
+func callListTasks(_ *Server, cfg *config.Config, raw json.RawMessage) (toolResult, error) {
+	var a listArgs
+	if err := decodeArgs(raw, &a); err != nil {
+		return toolResult{}, err
+	}
+	filter := board.FilterOptions{
+		Statuses:     a.Status,
+		Priorities:   a.Priority,
+		Assignee:     a.Assignee,
+		Tag:          a.Tag,
+		Search:       a.Search,
+		Blocked:      a.Blocked,
+		ParentID:     a.Parent,
+		Unclaimed:    a.Unclaimed,
+		ClaimedBy:    a.ClaimedBy,
+		ClaimTimeout: cfg.ClaimTimeoutDuration(),
+		Class:        a.Class,
+	}
+	if a.Query != "" {
+		var err error
+		if filter.Query, err = board.ParseQuery(a.Query, cfg); err != nil {
+			return toolResult{}, err
+		}
+	}
+	// Same defaults as `list`: archived tasks are hidden unless asked for.
+	if a.Archived {
+		filter.Statuses = []string{config.ArchivedStatus}
+	} else if len(a.Status) == 0 && (filter.Query == nil || !filter.Query.UsesField("status")) {
+		filter.ExcludeStatuses = []string{config.ArchivedStatus}
+	}
+
+	tasks, _, err := board.List(cfg, board.ListOptions{
+		Filter:    filter,
+		SortBy:    a.Sort,
+		Reverse:   a.Reverse,
+		Limit:     a.Limit,
+		Unblocked: a.Unblocked,
+	})
+	if err != nil {
+		return toolResult{}, err
+	}
+	if tasks == nil {
+		tasks = []*task.Task{}
+	}
+	return toolResult{structured: taskList{Tasks: tasks}}, nil
+}
+
+func callPickTask(s *Server, cfg *config.Config, raw json.RawMessage) (toolResult, error) {
+	var a pickArgs
+	if err := decodeArgs(raw, &a); err != nil {
+		return toolResult{}, err
+	}
+	picked, oldStatus, _, err := board.PickAndClaim(cfg, board.PickAndClaimParams{
+		Claimant:     a.Claim,
+		StatusFilter: a.Status,
+		MoveTarget:   a.Move,
+		Tags:         a.Tags,
+		Query:        a.Query,
+	}, s.now())
+	if err != nil {
+		return toolResult{}, err
+	}
+	return toolResult{structured: pickOutput{Task: picked, OldStatus: oldStatus}}, nil
+}
+
+func callMoveTask(s *Server, cfg *config.Config, raw json.RawMessage) (toolResult, error) {
+	var a moveArgs
+	if err := decodeArgs(raw, &a); err != nil {
+		return toolResult{}, err
+	}
+	if err := requireID(a.ID); err != nil {
+		return toolResult{}, err
+	}
+	if a.Status == "" {
+		return toolResult{}, clierr.New(clierr.InvalidInput, "status is required")
+	}
+	result, err := board.Move(cfg, board.MoveParams{
+		ID:        a.ID,
+		NewStatus: a.Status,
+		Claimant:  a.Claim,
+		SetClaim:  a.Claim != "",
+		IfRev:     a.IfRev,
+	}, s.now())
+	if err != nil {
+		return toolResult{}, err
+	}
+	return toolResult{structured: moveOutput{
+		Task:      result.Task,
+		Changed:   result.OldStatus != "",
+		OldStatus: result.OldStatus,
+		Warnings:  result.Warnings,
+	}}, nil
+}
+
+func callHandoffTask(s *Server, cfg *config.Config, raw json.RawMessage) (toolResult, error) {
+	var a handoffArgs
+	if err := decodeArgs(raw, &a); err != nil {
+		return toolResult{}, err
+	}
+	if err := requireID(a.ID); err != nil {
+		return toolResult{}, err
+	}
+	t, err := board.Handoff(cfg, board.HandoffParams{
+		ID:           a.ID,
+		Claimant:     a.Claim,
+		Release:      a.Release,
+		BlockReason:  a.Block,
+		Note:         a.Note,
+		AddTimestamp: a.Timestamp,
+		IfRev:        a.IfRev,
+	}, s.now())
+	if err != nil {
+		return toolResult{}, err
+	}
+	return toolResult{structured: t}, nil
+}
+
+func callHeartbeat(s *Server, cfg *config.Config, raw json.RawMessage) (toolResult, error) {
+	var a heartbeatArgs
+	if err := decodeArgs(raw, &a); err != nil {
+		return toolResult{}, err
+	}
+	if err := requireID(a.ID); err != nil {
+		return toolResult{}, err
+	}
+	t, err := board.Heartbeat(cfg, a.ID, a.Claim, s.now())
+	if err != nil {
+		return toolResult{}, err
+	}
+	return toolResult{structured: t}, nil
+}
+
+func callAppendNote(s *Server, cfg *config.Config, raw json.RawMessage) (toolResult, error) {
+	var a appendNoteArgs
+	if err := decodeArgs(raw, &a); err != nil {
+		return toolResult{}, err
+	}
+	if err := requireID(a.ID); err != nil {
+		return toolResult{}, err
+	}
+	if a.Text == "" {
+		return toolResult{}, clierr.New(clierr.InvalidInput, "text is required")
+	}
+	now := s.now()
+	result, err := board.Edit(cfg, a.ID, a.Claim, a.IfRev, false, func(t *task.Task) (bool, error) {
+		t.Body = board.AppendBody(t.Body, a.Text, a.Timestamp)
+		if a.Claim != "" {
+			t.ClaimedBy = a.Claim
+			t.ClaimedAt = &now
+		}
+		return true, nil
+	}, now)
+	if err != nil {
+		return toolResult{}, err
+	}
+	return toolResult{structured: result.Task}, nil
+}
+
+func callBoardSummary(s *Server, cfg *config.Config, raw json.RawMessage) (toolResult, error) {
+	if err := decodeArgs(raw, &summaryArgs{}); err != nil {
+		return toolResult{}, err
+	}
+	tasks, err := activeTasks(cfg)
+	if err != nil {
+		return toolResult{}, err
+	}
+	return toolResult{structured: board.Summary(cfg, tasks, s.now())}, nil
+}
+
+func callContext(s *Server, cfg *config.Config, raw json.RawMessage) (toolResult, error) {
+	var a contextArgs
+	if err := decodeArgs(raw, &a); err != nil {
+		return toolResult{}, err
+	}
+	tasks, err := activeTasks(cfg)
+	if err != nil {
+		return toolResult{}, err
+	}
+	data := board.GenerateContext(cfg, tasks, board.ContextOptions{Sections: a.Sections, Days: a.Days}, s.now())
+	return toolResult{structured: data, text: board.RenderContextMarkdown(data)}, nil
+}
+
+// activeTasks reads all tasks, excluding archived ones.
+func activeTasks(cfg *config.Config) ([]*task.Task, error) {
+	all, _, err := task.ReadAllLenient(cfg.TasksPath())
+	if err != nil {
+		return nil, err
+	}
+	tasks := make([]*task.Task, 0, len(all))
+	for _, t := range all {
+		if !cfg.IsArchivedStatus(t.Status) {
+			tasks = append(tasks, t)
+		}
+	}
+	return tasks, nil
+}
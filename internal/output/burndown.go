@@ -0,0 +1,186 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/antopolskiy/kanban-md/internal/board"
+)
+
+// Burndown chart dimensions, in cells.
+const (
+	burndownWidth   = 60
+	burndownHeight  = 12
+	burndownAxisW   = 6
+	burndownMaxCell = 3 // widest column of a day, for short series
+)
+
+// burndownColumn is one column of the burndown chart: the last day of a
+// group of days, and whether the scope grew or shrank during the group.
+type burndownColumn struct {
+	day            board.BurndownDay
+	added, removed bool
+}
+
+// BurndownChart renders a burndown as a burnup chart: one column per day
+// (or group of days), completed work filled and remaining work shaded up to
+// the scope, with markers below the axis on days the scope changed. weighted
+// charts the tasks' estimates instead of task counts.
+func BurndownChart(w io.Writer, b board.Burndown, weighted bool) {
+	fmt.Fprintln(w, lipgloss.NewStyle().Bold(true).Render(
+		fmt.Sprintf("Burndown: %s (%s – %s)", b.Scope, b.Since, b.Until)))
+	last := b.Days[len(b.Days)-1]
+	scope := fmt.Sprintf("%d tasks (%s estimated", last.Scope, formatEstimate(last.ScopeEstimate))
+	if b.Unestimated > 0 {
+		scope += fmt.Sprintf(", %d unestimated", b.Unestimated)
+	}
+	printField(w, "Scope", scope+")")
+	printField(w, "Completed", fmt.Sprintf("%d (%s)", last.Completed, formatEstimate(last.CompletedEstimate)))
+	printField(w, "Remaining", fmt.Sprintf("%d (%s)", last.Remaining, formatEstimate(last.RemainingEstimate)))
+	fmt.Fprintln(w)
+
+	cols := burndownColumns(b.Days)
+	cell := max(1, min(burndownMaxCell, burndownWidth/len(cols)))
+	value := func(d board.BurndownDay) (completed, scope float64) {
+		if weighted {
+			return d.CompletedEstimate, d.ScopeEstimate
+		}
+		return float64(d.Completed), float64(d.Scope)
+	}
+	peak := 0.0
+	for _, c := range cols {
+		_, s := value(c.day)
+		peak = max(peak, s)
+	}
+	top := strconv.Itoa(int(peak))
+	if weighted {
+		top = formatEstimate(peak)
+	}
+	if peak == 0 {
+		peak = 1
+	}
+	height := func(v float64) int { return int(math.Round(v / peak * burndownHeight)) }
+
+	for row := burndownHeight; row >= 1; row-- {
+		var line strings.Builder
+		for _, c := range cols {
+			completed, scope := value(c.day)
+			switch {
+			case row <= height(completed):
+				line.WriteString(strings.Repeat("█", cell))
+			case row <= height(scope):
+				line.WriteString(dimStyle.Render(strings.Repeat("░", cell)))
+			default:
+				line.WriteString(strings.Repeat(" ", cell))
+			}
+		}
+		axis := ""
+		if row == burndownHeight {
+			axis = top
+		}
+		fmt.Fprintln(w, strings.TrimRight(fmt.Sprintf("%*s │%s", burndownAxisW, axis, line.String()), " "))
+	}
+	fmt.Fprintf(w, "%*s └%s\n", burndownAxisW, "0", strings.Repeat("─", cell*len(cols)))
+
+	var markers strings.Builder
+	for _, c := range cols {
+		m := " "
+		switch {
+		case c.added && c.removed:
+			m = "◆"
+		case c.added:
+			m = "▲"
+		case c.removed:
+			m = "▼"
+		}
+		markers.WriteString(m + strings.Repeat(" ", cell-1))
+	}
+	if s := strings.TrimRight(markers.String(), " "); s != "" {
+		fmt.Fprintf(w, "%*s  %s\n", burndownAxisW, "", s)
+	}
+	dates := b.Since.String()
+	if !b.Until.Equal(b.Since.Time) {
+		to := b.Until.String()
+		dates += fmt.Sprintf("%*s", max(cell*len(cols)-len(dates), len(to)+1), to)
+	}
+	fmt.Fprintf(w, "%*s  %s\n", burndownAxisW, "", dates)
+	fmt.Fprintf(w, "%*s  %s\n", burndownAxisW, "", dimStyle.Render("█ completed  ░ remaining  ▲ added  ▼ removed"))
+
+	burndownScopeChanges(w, b)
+}
+
+// burndownColumns groups days so the chart fits burndownWidth columns.
+func burndownColumns(days []board.BurndownDay) []burndownColumn {
+	per := (len(days) + burndownWidth - 1) / burndownWidth
+	var cols []burndownColumn
+	for i := 0; i < len(days); i += per {
+		var c burndownColumn
+		for _, d := range days[i:min(i+per, len(days))] {
+			c.day = d
+			c.added = c.added || len(d.Added) > 0
+			c.removed = c.removed || len(d.Removed) > 0
+		}
+		cols = append(cols, c)
+	}
+	return cols
+}
+
+// burndownScopeChanges lists the days on which tasks entered or left the
+// scope.
+func burndownScopeChanges(w io.Writer, b board.Burndown) {
+	var lines []string
+	for _, d := range b.Days {
+		if ids := scopeChangeIDs(d); ids != "" {
+			lines = append(lines, fmt.Sprintf("  %s  %s", d.Date, ids))
+		}
+	}
+	if len(lines) == 0 {
+		return
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, headerStyle.Render("Scope changes"))
+	for _, l := range lines {
+		fmt.Fprintln(w, l)
+	}
+}
+
+// scopeChangeIDs renders the tasks added to (+#N) and removed from (-#N) the
+// scope on day d.
+func scopeChangeIDs(d board.BurndownDay) string {
+	parts := make([]string, 0, len(d.Added)+len(d.Removed))
+	for _, id := range d.Added {
+		parts = append(parts, "+#"+strconv.Itoa(id))
+	}
+	for _, id := range d.Removed {
+		parts = append(parts, "-#"+strconv.Itoa(id))
+	}
+	return strings.Join(parts, " ")
+}
+
+// formatEstimate renders hours of estimated work, e.g. "12.5h".
+func formatEstimate(h float64) string {
+	const precision = 10 // one decimal
+	return strconv.FormatFloat(math.Round(h*precision)/precision, 'f', -1, 64) + "h"
+}
+
+// BurndownCompact renders one line per day with the scope, completed and
+// remaining tasks, the remaining estimate and the scope changes.
+func BurndownCompact(w io.Writer, b board.Burndown) {
+	for _, d := range b.Days {
+		line := fmt.Sprintf("%s scope:%d done:%d left:%d est-left:%s/%s",
+			d.Date, d.Scope, d.Completed, d.Remaining,
+			formatEstimate(d.RemainingEstimate), formatEstimate(d.ScopeEstimate))
+		if ids := scopeChangeIDs(d); ids != "" {
+			line += " " + ids
+		}
+		fmt.Fprintln(w, line)
+	}
+	if b.Unestimated > 0 {
+		fmt.Fprintf(w, "unestimated:%d\n", b.Unestimated)
+	}
+}
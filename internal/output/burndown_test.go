@@ -0,0 +1,73 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/antopolskiy/kanban-md/internal/board"
+	"github.com/antopolskiy/kanban-md/internal/date"
+)
+
+func sampleBurndown() board.Burndown {
+	return board.Burndown{
+		Scope:       "tag v2",
+		Since:       date.New(2026, 5, 1),
+		Until:       date.New(2026, 5, 3),
+		Unestimated: 1,
+		Days: []board.BurndownDay{
+			{Date: date.New(2026, 5, 1), Scope: 2, Remaining: 2, ScopeEstimate: 8, RemainingEstimate: 8},
+			{Date: date.New(2026, 5, 2), Scope: 4, Completed: 1, Remaining: 3,
+				ScopeEstimate: 12, CompletedEstimate: 4, RemainingEstimate: 8, Added: []int{5, 6}},
+			{Date: date.New(2026, 5, 3), Scope: 3, Completed: 2, Remaining: 1,
+				ScopeEstimate: 10.5, CompletedEstimate: 8, RemainingEstimate: 2.5, Removed: []int{6}},
+		},
+	}
+}
+
+func TestBurndownChart(t *testing.T) {
+	disableColorForTest(t)
+	var buf bytes.Buffer
+	BurndownChart(&buf, sampleBurndown(), false)
+	got := buf.String()
+	for _, want := range []string{
+		"Burndown: tag v2 (2026-05-01 – 2026-05-03)",
+		"Scope:       3 tasks (10.5h estimated, 1 unestimated)",
+		"Completed:   2 (8h)",
+		"Remaining:   1 (2.5h)",
+		"     4 │   ░░░\n",    // scope peaks at 4 on day 2
+		"       │░░░░░░███\n", // 2 done on day 3: half the height
+		"       │░░░██████\n", // 1 done on day 2: a quarter
+		"     0 └─────────\n",
+		"          ▲  ▼\n",
+		"  2026-05-02  +#5 +#6\n",
+		"  2026-05-03  -#6\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestBurndownChart_Weighted(t *testing.T) {
+	disableColorForTest(t)
+	var buf bytes.Buffer
+	BurndownChart(&buf, sampleBurndown(), true)
+	if !strings.Contains(buf.String(), "   12h │   ░░░\n") {
+		t.Errorf("weighted chart should peak at the 12h scope estimate:\n%s", buf.String())
+	}
+}
+
+func TestBurndownCompact(t *testing.T) {
+	var buf bytes.Buffer
+	BurndownCompact(&buf, sampleBurndown())
+	for _, want := range []string{
+		"2026-05-02 scope:4 done:1 left:3 est-left:8h/12h +#5 +#6\n",
+		"2026-05-03 scope:3 done:2 left:1 est-left:2.5h/10.5h -#6\n",
+		"unestimated:1\n",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, buf.String())
+		}
+	}
+}
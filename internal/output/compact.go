@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/antopolskiy/kanban-md/internal/board"
+	"github.com/antopolskiy/kanban-md/internal/config"
 	"github.com/antopolskiy/kanban-md/internal/task"
 )
 
@@ -24,12 +25,29 @@ func TaskCompact(w io.Writer, tasks []*task.Task) {
 	}
 }
 
+// TaskTreeCompact renders a task tree in compact format, one line per task,
+// with subtasks indented under their parent.
+func TaskTreeCompact(w io.Writer, roots []*board.TaskNode) {
+	tasks, depths := flattenTree(roots)
+	if len(tasks) == 0 {
+		fmt.Fprintln(os.Stderr, "No tasks found.")
+		return
+	}
+
+	for i, t := range tasks {
+		fmt.Fprintln(w, strings.Repeat("  ", depths[i])+formatTaskLine(t))
+	}
+}
+
 // TaskDetailCompact renders a single task with detail in compact format.
 func TaskDetailCompact(w io.Writer, t *task.Task) {
 	line := formatTaskLine(t)
 	if t.Estimate != "" {
 		line += " est:" + t.Estimate
 	}
+	for _, name := range t.CustomKeys() {
+		line += " " + name + ":" + t.FormatCustom(name)
+	}
 	fmt.Fprintln(w, line)
 
 	// Timestamps line.
@@ -50,6 +68,25 @@ func TaskDetailCompact(w io.Writer, t *task.Task) {
 	}
 }
 
+// TimelineCompact renders the statuses a task has been in and how long it
+// stayed in each, on one line. It follows TaskDetailCompact in `show`.
+func TimelineCompact(w io.Writer, periods []board.StatusPeriod) {
+	parts := make([]string, len(periods))
+	for i, p := range periods {
+		parts[i] = p.Status + " " + formatHours(p.Hours)
+	}
+	fmt.Fprintln(w, "  timeline: "+strings.Join(parts, " > "))
+}
+
+// SubtasksCompact renders a parent task's subtasks and the share done in
+// compact format. It follows TaskDetailCompact in `show`.
+func SubtasksCompact(w io.Writer, r *board.Rollup) {
+	fmt.Fprintf(w, "  subtasks: %d/%d done (%d%%)\n", r.Done, r.Total, r.Percent)
+	for _, c := range r.Children {
+		fmt.Fprintf(w, "    #%d [%s] %s\n", c.ID, c.Status, c.Title)
+	}
+}
+
 // OverviewCompact renders a board summary in compact format.
 func OverviewCompact(w io.Writer, s board.Overview) {
 	fmt.Fprintf(w, "%s (%d tasks)\n", s.BoardName, s.TotalTasks)
@@ -90,6 +127,26 @@ func MetricsCompact(w io.Writer, m board.Metrics) {
 		"Efficiency: " + formatOptionalPercent(m.FlowEfficiency),
 	}
 	fmt.Fprintln(w, strings.Join(parts, " | "))
+	if m.LeadTimeP50Hours != nil {
+		fmt.Fprintf(w, "Lead p50/p85/p95: %s | Cycle p50/p85/p95: %s\n",
+			compactPercentiles(m.LeadTimeP50Hours, m.LeadTimeP85Hours, m.LeadTimeP95Hours),
+			compactPercentiles(m.CycleTimeP50Hours, m.CycleTimeP85Hours, m.CycleTimeP95Hours))
+	}
+	for _, g := range m.ByClass {
+		fmt.Fprintf(w, "Class %s: %d done lead %s cycle %s\n", g.Name, g.Completed,
+			compactPercentiles(g.LeadTimeP50Hours, g.LeadTimeP85Hours, g.LeadTimeP95Hours),
+			compactPercentiles(g.CycleTimeP50Hours, g.CycleTimeP85Hours, g.CycleTimeP95Hours))
+	}
+	for _, g := range m.ByTag {
+		fmt.Fprintf(w, "Tag %s: %d done lead %s cycle %s\n", g.Name, g.Completed,
+			compactPercentiles(g.LeadTimeP50Hours, g.LeadTimeP85Hours, g.LeadTimeP95Hours),
+			compactPercentiles(g.CycleTimeP50Hours, g.CycleTimeP85Hours, g.CycleTimeP95Hours))
+	}
+
+	for _, st := range m.StatusTimes {
+		fmt.Fprintf(w, "In %s: avg %s p50 %s p85 %s p95 %s (%d)\n", st.Status,
+			formatHours(st.AvgHours), formatHours(st.P50Hours), formatHours(st.P85Hours), formatHours(st.P95Hours), st.Count)
+	}
 
 	for _, a := range m.AgingItems {
 		title := a.Title
@@ -116,6 +173,37 @@ func ActivityLogCompact(w io.Writer, entries []board.LogEntry) {
 	}
 }
 
+// HistoryCompact renders a task's change history, one line per log entry.
+func HistoryCompact(w io.Writer, id int, entries []board.LogEntry) {
+	if len(entries) == 0 {
+		fmt.Fprintf(os.Stderr, "No history found for task #%d.\n", id)
+		return
+	}
+
+	for _, e := range entries {
+		line := e.Timestamp.Format("2006-01-02 15:04:05") + " " + e.Action
+		if e.ID != "" {
+			line += " [" + e.ID + "]"
+		}
+		if e.Actor != "" {
+			line += " @" + e.Actor
+		}
+		if len(e.Changes) == 0 {
+			if e.Detail != "" {
+				line += " " + e.Detail
+			}
+			fmt.Fprintln(w, line)
+			continue
+		}
+		parts := make([]string, len(e.Changes))
+		for i, c := range e.Changes {
+			before, after := FormatChange(c)
+			parts[i] = c.Field + ": " + before + " -> " + after
+		}
+		fmt.Fprintln(w, line+" "+strings.Join(parts, "; "))
+	}
+}
+
 // formatTaskLine builds the one-line representation of a task.
 func formatTaskLine(t *task.Task) string {
 	line := "#" + strconv.Itoa(t.ID) + " [" + t.Status + "/" + t.Priority + "] " + t.Title
@@ -140,3 +228,82 @@ func compactDuration(h *float64) string {
 	}
 	return FormatDuration(time.Duration(*h * float64(time.Hour)))
 }
+
+// ViewCompact renders saved views, one line per view.
+func ViewCompact(w io.Writer, views []config.ViewConfig) {
+	if len(views) == 0 {
+		fmt.Fprintln(os.Stderr, "No views defined.")
+		return
+	}
+
+	for _, v := range views {
+		if v.Description == "" {
+			fmt.Fprintln(w, v.Name)
+			continue
+		}
+		fmt.Fprintf(w, "%s: %s\n", v.Name, v.Description)
+	}
+}
+
+// TemplateCompact renders one line per task template: its name and
+// description.
+func TemplateCompact(w io.Writer, templates []*task.Template) {
+	if len(templates) == 0 {
+		fmt.Fprintln(os.Stderr, "No templates found.")
+		return
+	}
+
+	for _, t := range templates {
+		if t.Description == "" {
+			fmt.Fprintln(w, t.Name)
+			continue
+		}
+		fmt.Fprintf(w, "%s: %s\n", t.Name, t.Description)
+	}
+}
+
+// AgentsCompact renders one line per agent: name, state, last seen, last
+// action and claimed tasks.
+func AgentsCompact(w io.Writer, agents []board.Agent, now time.Time) {
+	if len(agents) == 0 {
+		fmt.Fprintln(os.Stderr, "No agents found.")
+		return
+	}
+
+	for _, a := range agents {
+		state := "stale"
+		if a.Live {
+			state = "live"
+		}
+		line := a.Name + " " + state
+		if !a.LastSeen.IsZero() {
+			line += " seen " + FormatDuration(now.Sub(a.LastSeen)) + " ago"
+		}
+		if action := agentLastAction(a); action != "" {
+			line += " (" + action + ")"
+		}
+		if len(a.Claims) > 0 {
+			line += " claims " + formatIDs(a.Claims)
+		}
+		fmt.Fprintln(w, line)
+	}
+}
+
+// ClaimsCompact renders one line per claim: task, claimant and expiry.
+func ClaimsCompact(w io.Writer, claims []board.Claim, now time.Time) {
+	if len(claims) == 0 {
+		fmt.Fprintln(os.Stderr, "No claims found.")
+		return
+	}
+
+	for _, c := range claims {
+		expiry := "never expires"
+		switch {
+		case c.Expired:
+			expiry = "expired"
+		case c.ExpiresAt != nil:
+			expiry = "expires in " + FormatDuration(c.ExpiresAt.Sub(now))
+		}
+		fmt.Fprintf(w, "#%d [%s] @%s %s: %s\n", c.ID, c.Status, c.ClaimedBy, expiry, c.Title)
+	}
+}
@@ -341,6 +341,21 @@ func TestActivityLogCompact(t *testing.T) {
 	}
 }
 
+func TestHistoryCompact(t *testing.T) {
+	var buf strings.Builder
+	HistoryCompact(&buf, 1, historyEntries())
+	out := buf.String()
+
+	for _, want := range []string{
+		"2026-02-08 12:00:00 edit [a1b2c3d4e5f6] @bot priority: low -> high; tags: -- -> a, b; body: (1 line) -> (3 lines)",
+		"2026-02-08 13:00:00 release bot",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("HistoryCompact missing %q in:\n%s", want, out)
+		}
+	}
+}
+
 func TestActivityLogCompactEmpty(t *testing.T) {
 	var buf strings.Builder
 	ActivityLogCompact(&buf, nil)
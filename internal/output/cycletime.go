@@ -0,0 +1,143 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/antopolskiy/kanban-md/internal/board"
+)
+
+// Cycle time chart dimensions, in cells.
+const (
+	histogramWidth = 40
+	scatterWidth   = 60
+	scatterHeight  = 12
+	scatterAxisW   = 8
+)
+
+// CycleTimeChart renders the cycle times of completed tasks as a histogram
+// (tasks per day of cycle time) and a scatter plot of cycle time against
+// completion date, with the p50, p85 and p95 lines.
+func CycleTimeChart(w io.Writer, ct board.CycleTimes) {
+	fmt.Fprintln(w, lipgloss.NewStyle().Bold(true).Render(
+		fmt.Sprintf("Cycle time (%d completed tasks)", len(ct.Points))))
+	if len(ct.Points) == 0 {
+		fmt.Fprintln(w, dimStyle.Render("No completed tasks."))
+		return
+	}
+	printField(w, "Cycle time", formatPercentiles(ct.P50Hours, ct.P85Hours, ct.P95Hours))
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, headerStyle.Render("Histogram"))
+	peak := 1
+	for _, b := range ct.Histogram {
+		peak = max(peak, b.Count)
+	}
+	for _, b := range ct.Histogram {
+		label := strconv.Itoa(b.FromDays) + "d"
+		if b.ToDays-b.FromDays > 1 {
+			label += "-" + strconv.Itoa(b.ToDays-1) + "d"
+		}
+		bar := strings.Repeat("█", int(math.Round(float64(b.Count)*histogramWidth/float64(peak))))
+		fmt.Fprintf(w, "  %7s %s %d\n", label, bar, b.Count)
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, headerStyle.Render("Scatter"))
+	cycleScatter(w, ct)
+}
+
+// cycleScatter plots cycle time (up) against completion date (right).
+func cycleScatter(w io.Writer, ct board.CycleTimes) {
+	first, last := ct.Points[0].Completed, ct.Points[len(ct.Points)-1].Completed
+	top := 0.0
+	for _, p := range ct.Points {
+		top = max(top, p.CycleTimeHours)
+	}
+	if top <= 0 {
+		top = 1
+	}
+	row := func(h float64) int {
+		return scatterHeight - 1 - int(math.Round(max(h, 0)/top*(scatterHeight-1)))
+	}
+
+	grid := make([][]rune, scatterHeight)
+	for i := range grid {
+		grid[i] = []rune(strings.Repeat(" ", scatterWidth))
+	}
+	labels := make([]string, scatterHeight)
+	for _, pl := range []struct {
+		name string
+		h    *float64
+	}{{"p50", ct.P50Hours}, {"p85", ct.P85Hours}, {"p95", ct.P95Hours}} {
+		r := row(*pl.h)
+		for c := range grid[r] {
+			grid[r][c] = '·'
+		}
+		labels[r] = strings.TrimSpace(labels[r] + " " + pl.name + " " + formatHours(*pl.h))
+	}
+	span := last.Sub(first)
+	for _, p := range ct.Points {
+		c := 0
+		if span > 0 {
+			c = int(math.Round(float64(p.Completed.Sub(first)) / float64(span) * (scatterWidth - 1)))
+		}
+		grid[row(p.CycleTimeHours)][c] = '●'
+	}
+
+	for i, cells := range grid {
+		axis := ""
+		switch i {
+		case 0:
+			axis = formatHours(top)
+		case scatterHeight - 1:
+			axis = "0h"
+		}
+		line := fmt.Sprintf("%*s │%s", scatterAxisW, axis, dimRuns(cells, '·'))
+		if labels[i] != "" {
+			line += " " + dimStyle.Render(labels[i])
+		}
+		fmt.Fprintln(w, strings.TrimRight(line, " "))
+	}
+	fmt.Fprintf(w, "%*s └%s\n", scatterAxisW, "", strings.Repeat("─", scatterWidth))
+	from, to := first.Local().Format(time.DateOnly), last.Local().Format(time.DateOnly)
+	if from == to {
+		to = ""
+	}
+	fmt.Fprintf(w, "%*s  %s%*s\n", scatterAxisW, "", from, scatterWidth-len(from), to)
+}
+
+// dimRuns renders cells with each run of r dimmed.
+func dimRuns(cells []rune, r rune) string {
+	var b strings.Builder
+	for i := 0; i < len(cells); {
+		j := i + 1
+		for j < len(cells) && (cells[j] == r) == (cells[i] == r) {
+			j++
+		}
+		run := string(cells[i:j])
+		if cells[i] == r {
+			run = dimStyle.Render(run)
+		}
+		b.WriteString(run)
+		i = j
+	}
+	return b.String()
+}
+
+// CycleTimeCompact renders one line per completed task with its cycle and
+// lead time, followed by the percentiles.
+func CycleTimeCompact(w io.Writer, ct board.CycleTimes) {
+	for _, p := range ct.Points {
+		fmt.Fprintf(w, "#%d %s cycle:%s lead:%s %s\n", p.ID, p.Completed.Local().Format(time.DateOnly),
+			formatHours(p.CycleTimeHours), formatHours(p.LeadTimeHours), p.Title)
+	}
+	fmt.Fprintf(w, "cycle p50/p85/p95: %s (%d tasks)\n",
+		compactPercentiles(ct.P50Hours, ct.P85Hours, ct.P95Hours), len(ct.Points))
+}
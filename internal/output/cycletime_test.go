@@ -0,0 +1,51 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/antopolskiy/kanban-md/internal/board"
+)
+
+func TestCycleTimeChart(t *testing.T) {
+	disableColorForTest(t)
+
+	t0 := time.Date(2026, 5, 1, 12, 0, 0, 0, time.Local)
+	p50, p85, p95 := 10.0, 40.0, 48.0
+	ct := board.CycleTimes{
+		Points: []board.CycleTimePoint{
+			{ID: 1, Title: "Fast", Completed: t0, CycleTimeHours: 10, LeadTimeHours: 12},
+			{ID: 2, Title: "Slow", Completed: t0.AddDate(0, 0, 5), CycleTimeHours: 48, LeadTimeHours: 50},
+		},
+		Histogram: []board.HistogramBin{{FromDays: 0, ToDays: 1, Count: 1}, {FromDays: 1, ToDays: 2}, {FromDays: 2, ToDays: 3, Count: 1}},
+		P50Hours:  &p50, P85Hours: &p85, P95Hours: &p95,
+	}
+
+	var buf bytes.Buffer
+	CycleTimeChart(&buf, ct)
+	out := buf.String()
+	for _, want := range []string{
+		"Cycle time (2 completed tasks)",
+		"p50 10h 0m · p85 1d 16h · p95 2d 0h",
+		"     0d " + strings.Repeat("█", 40) + " 1",
+		"     1d  0",
+		"2d 0h │····",    // the p95 line at the top...
+		"···● p95 2d 0h", // ...ends with the slow task, completed last
+		"│●····",         // the fast task on the p50 line, completed first
+		"  0h │\n",
+		"2026-05-01",
+		"2026-05-06",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	buf.Reset()
+	CycleTimeCompact(&buf, ct)
+	if !strings.Contains(buf.String(), "#2 2026-05-06 cycle:2d 0h lead:2d 2h Slow") {
+		t.Errorf("compact output:\n%s", buf.String())
+	}
+}
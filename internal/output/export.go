@@ -0,0 +1,234 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+	"html/template"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/antopolskiy/kanban-md/internal/board"
+	"github.com/antopolskiy/kanban-md/internal/task"
+)
+
+// exportTimeFormat is how timestamps appear in exported reports.
+const exportTimeFormat = "2006-01-02 15:04"
+
+// ExportMarkdown renders e as a single Markdown report: the WIP summary, then
+// one section per column listing its cards.
+func ExportMarkdown(w io.Writer, e *board.Export) {
+	fmt.Fprintf(w, "# %s\n\n", e.BoardName)
+	fmt.Fprintf(w, "Generated %s · %d tasks\n\n", e.Generated.Format(exportTimeFormat), e.Summary.TotalTasks)
+
+	fmt.Fprintln(w, "| Status | Tasks | WIP limit | Blocked | Overdue |")
+	fmt.Fprintln(w, "|--------|------:|----------:|--------:|--------:|")
+	for _, c := range e.Columns {
+		limit := "–"
+		if c.WIPLimit > 0 {
+			limit = strconv.Itoa(c.WIPLimit)
+			if c.OverWIP() {
+				limit += " ⚠"
+			}
+		}
+		fmt.Fprintf(w, "| %s | %d | %s | %d | %d |\n", markdownEscape(c.Status), c.Count, limit, c.Blocked, c.Overdue)
+	}
+
+	for _, c := range e.Columns {
+		fmt.Fprintf(w, "\n## %s (%s)\n\n", markdownEscape(c.Status), wipUsage(c))
+		if len(c.Tasks) == 0 {
+			fmt.Fprintln(w, "_No tasks._")
+			continue
+		}
+		for _, t := range c.Tasks {
+			fmt.Fprintf(w, "- **#%d** %s", t.ID, markdownEscape(t.Title))
+			if meta := exportCardMeta(t, e.Generated); len(meta) > 0 {
+				fmt.Fprint(w, " — "+strings.Join(meta, " · "))
+			}
+			fmt.Fprintln(w)
+		}
+	}
+}
+
+// exportCardMeta lists the details shown on an exported card.
+func exportCardMeta(t *task.Task, now time.Time) []string {
+	meta := []string{markdownEscape(t.Priority)}
+	if t.Class != "" {
+		meta = append(meta, markdownEscape(t.Class))
+	}
+	for _, tag := range t.Tags {
+		meta = append(meta, markdownCode(tag))
+	}
+	if t.Assignee != "" {
+		meta = append(meta, "@"+markdownEscape(t.Assignee))
+	}
+	if t.Due != nil {
+		due := "due " + t.Due.String()
+		if t.Completed == nil && t.Due.Before(now) {
+			due += " (overdue)"
+		}
+		meta = append(meta, due)
+	}
+	if t.Blocked {
+		blocked := "blocked"
+		if t.BlockReason != "" {
+			blocked += ": " + markdownEscape(t.BlockReason)
+		}
+		meta = append(meta, blocked)
+	}
+	if t.ClaimedBy != "" {
+		meta = append(meta, "claimed by "+markdownEscape(t.ClaimedBy))
+	}
+	return meta
+}
+
+// markdownEscape escapes s for inline Markdown text, including table cells
+// ("|" would otherwise end the cell).
+func markdownEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`", "[", `\[`, "<", "&lt;", "|", `\|`).Replace(s)
+}
+
+// markdownCode renders s as a code span. Backslashes do not escape inside
+// one, so the fence is made longer than any run of backticks in s.
+func markdownCode(s string) string {
+	longest, run := 0, 0
+	for _, r := range s {
+		if r == '`' {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	fence := strings.Repeat("`", longest+1)
+	if strings.HasPrefix(s, "`") || strings.HasSuffix(s, "`") {
+		s = " " + s + " "
+	}
+	return fence + s + fence
+}
+
+// wipUsage renders a column's task count against its WIP limit, e.g. "3/5".
+func wipUsage(c board.ExportColumn) string {
+	if c.WIPLimit > 0 {
+		return fmt.Sprintf("%d/%d", c.Count, c.WIPLimit)
+	}
+	return strconv.Itoa(c.Count)
+}
+
+// exportCSVHeader is the header row of ExportCSV. Its columns use the task
+// field names, so the file can be imported again with `import --from csv`.
+var exportCSVHeader = []string{
+	"id", "title", "status", "priority", "class", "assignee", "tags", "due", "estimate",
+	"blocked", "block_reason", "claimed_by", "parent", "created", "updated", "completed",
+}
+
+// ExportCSV renders the tasks of e as CSV, one row per task.
+func ExportCSV(w io.Writer, e *board.Export) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(exportCSVHeader); err != nil {
+		return err
+	}
+	for _, t := range e.Tasks() {
+		row := []string{
+			strconv.Itoa(t.ID), t.Title, t.Status, t.Priority, t.Class, t.Assignee,
+			strings.Join(t.Tags, ","), optionalDate(t), t.Estimate,
+			strconv.FormatBool(t.Blocked), t.BlockReason, t.ClaimedBy, optionalID(t.Parent),
+			t.Created.Format(time.RFC3339), t.Updated.Format(time.RFC3339), optionalTime(t.Completed),
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func optionalDate(t *task.Task) string {
+	if t.Due == nil {
+		return ""
+	}
+	return t.Due.String()
+}
+
+func optionalID(id *int) string {
+	if id == nil {
+		return ""
+	}
+	return strconv.Itoa(*id)
+}
+
+func optionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// ExportHTML renders e as a self-contained static HTML page (inline styles,
+// no scripts or external assets) showing the board columns and their cards.
+func ExportHTML(w io.Writer, e *board.Export) error {
+	return exportHTMLTemplate.Execute(w, e)
+}
+
+var exportHTMLTemplate = template.Must(template.New("board").Funcs(template.FuncMap{
+	"wip": wipUsage,
+	"overdue": func(t *task.Task, now time.Time) bool {
+		return t.Due != nil && t.Completed == nil && t.Due.Before(now)
+	},
+	"ts": func(t time.Time) string { return t.Format(exportTimeFormat) },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.BoardName}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 1.5rem; color: #1f2328; background: #f6f8fa; }
+h1 { margin: 0 0 .25rem; font-size: 1.5rem; }
+.meta { color: #656d76; font-size: .85rem; margin-bottom: 1rem; }
+.board { display: flex; gap: .75rem; align-items: flex-start; overflow-x: auto; }
+.column { flex: 0 0 17rem; background: #eaeef2; border-radius: 6px; padding: .5rem; }
+.column h2 { font-size: .95rem; margin: .25rem .25rem .5rem; display: flex; justify-content: space-between; }
+.column.over h2 .count { color: #cf222e; }
+.count { color: #656d76; font-weight: normal; }
+.card { background: #fff; border: 1px solid #d0d7de; border-radius: 6px; padding: .5rem; margin-bottom: .5rem; font-size: .85rem; }
+.card.blocked { border-left: 4px solid #cf222e; }
+.card .title { font-weight: 600; margin-bottom: .25rem; }
+.card .id { color: #656d76; font-weight: normal; }
+.badge { display: inline-block; border-radius: 10px; padding: 0 .45rem; margin: .1rem .15rem 0 0; font-size: .75rem; background: #eaeef2; }
+.p-critical { background: #ffebe9; color: #cf222e; }
+.p-high { background: #fff1e5; color: #bc4c00; }
+.tag { background: #ddf4ff; color: #0969da; }
+.overdue { color: #cf222e; font-weight: 600; }
+.note { color: #656d76; margin-top: .25rem; }
+.empty { color: #8c959f; font-style: italic; margin: .25rem; font-size: .85rem; }
+</style>
+</head>
+<body>
+<h1>{{.BoardName}}</h1>
+<div class="meta">{{.Summary.TotalTasks}} tasks · generated {{ts .Generated}}</div>
+<div class="board">
+{{- range .Columns}}
+<section class="column{{if .OverWIP}} over{{end}}">
+<h2><span>{{.Status}}</span><span class="count">{{wip .}}{{if .Blocked}} · {{.Blocked}} blocked{{end}}</span></h2>
+{{- range .Tasks}}
+<div class="card{{if .Blocked}} blocked{{end}}">
+<div class="title"><span class="id">#{{.ID}}</span> {{.Title}}</div>
+<span class="badge p-{{.Priority}}">{{.Priority}}</span>
+{{- if .Class}}<span class="badge">{{.Class}}</span>{{end}}
+{{- range .Tags}}<span class="badge tag">{{.}}</span>{{end}}
+{{- if .Due}}<span class="badge{{if overdue . $.Generated}} overdue{{end}}">due {{.Due}}</span>{{end}}
+{{- if .Assignee}}<div class="note">@{{.Assignee}}</div>{{end}}
+{{- if .ClaimedBy}}<div class="note">claimed by {{.ClaimedBy}}{{if .ClaimedAt}} since {{ts .ClaimedAt.Local}}{{end}}</div>{{end}}
+{{- if .Blocked}}<div class="note">blocked{{if .BlockReason}}: {{.BlockReason}}{{end}}</div>{{end}}
+</div>
+{{- else}}
+<div class="empty">No tasks</div>
+{{- end}}
+</section>
+{{- end}}
+</div>
+</body>
+</html>
+`))
@@ -0,0 +1,111 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/antopolskiy/kanban-md/internal/board"
+	"github.com/antopolskiy/kanban-md/internal/date"
+	"github.com/antopolskiy/kanban-md/internal/task"
+)
+
+func sampleExport() *board.Export {
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	due := date.New(2026, 3, 1)
+	blocked := &task.Task{
+		ID: 1, Title: "<Fix> login", Status: "in-progress", Priority: "high", Class: "expedite",
+		Tags: []string{"bug", "auth"}, Due: &due, Blocked: true, BlockReason: "needs keys", ClaimedBy: "agent-1",
+		Created: now, Updated: now,
+	}
+	return &board.Export{
+		BoardName: "Demo",
+		Generated: now,
+		Summary:   board.Overview{BoardName: "Demo", TotalTasks: 1},
+		Columns: []board.ExportColumn{
+			{StatusSummary: board.StatusSummary{Status: "todo"}, Tasks: []*task.Task{}},
+			{
+				StatusSummary: board.StatusSummary{Status: "in-progress", Count: 1, WIPLimit: 3, Blocked: 1, Overdue: 1},
+				Tasks:         []*task.Task{blocked},
+			},
+		},
+	}
+}
+
+func TestExportHTML(t *testing.T) {
+	var buf bytes.Buffer
+	if err := ExportHTML(&buf, sampleExport()); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"<title>Demo</title>", "&lt;Fix&gt; login", "1/3", "p-high", "expedite", `class="badge tag">auth`,
+		`class="badge overdue">due 2026-03-01`, "claimed by agent-1", "blocked: needs keys", "No tasks",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("HTML missing %q", want)
+		}
+	}
+	if strings.Contains(out, "<script") || strings.Contains(out, "http") {
+		t.Error("HTML should be self-contained, without scripts or external links")
+	}
+}
+
+func TestExportMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	ExportMarkdown(&buf, sampleExport())
+	out := buf.String()
+	for _, want := range []string{
+		"# Demo", "| in-progress | 1 | 3 | 1 | 1 |", "## in-progress (1/3)", "_No tasks._",
+		"- **#1** &lt;Fix> login — high · expedite · `bug` · `auth` · due 2026-03-01 (overdue) · blocked: needs keys",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Markdown missing %q in:\n%s", want, out)
+		}
+	}
+}
+
+func TestExportMarkdownEscapesCardMeta(t *testing.T) {
+	e := sampleExport()
+	tk := e.Columns[1].Tasks[0]
+	tk.Tags = []string{"a`b", "`x"}
+	tk.Assignee, tk.ClaimedBy, tk.BlockReason = "*bob*", "agent_1", ""
+	var buf bytes.Buffer
+	ExportMarkdown(&buf, e)
+	want := "``a`b`` · `` `x `` · @\\*bob\\* · due 2026-03-01 (overdue) · blocked · claimed by agent\\_1"
+	if out := buf.String(); !strings.Contains(out, want) {
+		t.Errorf("Markdown missing %q in:\n%s", want, out)
+	}
+}
+
+func TestExportMarkdownEscapesStatusAndClass(t *testing.T) {
+	e := sampleExport()
+	e.Columns[1].Status = "in|progress"
+	tk := e.Columns[1].Tasks[0]
+	tk.Priority, tk.Class = "p_1", "fast|lane"
+	var buf bytes.Buffer
+	ExportMarkdown(&buf, e)
+	out := buf.String()
+	for _, want := range []string{
+		"| in\\|progress | 1 | 3 | 1 | 1 |", "## in\\|progress (1/3)", "— p\\_1 · fast\\|lane ·",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Markdown missing %q in:\n%s", want, out)
+		}
+	}
+}
+
+func TestExportCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := ExportCSV(&buf, sampleExport()); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "id,title,status,priority") {
+		t.Fatalf("CSV = %q, want a header and one row", buf.String())
+	}
+	if !strings.HasPrefix(lines[1], `1,<Fix> login,in-progress,high,expedite,,"bug,auth",2026-03-01,`) {
+		t.Errorf("row = %q", lines[1])
+	}
+}
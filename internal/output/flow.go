@@ -0,0 +1,92 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/antopolskiy/kanban-md/internal/board"
+)
+
+// flowChartWidth is the width of the tallest bar in FlowTable.
+const flowChartWidth = 50
+
+// flowGlyphs fill the bands of the flow chart, from the last status (done)
+// to the first.
+var flowGlyphs = []rune{'█', '▓', '▒', '░', '#', '=', '+', ':', '.'}
+
+// FlowTable renders a cumulative flow diagram as one stacked bar per day,
+// finished work on the left, followed by the day's WIP and arrivals and
+// departures, and the average rates.
+func FlowTable(w io.Writer, f board.Flow) {
+	fmt.Fprintln(w, lipgloss.NewStyle().Bold(true).Render(
+		fmt.Sprintf("Cumulative flow %s – %s", f.Since, f.Until)))
+
+	bands := slices.Clone(f.Statuses)
+	slices.Reverse(bands)
+	legend := make([]string, len(bands))
+	for i, s := range bands {
+		legend[i] = flowBand(s, i, 1) + " " + s
+	}
+	fmt.Fprintln(w, strings.Join(legend, "  "))
+	fmt.Fprintln(w)
+
+	peak := 1
+	for _, d := range f.Days {
+		peak = max(peak, d.Total)
+	}
+	for _, d := range f.Days {
+		var bar strings.Builder
+		cum, pos := 0, 0
+		for i, s := range bands {
+			cum += d.Counts[s]
+			end := int(math.Round(float64(cum) * flowChartWidth / float64(peak)))
+			bar.WriteString(flowBand(s, i, end-pos))
+			pos = end
+		}
+		bar.WriteString(strings.Repeat(" ", flowChartWidth-pos))
+		fmt.Fprintf(w, "%s %s %4d  wip %-3d +%d -%d\n",
+			d.Date.Format("01-02"), bar.String(), d.Total, d.WIP, d.Started, d.Completed)
+	}
+
+	fmt.Fprintln(w)
+	printField(w, "Arrivals", formatRate(f.ArrivalRate)+" (tasks started)")
+	printField(w, "Departures", formatRate(f.DepartureRate)+" (tasks finished)")
+	printField(w, "Avg WIP", strconv.FormatFloat(f.AvgWIP, 'f', 1, 64))
+}
+
+// flowBand renders n cells of the band of status s, the i-th band from the
+// left.
+func flowBand(s string, i, n int) string {
+	if n <= 0 {
+		return ""
+	}
+	band := strings.Repeat(string(flowGlyphs[i%len(flowGlyphs)]), n)
+	if st, ok := statusStyles[s]; ok {
+		return st.Render(band)
+	}
+	return band
+}
+
+func formatRate(perDay float64) string {
+	return strconv.FormatFloat(perDay, 'f', 1, 64) + "/day"
+}
+
+// FlowCompact renders one line per day with the count of every status.
+func FlowCompact(w io.Writer, f board.Flow) {
+	for _, d := range f.Days {
+		parts := make([]string, 0, len(f.Statuses))
+		for _, s := range f.Statuses {
+			parts = append(parts, s+":"+strconv.Itoa(d.Counts[s]))
+		}
+		fmt.Fprintf(w, "%s %s wip:%d started:%d finished:%d\n",
+			d.Date, strings.Join(parts, " "), d.WIP, d.Started, d.Completed)
+	}
+	fmt.Fprintf(w, "arrivals:%s departures:%s avg-wip:%.1f\n",
+		formatRate(f.ArrivalRate), formatRate(f.DepartureRate), f.AvgWIP)
+}
@@ -0,0 +1,51 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/antopolskiy/kanban-md/internal/board"
+	"github.com/antopolskiy/kanban-md/internal/date"
+)
+
+func sampleFlow() board.Flow {
+	return board.Flow{
+		Since:    date.New(2026, 5, 1),
+		Until:    date.New(2026, 5, 2),
+		Statuses: []string{"todo", "done"},
+		Days: []board.FlowDay{
+			{Date: date.New(2026, 5, 1), Counts: map[string]int{"todo": 2, "done": 0}, Total: 2, WIP: 2, Started: 2},
+			{Date: date.New(2026, 5, 2), Counts: map[string]int{"todo": 1, "done": 3}, Total: 4, WIP: 1, Started: 2, Completed: 3},
+		},
+		ArrivalRate:   2,
+		DepartureRate: 1.5,
+		AvgWIP:        1.5,
+	}
+}
+
+func TestFlowTable(t *testing.T) {
+	var buf bytes.Buffer
+	FlowTable(&buf, sampleFlow())
+	got := buf.String()
+	for _, want := range []string{
+		"Cumulative flow 2026-05-01 – 2026-05-02",
+		"05-01 " + strings.Repeat("▓", 25) + strings.Repeat(" ", 25) + "    2  wip 2   +2 -0",
+		"05-02 " + strings.Repeat("█", 38) + strings.Repeat("▓", 12) + "    4  wip 1   +2 -3",
+		"2.0/day (tasks started)",
+		"1.5/day (tasks finished)",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestFlowCompact(t *testing.T) {
+	var buf bytes.Buffer
+	FlowCompact(&buf, sampleFlow())
+	want := "2026-05-02 todo:1 done:3 wip:1 started:2 finished:3\n"
+	if !strings.Contains(buf.String(), want) {
+		t.Errorf("output missing %q:\n%s", want, buf.String())
+	}
+}
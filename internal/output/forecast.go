@@ -0,0 +1,60 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/antopolskiy/kanban-md/internal/board"
+)
+
+// ForecastTable renders a Monte Carlo forecast: the question, the throughput
+// it was simulated from, and the answer at each confidence level.
+func ForecastTable(w io.Writer, f *board.Forecast) {
+	question := fmt.Sprintf("Forecast: when will %d tasks be done?", f.Tasks)
+	if f.Mode == board.ForecastHowMany {
+		question = fmt.Sprintf("Forecast: how many tasks will be done by %s?", f.By)
+	}
+	fmt.Fprintln(w, lipgloss.NewStyle().Bold(true).Render(question))
+	fmt.Fprintln(w)
+	printField(w, "Throughput", fmt.Sprintf("%.1f tasks/day (last %d days)", f.AvgThroughput, f.HistoryDays))
+	printField(w, "Simulations", strconv.Itoa(f.Runs))
+	fmt.Fprintln(w)
+
+	fmt.Fprintln(w, headerStyle.Render(fmt.Sprintf("%-11s %s", "CONFIDENCE", "FORECAST")))
+	for _, o := range f.Outcomes {
+		fmt.Fprintf(w, "%-11s %s\n", strconv.Itoa(o.Confidence)+"%", forecastAnswer(o))
+	}
+}
+
+// forecastAnswer renders the outcome at one confidence level.
+func forecastAnswer(o board.ForecastOutcome) string {
+	if o.Tasks != nil {
+		return fmt.Sprintf("%d tasks or more", *o.Tasks)
+	}
+	if *o.Days == 1 {
+		return o.Date.String() + " (tomorrow)"
+	}
+	return fmt.Sprintf("%s (in %d days)", o.Date, *o.Days)
+}
+
+// ForecastCompact renders a forecast on one line.
+func ForecastCompact(w io.Writer, f *board.Forecast) {
+	parts := make([]string, len(f.Outcomes))
+	for i, o := range f.Outcomes {
+		if o.Tasks != nil {
+			parts[i] = strconv.Itoa(o.Confidence) + "%:>=" + strconv.Itoa(*o.Tasks)
+		} else {
+			parts[i] = strconv.Itoa(o.Confidence) + "%:" + o.Date.String()
+		}
+	}
+	head := "done " + strconv.Itoa(f.Tasks) + " tasks by"
+	if f.Mode == board.ForecastHowMany {
+		head = "tasks done by " + f.By.String()
+	}
+	fmt.Fprintf(w, "%s %s (%.1f/day over %dd, %d runs)\n",
+		head, strings.Join(parts, " "), f.AvgThroughput, f.HistoryDays, f.Runs)
+}
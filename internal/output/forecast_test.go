@@ -0,0 +1,68 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/antopolskiy/kanban-md/internal/board"
+	"github.com/antopolskiy/kanban-md/internal/date"
+)
+
+func TestForecastTable(t *testing.T) {
+	disableColorForTest(t)
+
+	d1, d2 := date.New(2026, 5, 2), date.New(2026, 5, 12)
+	one, eleven := 1, 11
+	f := &board.Forecast{
+		Mode: board.ForecastWhen, Tasks: 8, Runs: 1000, HistoryDays: 30, AvgThroughput: 0.75,
+		Outcomes: []board.ForecastOutcome{
+			{Confidence: 50, Date: &d1, Days: &one},
+			{Confidence: 95, Date: &d2, Days: &eleven},
+		},
+	}
+
+	var buf bytes.Buffer
+	ForecastTable(&buf, f)
+	for _, want := range []string{
+		"when will 8 tasks be done?",
+		"0.8 tasks/day (last 30 days)",
+		"50%         2026-05-02 (tomorrow)",
+		"95%         2026-05-12 (in 11 days)",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, buf.String())
+		}
+	}
+
+	buf.Reset()
+	ForecastCompact(&buf, f)
+	if want := "done 8 tasks by 50%:2026-05-02 95%:2026-05-12 (0.8/day over 30d, 1000 runs)\n"; buf.String() != want {
+		t.Errorf("compact = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestForecastTable_HowMany(t *testing.T) {
+	disableColorForTest(t)
+
+	by := date.New(2026, 6, 1)
+	n := 14
+	f := &board.Forecast{
+		Mode: board.ForecastHowMany, By: &by, Runs: 1000, HistoryDays: 30, AvgThroughput: 0.5,
+		Outcomes: []board.ForecastOutcome{{Confidence: 85, Tasks: &n}},
+	}
+
+	var buf bytes.Buffer
+	ForecastTable(&buf, f)
+	for _, want := range []string{"how many tasks will be done by 2026-06-01?", "85%         14 tasks or more"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, buf.String())
+		}
+	}
+
+	buf.Reset()
+	ForecastCompact(&buf, f)
+	if !strings.Contains(buf.String(), "tasks done by 2026-06-01 85%:>=14") {
+		t.Errorf("compact = %q", buf.String())
+	}
+}
@@ -0,0 +1,165 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/antopolskiy/kanban-md/internal/board"
+)
+
+// GraphHighlight selects the parts of a graph that GraphDOT and GraphMermaid
+// draw attention to.
+type GraphHighlight struct {
+	Critical bool // the critical path
+	Blocked  bool // blocked tasks and the tasks waiting on them
+	Cycles   bool // dependency cycles
+}
+
+// Highlight colors shared by the DOT and Mermaid renderers.
+const (
+	graphCriticalColor = "#d62728"
+	graphBlockedColor  = "#ff7f0e"
+	graphChainColor    = "#ffe0b2"
+	graphCycleColor    = "#9467bd"
+	graphDoneColor     = "#999999"
+)
+
+// GraphDOT renders g in Graphviz DOT. Dependency edges point from the
+// prerequisite to the dependent task; parent edges are dashed.
+func GraphDOT(w io.Writer, g *board.Graph, hl GraphHighlight) {
+	fmt.Fprintln(w, "digraph kanban {")
+	fmt.Fprintln(w, "  rankdir=LR;")
+	fmt.Fprintln(w, `  node [shape=box, style="rounded"];`)
+	for _, n := range g.Nodes {
+		attrs := []string{`label="` + graphLabel(n, `\n`, dotEscape) + `"`}
+		attrs = append(attrs, dotNodeStyle(n, hl)...)
+		fmt.Fprintf(w, "  t%d [%s];\n", n.ID, strings.Join(attrs, ", "))
+	}
+	for _, e := range g.Edges {
+		var attrs []string
+		if e.Kind == board.EdgeParent {
+			attrs = append(attrs, "style=dashed", "arrowhead=none")
+		}
+		switch {
+		case hl.Cycles && e.Cycle:
+			attrs = append(attrs, dotQuoteAttr("color", graphCycleColor), "penwidth=2")
+		case hl.Critical && e.Critical:
+			attrs = append(attrs, dotQuoteAttr("color", graphCriticalColor), "penwidth=2")
+		}
+		if len(attrs) == 0 {
+			fmt.Fprintf(w, "  t%d -> t%d;\n", e.From, e.To)
+			continue
+		}
+		fmt.Fprintf(w, "  t%d -> t%d [%s];\n", e.From, e.To, strings.Join(attrs, ", "))
+	}
+	fmt.Fprintln(w, "}")
+}
+
+// dotNodeStyle returns the DOT attributes highlighting n.
+func dotNodeStyle(n board.GraphNode, hl GraphHighlight) []string {
+	var attrs []string
+	switch {
+	case hl.Blocked && n.Blocked:
+		attrs = append(attrs, `style="rounded,filled"`, dotQuoteAttr("fillcolor", graphBlockedColor))
+	case hl.Blocked && n.BlockedChain:
+		attrs = append(attrs, `style="rounded,filled"`, dotQuoteAttr("fillcolor", graphChainColor))
+	}
+	switch {
+	case hl.Cycles && n.InCycle:
+		attrs = append(attrs, dotQuoteAttr("color", graphCycleColor), "penwidth=2")
+	case hl.Critical && n.Critical:
+		attrs = append(attrs, dotQuoteAttr("color", graphCriticalColor), "penwidth=2")
+	}
+	if n.Done {
+		attrs = append(attrs, dotQuoteAttr("fontcolor", graphDoneColor))
+	}
+	return attrs
+}
+
+// GraphMermaid renders g as a Mermaid flowchart, with the same edge
+// directions as GraphDOT.
+func GraphMermaid(w io.Writer, g *board.Graph, hl GraphHighlight) {
+	fmt.Fprintln(w, "flowchart LR")
+	classes := map[string][]string{}
+	for _, n := range g.Nodes {
+		fmt.Fprintf(w, "  t%d[\"%s\"]\n", n.ID, graphLabel(n, "<br/>", mermaidEscape))
+		id := "t" + strconv.Itoa(n.ID)
+		switch {
+		case hl.Blocked && n.Blocked:
+			classes["blocked"] = append(classes["blocked"], id)
+		case hl.Blocked && n.BlockedChain:
+			classes["chain"] = append(classes["chain"], id)
+		}
+		switch {
+		case hl.Cycles && n.InCycle:
+			classes["cycle"] = append(classes["cycle"], id)
+		case hl.Critical && n.Critical:
+			classes["critical"] = append(classes["critical"], id)
+		}
+		if n.Done {
+			classes["done"] = append(classes["done"], id)
+		}
+	}
+
+	var critical, cycle []string
+	for i, e := range g.Edges {
+		arrow := "-->"
+		if e.Kind == board.EdgeParent {
+			arrow = "-.-"
+		}
+		fmt.Fprintf(w, "  t%d %s t%d\n", e.From, arrow, e.To)
+		switch {
+		case hl.Cycles && e.Cycle:
+			cycle = append(cycle, strconv.Itoa(i))
+		case hl.Critical && e.Critical:
+			critical = append(critical, strconv.Itoa(i))
+		}
+	}
+
+	styles := []struct{ class, style string }{
+		{"blocked", "fill:" + graphBlockedColor},
+		{"chain", "fill:" + graphChainColor},
+		{"critical", "stroke:" + graphCriticalColor + ",stroke-width:3px"},
+		{"cycle", "stroke:" + graphCycleColor + ",stroke-width:3px"},
+		{"done", "color:" + graphDoneColor},
+	}
+	for _, s := range styles {
+		if ids := classes[s.class]; len(ids) > 0 {
+			fmt.Fprintf(w, "  classDef %s %s\n", s.class, s.style)
+			fmt.Fprintf(w, "  class %s %s\n", strings.Join(ids, ","), s.class)
+		}
+	}
+	if len(critical) > 0 {
+		fmt.Fprintf(w, "  linkStyle %s stroke:%s,stroke-width:3px\n", strings.Join(critical, ","), graphCriticalColor)
+	}
+	if len(cycle) > 0 {
+		fmt.Fprintf(w, "  linkStyle %s stroke:%s,stroke-width:3px\n", strings.Join(cycle, ","), graphCycleColor)
+	}
+}
+
+// graphLabel renders a node label: "#ID title", then the status on its own
+// line. Text is escaped for the target format; sep is its line break.
+func graphLabel(n board.GraphNode, sep string, escape func(string) string) string {
+	status := n.Status
+	if n.Blocked {
+		status += " (blocked)"
+	}
+	return escape("#"+strconv.Itoa(n.ID)+" "+n.Title) + sep + escape(status)
+}
+
+// dotEscape escapes s for use inside a quoted DOT string.
+func dotEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", " ").Replace(s)
+}
+
+func dotQuoteAttr(name, value string) string {
+	return name + `="` + dotEscape(value) + `"`
+}
+
+// mermaidEscape replaces the characters that end or break a quoted Mermaid
+// label with entity codes.
+func mermaidEscape(s string) string {
+	return strings.NewReplacer("#", "#35;", `"`, "#quot;", "\n", " ").Replace(s)
+}
@@ -0,0 +1,72 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/antopolskiy/kanban-md/internal/board"
+)
+
+func sampleGraph() *board.Graph {
+	parent := 1
+	return &board.Graph{
+		Nodes: []board.GraphNode{
+			{ID: 1, Title: `Say "hi"`, Status: "todo", Blocked: true, Critical: true},
+			{ID: 2, Title: "Next", Status: "todo", DependsOn: []int{1}, Parent: &parent, BlockedChain: true, Critical: true},
+		},
+		Edges: []board.GraphEdge{
+			{From: 1, To: 2, Kind: board.EdgeDependsOn, Critical: true},
+			{From: 1, To: 2, Kind: board.EdgeParent},
+		},
+		CriticalPath: []int{1, 2},
+		Cycles:       [][]int{},
+	}
+}
+
+func TestGraphDOT(t *testing.T) {
+	var buf bytes.Buffer
+	GraphDOT(&buf, sampleGraph(), GraphHighlight{})
+	got := buf.String()
+	for _, want := range []string{
+		"digraph kanban {",
+		`t1 [label="#1 Say \"hi\"\ntodo (blocked)"];`,
+		"  t1 -> t2;\n",
+		"t1 -> t2 [style=dashed, arrowhead=none];",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("DOT output missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, graphCriticalColor) {
+		t.Errorf("DOT output highlights without --highlight:\n%s", got)
+	}
+
+	buf.Reset()
+	GraphDOT(&buf, sampleGraph(), GraphHighlight{Critical: true, Blocked: true})
+	got = buf.String()
+	if !strings.Contains(got, `t1 -> t2 [color="`+graphCriticalColor+`", penwidth=2];`) ||
+		!strings.Contains(got, `fillcolor="`+graphBlockedColor+`"`) {
+		t.Errorf("DOT output missing highlights:\n%s", got)
+	}
+}
+
+func TestGraphMermaid(t *testing.T) {
+	var buf bytes.Buffer
+	GraphMermaid(&buf, sampleGraph(), GraphHighlight{Critical: true, Blocked: true, Cycles: true})
+	got := buf.String()
+	for _, want := range []string{
+		"flowchart LR\n",
+		`t1["#35;1 Say #quot;hi#quot;<br/>todo (blocked)"]`,
+		"  t1 --> t2\n",
+		"  t1 -.- t2\n",
+		"  class t1 blocked\n",
+		"  class t2 chain\n",
+		"  class t1,t2 critical\n",
+		"  linkStyle 0 stroke:" + graphCriticalColor,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Mermaid output missing %q:\n%s", want, got)
+		}
+	}
+}
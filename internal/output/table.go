@@ -1,9 +1,11 @@
 package output
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -11,6 +13,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/antopolskiy/kanban-md/internal/board"
+	"github.com/antopolskiy/kanban-md/internal/config"
 	"github.com/antopolskiy/kanban-md/internal/task"
 )
 
@@ -52,19 +55,42 @@ func DisableColor() {
 
 // TaskTable renders a list of tasks as a formatted table.
 func TaskTable(w io.Writer, tasks []*task.Task) {
+	taskTable(w, tasks, make([]int, len(tasks)))
+}
+
+// TaskTreeTable renders a task tree as a formatted table, with subtasks
+// indented under their parent.
+func TaskTreeTable(w io.Writer, roots []*board.TaskNode) {
+	tasks, depths := flattenTree(roots)
+	taskTable(w, tasks, depths)
+}
+
+// taskTable renders tasks as a table, indenting each title by its depth in
+// a task tree.
+func taskTable(w io.Writer, tasks []*task.Task, depths []int) {
 	if len(tasks) == 0 {
 		fmt.Fprintln(os.Stderr, "No tasks found.")
 		return
 	}
 
+	titles := make([]string, len(tasks))
+	for i, t := range tasks {
+		title := t.Title
+		const maxTitle = 48
+		if len(title) > maxTitle {
+			title = title[:maxTitle-3] + "..."
+		}
+		titles[i] = treePrefix(depths[i]) + title
+	}
+
 	// Calculate column widths.
 	const pad = 2
 	idW, statusW, prioW, titleW, claimW, tagsW, dueW := 4, 8, 10, 5, 9, 6, 12
-	for _, t := range tasks {
+	for i, t := range tasks {
 		idW = max(idW, len(strconv.Itoa(t.ID))+pad)
 		statusW = max(statusW, len(t.Status)+pad)
 		prioW = max(prioW, len(t.Priority)+pad)
-		titleW = max(titleW, min(len(t.Title)+pad, 50)) //nolint:mnd // max title column width
+		titleW = max(titleW, lipgloss.Width(titles[i])+pad)
 		claimW = max(claimW, len(claimDisplay(t))+pad)
 		tagsW = max(tagsW, min(len(strings.Join(t.Tags, ","))+pad, 30)) //nolint:mnd // max tags column width
 	}
@@ -76,12 +102,7 @@ func TaskTable(w io.Writer, tasks []*task.Task) {
 	fmt.Fprintln(w, headerStyle.Render(strings.TrimRight(header, " ")))
 
 	// Print rows.
-	for _, t := range tasks {
-		title := t.Title
-		const maxTitle = 48
-		if len(title) > maxTitle {
-			title = title[:maxTitle-3] + "..."
-		}
+	for i, t := range tasks {
 		claim := claimDisplay(t)
 		if claim == "" {
 			claim = dimStyle.Render("--")
@@ -105,7 +126,7 @@ func TaskTable(w io.Writer, tasks []*task.Task) {
 			idW, t.ID,
 			padRight(styledValue(t.Status, statusStyles), statusW),
 			padRight(styledValue(t.Priority, priorityStyles), prioW),
-			padRight(title, titleW),
+			padRight(titles[i], titleW),
 			padRight(claim, claimW),
 			padRight(tags, tagsW),
 			due)
@@ -113,6 +134,30 @@ func TaskTable(w io.Writer, tasks []*task.Task) {
 	}
 }
 
+// flattenTree lists the tasks of a task tree depth-first, with their depth.
+func flattenTree(roots []*board.TaskNode) ([]*task.Task, []int) {
+	var tasks []*task.Task
+	var depths []int
+	var walk func(nodes []*board.TaskNode, depth int)
+	walk = func(nodes []*board.TaskNode, depth int) {
+		for _, n := range nodes {
+			tasks = append(tasks, n.Task)
+			depths = append(depths, depth)
+			walk(n.Subtasks, depth+1)
+		}
+	}
+	walk(roots, 0)
+	return tasks, depths
+}
+
+// treePrefix indents a title at the given depth of a task tree.
+func treePrefix(depth int) string {
+	if depth == 0 {
+		return ""
+	}
+	return strings.Repeat("  ", depth-1) + "└ "
+}
+
 // TaskDetail renders a single task with full detail.
 func TaskDetail(w io.Writer, t *task.Task) {
 	titleLine := fmt.Sprintf("Task #%d: %s", t.ID, t.Title)
@@ -136,6 +181,12 @@ func TaskDetail(w io.Writer, t *task.Task) {
 		printField(w, "Due", dimStyle.Render("--"))
 	}
 	printField(w, "Estimate", stringOrDash(t.Estimate))
+	if t.ExternalID != "" {
+		printField(w, "External ID", t.ExternalID)
+	}
+	for _, name := range t.CustomKeys() {
+		printField(w, name, t.FormatCustom(name))
+	}
 	printField(w, "Created", t.Created.Format("2006-01-02 15:04"))
 	printField(w, "Updated", t.Updated.Format("2006-01-02 15:04"))
 	if t.Started != nil {
@@ -163,6 +214,44 @@ func TaskDetail(w io.Writer, t *task.Task) {
 	}
 }
 
+// TimelineDetail renders the statuses a task has been in, with when it
+// entered each and how long it stayed. It follows TaskDetail in `show`.
+func TimelineDetail(w io.Writer, periods []board.StatusPeriod) {
+	const pad = 2
+	statusW := 0
+	for _, p := range periods {
+		statusW = max(statusW, len(p.Status)+pad)
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, headerStyle.Render("Status timeline"))
+	for _, p := range periods {
+		spent := formatHours(p.Hours)
+		if p.Exited == nil {
+			spent += dimStyle.Render(" (current)")
+		}
+		fmt.Fprintf(w, "  %s  %s%s\n",
+			p.Entered.Local().Format("2006-01-02 15:04"), padRight(styledValue(p.Status, statusStyles), statusW), spent)
+	}
+}
+
+// SubtasksDetail renders a parent task's subtasks with their statuses and
+// the share done. It follows TaskDetail in `show`.
+func SubtasksDetail(w io.Writer, r *board.Rollup) {
+	const pad = 2
+	idW, statusW := 0, 0
+	for _, c := range r.Children {
+		idW = max(idW, len(strconv.Itoa(c.ID)))
+		statusW = max(statusW, len(c.Status)+pad)
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, headerStyle.Render(fmt.Sprintf("Subtasks: %d/%d done (%d%%)", r.Done, r.Total, r.Percent)))
+	for _, c := range r.Children {
+		fmt.Fprintf(w, "  #%-*d %s%s\n", idW+1, c.ID, padRight(styledValue(c.Status, statusStyles), statusW), c.Title)
+	}
+}
+
 // OverviewTable renders a board summary as a formatted dashboard.
 func OverviewTable(w io.Writer, s board.Overview) {
 	fmt.Fprintln(w, lipgloss.NewStyle().Bold(true).Render(s.BoardName))
@@ -212,6 +301,27 @@ func MetricsTable(w io.Writer, m board.Metrics) {
 	printField(w, "Avg lead time", formatOptionalHours(m.AvgLeadTimeHours))
 	printField(w, "Avg cycle time", formatOptionalHours(m.AvgCycleTimeHours))
 	printField(w, "Flow efficiency", formatOptionalPercent(m.FlowEfficiency))
+	if m.LeadTimeP50Hours != nil {
+		printField(w, "Lead time", formatPercentiles(m.LeadTimeP50Hours, m.LeadTimeP85Hours, m.LeadTimeP95Hours))
+	}
+	if m.CycleTimeP50Hours != nil {
+		printField(w, "Cycle time", formatPercentiles(m.CycleTimeP50Hours, m.CycleTimeP85Hours, m.CycleTimeP95Hours))
+	}
+
+	if len(m.StatusTimes) > 0 {
+		fmt.Fprintln(w)
+		const statusTimeStatusW = 16
+		header := fmt.Sprintf("%-16s %6s %10s %10s %10s %10s", "TIME IN STATUS", "TASKS", "AVG", "P50", "P85", "P95")
+		fmt.Fprintln(w, headerStyle.Render(header))
+		for _, st := range m.StatusTimes {
+			fmt.Fprintf(w, "%s %6d %10s %10s %10s %10s\n",
+				padRight(styledValue(st.Status, statusStyles), statusTimeStatusW), st.Count,
+				formatHours(st.AvgHours), formatHours(st.P50Hours), formatHours(st.P85Hours), formatHours(st.P95Hours))
+		}
+	}
+
+	groupMetricsTable(w, "CLASS", m.ByClass)
+	groupMetricsTable(w, "TAG", m.ByTag)
 
 	if len(m.AgingItems) > 0 {
 		fmt.Fprintln(w)
@@ -231,11 +341,41 @@ func MetricsTable(w io.Writer, m board.Metrics) {
 	}
 }
 
+// groupMetricsTable renders the lead and cycle time percentiles of each
+// class or tag.
+func groupMetricsTable(w io.Writer, title string, groups []board.GroupMetrics) {
+	if len(groups) == 0 {
+		return
+	}
+	fmt.Fprintln(w)
+	header := fmt.Sprintf("%-16s %6s  %-26s  %s", title, "DONE", "LEAD P50/P85/P95", "CYCLE P50/P85/P95")
+	fmt.Fprintln(w, headerStyle.Render(header))
+	for _, g := range groups {
+		fmt.Fprintf(w, "%-16s %6d  %-26s  %s\n", g.Name, g.Completed,
+			compactPercentiles(g.LeadTimeP50Hours, g.LeadTimeP85Hours, g.LeadTimeP95Hours),
+			compactPercentiles(g.CycleTimeP50Hours, g.CycleTimeP85Hours, g.CycleTimeP95Hours))
+	}
+}
+
+// formatPercentiles renders p50, p85 and p95 durations in hours.
+func formatPercentiles(p50, p85, p95 *float64) string {
+	return "p50 " + formatOptionalHours(p50) + " · p85 " + formatOptionalHours(p85) + " · p95 " + formatOptionalHours(p95)
+}
+
+// compactPercentiles renders p50, p85 and p95 durations in hours as "a/b/c".
+func compactPercentiles(p50, p85, p95 *float64) string {
+	return compactDuration(p50) + "/" + compactDuration(p85) + "/" + compactDuration(p95)
+}
+
 func formatOptionalHours(h *float64) string {
 	if h == nil {
 		return dimStyle.Render("--")
 	}
-	return FormatDuration(time.Duration(*h * float64(time.Hour)))
+	return formatHours(*h)
+}
+
+func formatHours(h float64) string {
+	return FormatDuration(time.Duration(h * float64(time.Hour)))
 }
 
 func formatOptionalPercent(f *float64) string {
@@ -263,6 +403,95 @@ func ActivityLogTable(w io.Writer, entries []board.LogEntry) {
 	}
 }
 
+// HistoryTable renders a task's change history as a timeline: one header line
+// per log entry followed by its field-level changes.
+func HistoryTable(w io.Writer, id int, entries []board.LogEntry) {
+	if len(entries) == 0 {
+		fmt.Fprintf(os.Stderr, "No history found for task #%d.\n", id)
+		return
+	}
+
+	const fieldW = 14
+	for i, e := range entries {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		line := e.Timestamp.Format("2006-01-02 15:04:05") + "  " + e.Action
+		if e.Actor != "" {
+			line += " by " + claimStyle.Render("@"+e.Actor)
+		}
+		if e.ID != "" {
+			line += "  " + dimStyle.Render("(entry "+e.ID+")")
+		}
+		fmt.Fprintln(w, headerStyle.Render(line))
+		if len(e.Changes) == 0 {
+			if e.Detail != "" {
+				fmt.Fprintln(w, "  "+dimStyle.Render(e.Detail))
+			}
+			continue
+		}
+		for _, c := range e.Changes {
+			before, after := FormatChange(c)
+			fmt.Fprintf(w, "  %s %s -> %s\n", padRight(c.Field, fieldW), before, after)
+		}
+	}
+}
+
+// FormatChange renders the before and after values of a field change for
+// display. Unset values are shown as "--", lists are comma-separated, and the
+// body is summarized by line count.
+func FormatChange(c board.FieldChange) (before, after string) {
+	if c.Body != nil {
+		return lineCount(c.Body.BeforeLines), lineCount(c.Body.AfterLines)
+	}
+	if c.Field == "body" {
+		return bodySummary(c.Before), bodySummary(c.After)
+	}
+	return formatRawValue(c.Before), formatRawValue(c.After)
+}
+
+func formatRawValue(raw json.RawMessage) string {
+	var v any
+	if len(raw) == 0 || json.Unmarshal(raw, &v) != nil || v == nil {
+		return "--"
+	}
+	switch val := v.(type) {
+	case string:
+		if ts, err := time.Parse(time.RFC3339Nano, val); err == nil {
+			return ts.Local().Format("2006-01-02 15:04:05")
+		}
+		return stringOrDash(val)
+	case []any:
+		parts := make([]string, len(val))
+		for i, p := range val {
+			parts[i] = fmt.Sprint(p)
+		}
+		return strings.Join(parts, ", ")
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+func bodySummary(raw json.RawMessage) string {
+	var body string
+	if len(raw) == 0 || json.Unmarshal(raw, &body) != nil || body == "" {
+		return "--"
+	}
+	return lineCount(strings.Count(strings.TrimRight(body, "\n"), "\n") + 1)
+}
+
+// lineCount renders a body's length in lines, or "--" for an empty body.
+func lineCount(n int) string {
+	switch n {
+	case 0:
+		return "--"
+	case 1:
+		return "(1 line)"
+	default:
+		return fmt.Sprintf("(%d lines)", n)
+	}
+}
+
 // GroupedTable renders a grouped board view with per-group status breakdowns.
 func GroupedTable(w io.Writer, gs board.GroupedSummary) {
 	if len(gs.Groups) == 0 {
@@ -341,3 +570,279 @@ func styledValue(s string, styles map[string]lipgloss.Style) string {
 	}
 	return s
 }
+
+// ViewTable renders saved views as a name/description table.
+func ViewTable(w io.Writer, views []config.ViewConfig) {
+	if len(views) == 0 {
+		fmt.Fprintln(os.Stderr, "No views defined.")
+		return
+	}
+
+	nameW := len("NAME")
+	for _, v := range views {
+		nameW = max(nameW, len(v.Name))
+	}
+	fmt.Fprintln(w, headerStyle.Render(padRight("NAME", nameW)+"  DESCRIPTION"))
+	for _, v := range views {
+		fmt.Fprintf(w, "%s  %s\n", padRight(v.Name, nameW), stringOrDash(v.Description))
+	}
+}
+
+// TemplateTable renders task templates: one row per template with its
+// description.
+func TemplateTable(w io.Writer, templates []*task.Template) {
+	if len(templates) == 0 {
+		fmt.Fprintln(os.Stderr, "No templates found.")
+		return
+	}
+
+	nameW := len("NAME")
+	for _, t := range templates {
+		nameW = max(nameW, len(t.Name))
+	}
+	fmt.Fprintln(w, headerStyle.Render(padRight("NAME", nameW)+"  DESCRIPTION"))
+	for _, t := range templates {
+		fmt.Fprintf(w, "%s  %s\n", padRight(t.Name, nameW), stringOrDash(t.Description))
+	}
+}
+
+// TemplateDetail renders a task template: the defaults it sets, then its
+// body.
+func TemplateDetail(w io.Writer, t *task.Template) {
+	titleLine := "Template: " + t.Name
+	fmt.Fprintln(w, lipgloss.NewStyle().Bold(true).Render(titleLine))
+	fmt.Fprintln(w, strings.Repeat("─", len(titleLine)))
+
+	fields := []struct{ label, value string }{
+		{"Description", t.Description},
+		{"Title", t.Title},
+		{"Status", t.Status},
+		{"Priority", t.Priority},
+		{"Class", t.Class},
+		{"Assignee", t.Assignee},
+		{"Tags", strings.Join(t.Tags, ", ")},
+		{"Estimate", t.Estimate},
+		{"Due in", t.DueIn},
+	}
+	for _, f := range fields {
+		if f.value != "" {
+			printField(w, f.label, f.value)
+		}
+	}
+	names := make([]string, 0, len(t.Fields))
+	for name := range t.Fields {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		printField(w, name, task.FormatCustomValue(t.Fields[name]))
+	}
+
+	if t.Body != "" {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, t.Body)
+	}
+}
+
+// Column widths for AgentsTable.
+const (
+	agentStateWidth  = 5
+	agentSeenWidth   = 12
+	agentActionWidth = 16
+)
+
+// AgentsTable renders the agent registry: one row per agent with its state,
+// how long ago it was last seen, its last action and the tasks it has claimed.
+func AgentsTable(w io.Writer, agents []board.Agent, now time.Time) {
+	if len(agents) == 0 {
+		fmt.Fprintln(os.Stderr, "No agents found.")
+		return
+	}
+
+	nameW := len("AGENT")
+	for _, a := range agents {
+		nameW = max(nameW, len(a.Name))
+	}
+	header := padRight("AGENT", nameW) + "  " + padRight("STATE", agentStateWidth) + "  " +
+		padRight("LAST SEEN", agentSeenWidth) + "  " + padRight("LAST ACTION", agentActionWidth) + "  CLAIMS"
+	fmt.Fprintln(w, headerStyle.Render(header))
+
+	for _, a := range agents {
+		fmt.Fprintf(w, "%s  %s  %s  %s  %s\n",
+			padRight(claimStyle.Render(a.Name), nameW),
+			padRight(agentState(a), agentStateWidth),
+			padRight(lastSeenAgo(a, now), agentSeenWidth),
+			padRight(stringOrDash(agentLastAction(a)), agentActionWidth),
+			stringOrDash(formatIDs(a.Claims)))
+	}
+}
+
+func agentState(a board.Agent) string {
+	if a.Live {
+		return "live"
+	}
+	return dimStyle.Render("stale")
+}
+
+func lastSeenAgo(a board.Agent, now time.Time) string {
+	if a.LastSeen.IsZero() {
+		return dimStyle.Render("--")
+	}
+	return FormatDuration(now.Sub(a.LastSeen)) + " ago"
+}
+
+// agentLastAction renders an agent's last registered action, e.g. "move #4".
+func agentLastAction(a board.Agent) string {
+	if a.LastAction == "" {
+		return ""
+	}
+	if a.LastTask == 0 {
+		return a.LastAction
+	}
+	return a.LastAction + " #" + strconv.Itoa(a.LastTask)
+}
+
+// formatIDs renders task IDs as "#1,#4".
+func formatIDs(ids []int) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = "#" + strconv.Itoa(id)
+	}
+	return strings.Join(parts, ",")
+}
+
+// ReapTable renders the claims released (or, with dryRun, that would be
+// released) by `agents reap`.
+func ReapTable(w io.Writer, reaped []board.ReapedClaim, dryRun bool, now time.Time) {
+	if len(reaped) == 0 {
+		fmt.Fprintln(w, "No stale claims to reap.")
+		return
+	}
+	verb := "Released"
+	if dryRun {
+		verb = "Would release"
+	}
+	for _, r := range reaped {
+		seen := "never seen"
+		if !r.LastSeen.IsZero() {
+			seen = "last seen " + FormatDuration(now.Sub(r.LastSeen)) + " ago"
+		}
+		fmt.Fprintf(w, "%s claim on task #%d held by %s (%s)\n", verb, r.TaskID, r.Agent, seen)
+	}
+}
+
+// claimExpiryWidth is the width of the EXPIRES column in ClaimsTable.
+const claimExpiryWidth = 12
+
+// ClaimsTable renders claimed tasks with their claimant and the time left
+// until each claim expires.
+func ClaimsTable(w io.Writer, claims []board.Claim, now time.Time) {
+	if len(claims) == 0 {
+		fmt.Fprintln(os.Stderr, "No claims found.")
+		return
+	}
+
+	agentW, statusW := len("CLAIMED BY"), len("STATUS")
+	for _, c := range claims {
+		agentW = max(agentW, len(c.ClaimedBy))
+		statusW = max(statusW, len(c.Status))
+	}
+	header := fmt.Sprintf("%4s  %s  %s  %s  %s", "ID", padRight("STATUS", statusW),
+		padRight("CLAIMED BY", agentW), padRight("EXPIRES", claimExpiryWidth), "TITLE")
+	fmt.Fprintln(w, headerStyle.Render(header))
+
+	for _, c := range claims {
+		fmt.Fprintf(w, "%4d  %s  %s  %s  %s\n", c.ID,
+			padRight(styledValue(c.Status, statusStyles), statusW),
+			padRight(claimStyle.Render(c.ClaimedBy), agentW),
+			padRight(claimExpiry(c, now), claimExpiryWidth),
+			c.Title)
+	}
+}
+
+// claimExpiry renders the time left on a claim, "expired", or "never".
+func claimExpiry(c board.Claim, now time.Time) string {
+	switch {
+	case c.ExpiresAt == nil:
+		return dimStyle.Render("never")
+	case c.Expired:
+		return priorityStyles["critical"].Render("expired")
+	default:
+		return "in " + FormatDuration(c.ExpiresAt.Sub(now))
+	}
+}
+
+// ExpiredClaimsTable renders the claims cleared (or, with dryRun, that would
+// be cleared) by `claims expire`.
+func ExpiredClaimsTable(w io.Writer, claims []board.Claim, moveTo string, dryRun bool) {
+	if len(claims) == 0 {
+		fmt.Fprintln(w, "No expired claims.")
+		return
+	}
+	verb := "Expired"
+	if dryRun {
+		verb = "Would expire"
+	}
+	for _, c := range claims {
+		line := fmt.Sprintf("%s claim on task #%d held by %s", verb, c.ID, c.ClaimedBy)
+		if moveTo != "" && moveTo != c.Status {
+			line += " (" + c.Status + " -> " + moveTo + ")"
+		}
+		fmt.Fprintln(w, line)
+	}
+}
+
+// RecurredTable renders the tasks created by `recur`, or those that would be
+// created on a dry run.
+func RecurredTable(w io.Writer, tasks []board.RecurredTask, dryRun bool) {
+	if len(tasks) == 0 {
+		fmt.Fprintln(w, "No recurring tasks due.")
+		return
+	}
+	for _, rt := range tasks {
+		if rt.Error != "" {
+			fmt.Fprintf(os.Stderr, "Error: %s: %s\n", rt.Rule, rt.Error)
+			continue
+		}
+		if dryRun {
+			fmt.Fprintf(w, "Would create %q from %s (%s)\n", rt.Title, rt.Rule, rt.Occurrence.Format("2006-01-02 15:04"))
+			continue
+		}
+		fmt.Fprintf(w, "Created task #%d: %s (%s)\n", rt.ID, rt.Title, rt.Rule)
+	}
+}
+
+// ImportTable renders the result of `import`: one line per item, then a
+// summary.
+func ImportTable(w io.Writer, results []board.ImportResult) {
+	if len(results) == 0 {
+		fmt.Fprintln(w, "Nothing to import.")
+		return
+	}
+	counts := map[string]int{}
+	for _, r := range results {
+		counts[r.Action]++
+		ref := r.ExternalID
+		if ref == "" {
+			ref = "no external ID"
+		}
+		switch r.Action {
+		case board.ImportCreated:
+			fmt.Fprintf(w, "Created task #%d: %s (%s)\n", r.ID, r.Title, ref)
+		case board.ImportExists:
+			if r.ID == 0 { // repeated in the export; only seen on a dry run
+				fmt.Fprintf(w, "Duplicate %q (%s)\n", r.Title, ref)
+				continue
+			}
+			fmt.Fprintf(w, "Exists as #%d: %s (%s)\n", r.ID, r.Title, ref)
+		default:
+			fmt.Fprintf(w, "Would create %q in %s (%s)\n", r.Title, r.Status, ref)
+		}
+	}
+	switch {
+	case counts[board.ImportCreate] > 0:
+		fmt.Fprintf(w, "\n%d to create, %d already imported.\n", counts[board.ImportCreate], counts[board.ImportExists])
+	default:
+		fmt.Fprintf(w, "\n%d created, %d already imported.\n", counts[board.ImportCreated], counts[board.ImportExists])
+	}
+}
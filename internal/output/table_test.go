@@ -353,6 +353,29 @@ func TestTaskDetail(t *testing.T) {
 	}
 }
 
+func TestTaskDetailCustomFields(t *testing.T) {
+	disableColorForTest(t)
+
+	now := time.Now()
+	tk := &task.Task{
+		ID: 2, Title: "Custom", Status: "todo", Priority: "low", Created: now, Updated: now,
+		Custom: map[string]any{"severity": "s1", "areas": []any{"api", "ui"}, "points": 3},
+	}
+
+	var buf strings.Builder
+	TaskDetail(&buf, tk)
+	out := buf.String()
+
+	for _, want := range []string{"areas:       api, ui", "points:      3", "severity:    s1"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("TaskDetail missing %q in output:\n%s", want, out)
+		}
+	}
+	if strings.Index(out, "areas:") > strings.Index(out, "severity:") {
+		t.Errorf("custom fields not sorted:\n%s", out)
+	}
+}
+
 func TestTaskDetailCompleted(t *testing.T) {
 	disableColorForTest(t)
 
@@ -428,6 +451,9 @@ func TestMetricsTable(t *testing.T) {
 		AgingItems: []board.AgingItem{
 			{ID: 1, Title: "Aging task", Status: "in-progress", AgeHours: 72},
 		},
+		StatusTimes: []board.StatusTime{
+			{Status: "review", Count: 4, AvgHours: 30, P50Hours: 2, P85Hours: 50, P95Hours: 100},
+		},
 	}
 
 	var buf strings.Builder
@@ -440,6 +466,8 @@ func TestMetricsTable(t *testing.T) {
 		"12 tasks",
 		"50.0%",
 		"Aging task",
+		"TIME IN STATUS",
+		"review                4      1d 6h      2h 0m      2d 2h      4d 4h",
 	} {
 		if !strings.Contains(out, want) {
 			t.Errorf("MetricsTable missing %q in output:\n%s", want, out)
@@ -509,6 +537,59 @@ func TestActivityLogTableEmpty(t *testing.T) {
 	}
 }
 
+func historyEntries() []board.LogEntry {
+	return []board.LogEntry{
+		{
+			ID:        "a1b2c3d4e5f6",
+			Timestamp: time.Date(2026, 2, 8, 12, 0, 0, 0, time.UTC),
+			Action:    "edit",
+			TaskID:    1,
+			Detail:    "Task",
+			Actor:     "bot",
+			Changes: []board.FieldChange{
+				{Field: "priority", Before: []byte(`"low"`), After: []byte(`"high"`)},
+				{Field: "tags", After: []byte(`["a","b"]`)},
+				{Field: "body", Before: []byte(`"one"`), After: []byte(`"one\n\ntwo"`)},
+			},
+		},
+		{
+			Timestamp: time.Date(2026, 2, 8, 13, 0, 0, 0, time.UTC),
+			Action:    "release",
+			TaskID:    1,
+			Detail:    "bot",
+		},
+	}
+}
+
+func TestHistoryTable(t *testing.T) {
+	disableColorForTest(t)
+
+	var buf strings.Builder
+	HistoryTable(&buf, 1, historyEntries())
+	out := buf.String()
+
+	for _, want := range []string{
+		"edit by @bot  (entry a1b2c3d4e5f6)",
+		"priority       low -> high",
+		"tags           -- -> a, b",
+		"body           (1 line) -> (3 lines)",
+		"release",
+		"  bot",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("HistoryTable missing %q in output:\n%s", want, out)
+		}
+	}
+}
+
+func TestHistoryTableEmpty(t *testing.T) {
+	var buf strings.Builder
+	HistoryTable(&buf, 1, nil)
+	if buf.String() != "" {
+		t.Errorf("HistoryTable empty output to writer = %q, want empty", buf.String())
+	}
+}
+
 // ---------------------------------------------------------------------------
 // GroupedTable
 // ---------------------------------------------------------------------------
@@ -591,3 +672,73 @@ func TestGroupedTableEmpty(t *testing.T) {
 		t.Errorf("GroupedTable empty output to writer = %q, want empty", buf.String())
 	}
 }
+
+func TestTaskTreeTable(t *testing.T) {
+	disableColorForTest(t)
+
+	parent := 1
+	epic := &task.Task{ID: 1, Title: "Epic", Status: "todo", Priority: "high"}
+	child := &task.Task{ID: 2, Title: "Child", Status: "done", Priority: "low", Parent: &parent}
+	roots := []*board.TaskNode{{Task: epic, Subtasks: []*board.TaskNode{{Task: child}}}}
+
+	var buf strings.Builder
+	TaskTreeTable(&buf, roots)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected header + 2 rows, got %d lines:\n%s", len(lines), buf.String())
+	}
+	if !strings.Contains(lines[1], " Epic ") || !strings.Contains(lines[2], "└ Child") {
+		t.Errorf("expected Child indented under Epic:\n%s", buf.String())
+	}
+}
+
+func TestSubtasksDetail(t *testing.T) {
+	disableColorForTest(t)
+
+	r := &board.Rollup{
+		Children: []board.RollupChild{
+			{ID: 2, Title: "Part one", Status: "done", Done: true},
+			{ID: 3, Title: "Part two", Status: "in-progress"},
+		},
+		Done: 1, Total: 2, Percent: 50,
+	}
+
+	var buf strings.Builder
+	SubtasksDetail(&buf, r)
+	out := buf.String()
+	for _, want := range []string{"Subtasks: 1/2 done (50%)", "#2  done", "Part one", "#3  in-progress  Part two"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestTimelineDetail(t *testing.T) {
+	disableColorForTest(t)
+
+	t0 := time.Date(2026, 5, 1, 9, 0, 0, 0, time.Local)
+	exited := t0.Add(26 * time.Hour)
+	periods := []board.StatusPeriod{
+		{Status: "todo", Entered: t0, Exited: &exited, Hours: 26},
+		{Status: "in-progress", Entered: exited, Hours: 3},
+	}
+
+	var buf strings.Builder
+	TimelineDetail(&buf, periods)
+	out := buf.String()
+	for _, want := range []string{
+		"Status timeline",
+		"2026-05-01 09:00  todo         1d 2h",
+		"2026-05-02 11:00  in-progress  3h 0m (current)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	buf.Reset()
+	TimelineCompact(&buf, periods)
+	if want := "  timeline: todo 1d 2h > in-progress 3h 0m\n"; buf.String() != want {
+		t.Errorf("compact = %q, want %q", buf.String(), want)
+	}
+}
@@ -0,0 +1,194 @@
+package server
+
+import (
+	"slices"
+	"time"
+
+	"github.com/antopolskiy/kanban-md/internal/board"
+	"github.com/antopolskiy/kanban-md/internal/clierr"
+	"github.com/antopolskiy/kanban-md/internal/config"
+	"github.com/antopolskiy/kanban-md/internal/date"
+	"github.com/antopolskiy/kanban-md/internal/task"
+)
+
+// editRequest is the body of PATCH /api/tasks/{id}. Fields mirror the flags of
+// `kanban-md edit`; absent fields are left unchanged. Pointer fields can be
+// set to an empty value to clear them (e.g. "due": "" removes the due date).
+type editRequest struct {
+	Title      *string  `json:"title"`
+	Status     *string  `json:"status"`
+	Priority   *string  `json:"priority"`
+	Assignee   *string  `json:"assignee"`
+	Estimate   *string  `json:"estimate"`
+	Class      *string  `json:"class"`
+	Body       *string  `json:"body"`
+	AppendBody string   `json:"append_body"`
+	Timestamp  bool     `json:"timestamp"`
+	AddTags    []string `json:"add_tags"`
+	RemoveTags []string `json:"remove_tags"`
+	Due        *string  `json:"due"`
+	Parent     *int     `json:"parent"` // 0 clears the parent
+	AddDeps    []int    `json:"add_deps"`
+	RemoveDeps []int    `json:"remove_deps"`
+	Block      *string  `json:"block"`
+	Unblock    bool     `json:"unblock"`
+	Claim      string   `json:"claim"`
+	Release    bool     `json:"release"`
+	IfRev      string   `json:"if_rev"`
+}
+
+// apply mutates t according to the request and reports whether anything was
+// set. It is called by board.Edit under the board lock.
+func (req *editRequest) apply(cfg *config.Config, t *task.Task, now time.Time) (bool, error) {
+	changed, err := req.applyFields(cfg, t)
+	if err != nil {
+		return false, err
+	}
+	for _, fn := range []func(*task.Task, time.Time) (bool, error){
+		req.applyLinks,
+		req.applyState,
+	} {
+		c, fnErr := fn(t, now)
+		if fnErr != nil {
+			return false, fnErr
+		}
+		changed = changed || c
+	}
+	return changed, nil
+}
+
+// applyFields handles the scalar task fields and the body.
+func (req *editRequest) applyFields(cfg *config.Config, t *task.Task) (bool, error) {
+	changed := false
+	if req.Title != nil {
+		if *req.Title == "" {
+			return false, clierr.New(clierr.InvalidInput, "title cannot be empty")
+		}
+		t.Title = *req.Title
+		changed = true
+	}
+	if req.Status != nil {
+		if err := task.ValidateStatus(*req.Status, cfg.StatusNames()); err != nil {
+			return false, err
+		}
+		t.Status = *req.Status
+		changed = true
+	}
+	if req.Priority != nil {
+		if err := task.ValidatePriority(*req.Priority, cfg.Priorities); err != nil {
+			return false, err
+		}
+		t.Priority = *req.Priority
+		changed = true
+	}
+	if req.Class != nil {
+		if *req.Class != "" {
+			if err := task.ValidateClass(*req.Class, cfg.ClassNames()); err != nil {
+				return false, err
+			}
+		}
+		t.Class = *req.Class
+		changed = true
+	}
+	if req.Assignee != nil {
+		t.Assignee = *req.Assignee
+		changed = true
+	}
+	if req.Estimate != nil {
+		t.Estimate = *req.Estimate
+		changed = true
+	}
+	if req.Body != nil && req.AppendBody != "" {
+		return false, clierr.New(clierr.StatusConflict, "cannot use body and append_body together")
+	}
+	if req.Body != nil {
+		t.Body = *req.Body
+		changed = true
+	}
+	if req.AppendBody != "" {
+		t.Body = board.AppendBody(t.Body, req.AppendBody, req.Timestamp)
+		changed = true
+	}
+	return changed, nil
+}
+
+// applyLinks handles tags, due date, parent and dependencies.
+func (req *editRequest) applyLinks(t *task.Task, _ time.Time) (bool, error) {
+	changed := false
+	for _, tag := range req.AddTags {
+		if !slices.Contains(t.Tags, tag) {
+			t.Tags = append(t.Tags, tag)
+		}
+		changed = true
+	}
+	if len(req.RemoveTags) > 0 {
+		t.Tags = slices.DeleteFunc(t.Tags, func(tag string) bool { return slices.Contains(req.RemoveTags, tag) })
+		changed = true
+	}
+	if req.Due != nil {
+		t.Due = nil
+		if *req.Due != "" {
+			d, err := date.Parse(*req.Due)
+			if err != nil {
+				return false, task.FormatDueDate(*req.Due, err)
+			}
+			t.Due = &d
+		}
+		changed = true
+	}
+	if req.Parent != nil {
+		t.Parent = nil
+		if *req.Parent != 0 {
+			p := *req.Parent
+			t.Parent = &p
+		}
+		changed = true
+	}
+	for _, dep := range req.AddDeps {
+		if !slices.Contains(t.DependsOn, dep) {
+			t.DependsOn = append(t.DependsOn, dep)
+		}
+		changed = true
+	}
+	if len(req.RemoveDeps) > 0 {
+		t.DependsOn = slices.DeleteFunc(t.DependsOn, func(dep int) bool { return slices.Contains(req.RemoveDeps, dep) })
+		changed = true
+	}
+	return changed, nil
+}
+
+// applyState handles block/unblock and claim/release.
+func (req *editRequest) applyState(t *task.Task, now time.Time) (bool, error) {
+	changed := false
+	if req.Block != nil && req.Unblock {
+		return false, clierr.New(clierr.StatusConflict, "cannot use block and unblock together")
+	}
+	if req.Block != nil {
+		if *req.Block == "" {
+			return false, clierr.New(clierr.InvalidInput, "block reason is required")
+		}
+		t.Blocked = true
+		t.BlockReason = *req.Block
+		changed = true
+	}
+	if req.Unblock {
+		t.Blocked = false
+		t.BlockReason = ""
+		changed = true
+	}
+
+	if req.Claim != "" && req.Release {
+		return false, clierr.New(clierr.StatusConflict, "cannot use claim and release together")
+	}
+	if req.Claim != "" {
+		t.ClaimedBy = req.Claim
+		t.ClaimedAt = &now
+		changed = true
+	}
+	if req.Release {
+		t.ClaimedBy = ""
+		t.ClaimedAt = nil
+		changed = true
+	}
+	return changed, nil
+}
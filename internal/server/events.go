@@ -0,0 +1,110 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/antopolskiy/kanban-md/internal/board"
+	"github.com/antopolskiy/kanban-md/internal/config"
+	"github.com/antopolskiy/kanban-md/internal/watcher"
+)
+
+// keepAliveInterval is how often an idle event stream sends a comment line,
+// so proxies and clients do not time the connection out.
+const keepAliveInterval = 15 * time.Second
+
+// changeEvent is the payload of a "change" server-sent event.
+type changeEvent struct {
+	Time time.Time `json:"time"`
+	// Activity holds the activity log entries recorded since the previous
+	// event. It is empty when the files were changed outside kanban-md.
+	Activity []board.LogEntry `json:"activity"`
+}
+
+// handleEvents serves GET /api/events as a Server-Sent Events stream. A
+// "change" event is sent whenever the board's files change on disk, whether
+// the change came from this server, the CLI, the TUI or a text editor.
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	cfg, err := s.loadConfig()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, errors.New("streaming is not supported by this connection"))
+		return
+	}
+
+	changes := make(chan struct{}, 1)
+	wt, err := watcher.New(watchPaths(cfg), func() {
+		select {
+		case changes <- struct{}{}:
+		default: // a notification is already pending
+		}
+	})
+	if err != nil {
+		writeError(w, fmt.Errorf("watching board: %w", err))
+		return
+	}
+	defer wt.Close()
+
+	ctx := r.Context()
+	go wt.Run(ctx, nil)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	// The watcher is registered before this line is sent, so a client that
+	// has read it will not miss any later change.
+	fmt.Fprint(w, ": connected\n\n")
+	flusher.Flush()
+
+	ticker := time.NewTicker(keepAliveInterval)
+	defer ticker.Stop()
+
+	lastSeen := s.now()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			fmt.Fprint(w, ": ping\n\n")
+			flusher.Flush()
+		case <-changes:
+			ev := changeEvent{Time: s.now(), Activity: activitySince(cfg, lastSeen)}
+			if n := len(ev.Activity); n > 0 {
+				lastSeen = ev.Activity[n-1].Timestamp
+			}
+			data, _ := json.Marshal(ev) //nolint:errchkjson // plain struct, cannot fail
+			fmt.Fprintf(w, "event: change\ndata: %s\n\n", data)
+			flusher.Flush()
+		}
+	}
+}
+
+// activitySince returns log entries strictly newer than since.
+func activitySince(cfg *config.Config, since time.Time) []board.LogEntry {
+	entries, _ := board.ReadLog(cfg.Dir(), board.LogFilterOptions{Since: since})
+	out := make([]board.LogEntry, 0, len(entries))
+	for _, e := range entries {
+		if e.Timestamp.After(since) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// watchPaths returns the directories to watch for board changes: the tasks
+// directory and, if different, the kanban directory (config and log).
+func watchPaths(cfg *config.Config) []string {
+	paths := []string{cfg.TasksPath()}
+	if cfg.Dir() != cfg.TasksPath() {
+		paths = append(paths, cfg.Dir())
+	}
+	return paths
+}
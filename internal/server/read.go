@@ -0,0 +1,118 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/antopolskiy/kanban-md/internal/board"
+	"github.com/antopolskiy/kanban-md/internal/config"
+	"github.com/antopolskiy/kanban-md/internal/date"
+	"github.com/antopolskiy/kanban-md/internal/task"
+)
+
+// activeTasks reads all tasks, excluding archived ones.
+func activeTasks(cfg *config.Config) ([]*task.Task, error) {
+	all, _, err := task.ReadAllLenient(cfg.TasksPath())
+	if err != nil {
+		return nil, err
+	}
+	tasks := make([]*task.Task, 0, len(all))
+	for _, t := range all {
+		if !cfg.IsArchivedStatus(t.Status) {
+			tasks = append(tasks, t)
+		}
+	}
+	return tasks, nil
+}
+
+// handleBoard serves GET /api/board.
+func (s *Server) handleBoard(w http.ResponseWriter, _ *http.Request) {
+	cfg, err := s.loadConfig()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	tasks, err := activeTasks(cfg)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, board.Summary(cfg, tasks, s.now()))
+}
+
+// handleMetrics serves GET /api/metrics. The optional since=YYYY-MM-DD
+// parameter behaves like `kanban-md metrics --since`.
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	cfg, err := s.loadConfig()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	tasks, err := activeTasks(cfg)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if since := r.URL.Query().Get("since"); since != "" {
+		d, parseErr := date.Parse(since)
+		if parseErr != nil {
+			writeError(w, task.ValidateDate("since", since, parseErr))
+			return
+		}
+		filtered := make([]*task.Task, 0, len(tasks))
+		for _, t := range tasks {
+			if t.Completed == nil || t.Completed.After(d.Time) {
+				filtered = append(filtered, t)
+			}
+		}
+		tasks = filtered
+	}
+
+	entries, err := board.ReadLog(cfg.Dir(), board.LogFilterOptions{})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	now := s.now()
+	m := board.ComputeMetrics(cfg, tasks, now)
+	m.StatusTimes = board.ComputeStatusTimes(cfg, tasks, board.Transitions(entries), now)
+	writeJSON(w, http.StatusOK, m)
+}
+
+// handleLog serves GET /api/log. Query parameters mirror `kanban-md log`:
+// since, limit, action and task.
+func (s *Server) handleLog(w http.ResponseWriter, r *http.Request) {
+	cfg, err := s.loadConfig()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	q := r.URL.Query()
+	opts := board.LogFilterOptions{Action: q.Get("action")}
+	if since := q.Get("since"); since != "" {
+		d, parseErr := date.Parse(since)
+		if parseErr != nil {
+			writeError(w, task.ValidateDate("since", since, parseErr))
+			return
+		}
+		opts.Since = d.Time
+	}
+	if opts.Limit, err = intParam(q, "limit"); err != nil {
+		writeError(w, err)
+		return
+	}
+	if opts.TaskID, err = intParam(q, "task"); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	entries, err := board.ReadLog(cfg.Dir(), opts)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if entries == nil {
+		entries = []board.LogEntry{}
+	}
+	writeJSON(w, http.StatusOK, entries)
+}
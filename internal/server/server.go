@@ -0,0 +1,171 @@
+// Package server exposes board operations as a local HTTP/JSON API.
+//
+// The server keeps no state of its own: every request loads config.yml and
+// the task files from disk and mutates them through the internal/board
+// functions, under the same board lock the CLI uses. The CLI, the TUI and
+// the server can therefore operate on the same board side by side.
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/antopolskiy/kanban-md/internal/clierr"
+	"github.com/antopolskiy/kanban-md/internal/config"
+	"github.com/antopolskiy/kanban-md/internal/output"
+	"github.com/antopolskiy/kanban-md/internal/task"
+)
+
+// maxBodyBytes caps the size of a JSON request body.
+const maxBodyBytes = 1 << 20
+
+// Server serves the API for the board in one kanban directory.
+type Server struct {
+	dir string
+	now func() time.Time
+}
+
+// New creates a Server for the board in dir.
+func New(dir string) *Server {
+	return &Server{dir: dir, now: time.Now}
+}
+
+// Handler returns the HTTP handler with all API routes registered, behind
+// the checks of guard.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/board", s.handleBoard)
+	mux.HandleFunc("GET /api/tasks", s.handleList)
+	mux.HandleFunc("POST /api/tasks", s.handleCreate)
+	mux.HandleFunc("GET /api/tasks/{id}", s.handleShow)
+	mux.HandleFunc("PATCH /api/tasks/{id}", s.handleEdit)
+	mux.HandleFunc("DELETE /api/tasks/{id}", s.handleDelete)
+	mux.HandleFunc("POST /api/tasks/{id}/move", s.handleMove)
+	mux.HandleFunc("POST /api/tasks/{id}/handoff", s.handleHandoff)
+	mux.HandleFunc("POST /api/tasks/{id}/heartbeat", s.handleHeartbeat)
+	mux.HandleFunc("POST /api/tasks/{id}/archive", s.handleArchive)
+	mux.HandleFunc("POST /api/pick", s.handlePick)
+	mux.HandleFunc("GET /api/metrics", s.handleMetrics)
+	mux.HandleFunc("GET /api/log", s.handleLog)
+	mux.HandleFunc("GET /api/events", s.handleEvents)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		output.JSONError(w, clierr.InvalidInput, "no route for "+r.Method+" "+r.URL.Path, nil)
+	})
+	return guard(mux)
+}
+
+// guard rejects the requests a web page in the user's browser could make,
+// since the API has no authentication: requests addressed to a host other
+// than the loopback interface (as after DNS rebinding), and mutating
+// requests without a JSON Content-Type (browsers send text/plain and form
+// posts cross-site without asking the server first).
+func guard(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !loopbackHost(r.Host) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusForbidden)
+			output.JSONError(w, clierr.InvalidInput, "host "+strconv.Quote(r.Host)+" is not a loopback address",
+				map[string]any{"host": r.Host})
+			return
+		}
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			if mt, _, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err != nil || mt != "application/json" {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusUnsupportedMediaType)
+				output.JSONError(w, clierr.InvalidInput, r.Method+" requests must have Content-Type: application/json", nil)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// loopbackHost reports whether the Host header names the local machine:
+// localhost or a loopback IP, with or without a port.
+func loopbackHost(hostport string) bool {
+	host := hostport
+	if h, _, err := net.SplitHostPort(hostport); err == nil {
+		host = h
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// loadConfig reads the board config fresh from disk so changes made by the
+// CLI or another server are picked up on the next request.
+func (s *Server) loadConfig() (*config.Config, error) {
+	return config.Load(s.dir)
+}
+
+// taskID parses the {id} path segment.
+func taskID(r *http.Request) (int, error) {
+	raw := r.PathValue("id")
+	id, err := strconv.Atoi(raw)
+	if err != nil || id < 1 {
+		return 0, task.ValidateTaskID(raw)
+	}
+	return id, nil
+}
+
+// decodeBody decodes a JSON request body into dst. An empty body leaves dst
+// untouched; unknown fields are rejected so typos surface as errors.
+func decodeBody(w http.ResponseWriter, r *http.Request, dst any) error {
+	r.Body = http.MaxBytesReader(w, r.Body, maxBodyBytes)
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(dst); err != nil && !errors.Is(err, io.EOF) {
+		return clierr.Newf(clierr.InvalidInput, "invalid request body: %v", err)
+	}
+	return nil
+}
+
+// writeJSON writes v as an indented JSON response with the given status.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = output.JSON(w, v) // best-effort; the client may have gone away
+}
+
+// writeError writes err in the same shape as the CLI's --json errors, with an
+// HTTP status derived from the error code.
+func writeError(w http.ResponseWriter, err error) {
+	code, msg := clierr.InternalError, err.Error()
+	var details map[string]any
+	var cliErr *clierr.Error
+	if errors.As(err, &cliErr) {
+		code, msg, details = cliErr.Code, cliErr.Message, cliErr.Details
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatus(code))
+	output.JSONError(w, code, msg, details)
+}
+
+// httpStatus maps a clierr code to an HTTP status code.
+func httpStatus(code string) int {
+	switch code {
+	case clierr.TaskNotFound, clierr.BoardNotFound, clierr.NothingToPick:
+		return http.StatusNotFound
+	case clierr.TaskClaimed, clierr.Conflict, clierr.StatusConflict,
+		clierr.WIPLimitExceeded, clierr.ClassWIPExceeded,
+		clierr.TransitionNotAllowed, clierr.HookRejected, clierr.ClaimLost,
+		clierr.BoardAlreadyExists:
+		return http.StatusConflict
+	case clierr.LockTimeout:
+		return http.StatusServiceUnavailable
+	case clierr.InternalError:
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -0,0 +1,372 @@
+package server_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/antopolskiy/kanban-md/internal/board"
+	"github.com/antopolskiy/kanban-md/internal/config"
+	"github.com/antopolskiy/kanban-md/internal/server"
+	"github.com/antopolskiy/kanban-md/internal/task"
+)
+
+type apiTask struct {
+	ID        int    `json:"id"`
+	Title     string `json:"title"`
+	Status    string `json:"status"`
+	Priority  string `json:"priority"`
+	ClaimedBy string `json:"claimed_by"`
+	Rev       string `json:"rev"`
+}
+
+type apiError struct {
+	Error   string         `json:"error"`
+	Code    string         `json:"code"`
+	Details map[string]any `json:"details"`
+}
+
+func setupServer(t *testing.T) (*httptest.Server, *config.Config) {
+	t.Helper()
+	cfg, err := config.Init(filepath.Join(t.TempDir(), "kanban"), "API Test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(server.New(cfg.Dir()).Handler())
+	t.Cleanup(ts.Close)
+	return ts, cfg
+}
+
+func do(t *testing.T, ts *httptest.Server, method, path string, body any, dst any) int {
+	t.Helper()
+	var rdr io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rdr = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(t.Context(), method, ts.URL+path, rdr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if method != http.MethodGet {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if dst != nil {
+		if err := json.NewDecoder(resp.Body).Decode(dst); err != nil {
+			t.Fatalf("%s %s: decoding response: %v", method, path, err)
+		}
+	}
+	return resp.StatusCode
+}
+
+func TestServer_CreateShowList(t *testing.T) {
+	ts, _ := setupServer(t)
+
+	var created apiTask
+	if code := do(t, ts, http.MethodPost, "/api/tasks", map[string]any{
+		"title": "From API", "priority": "high",
+	}, &created); code != http.StatusCreated {
+		t.Fatalf("create status = %d, want 201", code)
+	}
+	if created.ID != 1 || created.Priority != "high" || created.Rev == "" {
+		t.Errorf("created = %+v", created)
+	}
+
+	var shown apiTask
+	if code := do(t, ts, http.MethodGet, "/api/tasks/1", nil, &shown); code != http.StatusOK {
+		t.Fatalf("show status = %d", code)
+	}
+	if shown.Title != "From API" || shown.Rev != created.Rev {
+		t.Errorf("shown = %+v, want title and rev of created", shown)
+	}
+
+	var list []apiTask
+	do(t, ts, http.MethodGet, "/api/tasks?priority=high", nil, &list)
+	if len(list) != 1 {
+		t.Errorf("list priority=high: got %d tasks, want 1", len(list))
+	}
+	list = nil
+	do(t, ts, http.MethodGet, "/api/tasks?priority=low", nil, &list)
+	if list == nil || len(list) != 0 {
+		t.Errorf("list priority=low: got %v, want empty array", list)
+	}
+}
+
+func TestServer_ErrorsUseCLICodes(t *testing.T) {
+	ts, _ := setupServer(t)
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       any
+		wantStatus int
+		wantCode   string
+	}{
+		{"not found", http.MethodGet, "/api/tasks/99", nil, http.StatusNotFound, "TASK_NOT_FOUND"},
+		{"bad id", http.MethodGet, "/api/tasks/abc", nil, http.StatusBadRequest, "INVALID_TASK_ID"},
+		{"missing title", http.MethodPost, "/api/tasks", map[string]any{}, http.StatusBadRequest, "INVALID_INPUT"},
+		{"unknown field", http.MethodPost, "/api/tasks", map[string]any{"title": "x", "bogus": 1}, http.StatusBadRequest, "INVALID_INPUT"},
+		{"bad status", http.MethodPost, "/api/tasks", map[string]any{"title": "x", "status": "nope"}, http.StatusBadRequest, "INVALID_STATUS"},
+		{"nothing to pick", http.MethodPost, "/api/pick", map[string]any{"claim": "a"}, http.StatusNotFound, "NOTHING_TO_PICK"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var e apiError
+			if code := do(t, ts, tt.method, tt.path, tt.body, &e); code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", code, tt.wantStatus)
+			}
+			if e.Code != tt.wantCode {
+				t.Errorf("code = %q, want %q (%s)", e.Code, tt.wantCode, e.Error)
+			}
+		})
+	}
+}
+
+func TestServer_RejectsCrossSiteRequests(t *testing.T) {
+	ts, cfg := setupServer(t)
+
+	send := func(method, path, contentType, host string) (int, apiError) {
+		t.Helper()
+		req, err := http.NewRequestWithContext(t.Context(), method, ts.URL+path, strings.NewReader(`{"title":"x"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		if host != "" {
+			req.Host = host
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		var e apiError
+		_ = json.NewDecoder(resp.Body).Decode(&e)
+		return resp.StatusCode, e
+	}
+
+	// A form or text/plain post, as a web page can send cross-site.
+	for _, ct := range []string{"", "text/plain", "application/x-www-form-urlencoded"} {
+		if code, e := send(http.MethodPost, "/api/tasks", ct, ""); code != http.StatusUnsupportedMediaType || e.Code != "INVALID_INPUT" {
+			t.Errorf("POST with Content-Type %q: status %d %q, want 415 INVALID_INPUT", ct, code, e.Code)
+		}
+	}
+	// A request to a rebound DNS name.
+	if code, _ := send(http.MethodPost, "/api/tasks", "application/json", "evil.example:8377"); code != http.StatusForbidden {
+		t.Errorf("POST to a foreign host: status %d, want 403", code)
+	}
+	if code, _ := send(http.MethodGet, "/api/tasks", "", "evil.example"); code != http.StatusForbidden {
+		t.Errorf("GET from a foreign host: status %d, want 403", code)
+	}
+	tasks, _, err := task.ReadAllLenient(cfg.TasksPath())
+	if err != nil || len(tasks) != 0 {
+		t.Fatalf("rejected requests created %d tasks (%v)", len(tasks), err)
+	}
+
+	for _, host := range []string{"localhost:8377", "[::1]:8377"} {
+		if code, _ := send(http.MethodPost, "/api/tasks", "application/json; charset=utf-8", host); code != http.StatusCreated {
+			t.Errorf("JSON POST to %s: status %d, want 201", host, code)
+		}
+	}
+}
+
+func TestServer_EditMoveWithIfRev(t *testing.T) {
+	ts, _ := setupServer(t)
+
+	var created apiTask
+	do(t, ts, http.MethodPost, "/api/tasks", map[string]any{"title": "Edit me"}, &created)
+
+	var edited apiTask
+	if code := do(t, ts, http.MethodPatch, "/api/tasks/1", map[string]any{
+		"title": "Edited", "add_tags": []string{"api"}, "if_rev": created.Rev,
+	}, &edited); code != http.StatusOK {
+		t.Fatalf("edit status = %d", code)
+	}
+	if edited.Title != "Edited" {
+		t.Errorf("title = %q, want Edited", edited.Title)
+	}
+
+	// The old revision is now stale.
+	var e apiError
+	if code := do(t, ts, http.MethodPost, "/api/tasks/1/move", map[string]any{
+		"status": "todo", "if_rev": created.Rev,
+	}, &e); code != http.StatusConflict || e.Code != "CONFLICT" {
+		t.Errorf("stale move: status=%d code=%q, want 409 CONFLICT", code, e.Code)
+	}
+
+	var moved struct {
+		Task    apiTask `json:"task"`
+		Changed bool    `json:"changed"`
+	}
+	if code := do(t, ts, http.MethodPost, "/api/tasks/1/move", map[string]any{
+		"status": "todo", "if_rev": edited.Rev,
+	}, &moved); code != http.StatusOK {
+		t.Fatalf("move status = %d", code)
+	}
+	if !moved.Changed || moved.Task.Status != "todo" {
+		t.Errorf("moved = %+v", moved)
+	}
+}
+
+func TestServer_PickHandoffArchiveDelete(t *testing.T) {
+	ts, _ := setupServer(t)
+	do(t, ts, http.MethodPost, "/api/tasks", map[string]any{"title": "A", "status": "todo"}, nil)
+	do(t, ts, http.MethodPost, "/api/tasks", map[string]any{"title": "B"}, nil)
+
+	var picked struct {
+		Task apiTask `json:"task"`
+	}
+	if code := do(t, ts, http.MethodPost, "/api/pick", map[string]any{
+		"claim": "agent-x", "status": "todo", "move": "in-progress",
+	}, &picked); code != http.StatusOK {
+		t.Fatalf("pick status = %d", code)
+	}
+	if picked.Task.ID != 1 || picked.Task.ClaimedBy != "agent-x" || picked.Task.Status != "in-progress" {
+		t.Errorf("picked = %+v", picked.Task)
+	}
+
+	if code := do(t, ts, http.MethodPost, "/api/tasks/1/heartbeat", map[string]any{"claim": "agent-x"}, nil); code != http.StatusOK {
+		t.Fatalf("heartbeat status = %d", code)
+	}
+	if code := do(t, ts, http.MethodPost, "/api/tasks/2/heartbeat", map[string]any{"claim": "agent-x"}, nil); code != http.StatusConflict {
+		t.Errorf("heartbeat on unclaimed task status = %d, want 409", code)
+	}
+
+	var handed apiTask
+	if code := do(t, ts, http.MethodPost, "/api/tasks/1/handoff", map[string]any{
+		"claim": "agent-x", "note": "done for now", "release": true,
+	}, &handed); code != http.StatusOK {
+		t.Fatalf("handoff status = %d", code)
+	}
+	if handed.Status != "review" || handed.ClaimedBy != "" {
+		t.Errorf("handed = %+v", handed)
+	}
+
+	var archived struct {
+		Task    apiTask `json:"task"`
+		Changed bool    `json:"changed"`
+	}
+	do(t, ts, http.MethodPost, "/api/tasks/1/archive", nil, &archived)
+	if !archived.Changed || archived.Task.Status != config.ArchivedStatus {
+		t.Errorf("archived = %+v", archived)
+	}
+
+	var deleted struct {
+		Task apiTask `json:"task"`
+	}
+	if code := do(t, ts, http.MethodDelete, "/api/tasks/2", nil, &deleted); code != http.StatusOK {
+		t.Fatalf("delete status = %d", code)
+	}
+
+	var list []apiTask
+	do(t, ts, http.MethodGet, "/api/tasks", nil, &list)
+	if len(list) != 0 {
+		t.Errorf("active tasks after archive/delete = %d, want 0", len(list))
+	}
+	do(t, ts, http.MethodGet, "/api/tasks?archived=true", nil, &list)
+	if len(list) != 2 {
+		t.Errorf("archived tasks = %d, want 2", len(list))
+	}
+}
+
+func TestServer_ReadEndpoints(t *testing.T) {
+	ts, _ := setupServer(t)
+	do(t, ts, http.MethodPost, "/api/tasks", map[string]any{"title": "A"}, nil)
+
+	var overview board.Overview
+	if code := do(t, ts, http.MethodGet, "/api/board", nil, &overview); code != http.StatusOK {
+		t.Fatalf("board status = %d", code)
+	}
+	if overview.BoardName != "API Test" || overview.TotalTasks != 1 {
+		t.Errorf("overview = %+v", overview)
+	}
+
+	var metrics board.Metrics
+	if code := do(t, ts, http.MethodGet, "/api/metrics", nil, &metrics); code != http.StatusOK {
+		t.Fatalf("metrics status = %d", code)
+	}
+
+	var entries []board.LogEntry
+	if code := do(t, ts, http.MethodGet, "/api/log?action=create", nil, &entries); code != http.StatusOK {
+		t.Fatalf("log status = %d", code)
+	}
+	if len(entries) != 1 || entries[0].TaskID != 1 {
+		t.Errorf("log entries = %+v", entries)
+	}
+
+	var e apiError
+	if code := do(t, ts, http.MethodGet, "/api/log?limit=x", nil, &e); code != http.StatusBadRequest {
+		t.Errorf("bad limit status = %d, want 400", code)
+	}
+}
+
+func TestServer_EventsStreamsChanges(t *testing.T) {
+	ts, cfg := setupServer(t)
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, ts.URL+"/api/events", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("Content-Type = %q", ct)
+	}
+
+	lines := make(chan string)
+	go func() {
+		sc := bufio.NewScanner(resp.Body)
+		for sc.Scan() {
+			lines <- sc.Text()
+		}
+		close(lines)
+	}()
+
+	if first := <-lines; first != ": connected" {
+		t.Fatalf("first line = %q, want connected comment", first)
+	}
+
+	// A change made outside the server (here: directly via the board package,
+	// as the CLI would) must show up on the stream.
+	if _, err := board.Create(cfg, board.CreateParams{Title: "Outside"}, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.After(5 * time.Second)
+	for {
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				t.Fatal("stream closed before a change event")
+			}
+			// Events may be split if writes straddle the debounce window;
+			// wait for the one carrying the create activity.
+			if data, found := strings.CutPrefix(line, "data: "); found && strings.Contains(data, `"action":"create"`) {
+				return
+			}
+		case <-deadline:
+			t.Fatal("no change event within 5s")
+		}
+	}
+}
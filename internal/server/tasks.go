@@ -0,0 +1,489 @@
+package server
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/antopolskiy/kanban-md/internal/board"
+	"github.com/antopolskiy/kanban-md/internal/clierr"
+	"github.com/antopolskiy/kanban-md/internal/config"
+	"github.com/antopolskiy/kanban-md/internal/date"
+	"github.com/antopolskiy/kanban-md/internal/task"
+)
+
+// handleList serves GET /api/tasks. Query parameters mirror the flags of
+// `kanban-md list`.
+func (s *Server) handleList(w http.ResponseWriter, r *http.Request) {
+	cfg, err := s.loadConfig()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	opts, err := listOptions(cfg, r.URL.Query())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	tasks, _, err := board.List(cfg, opts)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if tasks == nil {
+		tasks = []*task.Task{}
+	}
+	writeJSON(w, http.StatusOK, tasks)
+}
+
+// listOptions builds board.ListOptions from list query parameters.
+func listOptions(cfg *config.Config, q url.Values) (board.ListOptions, error) {
+	filter := board.FilterOptions{
+		Statuses:     splitList(q.Get("status")),
+		Priorities:   splitList(q.Get("priority")),
+		Assignee:     q.Get("assignee"),
+		Tag:          q.Get("tag"),
+		Search:       q.Get("search"),
+		ClaimedBy:    q.Get("claimed_by"),
+		Class:        q.Get("class"),
+		ClaimTimeout: cfg.ClaimTimeoutDuration(),
+	}
+
+	if query := q.Get("query"); query != "" {
+		var err error
+		if filter.Query, err = board.ParseQuery(query, cfg); err != nil {
+			return board.ListOptions{}, err
+		}
+	}
+
+	archived, err := boolParam(q, "archived")
+	if err != nil {
+		return board.ListOptions{}, err
+	}
+	// Same defaults as the CLI: archived tasks are hidden unless asked for.
+	if archived {
+		filter.Statuses = []string{config.ArchivedStatus}
+	} else if len(filter.Statuses) == 0 && (filter.Query == nil || !filter.Query.UsesField("status")) {
+		filter.ExcludeStatuses = []string{config.ArchivedStatus}
+	}
+
+	if filter.Unclaimed, err = boolParam(q, "unclaimed"); err != nil {
+		return board.ListOptions{}, err
+	}
+	if q.Has("blocked") {
+		blocked, blockedErr := boolParam(q, "blocked")
+		if blockedErr != nil {
+			return board.ListOptions{}, blockedErr
+		}
+		filter.Blocked = &blocked
+	}
+	if q.Has("parent") {
+		parent, parentErr := intParam(q, "parent")
+		if parentErr != nil {
+			return board.ListOptions{}, parentErr
+		}
+		filter.ParentID = &parent
+	}
+
+	opts := board.ListOptions{Filter: filter, SortBy: q.Get("sort")}
+	if opts.Reverse, err = boolParam(q, "reverse"); err != nil {
+		return board.ListOptions{}, err
+	}
+	if opts.Unblocked, err = boolParam(q, "unblocked"); err != nil {
+		return board.ListOptions{}, err
+	}
+	if opts.Limit, err = intParam(q, "limit"); err != nil {
+		return board.ListOptions{}, err
+	}
+	return opts, nil
+}
+
+// handleShow serves GET /api/tasks/{id}.
+func (s *Server) handleShow(w http.ResponseWriter, r *http.Request) {
+	cfg, err := s.loadConfig()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	id, err := taskID(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	path, err := task.FindByID(cfg.TasksPath(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	t, err := task.Read(path)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, t)
+}
+
+// createRequest is the body of POST /api/tasks.
+type createRequest struct {
+	Title     string   `json:"title"`
+	Status    string   `json:"status"`
+	Priority  string   `json:"priority"`
+	Class     string   `json:"class"`
+	Assignee  string   `json:"assignee"`
+	Tags      []string `json:"tags"`
+	Body      string   `json:"body"`
+	Due       string   `json:"due"`
+	Estimate  string   `json:"estimate"`
+	Parent    *int     `json:"parent"`
+	DependsOn []int    `json:"depends_on"`
+	Claim     string   `json:"claim"`
+}
+
+// handleCreate serves POST /api/tasks.
+func (s *Server) handleCreate(w http.ResponseWriter, r *http.Request) {
+	var req createRequest
+	if err := decodeBody(w, r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	if strings.TrimSpace(req.Title) == "" {
+		writeError(w, clierr.New(clierr.InvalidInput, "title is required"))
+		return
+	}
+
+	params := board.CreateParams{
+		Title:     req.Title,
+		Status:    req.Status,
+		Priority:  req.Priority,
+		Class:     req.Class,
+		Assignee:  req.Assignee,
+		Tags:      req.Tags,
+		Body:      req.Body,
+		Estimate:  req.Estimate,
+		Parent:    req.Parent,
+		DependsOn: req.DependsOn,
+		Claimant:  req.Claim,
+	}
+	if req.Due != "" {
+		d, err := date.Parse(req.Due)
+		if err != nil {
+			writeError(w, task.FormatDueDate(req.Due, err))
+			return
+		}
+		params.Due = &d
+	}
+
+	cfg, err := s.loadConfig()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	result, err := board.Create(cfg, params, s.now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, result.Task)
+}
+
+// handleEdit serves PATCH /api/tasks/{id}.
+func (s *Server) handleEdit(w http.ResponseWriter, r *http.Request) {
+	id, err := taskID(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var req editRequest
+	if err = decodeBody(w, r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	cfg, err := s.loadConfig()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	now := s.now()
+	result, err := board.Edit(cfg, id, req.Claim, req.IfRev, req.Release,
+		func(t *task.Task) (bool, error) {
+			return req.apply(cfg, t, now)
+		}, now)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, result.Task)
+}
+
+// handleDelete serves DELETE /api/tasks/{id}. It archives the task, like
+// `kanban-md delete`.
+func (s *Server) handleDelete(w http.ResponseWriter, r *http.Request) {
+	id, err := taskID(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	cfg, err := s.loadConfig()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	q := r.URL.Query()
+	result, err := board.Delete(cfg, id, q.Get("claim"), q.Get("if_rev"), s.now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"task":     result.Task,
+		"warnings": nonNil(result.Warnings),
+	})
+}
+
+// moveRequest is the body of POST /api/tasks/{id}/move.
+type moveRequest struct {
+	Status string `json:"status"`
+	Claim  string `json:"claim"`
+	IfRev  string `json:"if_rev"`
+}
+
+// handleMove serves POST /api/tasks/{id}/move.
+func (s *Server) handleMove(w http.ResponseWriter, r *http.Request) {
+	id, err := taskID(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var req moveRequest
+	if err = decodeBody(w, r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	if req.Status == "" {
+		writeError(w, clierr.New(clierr.InvalidInput, "status is required"))
+		return
+	}
+
+	cfg, err := s.loadConfig()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	result, err := board.Move(cfg, board.MoveParams{
+		ID:        id,
+		NewStatus: req.Status,
+		Claimant:  req.Claim,
+		SetClaim:  req.Claim != "",
+		IfRev:     req.IfRev,
+	}, s.now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"task":       result.Task,
+		"changed":    result.OldStatus != "",
+		"old_status": result.OldStatus,
+		"warnings":   nonNil(result.Warnings),
+	})
+}
+
+// handoffRequest is the body of POST /api/tasks/{id}/handoff.
+type handoffRequest struct {
+	Claim     string `json:"claim"`
+	Note      string `json:"note"`
+	Timestamp bool   `json:"timestamp"`
+	Block     string `json:"block"`
+	Release   bool   `json:"release"`
+	IfRev     string `json:"if_rev"`
+}
+
+// handleHandoff serves POST /api/tasks/{id}/handoff.
+func (s *Server) handleHandoff(w http.ResponseWriter, r *http.Request) {
+	id, err := taskID(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var req handoffRequest
+	if err = decodeBody(w, r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	cfg, err := s.loadConfig()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	t, err := board.Handoff(cfg, board.HandoffParams{
+		ID:           id,
+		Claimant:     req.Claim,
+		Release:      req.Release,
+		BlockReason:  req.Block,
+		Note:         req.Note,
+		AddTimestamp: req.Timestamp,
+		IfRev:        req.IfRev,
+	}, s.now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, t)
+}
+
+// heartbeatRequest is the body of POST /api/tasks/{id}/heartbeat.
+type heartbeatRequest struct {
+	Claim string `json:"claim"`
+}
+
+// handleHeartbeat serves POST /api/tasks/{id}/heartbeat.
+func (s *Server) handleHeartbeat(w http.ResponseWriter, r *http.Request) {
+	id, err := taskID(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var req heartbeatRequest
+	if err = decodeBody(w, r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	cfg, err := s.loadConfig()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	t, err := board.Heartbeat(cfg, id, req.Claim, s.now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, t)
+}
+
+// archiveRequest is the body of POST /api/tasks/{id}/archive.
+type archiveRequest struct {
+	Claim string `json:"claim"`
+}
+
+// handleArchive serves POST /api/tasks/{id}/archive.
+func (s *Server) handleArchive(w http.ResponseWriter, r *http.Request) {
+	id, err := taskID(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var req archiveRequest
+	if err = decodeBody(w, r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	cfg, err := s.loadConfig()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	result, err := board.Archive(cfg, id, req.Claim, s.now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"task":    result.Task,
+		"changed": result.OldStatus != "",
+	})
+}
+
+// pickRequest is the body of POST /api/pick.
+type pickRequest struct {
+	Claim  string   `json:"claim"`
+	Status string   `json:"status"`
+	Move   string   `json:"move"`
+	Tags   []string `json:"tags"`
+	Query  string   `json:"query"`
+}
+
+// handlePick serves POST /api/pick.
+func (s *Server) handlePick(w http.ResponseWriter, r *http.Request) {
+	var req pickRequest
+	if err := decodeBody(w, r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	cfg, err := s.loadConfig()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	picked, oldStatus, _, err := board.PickAndClaim(cfg, board.PickAndClaimParams{
+		Claimant:     req.Claim,
+		StatusFilter: req.Status,
+		MoveTarget:   req.Move,
+		Tags:         req.Tags,
+		Query:        req.Query,
+	}, s.now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"task":       picked,
+		"old_status": oldStatus,
+	})
+}
+
+// splitList splits a comma-separated query value, dropping empty items.
+func splitList(v string) []string {
+	if v == "" {
+		return nil
+	}
+	var out []string
+	for _, part := range strings.Split(v, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+// boolParam parses an optional boolean query parameter (absent = false).
+func boolParam(q url.Values, name string) (bool, error) {
+	v := q.Get(name)
+	if v == "" {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, clierr.Newf(clierr.InvalidInput, "invalid %s %q: must be true or false", name, v)
+	}
+	return b, nil
+}
+
+// intParam parses an optional integer query parameter (absent = 0).
+func intParam(q url.Values, name string) (int, error) {
+	v := q.Get(name)
+	if v == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, clierr.Newf(clierr.InvalidInput, "invalid %s %q: must be an integer", name, v)
+	}
+	return n, nil
+}
+
+// nonNil returns an empty slice for nil so JSON renders [] instead of null.
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
@@ -55,6 +55,28 @@ func EnsureConsistency(cfg *config.Config) (ConsistencyReport, error) {
 	return report, nil
 }
 
+// CheckConsistency returns the read warnings of the tasks and reports
+// whether EnsureConsistency has anything to repair, without changing anything.
+func CheckConsistency(cfg *config.Config) (warnings []ReadWarning, needsRepair bool, err error) {
+	tasks, warnings, err := ReadAllLenient(cfg.TasksPath())
+	if err != nil {
+		return nil, false, err
+	}
+	if len(tasks) == 0 {
+		return warnings, false, nil
+	}
+	if cfg.NextID <= maxTaskID(tasks) || len(duplicateTaskIDs(tasks)) > 0 {
+		return warnings, true, nil
+	}
+	timeout := cfg.ClaimTimeoutDuration()
+	for _, t := range tasks {
+		if needsFilenameRepair(t) || needsPermissionRepair(t, timeout) {
+			return warnings, true, nil
+		}
+	}
+	return warnings, false, nil
+}
+
 func initializeIDState(tasks []*Task, cfgNextID int) (map[int]bool, int) {
 	usedIDs := make(map[int]bool, len(tasks))
 	maxID := 0
@@ -266,23 +288,31 @@ func chooseTaskPath(tasksDir string, t *Task, currentPath string, occupied map[s
 // Errors are silently ignored (best-effort for filesystems without Unix permissions).
 func repairFilePermissions(tasks []*Task, timeout time.Duration) {
 	for _, t := range tasks {
-		if t.File == "" {
-			continue
-		}
-		claimed := isActiveClaim(t, timeout)
-		info, err := os.Stat(t.File)
-		if err != nil {
+		if !needsPermissionRepair(t, timeout) {
 			continue
 		}
-		isWritable := info.Mode().Perm()&0o200 != 0 //nolint:mnd // owner-write bit
-		if claimed && isWritable {
+		if isActiveClaim(t, timeout) {
 			_ = os.Chmod(t.File, fileModeReadOnly)
-		} else if !claimed && !isWritable {
+		} else {
 			_ = os.Chmod(t.File, fileMode)
 		}
 	}
 }
 
+// needsPermissionRepair reports whether the task file is writable when it
+// should be read-only (claimed) or the other way around.
+func needsPermissionRepair(t *Task, timeout time.Duration) bool {
+	if t.File == "" {
+		return false
+	}
+	info, err := os.Stat(t.File)
+	if err != nil {
+		return false
+	}
+	isWritable := info.Mode().Perm()&0o200 != 0 //nolint:mnd // owner-write bit
+	return isActiveClaim(t, timeout) == isWritable
+}
+
 // isActiveClaim returns true if the task has a non-expired claim.
 // Unlike CheckClaim, this is a pure read-only check that does not mutate the task.
 func isActiveClaim(t *Task, timeout time.Duration) bool {
@@ -30,6 +30,36 @@ func TestEnsureConsistency_RepairsDuplicateMismatchAndNextID(t *testing.T) {
 	}
 }
 
+func TestCheckConsistency_ReportsRepairsWithoutMakingThem(t *testing.T) {
+	cfg := setupConsistencyFixture(t)
+	before, err := os.ReadDir(cfg.TasksPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	warnings, needsRepair, err := CheckConsistency(cfg)
+	if err != nil {
+		t.Fatalf("CheckConsistency error: %v", err)
+	}
+	if !needsRepair || len(warnings) != 1 {
+		t.Fatalf("CheckConsistency = %d warnings, %v; want 1 warning and a repair needed", len(warnings), needsRepair)
+	}
+	after, err := os.ReadDir(cfg.TasksPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(after) != len(before) || cfg.NextID != 2 {
+		t.Fatalf("CheckConsistency changed the board: %d files (was %d), next_id %d", len(after), len(before), cfg.NextID)
+	}
+
+	if _, err := EnsureConsistency(cfg); err != nil {
+		t.Fatalf("EnsureConsistency error: %v", err)
+	}
+	if _, needsRepair, err = CheckConsistency(cfg); err != nil || needsRepair {
+		t.Fatalf("CheckConsistency after repair = %v, %v; want nothing to repair", needsRepair, err)
+	}
+}
+
 func setupConsistencyFixture(t *testing.T) *config.Config {
 	t.Helper()
 
@@ -0,0 +1,151 @@
+package task
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CustomValue returns the value of a custom frontmatter field.
+func (t *Task) CustomValue(name string) (any, bool) {
+	v, ok := t.Custom[name]
+	return v, ok
+}
+
+// SetCustom sets a custom frontmatter field.
+func (t *Task) SetCustom(name string, v any) {
+	if t.Custom == nil {
+		t.Custom = make(map[string]any)
+	}
+	t.Custom[name] = v
+}
+
+// UnsetCustom removes a custom frontmatter field. It reports whether the
+// field was set.
+func (t *Task) UnsetCustom(name string) bool {
+	if _, ok := t.Custom[name]; !ok {
+		return false
+	}
+	delete(t.Custom, name)
+	if len(t.Custom) == 0 {
+		t.Custom = nil
+	}
+	return true
+}
+
+// CustomKeys returns the names of the task's custom fields, sorted.
+func (t *Task) CustomKeys() []string {
+	keys := make([]string, 0, len(t.Custom))
+	for k := range t.Custom {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// CustomStrings returns a custom field's value as strings: one element for a
+// scalar, one per item for a list, and nil when the field is unset.
+func (t *Task) CustomStrings(name string) []string {
+	v, ok := t.Custom[name]
+	if !ok || v == nil {
+		return nil
+	}
+	if items, ok := v.([]any); ok {
+		strs := make([]string, len(items))
+		for i, item := range items {
+			strs[i] = FormatCustomValue(item)
+		}
+		return strs
+	}
+	if items, ok := v.([]string); ok {
+		return items
+	}
+	return []string{FormatCustomValue(v)}
+}
+
+// CustomInt returns a custom field's value as an integer, accepting the
+// numeric types produced by YAML and JSON decoding as well as numeric strings.
+func (t *Task) CustomInt(name string) (int, bool) {
+	switch v := t.Custom[name].(type) {
+	case int:
+		return v, true
+	case int64:
+		return int(v), true
+	case uint64:
+		return int(v), true //nolint:gosec // frontmatter integers are small
+	case float64:
+		return int(v), v == float64(int(v))
+	case string:
+		n, err := strconv.Atoi(v)
+		return n, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// FormatCustom returns the named custom field formatted for display, or ""
+// when it is not set.
+func (t *Task) FormatCustom(name string) string {
+	return FormatCustomValue(t.Custom[name])
+}
+
+// FormatCustomValue renders a custom field value for display: lists are
+// comma-separated, nested values are shown as Go values.
+func FormatCustomValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []string:
+		return strings.Join(v, ", ")
+	case []any:
+		strs := make([]string, len(v))
+		for i, item := range v {
+			strs[i] = FormatCustomValue(item)
+		}
+		return strings.Join(strs, ", ")
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// normalizeCustom converts decoded YAML values that would not survive a
+// rewrite or JSON encoding unchanged: timestamps (YAML decodes bare dates as
+// time.Time) become date or RFC 3339 strings, and mappings with non-string
+// keys get string keys.
+func normalizeCustom(m map[string]any) {
+	for k, v := range m {
+		m[k] = normalizeCustomValue(v)
+	}
+}
+
+func normalizeCustomValue(v any) any {
+	switch v := v.(type) {
+	case time.Time:
+		if v.Equal(time.Date(v.Year(), v.Month(), v.Day(), 0, 0, 0, 0, v.Location())) {
+			return v.Format(time.DateOnly)
+		}
+		return v.Format(time.RFC3339)
+	case []any:
+		for i := range v {
+			v[i] = normalizeCustomValue(v[i])
+		}
+		return v
+	case map[string]any:
+		normalizeCustom(v)
+		return v
+	case map[any]any:
+		m := make(map[string]any, len(v))
+		for k, item := range v {
+			m[fmt.Sprint(k)] = normalizeCustomValue(item)
+		}
+		return m
+	default:
+		return v
+	}
+}
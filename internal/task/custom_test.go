@@ -0,0 +1,126 @@
+package task
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/antopolskiy/kanban-md/internal/config"
+)
+
+func TestRead_PreservesUnknownFrontmatter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "001-custom.md")
+	content := `---
+id: 1
+title: Custom
+status: todo
+priority: medium
+created: 2026-02-01T10:00:00Z
+updated: 2026-02-01T10:00:00Z
+severity: s1
+points: 3
+reviewed: 2026-02-03
+external:
+    system: jira
+    key: ABC-12
+labels:
+    - a
+    - b
+---
+
+Body
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tk, err := Read(path)
+	if err != nil {
+		t.Fatalf("Read() error: %v", err)
+	}
+	if tk.Custom["severity"] != "s1" || tk.Custom["points"] != 3 {
+		t.Errorf("Custom = %#v", tk.Custom)
+	}
+	if tk.Custom["reviewed"] != "2026-02-03" {
+		t.Errorf("reviewed = %#v, want date string", tk.Custom["reviewed"])
+	}
+	if got := tk.CustomStrings("labels"); !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Errorf("CustomStrings(labels) = %v", got)
+	}
+	if _, err := json.Marshal(tk); err != nil {
+		t.Errorf("json.Marshal: %v", err)
+	}
+
+	// Rewriting keeps every unknown key.
+	tk.Title = "Renamed"
+	if err := Write(path, tk); err != nil {
+		t.Fatal(err)
+	}
+	again, err := Read(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(again.Custom, tk.Custom) {
+		t.Errorf("after rewrite Custom = %#v, want %#v", again.Custom, tk.Custom)
+	}
+	data, _ := os.ReadFile(path) //nolint:gosec // test file path
+	for _, want := range []string{"severity: s1", "points: 3", "system: jira", "key: ABC-12"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("rewritten file missing %q:\n%s", want, data)
+		}
+	}
+}
+
+func TestSetAndUnsetCustom(t *testing.T) {
+	tk := &Task{}
+	tk.SetCustom("points", 5)
+	tk.SetCustom("area", "ui")
+	if got := tk.CustomKeys(); !reflect.DeepEqual(got, []string{"area", "points"}) {
+		t.Errorf("CustomKeys() = %v", got)
+	}
+	if n, ok := tk.CustomInt("points"); !ok || n != 5 {
+		t.Errorf("CustomInt(points) = %d, %v", n, ok)
+	}
+	if !tk.UnsetCustom("points") || tk.UnsetCustom("points") {
+		t.Error("UnsetCustom(points) should succeed once")
+	}
+	tk.UnsetCustom("area")
+	if tk.Custom != nil {
+		t.Errorf("Custom = %#v, want nil when empty", tk.Custom)
+	}
+}
+
+func TestCustomInt_DecodedTypes(t *testing.T) {
+	tk := &Task{Custom: map[string]any{"f": 3.0, "s": "7", "x": 2.5, "w": "many"}}
+	for name, want := range map[string]int{"f": 3, "s": 7} {
+		if n, ok := tk.CustomInt(name); !ok || n != want {
+			t.Errorf("CustomInt(%s) = %d, %v; want %d", name, n, ok, want)
+		}
+	}
+	for _, name := range []string{"x", "w", "missing"} {
+		if _, ok := tk.CustomInt(name); ok {
+			t.Errorf("CustomInt(%s) ok, want not an int", name)
+		}
+	}
+}
+
+// Custom fields may not shadow built-in ones; the config's reserved list must
+// cover every frontmatter key of Task.
+func TestReservedFieldNamesCoverTaskFields(t *testing.T) {
+	rt := reflect.TypeFor[Task]()
+	for i := range rt.NumField() {
+		f := rt.Field(i)
+		for _, tag := range []string{f.Tag.Get("yaml"), f.Tag.Get("json")} {
+			name, _, _ := strings.Cut(tag, ",")
+			if name == "" || name == "-" {
+				continue
+			}
+			if !config.IsReservedFieldName(name) {
+				t.Errorf("task field %q is not reserved in config", name)
+			}
+		}
+	}
+}
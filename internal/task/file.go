@@ -2,6 +2,8 @@ package task
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"os"
@@ -9,6 +11,8 @@ import (
 	"strings"
 
 	"go.yaml.in/yaml/v3"
+
+	"github.com/antopolskiy/kanban-md/internal/atomicfile"
 )
 
 const fileMode = 0o600
@@ -16,6 +20,9 @@ const fileMode = 0o600
 // fileModeReadOnly is used for claimed task files to prevent external modification.
 const fileModeReadOnly = 0o444
 
+// revLen is the number of hex characters kept from the content hash.
+const revLen = 12
+
 // Read parses a task file and returns the Task with body populated.
 func Read(path string) (*Task, error) {
 	data, err := os.ReadFile(path) //nolint:gosec // task path from trusted source
@@ -35,14 +42,17 @@ func Read(path string) (*Task, error) {
 	if err := validateRequiredFields(&t); err != nil {
 		return nil, fmt.Errorf("parsing frontmatter in %s: %w", path, err)
 	}
+	normalizeCustom(t.Custom)
 
 	t.Body = body
 	t.File = path
+	t.Rev = revision(data)
 
 	return &t, nil
 }
 
-// Write serializes a task to a markdown file with YAML frontmatter.
+// Write serializes a task to a markdown file with YAML frontmatter. The file
+// is replaced atomically.
 func Write(path string, t *Task) error {
 	fm, err := yaml.Marshal(t)
 	if err != nil {
@@ -61,21 +71,44 @@ func Write(path string, t *Task) error {
 		}
 	}
 
-	// If the file exists and is read-only (claimed), make it writable before writing.
+	// If the file exists and is read-only (claimed), make it writable before
+	// replacing it; our own writes are allowed through.
 	unlockForWrite(path)
 
-	if err := os.WriteFile(path, buf.Bytes(), fileMode); err != nil {
-		return err
-	}
-
-	// If the task is claimed, lock the file to prevent external modifications.
+	// If the task is claimed, the new file is created read-only to prevent
+	// external modifications.
+	perm := os.FileMode(fileMode)
 	if t.ClaimedBy != "" {
-		lockFile(path)
+		perm = fileModeReadOnly
 	}
 
+	// Write to a temp file and rename it into place so concurrent readers
+	// (the TUI watcher, other agents) never observe a truncated task file.
+	if err := atomicfile.Write(path, buf.Bytes(), perm); err != nil {
+		return err
+	}
+	t.Rev = revision(buf.Bytes())
 	return nil
 }
 
+// revision returns a short content hash identifying one version of a task
+// file. The claimed_at line of the frontmatter is left out: claim renewals
+// (heartbeat, run --keepalive) rewrite it without changing the task, and must
+// not invalidate the revision its holder passes to --if-rev.
+func revision(data []byte) string {
+	h := sha256.New()
+	inFrontmatter := false
+	for i, line := range bytes.SplitAfter(data, []byte("\n")) {
+		if string(line) == "---\n" && (i == 0 || inFrontmatter) {
+			inFrontmatter = i == 0
+		} else if inFrontmatter && bytes.HasPrefix(line, []byte("claimed_at:")) {
+			continue
+		}
+		h.Write(line)
+	}
+	return hex.EncodeToString(h.Sum(nil))[:revLen]
+}
+
 // splitFrontmatter splits a markdown file into YAML frontmatter and body.
 // The file must start with "---\n". Returns frontmatter bytes and body string.
 func splitFrontmatter(data []byte) ([]byte, string, error) {
@@ -136,12 +169,6 @@ func unlockForWrite(path string) {
 	_ = os.Chmod(path, fileMode)
 }
 
-// lockFile makes a file read-only to prevent external modifications.
-// Errors are silently ignored (best-effort for filesystems without Unix permissions).
-func lockFile(path string) {
-	_ = os.Chmod(path, fileModeReadOnly)
-}
-
 func validateRequiredFields(t *Task) error {
 	if t.ID < 1 {
 		return errors.New("missing required field: id")
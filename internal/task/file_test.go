@@ -265,6 +265,105 @@ func TestWriteAndRename_ClaimedPreservesProtection(t *testing.T) {
 	}
 }
 
+func TestWriteAndRead_Rev(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "001-rev.md")
+
+	tk := newTestTask("Rev")
+	if err := Write(path, tk); err != nil {
+		t.Fatalf("Write() error: %v", err)
+	}
+	if tk.Rev == "" {
+		t.Fatal("Write() should set Rev")
+	}
+
+	got, err := Read(path)
+	if err != nil {
+		t.Fatalf("Read() error: %v", err)
+	}
+	if got.Rev != tk.Rev {
+		t.Errorf("Read() Rev = %q, want %q", got.Rev, tk.Rev)
+	}
+
+	got.Priority = "high"
+	if err := Write(path, got); err != nil {
+		t.Fatalf("Write() error: %v", err)
+	}
+	if got.Rev == tk.Rev {
+		t.Error("Rev should change when the file content changes")
+	}
+}
+
+func TestRevision_IgnoresClaimedAt(t *testing.T) {
+	base := "---\nid: 1\ntitle: A\nclaimed_by: bot\nclaimed_at: 2026-03-01T10:00:00Z\n---\n\nclaimed_at: in the body\n"
+	renewed := strings.Replace(base, "10:00:00Z", "11:00:00Z", 1)
+	if revision([]byte(base)) != revision([]byte(renewed)) {
+		t.Error("a new claimed_at should not change the revision")
+	}
+	bodyChanged := strings.Replace(base, "in the body", "elsewhere", 1)
+	if revision([]byte(base)) == revision([]byte(bodyChanged)) {
+		t.Error("body lines that look like claimed_at must still count")
+	}
+	released := strings.Replace(base, "claimed_by: bot\n", "", 1)
+	if revision([]byte(base)) == revision([]byte(released)) {
+		t.Error("a claim change must change the revision")
+	}
+}
+
+func TestWrite_ConcurrentReadsNeverSeePartialFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "001-atomic.md")
+
+	tk := newTestTask("Atomic")
+	tk.Body = strings.Repeat("body line\n", 500)
+	if err := Write(path, tk); err != nil {
+		t.Fatalf("Write() error: %v", err)
+	}
+
+	done := make(chan struct{})
+	errs := make(chan error, 1)
+	go func() {
+		defer close(errs)
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			if _, err := Read(path); err != nil {
+				errs <- err
+				return
+			}
+		}
+	}()
+
+	const rewrites = 200
+	for i := range rewrites {
+		tk.Priority = []string{"low", "medium", "high"}[i%3]
+		if err := Write(path, tk); err != nil {
+			close(done)
+			t.Fatalf("Write() #%d error: %v", i, err)
+		}
+	}
+	close(done)
+
+	if err := <-errs; err != nil {
+		t.Fatalf("concurrent Read() saw a partial file: %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		names := make([]string, 0, len(entries))
+		for _, e := range entries {
+			names = append(names, e.Name())
+		}
+		t.Errorf("expected only the task file after writes, got %v", names)
+	}
+}
+
 func TestReadMissingRequiredFields(t *testing.T) {
 	dir := t.TempDir()
 	path := filepath.Join(dir, "001-missing-fields.md")
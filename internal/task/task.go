@@ -28,10 +28,20 @@ type Task struct {
 	ClaimedBy   string     `yaml:"claimed_by,omitempty" json:"claimed_by,omitempty"`
 	ClaimedAt   *time.Time `yaml:"claimed_at,omitempty" json:"claimed_at,omitempty"`
 	Class       string     `yaml:"class,omitempty" json:"class,omitempty"`
+	ExternalID  string     `yaml:"external_id,omitempty" json:"external_id,omitempty"` // e.g. github:owner/repo#12
+
+	// Custom holds frontmatter keys that are not built-in fields: values of
+	// fields declared in the config's fields schema and any other keys added
+	// by people or tools, which are preserved when the task is rewritten.
+	Custom map[string]any `yaml:",inline" json:"custom,omitempty"`
 
 	// Body is the markdown content below the frontmatter (not in YAML).
 	Body string `yaml:"-" json:"body,omitempty"`
 
 	// File is the path to the task file (not in YAML).
 	File string `yaml:"-" json:"file,omitempty"`
+
+	// Rev is a hash of the task file's contents as last read or written
+	// (not in YAML). It changes whenever the file changes.
+	Rev string `yaml:"-" json:"rev,omitempty"`
 }
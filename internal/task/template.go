@@ -0,0 +1,60 @@
+package task
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// TemplateExt is the file extension of task templates.
+const TemplateExt = ".md"
+
+// Template is a task template: a markdown file whose frontmatter holds task
+// defaults and whose body is the skeleton of the task body. Title and body
+// may contain {title} (the title given on create) and the date placeholders
+// {date}, {year}, {month}, {week} and {day}.
+type Template struct {
+	Name        string         `yaml:"-" json:"name"` // file name without .md
+	Description string         `yaml:"description,omitempty" json:"description,omitempty"`
+	Title       string         `yaml:"title,omitempty" json:"title,omitempty"` // e.g. "Bug: {title}"
+	Status      string         `yaml:"status,omitempty" json:"status,omitempty"`
+	Priority    string         `yaml:"priority,omitempty" json:"priority,omitempty"`
+	Class       string         `yaml:"class,omitempty" json:"class,omitempty"`
+	Assignee    string         `yaml:"assignee,omitempty" json:"assignee,omitempty"`
+	Tags        []string       `yaml:"tags,omitempty" json:"tags,omitempty"`
+	Estimate    string         `yaml:"estimate,omitempty" json:"estimate,omitempty"`
+	DueIn       string         `yaml:"due_in,omitempty" json:"due_in,omitempty"` // due date relative to creation, e.g. 3d
+	Fields      map[string]any `yaml:"fields,omitempty" json:"fields,omitempty"` // custom field values
+	Body        string         `yaml:"-" json:"body,omitempty"`
+	File        string         `yaml:"-" json:"file,omitempty"`
+}
+
+// ReadTemplate parses a template file. A file without frontmatter is a
+// template with only a body.
+func ReadTemplate(path string) (*Template, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // template path from trusted kanban dir
+	if err != nil {
+		return nil, fmt.Errorf("reading template: %w", err)
+	}
+
+	var tmpl Template
+	if strings.HasPrefix(string(data), "---\n") {
+		fm, body, err := splitFrontmatter(data)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", path, err)
+		}
+		if err := yaml.Unmarshal(fm, &tmpl); err != nil {
+			return nil, fmt.Errorf("parsing frontmatter in %s: %w", path, err)
+		}
+		normalizeCustom(tmpl.Fields)
+		tmpl.Body = body
+	} else {
+		tmpl.Body = strings.TrimLeft(string(data), "\n")
+	}
+	tmpl.Name = strings.TrimSuffix(filepath.Base(path), TemplateExt)
+	tmpl.File = path
+	return &tmpl, nil
+}
@@ -1,6 +1,8 @@
 package task
 
 import (
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/antopolskiy/kanban-md/internal/clierr"
@@ -55,6 +57,18 @@ func ValidateSelfReference(id int) *clierr.Error {
 		WithDetails(map[string]any{"id": id})
 }
 
+// ValidateDependencyCycle returns a CLIError for a dependency (or parent)
+// reference that would close a cycle. cycle lists the task IDs along it,
+// starting and ending with the same task.
+func ValidateDependencyCycle(cycle []int) *clierr.Error {
+	parts := make([]string, len(cycle))
+	for i, id := range cycle {
+		parts[i] = "#" + strconv.Itoa(id)
+	}
+	return clierr.Newf(clierr.DependencyCycle, "dependency cycle: %s", strings.Join(parts, " -> ")).
+		WithDetails(map[string]any{"cycle": cycle})
+}
+
 // ValidateDependencyNotFound returns a CLIError for missing dependency.
 func ValidateDependencyNotFound(depID int) *clierr.Error {
 	return clierr.Newf(clierr.DependencyNotFound, "dependency task #%d not found", depID).
@@ -118,6 +132,22 @@ func ValidateTaskClaimed(id int, claimedBy, remaining string) *clierr.Error {
 		})
 }
 
+// ValidateClaimLost returns a CLIError when an agent renews a claim it no
+// longer holds: the claim was released, or another agent took it over.
+func ValidateClaimLost(id int, claimant, claimedBy string) *clierr.Error {
+	details := map[string]any{
+		"id":         id,
+		"claimant":   claimant,
+		"claimed_by": claimedBy,
+	}
+	if claimedBy == "" {
+		return clierr.Newf(clierr.ClaimLost, "task #%d is no longer claimed by %q", id, claimant).
+			WithDetails(details)
+	}
+	return clierr.Newf(clierr.ClaimLost, "task #%d is now claimed by %q, not %q", id, claimedBy, claimant).
+		WithDetails(details)
+}
+
 // ValidateClassWIPExceeded returns a CLIError for class-level WIP limit violations.
 func ValidateClassWIPExceeded(class string, limit, current int) *clierr.Error {
 	return clierr.Newf(clierr.ClassWIPExceeded,
@@ -151,6 +181,16 @@ func CheckClaim(t *Task, claimant string, timeout time.Duration) error {
 	return ValidateTaskClaimed(t.ID, t.ClaimedBy, remaining)
 }
 
+// CheckRev verifies that the task has not changed since the caller read it.
+// An empty want skips the check. Otherwise, returns a Conflict error carrying
+// the current revision so the caller can re-read and retry.
+func CheckRev(t *Task, want string) error {
+	if want == "" || want == t.Rev {
+		return nil
+	}
+	return ValidateConflict(t.ID, want, t.Rev)
+}
+
 // ValidateDependencyIDs checks that all dependency IDs exist and none are self-referencing.
 func ValidateDependencyIDs(tasksDir string, selfID int, ids []int) error {
 	for _, depID := range ids {
@@ -168,3 +208,15 @@ func ValidateDependencyIDs(tasksDir string, selfID int, ids []int) error {
 func FormatDueDate(input string, err error) *clierr.Error {
 	return ValidateDate("due", input, err)
 }
+
+// ValidateConflict returns a CLIError when a task's revision no longer matches
+// the one the caller expected.
+func ValidateConflict(id int, expected, current string) *clierr.Error {
+	return clierr.Newf(clierr.Conflict,
+		"task #%d has changed since revision %s (now %s); re-read it and retry", id, expected, current).
+		WithDetails(map[string]any{
+			"id":       id,
+			"expected": expected,
+			"current":  current,
+		})
+}
@@ -330,3 +330,26 @@ func TestValidateTaskClaimedMessageSuggestsReclaimFlag(t *testing.T) {
 		t.Errorf("error message should not suggest 'edit --release' as primary action, got: %q", msg)
 	}
 }
+
+func TestCheckRev(t *testing.T) {
+	tk := &Task{ID: 3, Rev: "abc123"}
+
+	if err := CheckRev(tk, ""); err != nil {
+		t.Errorf("empty want should skip the check, got %v", err)
+	}
+	if err := CheckRev(tk, "abc123"); err != nil {
+		t.Errorf("matching rev should pass, got %v", err)
+	}
+
+	err := CheckRev(tk, "stale0")
+	var cliErr *clierr.Error
+	if !errors.As(err, &cliErr) {
+		t.Fatalf("expected clierr.Error, got %T: %v", err, err)
+	}
+	if cliErr.Code != clierr.Conflict {
+		t.Errorf("code = %q, want %q", cliErr.Code, clierr.Conflict)
+	}
+	if cliErr.Details["current"] != "abc123" || cliErr.Details["expected"] != "stale0" {
+		t.Errorf("details = %v, want expected=stale0 current=abc123", cliErr.Details)
+	}
+}
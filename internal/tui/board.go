@@ -2,10 +2,11 @@
 package tui
 
 import (
+	"errors"
 	"fmt"
 	"os"
-	"path/filepath"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -19,7 +20,6 @@ import (
 
 	"github.com/antopolskiy/kanban-md/internal/board"
 	"github.com/antopolskiy/kanban-md/internal/config"
-	"github.com/antopolskiy/kanban-md/internal/filelock"
 	"github.com/antopolskiy/kanban-md/internal/task"
 )
 
@@ -62,12 +62,14 @@ const (
 	createInputOverhead  = 6                // dialogPadX*2 + border(2)
 	createBodyInputLines = 6                // fixed visible lines in create textarea
 
-	// Create wizard steps.
-	stepTitle    = 0
-	stepBody     = 1
-	stepPriority = 2
-	stepTags     = 3
-	stepCount    = 4
+	// Create wizard steps. The template step is only shown when creating a
+	// task on a board with templates.
+	stepTemplate = 0
+	stepTitle    = 1
+	stepBody     = 2
+	stepPriority = 3
+	stepTags     = 4
+	stepCount    = 5
 )
 
 // Board is the top-level bubbletea model.
@@ -95,6 +97,10 @@ type Board struct {
 	layout           layoutSnapshot
 	pointer          pointerState
 
+	// statusSince holds when each task entered its current status, from the
+	// activity log; card ages count from it.
+	statusSince map[int]time.Time
+
 	// Sort state.
 	sortField   string // one of sortFields
 	sortReverse bool   // true = descending order
@@ -104,9 +110,13 @@ type Board struct {
 	searchInput textinput.Model // input shown while typing the query
 	searchReady bool
 
+	// Saved view from config.yml, cycled with "v"; empty = no view.
+	activeView string
+
 	// Detail view.
 	detailTask      *task.Task
 	detailScrollOff int
+	allTasks        []*task.Task // every task read, including hidden ones, for subtask roll-ups
 
 	// Move view.
 	moveStatuses []string
@@ -118,8 +128,11 @@ type Board struct {
 
 	// Create wizard.
 	createStatus      string // column where task will be created
-	createStep        int    // current wizard step (0=title, 1=body, 2=priority, 3=tags)
+	createStep        int    // current wizard step (stepTemplate..stepTags)
 	createPriority    int    // index into cfg.Priorities
+	createTemplates   []*task.Template
+	createTemplate    int // 0 = no template, i = createTemplates[i-1]
+	createApplied     int // template whose defaults were last filled in
 	createIsEdit      bool
 	createEditID      int
 	createInputsReady bool
@@ -236,6 +249,9 @@ func (b *Board) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		b.invalidatePointerState()
 		b.err = msg.err
 		return b, nil
+	case HookFailedMsg:
+		b.err = msg.Err
+		return b, nil
 	}
 	return b, nil
 }
@@ -330,7 +346,7 @@ func (b *Board) handleBoardKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 // handleBoardActionKey handles the less-frequent board action keys (create,
-// edit, move, delete, refresh, sort, search, debug). Split out from
+// edit, move, delete, refresh, sort, search, view, debug). Split out from
 // handleBoardKey to keep each dispatch's cyclomatic complexity manageable.
 func (b *Board) handleBoardActionKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
@@ -351,6 +367,8 @@ func (b *Board) handleBoardActionKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		b.reloadKeepingSelection()
 	case "/":
 		b.handleSearchStart()
+	case "v":
+		b.cycleView()
 	case "ctrl+d":
 		b.view = viewDebug
 	}
@@ -371,6 +389,27 @@ func (b *Board) cycleSortField() {
 	b.reloadKeepingSelection()
 }
 
+// cycleView applies the next saved view from config (wrapping back to no
+// view after the last) and adopts the view's sort order when it defines one.
+func (b *Board) cycleView() {
+	names := b.cfg.ViewNames()
+	if len(names) == 0 {
+		b.err = errors.New("no saved views; add one with: kanban-md config set views.NAME ...")
+		return
+	}
+
+	next := ""
+	if idx := slices.Index(names, b.activeView); idx+1 < len(names) {
+		next = names[idx+1]
+	}
+	if v := b.cfg.ViewByName(next); v != nil && v.Sort != "" {
+		b.sortField = v.Sort
+		b.sortReverse = v.Reverse
+	}
+	b.activeView = next
+	b.reloadKeepingSelection()
+}
+
 // reloadKeepingSelection reloads tasks (re-applying sort/filter) and keeps the
 // cursor on the previously selected task when it is still visible.
 func (b *Board) reloadKeepingSelection() {
@@ -433,6 +472,19 @@ func (b *Board) handleSearchKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return b, cmd
 }
 
+// searchFilter returns the predicate for the active search query. A query
+// using the board query language's syntax (a field term such as "tag:api",
+// a comparison, parentheses or quotes) is evaluated as a board query; while
+// it is incomplete or invalid, and for any other query, matchesFilter applies.
+func searchFilter(query string, cfg *config.Config) func(*task.Task) bool {
+	if !strings.HasPrefix(query, "#") && strings.ContainsAny(query, ":=<>()\"") {
+		if q, err := board.ParseQuery(query, cfg); err == nil {
+			return q.Match
+		}
+	}
+	return func(t *task.Task) bool { return matchesFilter(t, query) }
+}
+
 // matchesFilter reports whether task t matches the active search query.
 //
 // A query starting with "#" searches ticket IDs: while typing, the digits
@@ -525,8 +577,14 @@ func (b *Board) handleCreateStart() {
 	b.createIsEdit = false
 	b.createEditID = 0
 	b.createStatus = col.status
-	b.createStep = stepTitle
 	b.createPriority = b.defaultPriorityIndex()
+	b.createTemplate, b.createApplied = 0, 0
+	templates, err := board.ListTemplates(b.cfg)
+	if err != nil {
+		b.err = err
+	}
+	b.createTemplates = templates
+	b.createStep = b.firstCreateStep()
 	b.createTitleInput.SetValue("")
 	b.createBodyInput.SetValue("")
 	b.createTagsInput.SetValue("")
@@ -534,6 +592,15 @@ func (b *Board) handleCreateStart() {
 	b.focusCreateField()
 }
 
+// firstCreateStep is the first wizard step: the template choice when creating
+// a task on a board with templates, the title otherwise.
+func (b *Board) firstCreateStep() int {
+	if !b.createIsEdit && len(b.createTemplates) > 0 {
+		return stepTemplate
+	}
+	return stepTitle
+}
+
 func (b *Board) handleEditStart() {
 	t := b.selectedTask()
 	if t == nil {
@@ -589,33 +656,28 @@ func (b *Board) handleCreateKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return b, nil
 	}
 
-	// Enter always submits the wizard (from any step).
-	if msg.Type == tea.KeyEnter {
+	// Enter submits the wizard from any step but the template choice, where
+	// it moves on to the title.
+	if msg.Type == tea.KeyEnter && b.createStep != stepTemplate {
 		if b.createIsEdit {
 			return b.executeEdit()
 		}
 		return b.executeCreate()
 	}
 
-	// Tab advances to next step.
-	if msg.String() == "tab" {
-		if b.createStep < stepCount-1 {
-			b.createStep++
-		}
-		b.focusCreateField()
+	// Tab advances to next step; Shift+Tab goes back to previous step.
+	switch msg.String() {
+	case "tab", "enter":
+		b.moveCreateStep(1)
 		return b, nil
-	}
-
-	// Shift+Tab goes back to previous step.
-	if msg.String() == keyShiftTab {
-		if b.createStep > 0 {
-			b.createStep--
-		}
-		b.focusCreateField()
+	case keyShiftTab:
+		b.moveCreateStep(-1)
 		return b, nil
 	}
 
 	switch b.createStep {
+	case stepTemplate:
+		return b.handleCreateTemplate(msg)
 	case stepTitle:
 		return b.handleCreateTitle(msg)
 	case stepBody:
@@ -628,6 +690,58 @@ func (b *Board) handleCreateKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return b, nil
 }
 
+// moveCreateStep moves the wizard delta steps, staying within its steps.
+// Leaving the template step fills in the chosen template's defaults.
+func (b *Board) moveCreateStep(delta int) {
+	if b.createStep == stepTemplate {
+		b.applyCreateTemplate()
+	}
+	b.createStep = min(max(b.createStep+delta, b.firstCreateStep()), stepCount-1)
+	b.focusCreateField()
+}
+
+func (b *Board) handleCreateTemplate(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "j", keyDown:
+		if b.createTemplate < len(b.createTemplates) {
+			b.createTemplate++
+		}
+	case "k", keyUp:
+		if b.createTemplate > 0 {
+			b.createTemplate--
+		}
+	}
+	return b, nil
+}
+
+// selectedCreateTemplate returns the template chosen in the wizard, or nil.
+func (b *Board) selectedCreateTemplate() *task.Template {
+	if b.createTemplate < 1 || b.createTemplate > len(b.createTemplates) {
+		return nil
+	}
+	return b.createTemplates[b.createTemplate-1]
+}
+
+// applyCreateTemplate fills the body, priority and tags inputs from the
+// chosen template, unless they were already filled from it.
+func (b *Board) applyCreateTemplate() {
+	if b.createTemplate == b.createApplied {
+		return
+	}
+	b.createApplied = b.createTemplate
+	body, tags, priority := "", "", b.defaultPriorityIndex()
+	if tmpl := b.selectedCreateTemplate(); tmpl != nil {
+		body = strings.TrimSuffix(tmpl.Body, "\n")
+		tags = strings.Join(tmpl.Tags, ",")
+		if i := b.cfg.PriorityIndex(tmpl.Priority); i >= 0 {
+			priority = i
+		}
+	}
+	b.createBodyInput.SetValue(body)
+	b.createTagsInput.SetValue(tags)
+	b.createPriority = priority
+}
+
 func (b *Board) handleCreateTitle(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	cmd := b.applyCreateTextInput(msg, &b.createTitleInput)
 	if cmd != nil {
@@ -732,28 +846,8 @@ func (b *Board) executeCreate() (tea.Model, tea.Cmd) {
 	priority := b.selectedCreatePriority()
 	tags := parseTagsCSV(b.createTagsInput.Value())
 
-	// Acquire exclusive lock to prevent concurrent creates from
-	// reading the same next_id and generating duplicate task IDs.
-	unlock, err := filelock.Lock(filepath.Join(b.cfg.Dir(), ".lock"))
-	if err != nil {
-		b.err = fmt.Errorf("acquiring lock: %w", err)
-		b.resetCreateState()
-		b.view = viewBoard
-		return b, nil
-	}
-	defer unlock() //nolint:errcheck // best-effort unlock
-
-	// Reload config from disk to get the current NextID, since another
-	// process may have created tasks while the TUI was running.
-	freshCfg, err := config.Load(b.cfg.Dir())
-	if err != nil {
-		b.err = fmt.Errorf("reloading config: %w", err)
-		b.resetCreateState()
-		b.view = viewBoard
-		return b, nil
-	}
-	b.cfg.NextID = freshCfg.NextID
-
+	// board.Create takes the board lock and reloads next_id from disk, since
+	// another process may have created tasks while the TUI was running.
 	params := board.CreateParams{
 		Title:    title,
 		Status:   b.createStatus,
@@ -762,7 +856,18 @@ func (b *Board) executeCreate() (tea.Model, tea.Cmd) {
 		Body:     body,
 	}
 
-	result, createErr := board.Create(b.cfg, params, b.now())
+	// The template's body, tags and priority are already in the inputs; the
+	// rest of its defaults and its placeholders are applied here.
+	var createErr error
+	if tmpl := b.selectedCreateTemplate(); tmpl != nil {
+		rest := *tmpl
+		rest.Body, rest.Tags, params.Body = params.Body, nil, ""
+		params, createErr = board.ApplyTemplate(b.cfg, &rest, params, b.now())
+	}
+	var result *board.CreateResult
+	if createErr == nil {
+		result, createErr = board.Create(b.cfg, params, b.now())
+	}
 
 	b.resetCreateState()
 	b.view = viewBoard
@@ -789,7 +894,7 @@ func (b *Board) executeEdit() (tea.Model, tea.Cmd) {
 	tags := parseTagsCSV(b.createTagsInput.Value())
 	editID := b.createEditID
 
-	_, editErr := board.Edit(b.cfg, editID, tuiClaimant(), false,
+	_, editErr := board.Edit(b.cfg, editID, tuiClaimant(), "", false,
 		func(t *task.Task) (bool, error) {
 			t.Title = title
 			t.Body = body
@@ -916,6 +1021,21 @@ func (b *Board) handleDebugKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return b, nil
 }
 
+// loadStatusSince records when each task entered its current status. Without
+// a readable activity log, card ages fall back to the last update.
+func (b *Board) loadStatusSince(tasks []*task.Task) {
+	entries, err := board.ReadLog(b.cfg.Dir(), board.LogFilterOptions{})
+	if err != nil {
+		b.statusSince = nil
+		return
+	}
+	timelines := board.Timelines(tasks, board.Transitions(entries), b.now())
+	b.statusSince = make(map[int]time.Time, len(timelines))
+	for id, periods := range timelines {
+		b.statusSince[id] = periods[len(periods)-1].Entered
+	}
+}
+
 // loadTasks reads all tasks and organizes them into columns.
 func (b *Board) loadTasks() {
 	tasks, _, err := task.ReadAllLenient(b.cfg.TasksPath())
@@ -924,15 +1044,19 @@ func (b *Board) loadTasks() {
 		return
 	}
 	b.err = nil
+	b.allTasks = tasks
+	b.loadStatusSince(tasks)
 
 	// Filter out archived tasks and (when active) tasks not matching the
-	// search query from the TUI display.
+	// saved view or search query from the TUI display.
+	inView := b.viewFilter(tasks)
+	matches := searchFilter(b.filterQuery, b.cfg)
 	var visibleTasks []*task.Task
 	for _, t := range tasks {
 		if b.cfg.IsArchivedStatus(t.Status) {
 			continue
 		}
-		if !matchesFilter(t, b.filterQuery) {
+		if !inView(t) || !matches(t) {
 			continue
 		}
 		visibleTasks = append(visibleTasks, t)
@@ -981,6 +1105,37 @@ func (b *Board) loadTasks() {
 	b.clampRow()
 }
 
+// viewFilter returns the predicate for the active saved view. The view's
+// filters apply as in `kanban-md view`; its limit is ignored on the board.
+// A view that was removed from config or no longer validates is dropped.
+func (b *Board) viewFilter(all []*task.Task) func(*task.Task) bool {
+	matchAll := func(*task.Task) bool { return true }
+	if b.activeView == "" {
+		return matchAll
+	}
+	v := b.cfg.ViewByName(b.activeView)
+	if v == nil {
+		b.activeView = ""
+		return matchAll
+	}
+	opts, err := board.ViewListOptions(b.cfg, v)
+	if err != nil {
+		b.err = err
+		b.activeView = ""
+		return matchAll
+	}
+
+	matched := board.Filter(all, opts.Filter)
+	if opts.Unblocked {
+		matched = board.FilterUnblockedWithLookup(matched, all, b.cfg)
+	}
+	keep := make(map[int]bool, len(matched))
+	for _, t := range matched {
+		keep[t.ID] = true
+	}
+	return func(t *task.Task) bool { return keep[t.ID] }
+}
+
 // refreshDetailTask updates the detail view task pointer after a reload.
 // If the task was deleted or moved to an archived status, it closes the detail view.
 func (b *Board) refreshDetailTask() {
@@ -1152,38 +1307,46 @@ func (b *Board) fitCardsInHeight(col *column, avail, width int) int {
 	return count
 }
 
-// moveNext moves the selected task to the next board status (excludes archived).
+// moveNext moves the selected task to the next board status (excludes
+// archived), following the configured transitions.
 func (b *Board) moveNext() (tea.Model, tea.Cmd) {
 	t := b.selectedTask()
 	if t == nil {
 		return b, nil
 	}
 
-	boardStatuses := b.cfg.BoardStatuses()
-	idx := indexOf(boardStatuses, t.Status)
-	if idx < 0 || idx >= len(boardStatuses)-1 {
+	next := b.cfg.AdjacentStatus(b.cfg.BoardStatuses(), t.Status, t.Class, 1)
+	if next == "" && b.cfg.HasTransitions() {
+		b.err = fmt.Errorf("task #%d has no allowed next status from %s", t.ID, t.Status)
+		return b, nil
+	}
+	if next == "" {
 		b.err = fmt.Errorf("task #%d is already at the last status", t.ID)
 		return b, nil
 	}
 
-	return b.executeMove(boardStatuses[idx+1])
+	return b.executeMove(next)
 }
 
-// movePrev moves the selected task to the previous board status (excludes archived).
+// movePrev moves the selected task to the previous board status (excludes
+// archived), following the configured transitions.
 func (b *Board) movePrev() (tea.Model, tea.Cmd) {
 	t := b.selectedTask()
 	if t == nil {
 		return b, nil
 	}
 
-	boardStatuses := b.cfg.BoardStatuses()
-	idx := indexOf(boardStatuses, t.Status)
-	if idx <= 0 {
+	prev := b.cfg.AdjacentStatus(b.cfg.BoardStatuses(), t.Status, t.Class, -1)
+	if prev == "" && b.cfg.HasTransitions() {
+		b.err = fmt.Errorf("task #%d has no allowed previous status from %s", t.ID, t.Status)
+		return b, nil
+	}
+	if prev == "" {
 		b.err = fmt.Errorf("task #%d is already at the first status", t.ID)
 		return b, nil
 	}
 
-	return b.executeMove(boardStatuses[idx-1])
+	return b.executeMove(prev)
 }
 
 // raisePriority increases the selected task's priority by one level.
@@ -1219,8 +1382,23 @@ func (b *Board) lowerPriority() (tea.Model, tea.Cmd) {
 }
 
 func (b *Board) executePriorityChange(t *task.Task, newPriority string) (tea.Model, tea.Cmd) {
-	oldPriority := t.Priority
 	taskID := t.ID
+
+	unlock, err := board.Lock(b.cfg)
+	if err != nil {
+		b.err = fmt.Errorf("updating priority for task #%d: %w", taskID, err)
+		return b, nil
+	}
+	defer unlock() //nolint:errcheck // best-effort unlock
+
+	// Pick up changes other processes made since the last reload so this
+	// write does not clobber them.
+	if fresh, readErr := task.Read(t.File); readErr == nil {
+		*t = *fresh
+	}
+
+	before := board.TakeSnapshot(t)
+	oldPriority := t.Priority
 	t.Priority = newPriority
 	t.Updated = time.Now()
 
@@ -1230,7 +1408,7 @@ func (b *Board) executePriorityChange(t *task.Task, newPriority string) (tea.Mod
 		return b, nil
 	}
 
-	board.LogMutation(b.cfg.Dir(), "priority", taskID, oldPriority+" -> "+newPriority)
+	board.LogChange(b.cfg.Dir(), "priority", board.Actor("", t), before, t, oldPriority+" -> "+newPriority)
 	b.loadTasks()
 
 	// After re-sort, find the task at its new position and follow it.
@@ -1247,16 +1425,6 @@ func (b *Board) executePriorityChange(t *task.Task, newPriority string) (tea.Mod
 	return b, nil
 }
 
-// indexOf returns the index of item in slice, or -1.
-func indexOf(slice []string, item string) int {
-	for i, s := range slice {
-		if s == item {
-			return i
-		}
-	}
-	return -1
-}
-
 func (b *Board) executeMove(targetStatus string) (tea.Model, tea.Cmd) {
 	t := b.selectedTask()
 	if t == nil {
@@ -1311,7 +1479,7 @@ func (b *Board) taskClaimant(taskID int) string {
 }
 
 func (b *Board) executeDelete() (tea.Model, tea.Cmd) {
-	_, deleteErr := board.Delete(b.cfg, b.deleteID, tuiClaimant(), b.now())
+	_, deleteErr := board.Delete(b.cfg, b.deleteID, tuiClaimant(), "", b.now())
 
 	b.view = viewBoard
 	b.loadTasks()
@@ -1339,6 +1507,9 @@ type ReloadMsg struct{}
 
 type errMsg struct{ err error }
 
+// HookFailedMsg reports a post-hook that failed after a mutation.
+type HookFailedMsg struct{ Err error }
+
 // TickMsg is sent periodically to refresh duration displays.
 type TickMsg struct{}
 
@@ -1926,7 +2097,11 @@ func (b *Board) cardContentLines(t *task.Task, width int) []string {
 	}
 
 	if b.cfg.StatusShowDuration(t.Status) {
-		ageDur := b.now().Sub(t.Updated)
+		since, ok := b.statusSince[t.ID]
+		if !ok {
+			since = t.Updated
+		}
+		ageDur := b.now().Sub(since)
 		age := humanDuration(ageDur)
 		details = append(details, b.ageStyle(ageDur).Render(age))
 	}
@@ -2077,6 +2252,9 @@ func (b *Board) renderStatusBar() string {
 	if b.mouseEnabled {
 		parts = append(parts, statusBarPart{text: " | mouse"})
 	}
+	if b.activeView != "" {
+		parts = append(parts, statusBarPart{text: " | view:" + b.activeView})
+	}
 	if b.filterQuery != "" {
 		parts = append(parts, statusBarPart{text: fmt.Sprintf(" | filter:%q", b.filterQuery)})
 	}
@@ -2173,7 +2351,7 @@ func (b *Board) viewDetail() string {
 		return "No task selected."
 	}
 
-	lines := detailLines(t, b.width)
+	lines := detailLines(t, b.detailRollup(), b.width)
 
 	// Reserve space for the blank separator line and the fixed status hint.
 	viewHeight := b.height - 2 //nolint:mnd // 2 = blank line + hint line
@@ -2223,7 +2401,15 @@ func (b *Board) viewDetail() string {
 	return visible + "\n\n" + dimStyle.Render(truncate(hint, b.width))
 }
 
-func detailLines(t *task.Task, width int) []string {
+// detailRollup returns the subtask roll-up of the task in the detail view.
+func (b *Board) detailRollup() *board.Rollup {
+	if b.detailTask == nil {
+		return nil
+	}
+	return board.ParentRollup(b.cfg, b.allTasks, b.detailTask.ID)
+}
+
+func detailLines(t *task.Task, subtasks *board.Rollup, width int) []string {
 	var lines []string
 	header := fmt.Sprintf("Task #%d: %s", t.ID, t.Title)
 	// Word-wrap the header so long titles fit within the available terminal width.
@@ -2246,6 +2432,7 @@ func detailLines(t *task.Task, width int) []string {
 		lines = append(lines, "")
 		lines = append(lines, errorStyle.Render("BLOCKED: "+t.BlockReason))
 	}
+	lines = append(lines, detailSubtaskLines(subtasks, width)...)
 	if t.Body != "" {
 		lines = append(lines, "")
 		body := unescapeBody(t.Body)
@@ -2331,6 +2518,29 @@ func detailMetadataLines(t *task.Task) []string {
 	if t.Estimate != "" {
 		lines = append(lines, detailLabelStyle.Render("Estimate:")+"  "+t.Estimate)
 	}
+	for _, name := range t.CustomKeys() {
+		lines = append(lines, detailLabelStyle.Render(name+":")+"  "+t.FormatCustom(name))
+	}
+	return lines
+}
+
+// detailSubtaskLines renders a parent's subtasks with their statuses and
+// the share done; finished subtasks are dimmed.
+func detailSubtaskLines(r *board.Rollup, width int) []string {
+	if r == nil {
+		return nil
+	}
+	lines := []string{
+		"",
+		detailLabelStyle.Render("Subtasks:") + "  " + fmt.Sprintf("%d/%d done (%d%%)", r.Done, r.Total, r.Percent),
+	}
+	for _, c := range r.Children {
+		line := truncate(fmt.Sprintf("  #%d [%s] %s", c.ID, c.Status, c.Title), width)
+		if c.Done {
+			line = dimStyle.Render(line)
+		}
+		lines = append(lines, line)
+	}
 	return lines
 }
 
@@ -2400,11 +2610,14 @@ func (b *Board) viewCreateDialog() string {
 		headerText = fmt.Sprintf("Edit task #%d in %s", b.createEditID, b.createStatus)
 	}
 	header := lipgloss.NewStyle().Bold(true).Render(headerText)
+	first := b.firstCreateStep()
 	stepLabel := dimStyle.Render(fmt.Sprintf("  Step %d/%d: %s",
-		b.createStep+1, stepCount, b.stepName()))
+		b.createStep-first+1, stepCount-first, b.stepName()))
 
 	var body string
 	switch b.createStep {
+	case stepTemplate:
+		body = b.viewCreateTemplate()
 	case stepTitle:
 		body = b.viewCreateTitle()
 	case stepBody:
@@ -2423,6 +2636,8 @@ func (b *Board) viewCreateDialog() string {
 
 func (b *Board) stepName() string {
 	switch b.createStep {
+	case stepTemplate:
+		return "Template"
 	case stepTitle:
 		return "Title"
 	case stepBody:
@@ -2443,7 +2658,12 @@ func (b *Board) createHint() string {
 	}
 
 	switch b.createStep {
+	case stepTemplate:
+		return "↑/↓:select  tab/enter:next  esc:cancel"
 	case stepTitle:
+		if b.firstCreateStep() == stepTemplate {
+			return fmt.Sprintf("tab:next  shift+tab:back  enter:%s  esc:cancel", action)
+		}
 		return fmt.Sprintf("tab:next  enter:%s  esc:cancel", action)
 	case stepBody:
 		return fmt.Sprintf("tab:next  shift+tab:back  enter:%s  esc:cancel", action)
@@ -2456,6 +2676,20 @@ func (b *Board) createHint() string {
 	}
 }
 
+func (b *Board) viewCreateTemplate() string {
+	label := lipgloss.NewStyle().Bold(true).Render("Template:")
+	items := []string{"  " + dimStyle.Render("(none)")}
+	for _, tmpl := range b.createTemplates {
+		item := "  " + tmpl.Name
+		if tmpl.Description != "" {
+			item += "  " + dimStyle.Render(tmpl.Description)
+		}
+		items = append(items, item)
+	}
+	items[b.createTemplate] = ">" + items[b.createTemplate][1:]
+	return label + "\n" + strings.Join(items, "\n")
+}
+
 func (b *Board) viewCreateTitle() string {
 	b.applyCreateInputLayout()
 	return b.renderLabeledCreateInput("Title: ", b.createTitleInput.View())
@@ -2535,7 +2769,8 @@ func (b *Board) viewHelp() string {
 		{"d", "Delete task"},
 		{"s", "Cycle sort field (priority/created/updated/title)"},
 		{"S", "Reverse sort direction"},
-		{"/", "Search by title, or by ID with #12 (trailing space = exact)"},
+		{"/", "Search title, query (tag:api), or ID #12 (space = exact)"},
+		{"v", "Cycle saved views from config.yml"},
 		{"r", "Refresh board"},
 		{"?", "Show this help"},
 		{"esc/q", "Quit"},
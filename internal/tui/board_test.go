@@ -11,6 +11,7 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/antopolskiy/kanban-md/internal/board"
 	"github.com/antopolskiy/kanban-md/internal/config"
 	"github.com/antopolskiy/kanban-md/internal/date"
 	"github.com/antopolskiy/kanban-md/internal/task"
@@ -278,6 +279,33 @@ func TestBoard_MoveNext(t *testing.T) {
 	_ = b.View()
 }
 
+func TestBoard_MoveNextFollowsTransitions(t *testing.T) {
+	b, cfg := setupTestBoard(t)
+	cfg.Transitions = []config.TransitionConfig{{From: "backlog", To: []string{"done"}}}
+
+	// Task 1 in backlog may only move to done.
+	b = sendKey(b, "n")
+	path, err := task.FindByID(cfg.TasksPath(), 1)
+	if err != nil {
+		t.Fatalf("finding task: %v", err)
+	}
+	tk, err := task.Read(path)
+	if err != nil {
+		t.Fatalf("reading task: %v", err)
+	}
+	if tk.Status != "done" {
+		t.Errorf("expected status 'done', got %q", tk.Status)
+	}
+
+	// No rule leaves in-progress.
+	b = sendKey(b, "l")
+	b = sendKey(b, "l")
+	b = sendKey(b, "n")
+	if !containsStr(b.View(), "no allowed next status") {
+		t.Error("expected an error when no transition leaves the status")
+	}
+}
+
 func TestBoard_MovePrev(t *testing.T) {
 	b, cfg := setupTestBoard(t)
 
@@ -1011,6 +1039,37 @@ func TestBoard_ReloadMsg_RefreshesDetailView(t *testing.T) {
 	}
 }
 
+func TestBoard_DetailShowsSubtasks(t *testing.T) {
+	b, cfg := setupTestBoard(t)
+
+	// Make Task B (backlog) and Task D (done) subtasks of Task A.
+	for _, id := range []int{2, 4} {
+		path, err := task.FindByID(cfg.TasksPath(), id)
+		if err != nil {
+			t.Fatalf("finding task: %v", err)
+		}
+		tk, err := task.Read(path)
+		if err != nil {
+			t.Fatalf("reading task: %v", err)
+		}
+		parent := 1
+		tk.Parent = &parent
+		if err := task.Write(path, tk); err != nil {
+			t.Fatalf("writing task: %v", err)
+		}
+	}
+	m, _ := b.Update(tui.ReloadMsg{})
+	b = m.(*tui.Board)
+
+	b = sendKey(b, "enter")
+	v := b.View()
+	for _, want := range []string{"Subtasks:", "1/2 done (50%)", "#2 [backlog] Task B", "#4 [done] Task D"} {
+		if !containsStr(v, want) {
+			t.Errorf("expected %q in detail view of a parent task", want)
+		}
+	}
+}
+
 func TestBoard_ReloadMsg_ClosesDetailOnDelete(t *testing.T) {
 	b, cfg := setupTestBoard(t)
 
@@ -1785,6 +1844,28 @@ func TestBoard_InitReturnsTickCmd(t *testing.T) {
 	}
 }
 
+func TestBoard_AgeCountsFromStatusEntry(t *testing.T) {
+	b, cfg := setupTestBoard(t)
+
+	// Task C (updated 2h ago) moved to in-progress 30 minutes ago.
+	err := board.AppendLog(cfg.Dir(), board.LogEntry{
+		Timestamp:  testRefTime.Add(90 * time.Minute),
+		Action:     "move",
+		TaskID:     3,
+		Transition: &board.StatusTransition{From: "backlog", To: "in-progress"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, _ := b.Update(tui.ReloadMsg{})
+	b = m.(*tui.Board)
+
+	v := b.View()
+	if !containsStr(v, "30m") {
+		t.Errorf("expected Task C's age to count from entering in-progress (30m), got:\n%s", v)
+	}
+}
+
 func TestBoard_TickMsgUpdatesAge(t *testing.T) {
 	b, _ := setupTestBoard(t)
 
@@ -2625,3 +2706,83 @@ func TestBoard_SearchByIDTitleModeUnaffected(t *testing.T) {
 		t.Error("expected only Alpha for title query 'alph'")
 	}
 }
+
+func TestBoard_SearchByQuery(t *testing.T) {
+	b, _ := setupTestBoard(t)
+
+	b = typeSearch(b, "priority:high AND NOT status:in-progress")
+	v := b.View()
+	if !containsStr(v, "Task A") {
+		t.Error("expected Task A to match the query")
+	}
+	for _, gone := range []string{"Task B", "Task C", "Task D"} {
+		if containsStr(v, gone) {
+			t.Errorf("expected %q to be filtered out by the query", gone)
+		}
+	}
+}
+
+func TestBoard_SearchIncompleteQueryFallsBackToTitle(t *testing.T) {
+	b, _ := setupTestBoard(t)
+
+	// "priority:" is not a complete query yet; it matches titles, so nothing
+	// is shown rather than an error.
+	b = typeSearch(b, "priority:")
+	v := b.View()
+	for _, gone := range []string{"Task A", "Task B", "Task C", "Task D"} {
+		if containsStr(v, gone) {
+			t.Errorf("expected %q filtered out while the query is incomplete", gone)
+		}
+	}
+}
+
+func TestBoard_CycleSavedViews(t *testing.T) {
+	b, cfg := setupTestBoard(t)
+	cfg.Views = []config.ViewConfig{
+		{Name: "urgent", Priorities: []string{"high"}},
+		{Name: "finished", Statuses: []string{"done"}},
+	}
+
+	b = sendKey(b, "v")
+	v := b.View()
+	if !containsStr(v, "view:urgent") {
+		t.Error("expected status bar to show view:urgent")
+	}
+	for _, want := range []string{"Task A", "Task C"} {
+		if !containsStr(v, want) {
+			t.Errorf("expected %q in the urgent view", want)
+		}
+	}
+	for _, gone := range []string{"Task B", "Task D"} {
+		if containsStr(v, gone) {
+			t.Errorf("expected %q filtered out by the urgent view", gone)
+		}
+	}
+
+	b = sendKey(b, "v")
+	v = b.View()
+	if !containsStr(v, "view:finished") || !containsStr(v, "Task D") || containsStr(v, "Task A") {
+		t.Error("expected the finished view to show only Task D")
+	}
+
+	// Cycling past the last view clears it.
+	b = sendKey(b, "v")
+	v = b.View()
+	if containsStr(v, "view:") {
+		t.Error("expected no active view after cycling past the last one")
+	}
+	for _, want := range []string{"Task A", "Task B", "Task C", "Task D"} {
+		if !containsStr(v, want) {
+			t.Errorf("expected %q with no view applied", want)
+		}
+	}
+}
+
+func TestBoard_CycleSavedViewsNoneDefined(t *testing.T) {
+	b, _ := setupTestBoard(t)
+
+	b = sendKey(b, "v")
+	if !containsStr(b.View(), "no saved views") {
+		t.Error("expected a hint when no views are defined")
+	}
+}
@@ -697,3 +697,46 @@ func countTaskFiles(t *testing.T, dir string) int {
 	}
 	return count
 }
+
+func TestCreate_TemplateStep(t *testing.T) {
+	b, cfg := setupTestBoard(t)
+	dir := filepath.Join(cfg.Dir(), "templates")
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		t.Fatal(err)
+	}
+	bug := "---\ndescription: Report a defect\ntitle: \"Bug: {title}\"\npriority: high\ntags: [bug]\n---\n## Steps to reproduce\n"
+	if err := os.WriteFile(filepath.Join(dir, "bug.md"), []byte(bug), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	b = sendKey(b, "c")
+	v := b.View()
+	if !containsStr(v, "Step 1/5: Template") || !containsStr(v, "Report a defect") {
+		t.Fatal("expected template step listing the bug template, got:", v)
+	}
+	b = sendKey(b, "j")
+	b = sendSpecialKey(b, tea.KeyEnter) // choose the template
+	if v = b.View(); !containsStr(v, "Step 2/5: Title") {
+		t.Fatal("expected title step after choosing a template, got:", v)
+	}
+	b = typeText(b, "Crash on save")
+	_ = sendSpecialKey(b, tea.KeyEnter) // create
+
+	tasks, err := task.ReadAll(cfg.TasksPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var created *task.Task
+	for _, tk := range tasks {
+		if strings.HasPrefix(tk.Title, "Bug: ") {
+			created = tk
+		}
+	}
+	if created == nil {
+		t.Fatal("expected a task created from the bug template")
+	}
+	if created.Title != "Bug: Crash on save" || created.Priority != "high" ||
+		len(created.Tags) != 1 || created.Tags[0] != "bug" || !strings.Contains(created.Body, "Steps to reproduce") {
+		t.Errorf("created = %+v, want the bug template applied", created)
+	}
+}
@@ -509,9 +509,9 @@ func (b *Board) clampDetailScroll() {
 	}
 	viewHeight := b.height - detailChrome
 	if viewHeight < 1 {
-		viewHeight = len(detailLines(b.detailTask, b.width))
+		viewHeight = len(detailLines(b.detailTask, b.detailRollup(), b.width))
 	}
-	maxOff := len(detailLines(b.detailTask, b.width)) - viewHeight
+	maxOff := len(detailLines(b.detailTask, b.detailRollup(), b.width)) - viewHeight
 	if maxOff < 0 {
 		maxOff = 0
 	}
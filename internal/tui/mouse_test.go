@@ -238,7 +238,7 @@ func TestMouseWheelScrollsDetailThreeLinesAndClamps(t *testing.T) {
 			X: 10, Y: 10, Button: tea.MouseButtonWheelDown, Action: tea.MouseActionPress,
 		})
 	}
-	maxOff := len(detailLines(b.detailTask, b.width)) - (b.height - 2)
+	maxOff := len(detailLines(b.detailTask, b.detailRollup(), b.width)) - (b.height - 2)
 	if b.detailScrollOff != maxOff {
 		t.Fatalf("detail bottom offset=%d, want %d", b.detailScrollOff, maxOff)
 	}